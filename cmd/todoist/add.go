@@ -1,22 +1,35 @@
 package main
 
 import (
+	"fmt"
+	"io"
 	"os"
 	"strings"
 
 	"github.com/buddyh/todoist-cli/internal/api"
-	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/hooks"
+	"github.com/buddyh/todoist-cli/internal/i18n"
 	"github.com/spf13/cobra"
 )
 
 func newAddCmd(flags *rootFlags) *cobra.Command {
+	return newAddTaskCmd(flags, false)
+}
+
+// newAddTaskCmd builds 'add', or with inbox 'inbox add', which ignores
+// default_project and has no -p or -s
+func newAddTaskCmd(flags *rootFlags, inbox bool) *cobra.Command {
 	var (
-		description string
-		due         string
-		priority    int
-		project     string
-		section     string
-		labels      []string
+		description     string
+		descriptionFile string
+		due             string
+		strictDue       bool
+		tz              string
+		priority        int
+		project         string
+		section         string
+		labels          []string
 	)
 
 	cmd := &cobra.Command{
@@ -28,14 +41,28 @@ Examples:
   todoist add "Buy groceries"
   todoist add "Call mom" -d tomorrow
   todoist add "Urgent task" -P 1 -d "today 5pm"
+  todoist add "Pay rent" -d "1st of month" --strict-due
+  todoist add "Call Berlin office" -d "tomorrow 9am" --tz Europe/Berlin
   todoist add "Work task" -p Work -l urgent -l followup
-  todoist add "Meeting prep" --description "Prepare slides for Q4 review"`,
+  todoist add "Meeting prep" --description "Prepare slides for Q4 review"
+  todoist add "Write up notes" --description-file notes.md
+  pbpaste | todoist add "Follow up" --description -`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			out := output.NewFormatter(os.Stdout, flags.asJSON)
+			out := newFormatter(flags)
 			content := strings.Join(args, " ")
+			description, err := readDescription(description, descriptionFile)
+			if err != nil {
+				return err
+			}
+			if tz != "" && due == "" {
+				return fmt.Errorf("--tz needs --due")
+			}
+			if err := validateTimezone(tz); err != nil {
+				return err
+			}
 
-			client, err := getClient()
+			client, err := getClientWithFlags(flags)
 			if err != nil {
 				return err
 			}
@@ -52,7 +79,11 @@ Examples:
 				params.Priority = 5 - priority
 			}
 
-			// Find project ID if name given
+			// Find project ID if name given, else use the configured default;
+			// without either the task goes to the Inbox
+			if project == "" && !inbox {
+				project = config.Settings().DefaultProject
+			}
 			if project != "" {
 				p, err := client.FindProject(project)
 				if err != nil {
@@ -75,21 +106,74 @@ Examples:
 				}
 			}
 
+			if err := hooks.Run(stderr, hooks.PreAdd, params); err != nil {
+				return err
+			}
+
+			profileBanner(flags, i18n.T("Adding %s", content))
 			task, err := client.AddTask(params)
 			if err != nil {
 				return err
 			}
+			if err := checkDue(flags, client, task, due, strictDue); err != nil {
+				return err
+			}
+			if tz != "" {
+				if task, err = applyTimezone(client, task, tz); err != nil {
+					return err
+				}
+			}
+			hooks.RunPost(stderr, hooks.PostAdd, task)
 
 			return out.WriteTask(task)
 		},
 	}
 
-	cmd.Flags().StringVar(&description, "description", "", "task description/notes")
+	cmd.Flags().StringVar(&description, "description", "", "task description/notes (- reads stdin)")
+	cmd.Flags().StringVar(&descriptionFile, "description-file", "", "read the description from a file (- for stdin)")
 	cmd.Flags().StringVarP(&due, "due", "d", "", "due date (e.g., 'tomorrow', 'next monday 3pm')")
+	cmd.Flags().StringVar(&tz, "tz", "", "fix the due time to this timezone, e.g. Europe/Berlin")
+	cmd.Flags().BoolVar(&strictDue, "strict-due", false, "fail, without creating the task, when Todoist does not understand --due")
 	cmd.Flags().IntVarP(&priority, "priority", "P", 0, "priority 1-4 (1=highest)")
-	cmd.Flags().StringVarP(&project, "project", "p", "", "project name")
-	cmd.Flags().StringVarP(&section, "section", "s", "", "section name (requires project)")
+	if inbox {
+		cmd.Short = "Add a task to the Inbox"
+		cmd.Long = `Add a task to the Inbox, whatever default_project is set to. Takes the
+same flags as 'todoist add' apart from --project and --section.
+
+Examples:
+  todoist inbox add "Call the plumber"
+  todoist inbox add "Renew passport" -d "next friday" -l errands`
+	} else {
+		cmd.Flags().StringVarP(&project, "project", "p", "", "project name (default: the config's default_project, else the Inbox)")
+		cmd.Flags().StringVarP(&section, "section", "s", "", "section name (requires project)")
+	}
 	cmd.Flags().StringArrayVarP(&labels, "label", "l", nil, "add label (can be repeated)")
 
 	return cmd
 }
+
+// readDescription resolves --description and --description-file. "-" reads
+// stdin; newlines and markdown are kept, apart from trailing newlines.
+func readDescription(value, file string) (string, error) {
+	if value != "" && file != "" {
+		return "", fmt.Errorf("use either --description or --description-file, not both")
+	}
+	if value == "-" {
+		file = "-"
+	}
+	if file == "" {
+		return value, nil
+	}
+
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read description: %w", err)
+	}
+	return strings.TrimRight(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n"), nil
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func newAliasCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alias",
+		Short: "Manage command aliases",
+		Long: `Define shortcuts for commands you run often, like git aliases.
+
+An alias expands to its arguments, followed by any arguments given after
+it. Quote arguments containing spaces. Aliases cannot replace built-in
+commands; an alias hidden by a command added in a later release is flagged
+by 'alias list' and when it is run.
+
+Examples:
+  todoist alias set work 'tasks -p Work --sort due'
+  todoist alias set urgent 'tasks --filter "p1 & today"'
+  todoist work
+  todoist work --json
+  todoist alias list
+  todoist alias remove work`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAliasList(cmd.Root(), flags)
+		},
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "set <name> <command>",
+		Short: "Create or replace an alias",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+			name, expansion := args[0], args[1]
+
+			if err := checkShortcutName(cmd.Root(), "alias", name); err != nil {
+				return err
+			}
+			words, err := splitArgs(expansion)
+			if err != nil {
+				return err
+			}
+			if len(words) == 0 {
+				return fmt.Errorf("alias %s needs a command", name)
+			}
+
+			cfg, err := config.LoadForUpdate()
+			if err != nil {
+				return err
+			}
+			if cfg.Aliases == nil {
+				cfg.Aliases = make(map[string]string)
+			}
+			cfg.Aliases[name] = expansion
+			if err := config.Save(cfg); err != nil {
+				return err
+			}
+
+			out.WriteSuccess(i18n.T("Alias %s = %s", name, expansion))
+			return nil
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List aliases",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAliasList(cmd.Root(), flags)
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:     "remove <name>",
+		Aliases: []string{"rm"},
+		Short:   "Remove an alias",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			cfg, err := config.LoadForUpdate()
+			if err != nil {
+				return err
+			}
+			if _, ok := cfg.Aliases[args[0]]; !ok {
+				return fmt.Errorf("alias not found: %s", args[0])
+			}
+			delete(cfg.Aliases, args[0])
+			if err := config.Save(cfg); err != nil {
+				return err
+			}
+
+			out.WriteSuccess(i18n.T("Removed alias %s", args[0]))
+			return nil
+		},
+	})
+
+	return cmd
+}
+
+// isBuiltin reports whether name runs a built-in command, which always wins
+// over an alias or view of the same name
+func isBuiltin(root *cobra.Command, name string) bool {
+	c, _, err := root.Find([]string{name})
+	return err == nil && c != root
+}
+
+func runAliasList(root *cobra.Command, flags *rootFlags) error {
+	out := newFormatter(flags)
+	aliases := config.Settings().Aliases
+
+	if flags.asJSON {
+		if aliases == nil {
+			aliases = map[string]string{}
+		}
+		return out.JSON(aliases)
+	}
+
+	if len(aliases) == 0 {
+		out.Printf("%s\n", i18n.T("No aliases defined."))
+		return nil
+	}
+
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		out.Printf("%s = %s\n", name, aliases[name])
+		if isBuiltin(root, name) {
+			out.Printf("  %s\n", out.Color().Wrap(output.ANSIYellow, i18n.T("hidden by the built-in %s command; rename this alias", name)))
+		}
+	}
+	return nil
+}
+
+// expandAlias replaces the command name in args with its alias or saved
+// view expansion. Global flags before the name are kept in place. Built-in
+// commands always win, a name that is both an alias and a view is refused,
+// and an expansion is not expanded again.
+func expandAlias(root *cobra.Command, args []string) ([]string, error) {
+	cfg := config.Settings()
+	if len(cfg.Aliases) == 0 && len(cfg.Views) == 0 {
+		return args, nil
+	}
+
+	i := commandIndex(root, args)
+	if i >= len(args) {
+		return args, nil
+	}
+
+	// 'view run <name>' names a view explicitly
+	if args[i] == "view" && i+2 < len(args) && args[i+1] == "run" {
+		view, ok := cfg.Views[args[i+2]]
+		if !ok {
+			return args, nil
+		}
+		return splice(args, i, 3, view, "view "+args[i+2])
+	}
+
+	if isBuiltin(root, args[i]) {
+		if _, ok := cfg.Aliases[args[i]]; ok {
+			fmt.Fprintln(stderr, i18n.T("Warning: alias %s is hidden by the built-in %s command; rename the alias to use it", args[i], args[i]))
+		}
+		return args, nil
+	}
+	expansion, ok := cfg.Aliases[args[i]]
+	if view, isView := cfg.Views[args[i]]; isView {
+		if ok {
+			return nil, fmt.Errorf("%s is both an alias and a view; use 'todoist view run %s'", args[i], args[i])
+		}
+		return splice(args, i, 1, view, "view "+args[i])
+	}
+	if !ok {
+		return args, nil
+	}
+	return splice(args, i, 1, expansion, "alias "+args[i])
+}
+
+// splice replaces the n arguments at i with the words of expansion
+func splice(args []string, i, n int, expansion, what string) ([]string, error) {
+	words, err := splitArgs(expansion)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", what, err)
+	}
+	expanded := append([]string{}, args[:i]...)
+	expanded = append(expanded, words...)
+	return append(expanded, args[i+n:]...), nil
+}
+
+// commandIndex returns the position of the command name in args: the first
+// argument that is not a global flag or a global flag's value
+func commandIndex(root *cobra.Command, args []string) int {
+	i := 0
+	for i < len(args) && strings.HasPrefix(args[i], "-") && args[i] != "--" {
+		name := strings.TrimLeft(args[i], "-")
+		i++
+		if strings.Contains(name, "=") {
+			continue
+		}
+		f := root.PersistentFlags().Lookup(name)
+		if len(name) == 1 {
+			f = root.PersistentFlags().ShorthandLookup(name)
+		}
+		if f != nil && f.NoOptDefVal == "" && i < len(args) {
+			i++ // skip the flag's value
+		}
+	}
+	return i
+}
+
+// splitArgs splits a command line into words the way a POSIX shell would for
+// plain words, single and double quotes, and backslash escapes
+func splitArgs(s string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case quote == '"':
+			switch {
+			case r == '"':
+				quote = 0
+			case r == '\\' && i+1 < len(runes) && strings.ContainsRune(`"\$`+"`", runes[i+1]):
+				i++
+				word.WriteRune(runes[i])
+			default:
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == '\\' && i+1 < len(runes):
+			i++
+			word.WriteRune(runes[i])
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// assertExitCode is the exit status of a failed todoist assert, apart
+// from errors that kept the check from running
+const assertExitCode = 5
+
+// assertFailed carries a failed assertion back to main
+type assertFailed struct {
+	msg string
+}
+
+func (e *assertFailed) Error() string {
+	return "assertion failed: " + e.msg
+}
+
+// assertBounds is the allowed number of matching tasks; a negative bound
+// is not checked
+type assertBounds struct {
+	min, max int
+}
+
+// countTasks says how many tasks are what, e.g. `3 tasks match "overdue"`
+func countTasks(n int, what string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 task %s", what)
+	}
+	return fmt.Sprintf("%d tasks %s", n, what)
+}
+
+// check returns why n tasks break the bounds, or "" when they do not
+func (b assertBounds) check(n int, what string) string {
+	switch {
+	case b.max >= 0 && n > b.max:
+		return fmt.Sprintf("%s, at most %d allowed", countTasks(n, what), b.max)
+	case b.min >= 0 && n < b.min:
+		return fmt.Sprintf("%s, at least %d required", countTasks(n, what), b.min)
+	}
+	return ""
+}
+
+func newAssertCmd(flags *rootFlags) *cobra.Command {
+	var (
+		filter  string
+		project string
+		bounds  assertBounds
+	)
+
+	cmd := &cobra.Command{
+		Use:   "assert",
+		Short: "Fail when the number of matching tasks is out of bounds",
+		Long: `Count the open tasks in --project and matching --filter, and exit
+with status 5 when there are more than --max or fewer than --min. Without
+either, no task may match. Use it in cron jobs and CI to check on your
+lists: a failure is reported on stderr, and the tasks over --max are
+listed.
+
+Examples:
+  todoist assert --filter overdue --max 0
+  todoist assert -p Inbox
+  todoist assert --filter "today & p1" --max 3
+  todoist assert -p Routines --filter "no date" --max 0 --quiet`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+			if filter == "" && project == "" {
+				return fmt.Errorf("give a --filter or --project to check")
+			}
+			if !cmd.Flags().Changed("max") {
+				bounds.max = -1
+				if !cmd.Flags().Changed("min") {
+					bounds.max = 0
+				}
+			}
+			if !cmd.Flags().Changed("min") {
+				bounds.min = -1
+			}
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+			var projectID string
+			var what []string
+			if project != "" {
+				p, err := client.FindProject(project)
+				if err != nil {
+					return err
+				}
+				projectID = p.ID
+				what = append(what, "in "+p.Name)
+			}
+			if filter != "" {
+				what = append(what, fmt.Sprintf("match %q", filter))
+			}
+			tasks, err := client.GetTasks(projectID, filter)
+			if err != nil {
+				return err
+			}
+			out.TaskOrder().Sort(tasks)
+
+			failure := bounds.check(len(tasks), strings.Join(what, " and "))
+			if flags.asJSON {
+				result := map[string]interface{}{"passed": failure == "", "count": len(tasks), "tasks": tasks}
+				if bounds.max >= 0 {
+					result["max"] = bounds.max
+				}
+				if bounds.min >= 0 {
+					result["min"] = bounds.min
+				}
+				if err := out.JSON(result); err != nil {
+					return err
+				}
+			} else if !flags.quiet {
+				if failure == "" {
+					out.Printf("OK: %s\n", countTasks(len(tasks), strings.Join(what, " and ")))
+				} else if bounds.max >= 0 && len(tasks) > bounds.max {
+					if err := loadNames(client, out); err != nil {
+						return err
+					}
+					for i := range tasks {
+						out.Printf("%s\n", out.FormatTaskLine(&tasks[i]))
+					}
+				}
+			}
+			if failure != "" {
+				return &assertFailed{msg: failure}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&filter, "filter", "f", "", "count the tasks matching this Todoist filter")
+	cmd.Flags().StringVarP(&project, "project", "p", "", "count the tasks in this project")
+	cmd.Flags().IntVar(&bounds.max, "max", 0, "fail when more tasks match")
+	cmd.Flags().IntVar(&bounds.min, "min", 0, "fail when fewer tasks match")
+
+	return cmd
+}
@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+func TestAssertBounds(t *testing.T) {
+	for _, tc := range []struct {
+		bounds assertBounds
+		n      int
+		want   string
+	}{
+		{assertBounds{min: -1, max: 0}, 0, ""},
+		{assertBounds{min: -1, max: 0}, 1, `1 task match "overdue", at most 0 allowed`},
+		{assertBounds{min: 2, max: 5}, 3, ""},
+		{assertBounds{min: 2, max: 5}, 6, `6 tasks match "overdue", at most 5 allowed`},
+		{assertBounds{min: 2, max: -1}, 1, `1 task match "overdue", at least 2 required`},
+		{assertBounds{min: 2, max: -1}, 100, ""},
+	} {
+		if got := tc.bounds.check(tc.n, `match "overdue"`); got != tc.want {
+			t.Errorf("%+v with %d: got %q, want %q", tc.bounds, tc.n, got, tc.want)
+		}
+	}
+}
@@ -6,8 +6,8 @@ import (
 	"os"
 	"strings"
 
-	"github.com/buddyh/todoist-cli/internal/api"
 	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/i18n"
 	"github.com/buddyh/todoist-cli/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -23,10 +23,14 @@ Get your token from: https://todoist.com/app/settings/integrations/developer
 You can either:
   1. Pass the token as an argument: todoist auth <token>
   2. Run interactively and paste when prompted: todoist auth
-  3. Set TODOIST_API_TOKEN environment variable`,
+  3. Set TODOIST_API_TOKEN environment variable
+
+Use --profile to keep several accounts side by side:
+  todoist auth --profile work <token>
+  todoist auth use work`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			out := output.NewFormatter(os.Stdout, flags.asJSON)
+			out := newFormatter(flags)
 			var token string
 
 			if len(args) > 0 {
@@ -47,19 +51,36 @@ You can either:
 			}
 
 			// Validate token by making a test request
-			client := api.NewClient(token)
-			_, err := client.GetProjects()
+			client, err := newAPIClient(token)
 			if err != nil {
+				return err
+			}
+			if _, err := client.GetProjects(); err != nil {
 				return fmt.Errorf("invalid token: %w", err)
 			}
 
-			// Save to config
-			cfg := &config.Config{APIToken: token}
+			// Remember the account email so prompts can show which account is active
+			var email string
+			if user, err := client.GetUser(); err == nil {
+				email = user.Email
+			}
+
+			// Save to config, keeping any other settings already on disk
+			cfg, err := config.LoadForUpdate()
+			if err != nil {
+				return err
+			}
+			name := cfg.ProfileName()
+			cfg.SetProfile(name, config.Profile{APIToken: token, Email: email})
 			if err := config.Save(cfg); err != nil {
 				return err
 			}
 
-			out.WriteSuccess(fmt.Sprintf("Authenticated successfully. Config saved to %s", config.ConfigPath()))
+			msg := i18n.T("Authenticated successfully. Config saved to %s", config.ConfigPath())
+			if name != config.DefaultProfile {
+				msg = i18n.T("Authenticated profile %s. Config saved to %s", name, config.ConfigPath())
+			}
+			out.WriteSuccess(msg)
 			return nil
 		},
 	}
@@ -68,17 +89,37 @@ You can either:
 	cmd.AddCommand(&cobra.Command{
 		Use:   "logout",
 		Short: "Remove stored credentials",
+		Long: `Remove the active profile's stored credentials, or with --profile that
+profile's. Other profiles and settings such as aliases and views are kept.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			out := output.NewFormatter(os.Stdout, flags.asJSON)
-			path := config.ConfigPath()
-			if err := os.Remove(path); err != nil {
-				if os.IsNotExist(err) {
-					out.WriteSuccess("No credentials stored.")
-					return nil
+			out := newFormatter(flags)
+
+			cfg, err := config.LoadForUpdate()
+			if err != nil {
+				return err
+			}
+			if flags.profile != "" {
+				if _, ok := cfg.Profile(flags.profile); !ok {
+					return fmt.Errorf("profile not found: %s", flags.profile)
 				}
-				return fmt.Errorf("failed to remove config: %w", err)
+				cfg.RemoveProfile(flags.profile)
+				if err := config.Save(cfg); err != nil {
+					return err
+				}
+				out.WriteSuccess(i18n.T("Removed profile %s.", flags.profile))
+				return nil
 			}
-			out.WriteSuccess("Logged out successfully.")
+
+			name := cfg.ProfileName()
+			if p, ok := cfg.Profile(name); !ok || p.APIToken == "" {
+				out.WriteSuccess(i18n.T("No credentials stored."))
+				return nil
+			}
+			cfg.RemoveProfile(name)
+			if err := config.Save(cfg); err != nil {
+				return err
+			}
+			out.WriteSuccess(i18n.T("Logged out successfully."))
 			return nil
 		},
 	})
@@ -88,13 +129,81 @@ You can either:
 		Use:   "status",
 		Short: "Check authentication status",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			out := output.NewFormatter(os.Stdout, flags.asJSON)
+			out := newFormatter(flags)
 			_, err := config.Load()
 			if err != nil {
 				out.WriteError(err)
 				return nil
 			}
-			out.WriteSuccess("Authenticated")
+			if label := accountLabel(); label != "" {
+				out.WriteSuccess(i18n.T("Authenticated as %s", label))
+				return nil
+			}
+			out.WriteSuccess(i18n.T("Authenticated"))
+			return nil
+		},
+	})
+
+	// Add use subcommand
+	cmd.AddCommand(&cobra.Command{
+		Use:   "use <profile>",
+		Short: "Switch the active profile",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+			cfg, err := config.LoadForUpdate()
+			if err != nil {
+				return err
+			}
+			if _, ok := cfg.Profile(args[0]); !ok {
+				return fmt.Errorf("profile not found: %s", args[0])
+			}
+			cfg.ActiveProfile = args[0]
+			if args[0] == config.DefaultProfile {
+				cfg.ActiveProfile = ""
+			}
+			if err := config.Save(cfg); err != nil {
+				return err
+			}
+			out.WriteSuccess(i18n.T("Switched to profile %s", args[0]))
+			return nil
+		},
+	})
+
+	// Add profiles subcommand
+	cmd.AddCommand(&cobra.Command{
+		Use:   "profiles",
+		Short: "List configured profiles",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+			cfg := config.Settings()
+			active := cfg.ProfileName()
+
+			type profileInfo struct {
+				Name   string `json:"name"`
+				Email  string `json:"email,omitempty"`
+				Active bool   `json:"active"`
+			}
+			var profiles []profileInfo
+			for _, name := range cfg.ProfileNames() {
+				p, _ := cfg.Profile(name)
+				profiles = append(profiles, profileInfo{Name: name, Email: p.Email, Active: name == active})
+			}
+
+			if flags.asJSON {
+				return out.JSON(profiles)
+			}
+			if len(profiles) == 0 {
+				out.Printf("%s\n", i18n.T("No profiles configured."))
+				return nil
+			}
+			for _, p := range profiles {
+				marker := " "
+				if p.Active {
+					marker = "*"
+				}
+				out.Printf("%s %s %s\n", marker, p.Name, out.Color().Wrap(output.ANSIGray, p.Email))
+			}
 			return nil
 		},
 	})
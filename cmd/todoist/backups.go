@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+func newBackupsCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "backups",
+		Aliases: []string{"backup"},
+		Short:   "List and download Todoist's automatic backups",
+		Long: `Todoist keeps automatic backups of your account as zip files of CSV
+exports. List them, or download one to keep your own copy.
+
+Examples:
+  todoist backups list
+  todoist backups download --latest --out ~/Backups/todoist
+  todoist backups download "2024-03-01 04:37"`,
+	}
+
+	cmd.AddCommand(newBackupsListCmd(flags))
+	cmd.AddCommand(newBackupsDownloadCmd(flags))
+
+	return cmd
+}
+
+func newBackupsListCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the available backups",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			backups, err := client.GetBackups()
+			if err != nil {
+				return err
+			}
+
+			if flags.asJSON {
+				return out.JSON(backups)
+			}
+			if len(backups) == 0 {
+				out.Printf("%s\n", i18n.T("No backups found."))
+				return nil
+			}
+			for _, b := range backups {
+				out.Printf("%s\n", b.Version)
+			}
+			return nil
+		},
+	}
+}
+
+func newBackupsDownloadCmd(flags *rootFlags) *cobra.Command {
+	var (
+		latest bool
+		dir    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "download [version]",
+		Short: "Download a backup zip file",
+		Long: `Download a backup, named by its version as todoist backups list shows it,
+or the newest one with --latest, into the --out directory (the current
+directory by default). The file is named after the version and readable
+only by you.
+
+Examples:
+  todoist backups download --latest
+  todoist backups download "2024-03-01 04:37" --out backups/`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if latest == (len(args) == 1) {
+				return fmt.Errorf("give a backup version or --latest")
+			}
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			backups, err := client.GetBackups()
+			if err != nil {
+				return err
+			}
+			var backup *api.Backup
+			for i, b := range backups {
+				if latest && (backup == nil || b.Version > backup.Version) || !latest && b.Version == args[0] {
+					backup = &backups[i]
+				}
+			}
+			if backup == nil {
+				if latest {
+					return fmt.Errorf("no backups found")
+				}
+				return fmt.Errorf("backup not found: %s (see todoist backups list)", args[0])
+			}
+
+			path := filepath.Join(dir, backupFileName(backup.Version))
+			size, err := downloadBackup(client, *backup, path)
+			if err != nil {
+				return err
+			}
+
+			if flags.asJSON {
+				return out.JSON(map[string]interface{}{"version": backup.Version, "path": path, "bytes": size})
+			}
+			if flags.quiet {
+				out.Printf("%s\n", path)
+				return nil
+			}
+			out.WriteSuccess(i18n.T("Saved backup %s to %s (%d bytes)", backup.Version, path, size))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&latest, "latest", false, "download the newest backup")
+	cmd.Flags().StringVarP(&dir, "out", "o", ".", "directory to save the backup in")
+
+	return cmd
+}
+
+// backupFileName names the file for a backup version, e.g.
+// todoist-backup-2024-03-01_0437.zip
+func backupFileName(version string) string {
+	name := strings.NewReplacer(" ", "_", ":", "", "/", "-").Replace(version)
+	return "todoist-backup-" + name + ".zip"
+}
+
+// downloadBackup saves a backup to path through a temporary file, so an
+// interrupted download never leaves a partial zip behind
+func downloadBackup(client *api.Client, b api.Backup, path string) (int64, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return 0, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	tmp, err := os.CreateTemp(dir, ".todoist-backup-*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create backup file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	size, err := client.DownloadBackup(context.Background(), b, tmp)
+	if cerr := tmp.Close(); err == nil && cerr != nil {
+		err = fmt.Errorf("failed to write backup: %w", cerr)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, fmt.Errorf("failed to save backup: %w", err)
+	}
+	return size, nil
+}
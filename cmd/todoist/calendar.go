@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/cache"
+	"github.com/buddyh/todoist-cli/internal/caldav"
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// calendarStateAge is how long calendar push remembers what it wrote
+const calendarStateAge = 10 * 365 * 24 * time.Hour
+
+// pushedEntry is a calendar entry written by calendar push
+type pushedEntry struct {
+	Name      string `json:"name"` // resource name in the collection
+	ProjectID string `json:"project_id"`
+	Hash      string `json:"hash"` // of the entry as last written, to skip unchanged ones
+}
+
+// pushCounts counts what calendar push did
+type pushCounts struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Removed   int `json:"removed"`
+}
+
+// calendarEntry makes the calendar entry for a task with a due date, or
+// returns false when its date cannot be read
+func calendarEntry(t api.Task, event bool) (caldav.Entry, bool) {
+	e := caldav.Entry{
+		UID:         "todoist-" + t.ID + "@todoist-cli",
+		Summary:     t.Content,
+		Description: t.Description,
+		URL:         api.TaskURL(t.ID),
+		Categories:  t.Labels,
+		Priority:    map[int]int{4: 1, 3: 3, 2: 5}[t.Priority],
+		Event:       event,
+	}
+	if t.Due == nil {
+		return e, false
+	}
+	if t.Due.Datetime != "" {
+		at, err := time.Parse(time.RFC3339, t.Due.Datetime)
+		if err != nil {
+			// A floating time, without a zone: the local one
+			at, err = time.ParseInLocation("2006-01-02T15:04:05", t.Due.Datetime, output.Location())
+		}
+		e.Due = at
+		return e, err == nil
+	}
+	day, err := time.Parse("2006-01-02", dueDate(t))
+	e.Due, e.AllDay = day, true
+	return e, err == nil
+}
+
+func newCalendarCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "calendar",
+		Short: "Put tasks on a calendar",
+	}
+
+	cmd.AddCommand(newCalendarPushCmd(flags))
+
+	return cmd
+}
+
+func newCalendarPushCmd(flags *rootFlags) *cobra.Command {
+	var (
+		rawURL   string
+		user     string
+		projects []string
+		filter   string
+		events   bool
+		dryRun   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "push",
+		Short: "Write tasks with due dates to a CalDAV calendar",
+		Long: `Write tasks with due dates to a CalDAV calendar collection, such as a
+Nextcloud, Fastmail or iCloud calendar, as to-dos, or as events with
+--events. Each task becomes one entry, named after its ID, so running push
+again updates the entries rather than adding new ones; entries that have
+not changed since the last push are skipped.
+
+Entries for tasks in the pushed projects that are no longer due, because
+they were completed, deleted or had their date removed, are deleted from
+the calendar. With --filter nothing is deleted.
+
+The password is read from TODOIST_CALDAV_PASSWORD, or from the URL.
+
+Examples:
+  todoist calendar push --caldav-url https://dav.example.com/calendars/me/tasks/ --user me -p Work
+  todoist calendar push --caldav-url $URL --filter "7 days" --events
+  todoist calendar push --caldav-url $URL --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+			notes := !flags.asJSON && !flags.quiet
+
+			cal, err := caldav.New(rawURL, user, os.Getenv("TODOIST_CALDAV_PASSWORD"))
+			if err != nil {
+				return err
+			}
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			// The tasks to push, and the projects whose old entries can go
+			var tasks []api.Task
+			scope := make(map[string]bool)
+			if len(projects) == 0 {
+				if tasks, err = client.GetTasks("", filter); err != nil {
+					return err
+				}
+			}
+			for _, name := range projects {
+				p, err := client.FindProject(name)
+				if err != nil {
+					return err
+				}
+				some, err := client.GetTasks(p.ID, filter)
+				if err != nil {
+					return err
+				}
+				tasks = append(tasks, some...)
+				scope[p.ID] = true
+			}
+
+			sum := sha256.Sum256([]byte(cal.URL()))
+			key := config.Settings().ProfileName() + "-caldav-" + hex.EncodeToString(sum[:6])
+			pushed := make(map[string]pushedEntry)
+			cache.Load(key, calendarStateAge, &pushed)
+
+			var counts pushCounts
+			ctx := context.Background()
+			now := time.Now()
+			due := make(map[string]bool)
+			err = func() error {
+				for _, t := range tasks {
+					e, ok := calendarEntry(t, events)
+					if !ok {
+						continue
+					}
+					due[t.ID] = true
+					hash := sha256.Sum256(e.ICS(time.Time{}))
+					entry := pushedEntry{Name: "todoist-" + t.ID + ".ics", ProjectID: t.ProjectID, Hash: hex.EncodeToString(hash[:])}
+					old, seen := pushed[t.ID]
+					switch {
+					case seen && old.Hash == entry.Hash:
+						counts.Unchanged++
+						continue
+					case seen:
+						counts.Updated++
+					default:
+						counts.Created++
+					}
+					if dryRun {
+						continue
+					}
+					if err := cal.Put(ctx, entry.Name, e.ICS(now)); err != nil {
+						return err
+					}
+					pushed[t.ID] = entry
+				}
+
+				if filter != "" {
+					return nil
+				}
+				for id, entry := range pushed {
+					if due[id] || len(scope) > 0 && !scope[entry.ProjectID] {
+						continue
+					}
+					counts.Removed++
+					if dryRun {
+						continue
+					}
+					if err := cal.Delete(ctx, entry.Name); err != nil {
+						return err
+					}
+					delete(pushed, id)
+				}
+				return nil
+			}()
+			// Remember what was written, even when a request failed part way
+			if !dryRun {
+				if serr := cache.Save(key, pushed); serr != nil && notes {
+					fmt.Fprintln(stderr, i18n.T("Warning: could not remember the pushed entries: %v", serr))
+				}
+			}
+			if err != nil {
+				return err
+			}
+
+			if flags.asJSON {
+				return out.JSON(counts)
+			}
+			if flags.quiet {
+				return nil
+			}
+			verb := "Pushed to"
+			if dryRun {
+				verb = "Would push to"
+			}
+			out.Printf("%s %s: %d created, %d updated, %d unchanged, %d removed\n",
+				verb, cal.URL(), counts.Created, counts.Updated, counts.Unchanged, counts.Removed)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&rawURL, "caldav-url", "", "URL of the CalDAV calendar collection (required)")
+	cmd.Flags().StringVar(&user, "user", "", "CalDAV user name")
+	cmd.Flags().StringArrayVarP(&projects, "project", "p", nil, "push this project's tasks (repeatable; default all)")
+	cmd.Flags().StringVarP(&filter, "filter", "f", "", "push only tasks matching this Todoist filter")
+	cmd.Flags().BoolVar(&events, "events", false, "write events instead of to-dos")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "count the changes without writing to the calendar")
+	cmd.MarkFlagRequired("caldav-url")
+
+	return cmd
+}
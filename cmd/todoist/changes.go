@@ -0,0 +1,283 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/cache"
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/dates"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// changesSeenAge is how long the time of the last todoist changes run is
+// remembered
+const changesSeenAge = 10 * 365 * 24 * time.Hour
+
+// change is one thing that happened in a project, taken from the activity log
+type change struct {
+	Kind      string `json:"kind"` // added, completed, rescheduled or commented
+	ProjectID string `json:"project_id"`
+	TaskID    string `json:"task_id"`
+	Content   string `json:"content"`       // the task, or the comment's text
+	Due       string `json:"due,omitempty"` // the new due date of a rescheduled task
+	By        string `json:"by,omitempty"`  // user ID of who made the change
+	At        string `json:"at"`
+}
+
+// changeCounts counts changes by kind
+type changeCounts struct {
+	Added       int `json:"added"`
+	Completed   int `json:"completed"`
+	Rescheduled int `json:"rescheduled"`
+	Comments    int `json:"comments"`
+}
+
+// changesFrom picks the task additions, completions, reschedules and new
+// comments in the given projects out of the activity log
+func changesFrom(events []api.Activity, projects map[string]bool) []change {
+	var changes []change
+	for _, e := range events {
+		if !projects[e.ParentProjectID] {
+			continue
+		}
+		c := change{
+			ProjectID: e.ParentProjectID,
+			TaskID:    e.ObjectID,
+			Content:   e.ExtraData.Content,
+			By:        e.InitiatorID,
+			At:        e.EventDate,
+		}
+		switch {
+		case e.ObjectType == "item" && e.EventType == "added":
+			c.Kind = "added"
+		case e.ObjectType == "item" && e.EventType == "completed":
+			c.Kind = "completed"
+		case e.ObjectType == "item" && e.EventType == "updated" && e.ExtraData.DueDate != e.ExtraData.LastDueDate:
+			c.Kind = "rescheduled"
+			c.Due = e.ExtraData.DueDate
+		case e.ObjectType == "note" && e.EventType == "added":
+			c.Kind = "commented"
+			c.TaskID = e.ParentItemID
+		default:
+			continue
+		}
+		changes = append(changes, c)
+	}
+	return changes
+}
+
+// countChanges tallies changes by kind
+func countChanges(changes []change) changeCounts {
+	var n changeCounts
+	for _, c := range changes {
+		switch c.Kind {
+		case "added":
+			n.Added++
+		case "completed":
+			n.Completed++
+		case "rescheduled":
+			n.Rescheduled++
+		case "commented":
+			n.Comments++
+		}
+	}
+	return n
+}
+
+// line describes the counts, e.g. "3 added, 1 completed, 2 comments"
+func (n changeCounts) line() string {
+	var parts []string
+	if n.Added > 0 {
+		parts = append(parts, i18n.T("%d added", n.Added))
+	}
+	if n.Completed > 0 {
+		parts = append(parts, i18n.T("%d completed", n.Completed))
+	}
+	if n.Rescheduled > 0 {
+		parts = append(parts, i18n.T("%d rescheduled", n.Rescheduled))
+	}
+	if n.Comments == 1 {
+		parts = append(parts, i18n.T("1 comment"))
+	} else if n.Comments > 1 {
+		parts = append(parts, i18n.T("%d comments", n.Comments))
+	}
+	if len(parts) == 0 {
+		return i18n.T("No changes")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// describe says what happened, e.g. `Alice rescheduled "Budget" to 2024-03-20`
+func (c change) describe(who string) string {
+	switch c.Kind {
+	case "rescheduled":
+		if c.Due == "" {
+			return i18n.T("%s removed the date of %q", who, c.Content)
+		}
+		return i18n.T("%s rescheduled %q to %s", who, c.Content, c.Due)
+	case "commented":
+		return i18n.T("%s commented: %q", who, c.Content)
+	case "added":
+		return i18n.T("%s added %q", who, c.Content)
+	case "completed":
+		return i18n.T("%s completed %q", who, c.Content)
+	}
+	return fmt.Sprintf("%s %s %q", who, c.Kind, c.Content)
+}
+
+func newChangesCmd(flags *rootFlags) *cobra.Command {
+	var (
+		since string
+		all   bool
+		peek  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "changes",
+		Short: "Show what changed in shared projects since you last looked",
+		Long: `Summarize the tasks added, completed and rescheduled and the comments
+posted in shared and team projects since the last time you ran
+todoist changes, from the activity log. The first run covers the last day.
+
+The time of each run is remembered per profile; --peek leaves it as it was.
+
+Examples:
+  todoist changes
+  todoist changes --since monday
+  todoist changes --all --peek
+  todoist changes --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+			notes := !flags.asJSON && !flags.quiet
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+			key := config.Settings().ProfileName() + "-changes-seen"
+			var from time.Time
+			switch {
+			case since != "":
+				day, err := dates.Parse(since, now)
+				if err != nil {
+					return fmt.Errorf("invalid --since: %w", err)
+				}
+				loc := output.Location()
+				day = day.In(loc)
+				from = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+			case !cache.Load(key, changesSeenAge, &from):
+				from = now.Add(-24 * time.Hour)
+				if notes {
+					fmt.Fprintln(stderr, i18n.T("First run: showing the last day"))
+				}
+			}
+
+			projects, err := client.GetProjects()
+			if err != nil {
+				return err
+			}
+			watched := make(map[string]bool)
+			names := make(map[string]string, len(projects))
+			for _, p := range projects {
+				names[p.ID] = p.Name
+				if all || p.IsShared || p.WorkspaceID != "" {
+					watched[p.ID] = true
+				}
+			}
+
+			events, err := client.GetActivities(from)
+			if err != nil {
+				return fmt.Errorf("failed to read activity log: %w", err)
+			}
+			changes := changesFrom(events, watched)
+			counts := countChanges(changes)
+
+			if !peek {
+				if err := cache.Save(key, now); err != nil && notes {
+					fmt.Fprintln(stderr, i18n.T("Warning: could not remember this run: %v", err))
+				}
+			}
+
+			if flags.asJSON {
+				if changes == nil {
+					changes = []change{}
+				}
+				return out.JSON(map[string]interface{}{
+					"since":   from.UTC().Format(time.RFC3339),
+					"counts":  counts,
+					"changes": changes,
+				})
+			}
+
+			out.Printf("%s\n", i18n.T("Since %s: %s", from.In(output.Location()).Format("Mon Jan 2 15:04"), counts.line()))
+			if len(changes) == 0 {
+				return nil
+			}
+			who, err := changeAuthors(client, changes)
+			if err != nil {
+				return err
+			}
+
+			var order []string
+			byProject := make(map[string][]change)
+			for _, c := range changes {
+				if byProject[c.ProjectID] == nil {
+					order = append(order, c.ProjectID)
+				}
+				byProject[c.ProjectID] = append(byProject[c.ProjectID], c)
+			}
+			for _, id := range order {
+				name := names[id]
+				if name == "" {
+					name = id
+				}
+				out.Printf("\n%s\n", name)
+				for _, c := range byProject[id] {
+					by := who[c.By]
+					if by == "" {
+						by = i18n.T("Someone")
+					}
+					out.Printf("  %s\n", c.describe(by))
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "show changes from this date instead (e.g. monday, 2024-03-01, -7d)")
+	cmd.Flags().BoolVar(&all, "all", false, "include personal projects")
+	cmd.Flags().BoolVar(&peek, "peek", false, "do not remember this run as the last look")
+
+	return cmd
+}
+
+// changeAuthors names who made the changes: collaborators, and the user,
+// who has no collaborator entry in projects only shared with a team
+func changeAuthors(client *api.Client, changes []change) (map[string]string, error) {
+	names, err := people(client)
+	if err != nil {
+		return nil, err
+	}
+	if names == nil {
+		names = make(map[string]string)
+	}
+	for _, c := range changes {
+		if _, ok := names[c.By]; !ok && c.By != "" {
+			user, err := client.GetUser()
+			if err != nil {
+				return nil, err
+			}
+			names[user.ID] = user.FullName
+			break
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+func newProjectCollaboratorsCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "collaborators <project>",
+		Aliases: []string{"members"},
+		Short:   "List the people a project is shared with",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			p, err := client.FindProject(args[0])
+			if err != nil {
+				return err
+			}
+
+			collaborators, err := client.GetCollaborators(p.ID)
+			if err != nil {
+				return err
+			}
+
+			return out.WriteCollaborators(collaborators)
+		},
+	}
+
+	return cmd
+}
+
+func newProjectShareCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "share <project> <email>",
+		Short: "Invite someone to a project",
+		Long: `Invite someone to a project by email. People without a Todoist account
+receive an invitation email.
+
+Examples:
+  todoist projects share Work alice@example.com`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			p, err := client.FindProject(args[0])
+			if err != nil {
+				return err
+			}
+
+			if err := client.ShareProject(p.ID, args[1]); err != nil {
+				return err
+			}
+
+			out.WriteSuccess(i18n.T("Shared %s with %s", p.Name, args[1]))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func newProjectUnshareCmd(flags *rootFlags) *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "unshare <project> <email>",
+		Short: "Remove someone from a project",
+		Long: `Remove a collaborator from a project. Tasks assigned to them become
+unassigned.
+
+Examples:
+  todoist projects unshare Work alice@example.com
+  todoist projects unshare Work alice@example.com --force`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			p, err := client.FindProject(args[0])
+			if err != nil {
+				return err
+			}
+
+			if !force && !flags.asJSON {
+				fmt.Print(i18n.T("Remove %s from %s%s? [y/N] ", args[1], p.Name, confirmSuffix()))
+				reader := bufio.NewReader(os.Stdin)
+				input, _ := reader.ReadString('\n')
+				if strings.ToLower(strings.TrimSpace(input)) != "y" {
+					out.WriteSuccess(i18n.T("Cancelled"))
+					return nil
+				}
+			}
+
+			if err := client.UnshareProject(p.ID, args[1]); err != nil {
+				return err
+			}
+
+			out.WriteSuccess(i18n.T("Removed %s from %s", args[1], p.Name))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "skip confirmation")
+
+	return cmd
+}
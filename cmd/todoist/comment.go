@@ -1,29 +1,50 @@
 package main
 
 import (
-	"os"
+	"fmt"
 	"strings"
+	"time"
 
-	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
 	"github.com/spf13/cobra"
 )
 
 func newCommentCmd(flags *rootFlags) *cobra.Command {
+	var (
+		since string
+		limit int
+	)
+
 	cmd := &cobra.Command{
 		Use:     "comment <task-id> [message]",
 		Aliases: []string{"note"},
 		Short:   "Add or view comments on a task",
 		Long: `Add a comment to a task, or view existing comments.
 
+Comments are listed oldest first with who posted them and the names of
+attached files. Long threads are fetched page by page; --since and --limit
+narrow them to the recent part.
+
 Examples:
   todoist comment 123456                    # View comments
+  todoist comment 123456 --limit 5          # The 5 latest comments
+  todoist comment 123456 --since monday     # Comments posted since Monday
   todoist comment 123456 "This is a note"   # Add comment`,
 		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			out := output.NewFormatter(os.Stdout, flags.asJSON)
+			out := newFormatter(flags)
 			taskID := args[0]
 
-			client, err := getClient()
+			if limit < 0 {
+				return fmt.Errorf("--limit must not be negative")
+			}
+			sinceDay, err := flagDay(since)
+			if err != nil {
+				return fmt.Errorf("invalid --since: %w", err)
+			}
+
+			client, err := getClientWithFlags(flags)
 			if err != nil {
 				return err
 			}
@@ -39,7 +60,7 @@ Examples:
 				if flags.asJSON {
 					return out.JSON(comment)
 				}
-				out.WriteSuccess("Comment added")
+				out.WriteSuccess(i18n.T("Comment added"))
 				return nil
 			}
 
@@ -48,10 +69,126 @@ Examples:
 			if err != nil {
 				return err
 			}
+			comments = postedSince(comments, sinceDay)
+			if limit > 0 && len(comments) > limit {
+				if !flags.asJSON && !flags.quiet {
+					fmt.Fprintln(stderr, i18n.T("Showing the last %d of %d comments", limit, len(comments)))
+				}
+				comments = comments[len(comments)-limit:]
+			}
 
+			if err := loadAuthors(client, out, comments); err != nil {
+				return err
+			}
 			return out.WriteComments(comments)
 		},
 	}
 
+	cmd.Flags().StringVar(&since, "since", "", "show only comments posted on or after this date (e.g. 2024-01-01, -7d)")
+	cmd.Flags().IntVar(&limit, "limit", 0, "show only the latest N comments")
+
+	cmd.AddCommand(newCommentReactCmd(flags))
+
+	return cmd
+}
+
+func newCommentReactCmd(flags *rootFlags) *cobra.Command {
+	var remove bool
+
+	cmd := &cobra.Command{
+		Use:   "react <comment-id> <emoji>",
+		Short: "React to a comment with an emoji",
+		Long: `Add your reaction to a comment, or take it back with --remove.
+
+Comment IDs are shown by todoist comment <task-id> --json.
+
+Examples:
+  todoist comment react 2995104339 👍
+  todoist comment react 2995104339 👍 --remove`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+			commentID, emoji := args[0], strings.TrimSpace(args[1])
+			if emoji == "" {
+				return fmt.Errorf("give an emoji to react with")
+			}
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+			user, err := client.GetUser()
+			if err != nil {
+				return err
+			}
+			comment, err := client.GetComment(commentID)
+			if err != nil {
+				return err
+			}
+
+			reactions := react(comment.Reactions, emoji, user.ID, !remove)
+			if err := client.SetCommentReactions(commentID, reactions); err != nil {
+				return fmt.Errorf("failed to update reactions: %w", err)
+			}
+			comment.Reactions = reactions
+
+			if flags.asJSON {
+				return out.JSON(comment)
+			}
+			if flags.quiet {
+				out.Printf("%s\n", commentID)
+				return nil
+			}
+			if remove {
+				out.WriteSuccess(i18n.T("Removed %s from comment %s", emoji, commentID))
+			} else {
+				out.WriteSuccess(i18n.T("Reacted %s to comment %s", emoji, commentID))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&remove, "remove", false, "take your reaction back")
+
 	return cmd
 }
+
+// react returns reactions with userID added to (or removed from) emoji,
+// leaving the original map unchanged
+func react(reactions map[string][]string, emoji, userID string, add bool) map[string][]string {
+	updated := make(map[string][]string, len(reactions)+1)
+	for e, users := range reactions {
+		updated[e] = append([]string(nil), users...)
+	}
+
+	var users []string
+	for _, u := range updated[emoji] {
+		if u != userID {
+			users = append(users, u)
+		}
+	}
+	if add {
+		users = append(users, userID)
+	}
+	if len(users) == 0 {
+		delete(updated, emoji)
+	} else {
+		updated[emoji] = users
+	}
+	return updated
+}
+
+// postedSince keeps the comments posted on or after day (YYYY-MM-DD, local
+// time); an empty day keeps them all
+func postedSince(comments []api.Comment, day string) []api.Comment {
+	if day == "" {
+		return comments
+	}
+	var kept []api.Comment
+	for _, c := range comments {
+		if at, err := time.Parse(time.RFC3339, c.PostedAt); err == nil && at.Local().Format("2006-01-02") >= day {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
@@ -2,47 +2,110 @@ package main
 
 import (
 	"fmt"
-	"os"
+	"time"
 
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/dates"
+	"github.com/buddyh/todoist-cli/internal/hooks"
+	"github.com/buddyh/todoist-cli/internal/i18n"
 	"github.com/buddyh/todoist-cli/internal/output"
 	"github.com/spf13/cobra"
 )
 
 func newCompleteCmd(flags *rootFlags) *cobra.Command {
+	var date, match string
+
 	cmd := &cobra.Command{
-		Use:   "complete <task-id>",
-		Short: "Mark a task as complete",
-		Long: `Mark a task as complete by its ID.
+		Use:   "complete [task-id...]",
+		Short: "Mark tasks as complete",
+		Long: `Mark one or more tasks as complete by ID.
+
+Without an ID, pick from today's tasks interactively. --match completes the
+task whose content contains the text instead, looking at today's and
+overdue tasks first and then all tasks; when several match, you choose.
+Use --date to record when the task was actually finished. Completing more
+than confirm_threshold tasks (default 10) lists them and asks first. Tasks
+waiting on a completed one (see todoist deps) are named, and unblocked when
+nothing else holds them up.
 
 Examples:
   todoist complete 1234567890
-  todoist done 1234567890`,
-		Args: cobra.ExactArgs(1),
+  todoist done 1234567890
+  todoist complete 1234567890 --date "yesterday 6pm"
+  todoist complete 1234567890 --date 2024-01-15
+  todoist complete 123 456 789
+  todoist complete --match "call mom"`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runComplete(flags, args[0])
+			return completeArgs(flags, args, date, match)
 		},
 	}
 
+	cmd.Flags().StringVar(&date, "date", "", "completion date/time (e.g., 'yesterday 6pm', '2024-01-15 18:00')")
+	cmd.Flags().StringVarP(&match, "match", "m", "", "complete the task whose content matches this text")
+
 	return cmd
 }
 
 func newDoneCmd(flags *rootFlags) *cobra.Command {
+	var date, match string
+
 	cmd := &cobra.Command{
-		Use:   "done <task-id>",
-		Short: "Mark a task as complete (alias for complete)",
-		Args:  cobra.ExactArgs(1),
+		Use:   "done [task-id...]",
+		Short: "Mark tasks as complete (alias for complete)",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runComplete(flags, args[0])
+			return completeArgs(flags, args, date, match)
 		},
 	}
 
+	cmd.Flags().StringVar(&date, "date", "", "completion date/time (e.g., 'yesterday 6pm', '2024-01-15 18:00')")
+	cmd.Flags().StringVarP(&match, "match", "m", "", "complete the task whose content matches this text")
+
 	return cmd
 }
 
-func runComplete(flags *rootFlags, taskID string) error {
-	out := output.NewFormatter(os.Stdout, flags.asJSON)
+// completeArgs completes the tasks named by IDs, --match, or the picker
+func completeArgs(flags *rootFlags, args []string, date, match string) error {
+	if match != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("cannot combine task IDs with --match")
+		}
+		taskID, err := matchTaskID(flags, match)
+		if err != nil {
+			return err
+		}
+		return runComplete(flags, taskID, date)
+	}
+	if len(args) > 1 {
+		return runCompleteMany(flags, args, date)
+	}
+	taskID, err := taskIDArg(flags, args)
+	if err != nil {
+		return err
+	}
+	return runComplete(flags, taskID, date)
+}
+
+// parseCompletedAt parses --date, returning the zero time when it is empty
+func parseCompletedAt(date string) (time.Time, error) {
+	if date == "" {
+		return time.Time{}, nil
+	}
+	t, err := dates.Parse(date, time.Now())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --date: %w", err)
+	}
+	return t, nil
+}
+
+func runComplete(flags *rootFlags, taskID, date string) error {
+	out := newFormatter(flags)
 
-	client, err := getClient()
+	completedAt, err := parseCompletedAt(date)
+	if err != nil {
+		return err
+	}
+
+	client, err := getClientWithFlags(flags)
 	if err != nil {
 		return err
 	}
@@ -53,10 +116,62 @@ func runComplete(flags *rootFlags, taskID string) error {
 		return err
 	}
 
-	if err := client.CompleteTask(taskID); err != nil {
+	warnBlocked(client, flags, *task)
+	profileBanner(flags, i18n.T("Completing %s", task.Content))
+	if completedAt.IsZero() {
+		if err := client.CompleteTask(taskID); err != nil {
+			return err
+		}
+		hooks.RunPost(stderr, hooks.PostComplete, task)
+		out.WriteSuccess(i18n.T("Completed: %s", task.Content))
+		releaseDependents(client, flags, []api.Task{*task})
+		return nil
+	}
+
+	if err := client.CompleteTaskAt(taskID, completedAt); err != nil {
+		return err
+	}
+	hooks.RunPost(stderr, hooks.PostComplete, task)
+	out.WriteSuccess(i18n.T("Completed: %s (as of %s)", task.Content, output.FormatDate(completedAt, "2006-01-02")+" "+output.FormatClock(completedAt)))
+	releaseDependents(client, flags, []api.Task{*task})
+	return nil
+}
+
+// runCompleteMany completes several tasks in batched requests, asking first
+// when there are more than the confirmation threshold
+func runCompleteMany(flags *rootFlags, taskIDs []string, date string) error {
+	out := newFormatter(flags)
+
+	completedAt, err := parseCompletedAt(date)
+	if err != nil {
+		return err
+	}
+
+	client, err := getClientWithFlags(flags)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := fetchTasks(client, taskIDs)
+	if err != nil {
 		return err
 	}
+	if ok, err := confirmBulk(flags, i18n.T("Complete"), tasks); err != nil || !ok {
+		if err == nil {
+			out.WriteSuccess(i18n.T("Cancelled"))
+		}
+		return err
+	}
+
+	profileBanner(flags, i18n.T("Completing %d tasks", len(tasks)))
+	if err := client.CompleteTasks(taskIDs, completedAt); err != nil {
+		return err
+	}
+	for i := range tasks {
+		hooks.RunPost(stderr, hooks.PostComplete, &tasks[i])
+	}
 
-	out.WriteSuccess(fmt.Sprintf("Completed: %s", task.Content))
+	out.WriteSuccess(i18n.T("Completed %d tasks", len(tasks)))
+	releaseDependents(client, flags, tasks)
 	return nil
 }
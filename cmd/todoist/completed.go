@@ -1,61 +1,188 @@
 package main
 
 import (
+	"fmt"
 	"os"
+	"time"
 
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
 	"github.com/buddyh/todoist-cli/internal/output"
 	"github.com/spf13/cobra"
 )
 
+// maxCompletedPage is the largest page the completed tasks endpoint returns
+const maxCompletedPage = 200
+
 func newCompletedCmd(flags *rootFlags) *cobra.Command {
 	var (
-		project string
-		since   string
-		until   string
-		limit   int
+		project      string
+		since        string
+		until        string
+		limit        int
+		cursor       string
+		all          bool
+		withSubtasks bool
+		report       bool
+		outputFile   string
 	)
 
 	cmd := &cobra.Command{
 		Use:     "completed",
 		Aliases: []string{"history"},
 		Short:   "Show completed tasks",
-		Long: `Show recently completed tasks.
+		Long: `Show recently completed tasks, grouped by day.
+
+Completed subtasks are hidden unless --with-subtasks is given, in which
+case they are nested under their parent.
+
+--since and --until take dates like 2024-01-15, "last monday", -7d,
+"3 days ago" or "beginning of month".
+
+--markdown-report writes a changelog-style markdown document of every task
+completed in the range, grouped by week and section, for sharing status.
 
 Examples:
   todoist completed
   todoist completed --limit 20
   todoist completed --since 2024-01-01
-  todoist completed -p Work`,
+  todoist completed --since "beginning of month"
+  todoist completed -p Work
+  todoist completed --since 2024-01-08 --until 2024-01-14 --all --with-subtasks
+  todoist completed --markdown-report -p Work --since 2024-01-01 -o work.md`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			out := output.NewFormatter(os.Stdout, flags.asJSON)
+			out := newFormatter(flags)
+
+			if outputFile != "" && !report {
+				return fmt.Errorf("--output is only used with --markdown-report")
+			}
+			if report && flags.asJSON {
+				return fmt.Errorf("--markdown-report cannot be combined with --json")
+			}
+
+			var err error
+			if since, err = flagDay(since); err != nil {
+				return fmt.Errorf("invalid --since: %w", err)
+			}
+			if until, err = flagDay(until); err != nil {
+				return fmt.Errorf("invalid --until: %w", err)
+			}
 
-			client, err := getClient()
+			client, err := getClientWithFlags(flags)
 			if err != nil {
 				return err
 			}
 
-			var projectID string
+			var projectID, projectName string
 			if project != "" {
 				p, err := client.FindProject(project)
 				if err != nil {
 					return err
 				}
-				projectID = p.ID
+				projectID, projectName = p.ID, p.Name
 			}
 
-			resp, err := client.GetCompletedTasks(projectID, since, until, limit)
+			// Reports cover the whole range
+			resp, err := fetchCompleted(client, projectID, since, until, cursor, limit, all || report)
 			if err != nil {
 				return err
 			}
 
+			if !withSubtasks {
+				items := resp.Items[:0]
+				for _, t := range resp.Items {
+					if t.ParentID == "" {
+						items = append(items, t)
+					}
+				}
+				resp.Items = items
+			}
+
+			if report {
+				return writeCompletedReport(client, out, resp.Items, projectName, since, until, outputFile)
+			}
+
+			if err := loadNames(client, out); err != nil {
+				return err
+			}
 			return out.WriteCompletedTasks(resp)
 		},
 	}
 
 	cmd.Flags().StringVarP(&project, "project", "p", "", "filter by project")
-	cmd.Flags().StringVar(&since, "since", "", "start date (YYYY-MM-DD)")
-	cmd.Flags().StringVar(&until, "until", "", "end date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&since, "since", "", "start date (e.g. 2024-01-01, \"last monday\", -7d)")
+	cmd.Flags().StringVar(&until, "until", "", "end date")
 	cmd.Flags().IntVarP(&limit, "limit", "n", 30, "max results")
+	cmd.Flags().StringVar(&cursor, "cursor", "", "continue from a previous page's next cursor")
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "fetch every page")
+	cmd.Flags().BoolVar(&withSubtasks, "with-subtasks", false, "include completed subtasks, nested under their parent")
+	cmd.Flags().BoolVar(&report, "markdown-report", false, "print a markdown report grouped by week and section")
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "write the markdown report to this file")
 
 	return cmd
 }
+
+// writeCompletedReport renders the markdown report to stdout or a file.
+// Sections are named after their project when the report spans projects.
+func writeCompletedReport(client *api.Client, out *output.Formatter, items []api.CompletedTask, projectName, since, until, file string) error {
+	projects, sections, err := client.GetProjectsAndSections()
+	if err != nil {
+		return err
+	}
+	projectNames := make(map[string]string, len(projects))
+	for _, p := range projects {
+		projectNames[p.ID] = p.Name
+	}
+
+	r := completedReport{
+		title:    "Completed tasks",
+		since:    since,
+		until:    until,
+		sections: make(map[string]string, len(sections)),
+		loc:      output.Location(),
+	}
+	for _, sec := range sections {
+		r.sections[sec.ID] = sec.Name
+		if projectName == "" {
+			r.sections[sec.ID] = projectNames[sec.ProjectID] + " / " + sec.Name
+		}
+	}
+	if projectName != "" {
+		r.title = projectName + ": completed tasks"
+	}
+
+	md := r.render(items, time.Now())
+	if file == "" {
+		out.Printf("%s", md)
+		return nil
+	}
+	if err := os.WriteFile(file, []byte(md), 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	out.WriteSuccess(i18n.T("Wrote %d tasks to %s", len(items), file))
+	return nil
+}
+
+// fetchCompleted follows cursors from cursor until limit completed tasks are
+// collected, or to the last page when all is set
+func fetchCompleted(client *api.Client, projectID, since, until, cursor string, limit int, all bool) (*api.CompletedTasksResponse, error) {
+	resp := &api.CompletedTasksResponse{}
+	for pages := 1; ; pages++ {
+		pageSize := maxCompletedPage
+		if !all {
+			pageSize = min(limit-len(resp.Items), maxCompletedPage)
+		}
+		page, err := client.GetCompletedTasks(projectID, since, until, pageSize, cursor)
+		if err != nil {
+			return nil, err
+		}
+		resp.Items = append(resp.Items, page.Items...)
+		resp.NextCursor = page.NextCursor
+		cursor = page.NextCursor
+		if cursor == "" || (!all && len(resp.Items) >= limit) {
+			reporter.Report("Fetching completed tasks", pages, pages)
+			return resp, nil
+		}
+		reporter.Report("Fetching completed tasks", pages, 0)
+	}
+}
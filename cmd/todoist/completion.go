@@ -0,0 +1,170 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/cache"
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// completionCacheAge is how long completion candidates are reused before
+// the API is asked again.
+const completionCacheAge = 10 * time.Minute
+
+type completionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// cachedNames returns completion candidates from the local cache, refreshing
+// them from the API when the entry is missing or stale. Errors yield no
+// candidates rather than breaking the shell.
+func cachedNames(key string, fetch func(*api.Client) ([]string, error)) []string {
+	key = config.Settings().ProfileName() + "-" + key
+
+	var names []string
+	if cache.Load(key, completionCacheAge, &names) {
+		return names
+	}
+
+	client, err := getClient()
+	if err != nil {
+		return nil
+	}
+	names, err = fetch(client)
+	if err != nil {
+		return nil
+	}
+	cache.Save(key, names)
+	return names
+}
+
+// matching keeps candidates that start with (or contain) the typed prefix
+func matching(names []string, toComplete string) []string {
+	prefix := strings.ToLower(strings.TrimPrefix(toComplete, "@"))
+	var result []string
+	for _, n := range names {
+		if strings.Contains(strings.ToLower(n), prefix) {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+func completeProjects(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	names := cachedNames("projects", func(c *api.Client) ([]string, error) {
+		projects, err := c.GetProjects()
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, len(projects))
+		for i, p := range projects {
+			names[i] = p.Name
+		}
+		return names, nil
+	})
+	return matching(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeSections completes sections of the --project given on the command
+// line, or every section when no project has been typed yet.
+func completeSections(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	project := ""
+	if f := cmd.Flag("project"); f != nil {
+		project = f.Value.String()
+	}
+
+	names := cachedNames("sections-"+strings.ToLower(project), func(c *api.Client) ([]string, error) {
+		var projectID string
+		if project != "" {
+			p, err := c.FindProject(project)
+			if err != nil {
+				return nil, err
+			}
+			projectID = p.ID
+		}
+		sections, err := c.GetSections(projectID)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, len(sections))
+		for i, s := range sections {
+			names[i] = s.Name
+		}
+		return names, nil
+	})
+	return matching(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeLabels(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	names := cachedNames("labels", func(c *api.Client) ([]string, error) {
+		labels, err := c.GetLabels()
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, len(labels))
+		for i, l := range labels {
+			names[i] = l.Name
+		}
+		return names, nil
+	})
+	return matching(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeFilters offers saved filter queries, described by their names
+func completeFilters(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	entries := cachedNames("filters", func(c *api.Client) ([]string, error) {
+		filters, err := c.GetFilters()
+		if err != nil {
+			return nil, err
+		}
+		entries := make([]string, len(filters))
+		for i, f := range filters {
+			entries[i] = f.Query + "\t" + f.Name
+		}
+		return entries, nil
+	})
+	return matching(entries, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTaskLabelArgs completes label names after the task ID in
+// 'todoist task-label add/remove <task-id> <label...>'
+func completeTaskLabelArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeLabels(cmd, args, toComplete)
+}
+
+// registerCompletions attaches a completion function to a flag if the
+// command defines it.
+func registerCompletions(cmd *cobra.Command, fns map[string]completionFunc) {
+	for name, fn := range fns {
+		if cmd.Flags().Lookup(name) != nil {
+			cmd.RegisterFlagCompletionFunc(name, fn)
+		}
+	}
+}
+
+// registerAllCompletions walks the command tree and wires dynamic
+// completion into every project, section, label and filter flag.
+func registerAllCompletions(root *cobra.Command) {
+	fns := map[string]completionFunc{
+		"project":      completeProjects,
+		"section":      completeSections,
+		"label":        completeLabels,
+		"labels":       completeLabels,
+		"add-label":    completeLabels,
+		"remove-label": completeLabels,
+		"filter":       completeFilters,
+	}
+
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		registerCompletions(cmd, fns)
+		for _, sub := range cmd.Commands() {
+			walk(sub)
+		}
+	}
+	walk(root)
+}
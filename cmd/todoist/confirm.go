@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/buddyh/todoist-cli/internal/picker"
+)
+
+// defaultConfirmThreshold is how many tasks a bulk change may touch before
+// it asks for confirmation
+const defaultConfirmThreshold = 10
+
+// maxConfirmRows caps the summary shown before a bulk change
+const maxConfirmRows = 20
+
+// confirmThreshold returns confirm_threshold from the config, or the
+// default. A negative threshold never asks.
+func confirmThreshold() int {
+	if n := config.Settings().ConfirmAbove; n != 0 {
+		return n
+	}
+	return defaultConfirmThreshold
+}
+
+// needsConfirm reports whether a bulk change to n tasks asks first
+func needsConfirm(flags *rootFlags, n int) bool {
+	limit := confirmThreshold()
+	return !flags.yes && limit >= 0 && n > limit
+}
+
+// fetchTasks gets each task by ID, for summaries and hooks
+func fetchTasks(client *api.Client, taskIDs []string) ([]api.Task, error) {
+	tasks := make([]api.Task, 0, len(taskIDs))
+	for _, id := range taskIDs {
+		t, err := client.GetTask(id)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, *t)
+	}
+	return tasks, nil
+}
+
+// confirmBulk asks before a bulk change when it touches more tasks than the
+// threshold and --yes was not given. It returns false when the user declines.
+func confirmBulk(flags *rootFlags, action string, tasks []api.Task) (bool, error) {
+	if !needsConfirm(flags, len(tasks)) {
+		return true, nil
+	}
+	return askBulk(flags, action, tasks)
+}
+
+// askBulk lists the tasks a bulk change will touch and asks before going
+// ahead; action is the translated verb of the prompt, e.g. "Delete".
+// Without a terminal to ask on, or with --json, it fails instead of
+// guessing.
+func askBulk(flags *rootFlags, action string, tasks []api.Task) (bool, error) {
+	if !canAsk(flags) {
+		return false, fmt.Errorf("refusing to change %d tasks without confirmation; pass --yes to proceed", len(tasks))
+	}
+
+	mode, _ := parseColorMode(flags.color)
+	summary := output.NewFormatterWithColor(os.Stderr, false, mode)
+	summary.SetTable([]string{"id", "priority", "due", "content"})
+	shown := tasks
+	if len(shown) > maxConfirmRows {
+		shown = shown[:maxConfirmRows]
+	}
+	if err := summary.WriteTasks(shown); err != nil {
+		return false, err
+	}
+	if more := len(tasks) - len(shown); more > 0 {
+		fmt.Fprintln(os.Stderr, i18n.T("... and %d more", more))
+	}
+
+	return askYes(i18n.T("%s %d tasks%s? [y/N] ", action, len(tasks), confirmSuffix())), nil
+}
+
+// canAsk reports whether a confirmation prompt has someone to answer it:
+// stdin is a terminal and --json is not set
+func canAsk(flags *rootFlags) bool {
+	return !flags.asJSON && picker.IsTerminal(os.Stdin)
+}
+
+// askYes shows prompt on stderr and reports whether the answer read from
+// stdin is yes
+func askYes(prompt string) bool {
+	fmt.Fprint(os.Stderr, prompt)
+	input, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	return strings.ToLower(strings.TrimSpace(input)) == "y"
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+func newProjectCopyCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "copy <project> <new-name>",
+		Short: "Copy a project with its sections and open tasks",
+		Long: `Create a new project with the same color, sections and open tasks,
+including subtasks. Completed tasks and comments are not copied.
+
+Examples:
+  todoist projects copy "Client template" "Client C"`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			src, err := client.FindProject(args[0])
+			if err != nil {
+				return err
+			}
+			sections, err := client.GetSections(src.ID)
+			if err != nil {
+				return err
+			}
+			tasks, err := client.GetTasks(src.ID, "")
+			if err != nil {
+				return err
+			}
+
+			var batch api.Batch
+			projectID := batch.AddProject(args[1], src.Color, src.ParentID)
+			sectionIDs := make(map[string]string, len(sections))
+			sort.SliceStable(sections, func(i, j int) bool { return sections[i].SectionOrder < sections[j].SectionOrder })
+			for _, s := range sections {
+				sectionIDs[s.ID] = batch.AddSection(s.Name, projectID)
+			}
+			queueTaskCopies(&batch, tasks, projectID, sectionIDs)
+
+			copyBanner(flags, fmt.Sprintf("Copying %s: %d sections, %d tasks", src.Name, len(sections), len(tasks)))
+			ids, err := client.CommitBatch(&batch)
+			if err != nil {
+				return fmt.Errorf("failed to copy project: %w", err)
+			}
+
+			project, err := client.GetProject(ids[projectID])
+			if err != nil {
+				return err
+			}
+			return out.WriteProject(project)
+		},
+	}
+
+	return cmd
+}
+
+func newSectionCopyCmd(flags *rootFlags) *cobra.Command {
+	var to string
+
+	cmd := &cobra.Command{
+		Use:   "copy <project>/<section>",
+		Short: "Copy a section and its open tasks to a project",
+		Long: `Create a section with the same name in the target project and copy the
+section's open tasks, including subtasks, into it.
+
+Examples:
+  todoist sections copy "Templates/Launch checklist" --to "Product X"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			projectName, sectionName, ok := strings.Cut(args[0], "/")
+			if !ok || projectName == "" || sectionName == "" {
+				return fmt.Errorf("name the section as Project/Section")
+			}
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			projects, err := client.GetProjects()
+			if err != nil {
+				return err
+			}
+			src, err := api.MatchProject(projects, projectName)
+			if err != nil {
+				return err
+			}
+			dst, err := api.MatchProject(projects, to)
+			if err != nil {
+				return err
+			}
+
+			sections, err := client.GetSections(src.ID)
+			if err != nil {
+				return err
+			}
+			sectionID := findSectionID(sections, sectionName)
+			if sectionID == "" {
+				return fmt.Errorf("section not found in %s: %s", src.Name, sectionName)
+			}
+			var section api.Section
+			for _, s := range sections {
+				if s.ID == sectionID {
+					section = s
+				}
+			}
+
+			all, err := client.GetTasks(src.ID, "")
+			if err != nil {
+				return err
+			}
+			var tasks []api.Task
+			for _, t := range all {
+				if t.SectionID == sectionID {
+					tasks = append(tasks, t)
+				}
+			}
+
+			var batch api.Batch
+			newSection := batch.AddSection(section.Name, dst.ID)
+			queueTaskCopies(&batch, tasks, dst.ID, map[string]string{sectionID: newSection})
+
+			copyBanner(flags, fmt.Sprintf("Copying %s/%s: %d tasks", src.Name, section.Name, len(tasks)))
+			ids, err := client.CommitBatch(&batch)
+			if err != nil {
+				return fmt.Errorf("failed to copy section: %w", err)
+			}
+
+			copied := api.Section{ID: ids[newSection], ProjectID: dst.ID, Name: section.Name}
+			if flags.asJSON {
+				return out.JSON(copied)
+			}
+			out.WriteSuccess(i18n.T("Copied section %s to %s (%d tasks)", section.Name, dst.Name, len(tasks)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&to, "to", "", "target project name (required)")
+	cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+// queueTaskCopies adds tasks to the batch in projectID, parents before their
+// subtasks, mapping each section through sectionIDs. Subtasks whose parent
+// is not among tasks become top-level tasks.
+func queueTaskCopies(batch *api.Batch, tasks []api.Task, projectID string, sectionIDs map[string]string) {
+	present := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		present[t.ID] = true
+	}
+
+	children := make(map[string][]api.Task)
+	for _, t := range tasks {
+		parent := t.ParentID
+		if !present[parent] {
+			parent = ""
+		}
+		children[parent] = append(children[parent], t)
+	}
+	for _, list := range children {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].ChildOrder < list[j].ChildOrder })
+	}
+
+	var queue func(parentID, newParentID string)
+	queue = func(parentID, newParentID string) {
+		for _, t := range children[parentID] {
+			sectionID := ""
+			if newParentID == "" {
+				sectionID = sectionIDs[t.SectionID]
+			}
+			queue(t.ID, batch.AddTask(t, projectID, sectionID, newParentID))
+		}
+	}
+	queue("", "")
+}
+
+// copyBanner announces a copy on stderr, since large ones take a while
+func copyBanner(flags *rootFlags, msg string) {
+	if !flags.quiet && !flags.asJSON {
+		fmt.Fprintln(stderr, msg)
+	}
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/daemon"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+func newDaemonCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Run a background service that speeds up other commands",
+		Long: `Run in the foreground, forwarding API requests from other todoist commands
+over a local socket.
+
+Each command normally opens a new connection to the API, paying for DNS,
+TCP and TLS setup every time. While the daemon runs, commands send their
+requests through it instead and reuse its open HTTP/2 connections, which
+makes short commands such as status and next noticeably faster.
+
+The daemon also keeps what separate commands cannot share: one rate limit
+budget, so concurrent commands pace together, and the state of Sync reads,
+which it refreshes with incremental syncs instead of full ones.
+
+Commands fall back to connecting directly whenever the daemon is not
+running. Stop it with Ctrl-C, todoist daemon stop, or a service manager.
+
+Examples:
+  todoist daemon &
+  todoist daemon status
+  todoist daemon stop`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			socket := daemon.SocketPath()
+			if !flags.quiet {
+				fmt.Fprintln(stderr, i18n.T("Listening on %s", socket))
+			}
+			return daemon.Serve(ctx, socket, api.Transport())
+		},
+	}
+
+	cmd.AddCommand(newDaemonStatusCmd(flags))
+	cmd.AddCommand(newDaemonStopCmd(flags))
+	cmd.AddCommand(newDaemonInstallCmd(flags))
+	cmd.AddCommand(newDaemonUninstallCmd(flags))
+
+	return cmd
+}
+
+func newDaemonStatusCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show whether the daemon is running and what it has served",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			socket := daemon.SocketPath()
+			var st *daemon.Status
+			if daemon.Running(socket) {
+				st = &daemon.Status{}
+				if err := daemon.Call(socket, "status", st); err != nil {
+					return err
+				}
+			}
+
+			if flags.asJSON {
+				return out.JSON(map[string]interface{}{
+					"running": st != nil,
+					"socket":  socket,
+					"daemon":  st,
+				})
+			}
+			if st == nil {
+				out.Printf("%s\n", i18n.T("Daemon not running"))
+				return nil
+			}
+			out.Printf("%s\n", i18n.T("Daemon running on %s (pid %d)", socket, st.PID))
+			out.Printf("%s\n", i18n.T("Up:       %s", time.Since(st.Started).Round(time.Second)))
+			out.Printf("%s\n", i18n.T("Requests: %d (%d Sync reads answered incrementally)", st.Requests, st.SyncReads))
+			if st.RateLimit != nil {
+				out.Printf("%s\n", i18n.T("Budget:   %d of %d requests left", st.RateLimit.Remaining, st.RateLimit.Limit))
+			}
+			return nil
+		},
+	}
+}
+
+func newDaemonStopCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "stop",
+		Short: "Stop the running daemon",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			socket := daemon.SocketPath()
+			if !daemon.Running(socket) {
+				return fmt.Errorf("daemon not running")
+			}
+			if err := daemon.Call(socket, "stop", nil); err != nil {
+				return fmt.Errorf("failed to stop daemon: %w", err)
+			}
+			if flags.asJSON {
+				return out.JSON(map[string]interface{}{"stopped": true})
+			}
+			out.WriteSuccess(i18n.T("Daemon stopped"))
+			return nil
+		},
+	}
+}
+
+// serviceName is what a service may be called: it becomes part of file names
+var serviceName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// serviceCtl runs systemctl or launchctl; replaced in tests
+var serviceCtl = func(name string, args ...string) error {
+	out, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%s %s: %s", name, strings.Join(args, " "), msg)
+		}
+		return fmt.Errorf("%s %s: %w", name, strings.Join(args, " "), err)
+	}
+	return nil
+}
+
+// serviceFile returns where the service manager of this platform keeps the
+// service's definition, and what it says
+func serviceFile(s daemon.Service) (string, string, error) {
+	switch runtime.GOOS {
+	case "linux":
+		return filepath.Join(daemon.SystemdDir(), s.UnitName()), s.SystemdUnit(), nil
+	case "darwin":
+		home, _ := os.UserHomeDir()
+		logPath := filepath.Join(home, "Library", "Logs", "todoist-"+s.Name+".log")
+		return filepath.Join(daemon.LaunchAgentsDir(), s.Label()+".plist"), s.LaunchdPlist(logPath), nil
+	}
+	return "", "", fmt.Errorf("services are only supported with systemd on Linux and launchd on macOS")
+}
+
+// startService enables the service at login and starts it now
+func startService(s daemon.Service, path string) error {
+	if runtime.GOOS == "darwin" {
+		// Reloading picks up a changed plist
+		serviceCtl("launchctl", "unload", path)
+		return serviceCtl("launchctl", "load", "-w", path)
+	}
+	if err := serviceCtl("systemctl", "--user", "daemon-reload"); err != nil {
+		return err
+	}
+	return serviceCtl("systemctl", "--user", "enable", "--now", s.UnitName())
+}
+
+// stopService stops the service and keeps it from starting at login
+func stopService(s daemon.Service, path string) error {
+	if runtime.GOOS == "darwin" {
+		return serviceCtl("launchctl", "unload", "-w", path)
+	}
+	return serviceCtl("systemctl", "--user", "disable", "--now", s.UnitName())
+}
+
+func newDaemonInstallCmd(flags *rootFlags) *cobra.Command {
+	var (
+		name      string
+		printOnly bool
+		noStart   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "install [-- <command>...]",
+		Short: "Run the daemon, or another command, as a login service",
+		Long: `Write a systemd user unit (Linux) or launchd agent (macOS) that starts
+todoist daemon at login and restarts it when it fails, then enable and
+start it. Running install again updates the service.
+
+Any other long-running command can be installed instead by giving it after
+--, under its own --name; its output goes to the journal on Linux and to
+~/Library/Logs/todoist-<name>.log on macOS. The service uses the profile
+chosen with --profile.
+
+Examples:
+  todoist daemon install
+  todoist daemon install --print
+  todoist daemon install --name events -- events --follow --log-format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+			if !serviceName.MatchString(name) {
+				return fmt.Errorf("invalid --name %q (use lowercase letters, digits and dashes)", name)
+			}
+			if len(args) == 0 {
+				args = []string{"daemon"}
+			}
+			if flags.profile != "" {
+				args = append([]string{"--profile", flags.profile}, args...)
+			}
+
+			exe, err := os.Executable()
+			if err != nil {
+				return fmt.Errorf("failed to find the todoist executable: %w", err)
+			}
+			if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+				exe = resolved
+			}
+			svc := daemon.Service{Name: name, Exe: exe, Args: args}
+			path, content, err := serviceFile(svc)
+			if err != nil {
+				return err
+			}
+			if printOnly {
+				out.Printf("%s", content)
+				return nil
+			}
+
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+			}
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", path, err)
+			}
+			if !noStart {
+				if err := startService(svc, path); err != nil {
+					return fmt.Errorf("wrote %s but could not start it: %w", path, err)
+				}
+			}
+
+			if flags.asJSON {
+				return out.JSON(map[string]interface{}{"name": name, "path": path, "command": args, "started": !noStart})
+			}
+			if noStart {
+				out.WriteSuccess(i18n.T("Wrote %s", path))
+			} else {
+				out.WriteSuccess(i18n.T("Installed and started todoist %s (%s)", strings.Join(args, " "), path))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "daemon", "name of the service, e.g. todoist-daemon")
+	cmd.Flags().BoolVar(&printOnly, "print", false, "print the service definition without installing it")
+	cmd.Flags().BoolVar(&noStart, "no-start", false, "write the service definition without enabling or starting it")
+
+	return cmd
+}
+
+func newDaemonUninstallCmd(flags *rootFlags) *cobra.Command {
+	var name string
+
+	cmd := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Stop and remove a service written by daemon install",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+			if !serviceName.MatchString(name) {
+				return fmt.Errorf("invalid --name %q (use lowercase letters, digits and dashes)", name)
+			}
+
+			svc := daemon.Service{Name: name}
+			path, _, err := serviceFile(svc)
+			if err != nil {
+				return err
+			}
+			if _, err := os.Stat(path); err != nil {
+				return fmt.Errorf("no service named %s is installed (%s)", name, path)
+			}
+			if err := stopService(svc, path); err != nil {
+				fmt.Fprintln(stderr, i18n.T("Warning: %v", err))
+			}
+			if err := os.Remove(path); err != nil {
+				return fmt.Errorf("failed to remove %s: %w", path, err)
+			}
+			if runtime.GOOS == "linux" {
+				serviceCtl("systemctl", "--user", "daemon-reload")
+			}
+
+			if flags.asJSON {
+				return out.JSON(map[string]interface{}{"name": name, "path": path, "removed": true})
+			}
+			out.WriteSuccess(i18n.T("Removed %s", path))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "daemon", "name of the service to remove")
+
+	return cmd
+}
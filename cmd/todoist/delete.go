@@ -6,7 +6,8 @@ import (
 	"os"
 	"strings"
 
-	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/buddyh/todoist-cli/internal/hooks"
+	"github.com/buddyh/todoist-cli/internal/i18n"
 	"github.com/spf13/cobra"
 )
 
@@ -14,22 +15,29 @@ func newDeleteCmd(flags *rootFlags) *cobra.Command {
 	var force bool
 
 	cmd := &cobra.Command{
-		Use:     "delete <task-id>",
+		Use:     "delete [task-id...]",
 		Aliases: []string{"rm", "remove"},
-		Short:   "Delete a task permanently",
-		Long: `Delete a task permanently by its ID.
+		Short:   "Delete tasks permanently",
+		Long: `Delete one or more tasks permanently by ID.
 
 This action cannot be undone. Use 'todoist complete' to mark as done instead.
+Without an ID, pick from today's tasks interactively.
 
 Examples:
   todoist delete 1234567890
-  todoist delete 1234567890 --force  # Skip confirmation`,
-		Args: cobra.ExactArgs(1),
+  todoist delete 1234567890 --force  # Skip confirmation
+  todoist delete 123 456 789`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			out := output.NewFormatter(os.Stdout, flags.asJSON)
-			taskID := args[0]
+			out := newFormatter(flags)
+			if len(args) > 1 {
+				return runDeleteMany(flags, args, force)
+			}
+			taskID, err := taskIDArg(flags, args)
+			if err != nil {
+				return err
+			}
 
-			client, err := getClient()
+			client, err := getClientWithFlags(flags)
 			if err != nil {
 				return err
 			}
@@ -42,20 +50,25 @@ Examples:
 
 			// Confirm unless force flag
 			if !force && !flags.asJSON {
-				fmt.Printf("Delete task: %s\nThis cannot be undone. Continue? [y/N] ", task.Content)
+				fmt.Print(i18n.T("Delete task: %s%s\nThis cannot be undone. Continue? [y/N] ", task.Content, confirmSuffix()))
 				reader := bufio.NewReader(os.Stdin)
 				input, _ := reader.ReadString('\n')
 				if strings.ToLower(strings.TrimSpace(input)) != "y" {
-					out.WriteSuccess("Cancelled")
+					out.WriteSuccess(i18n.T("Cancelled"))
 					return nil
 				}
 			}
 
+			if err := hooks.Run(stderr, hooks.PreDelete, task); err != nil {
+				return err
+			}
+
 			if err := client.DeleteTask(taskID); err != nil {
 				return err
 			}
+			hooks.RunPost(stderr, hooks.PostDelete, task)
 
-			out.WriteSuccess(fmt.Sprintf("Deleted: %s", task.Content))
+			out.WriteSuccess(i18n.T("Deleted: %s", task.Content))
 			return nil
 		},
 	}
@@ -64,3 +77,48 @@ Examples:
 
 	return cmd
 }
+
+// runDeleteMany deletes several tasks in batched requests after listing them.
+// Like a single delete it asks unless --force is given; with --json it only
+// asks above the confirmation threshold.
+func runDeleteMany(flags *rootFlags, taskIDs []string, force bool) error {
+	out := newFormatter(flags)
+
+	client, err := getClientWithFlags(flags)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := fetchTasks(client, taskIDs)
+	if err != nil {
+		return err
+	}
+
+	if !force && (needsConfirm(flags, len(tasks)) || !flags.asJSON && !flags.yes) {
+		ok, err := askBulk(flags, i18n.T("Delete"), tasks)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			out.WriteSuccess(i18n.T("Cancelled"))
+			return nil
+		}
+	}
+
+	for i := range tasks {
+		if err := hooks.Run(stderr, hooks.PreDelete, &tasks[i]); err != nil {
+			return err
+		}
+	}
+
+	profileBanner(flags, i18n.T("Deleting %d tasks", len(tasks)))
+	if err := client.DeleteTasks(taskIDs); err != nil {
+		return err
+	}
+	for i := range tasks {
+		hooks.RunPost(stderr, hooks.PostDelete, &tasks[i])
+	}
+
+	out.WriteSuccess(i18n.T("Deleted %d tasks", len(tasks)))
+	return nil
+}
@@ -0,0 +1,452 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// defaultBlockedLabel marks tasks waiting on others unless "blocked_label"
+// is configured
+const defaultBlockedLabel = "blocked"
+
+func blockedLabel() string {
+	if l := strings.TrimPrefix(config.Settings().BlockedLabel, "@"); l != "" {
+		return l
+	}
+	return defaultBlockedLabel
+}
+
+// blockedByLine matches a "blocked-by: <id>, <id>" line in a description
+var blockedByLine = regexp.MustCompile(`(?im)^[ \t]*blocked-by:[ \t]*(.*?)[ \t]*$`)
+
+// blockers returns the IDs of the tasks a task waits on, from the
+// blocked-by lines of its description
+func blockers(t api.Task) []string {
+	var ids []string
+	for _, m := range blockedByLine.FindAllStringSubmatch(t.Description, -1) {
+		for _, id := range strings.FieldsFunc(m[1], func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+			if id = strings.TrimPrefix(id, "#"); id != "" && !contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// withBlocker adds a blocked-by line for id to a description
+func withBlocker(description, id string) string {
+	if contains(blockers(api.Task{Description: description}), id) {
+		return description
+	}
+	if description != "" && !strings.HasSuffix(description, "\n") {
+		description += "\n"
+	}
+	return description + "blocked-by: " + id
+}
+
+// withoutBlocker removes id from the blocked-by lines of a description,
+// dropping lines left empty
+func withoutBlocker(description, id string) string {
+	lines := strings.Split(description, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		m := blockedByLine.FindStringSubmatch(line)
+		if m == nil {
+			kept = append(kept, line)
+			continue
+		}
+		var rest []string
+		for _, b := range blockers(api.Task{Description: line}) {
+			if b != id {
+				rest = append(rest, b)
+			}
+		}
+		if len(rest) > 0 {
+			kept = append(kept, "blocked-by: "+strings.Join(rest, ", "))
+		}
+	}
+	return strings.TrimRight(strings.Join(kept, "\n"), "\n")
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// depNode is a task in the dependency graph
+type depNode struct {
+	TaskID    string   `json:"task_id"`
+	Content   string   `json:"content"`
+	BlockedBy []string `json:"blocked_by,omitempty"` // every blocker named
+	WaitingOn []string `json:"waiting_on,omitempty"` // the blockers still open
+	Blocks    []string `json:"blocks,omitempty"`     // open tasks waiting on this one
+	Labeled   bool     `json:"labeled"`              // has the blocked label
+	task      *api.Task
+}
+
+// depGraph links the open tasks that take part in dependencies, in the
+// order given. Blockers that are not among the tasks count as done.
+func depGraph(tasks []api.Task, label string) []*depNode {
+	open := make(map[string]*api.Task, len(tasks))
+	for i := range tasks {
+		open[tasks[i].ID] = &tasks[i]
+	}
+	nodes := make(map[string]*depNode)
+	node := func(t *api.Task) *depNode {
+		n := nodes[t.ID]
+		if n == nil {
+			n = &depNode{TaskID: t.ID, Content: t.Content, task: t}
+			for _, l := range t.Labels {
+				n.Labeled = n.Labeled || strings.EqualFold(l, label)
+			}
+			nodes[t.ID] = n
+		}
+		return n
+	}
+	for i := range tasks {
+		t := &tasks[i]
+		ids := blockers(*t)
+		if len(ids) == 0 {
+			continue
+		}
+		n := node(t)
+		n.BlockedBy = ids
+		for _, id := range ids {
+			if b := open[id]; b != nil && id != t.ID {
+				n.WaitingOn = append(n.WaitingOn, id)
+				bn := node(b)
+				bn.Blocks = append(bn.Blocks, t.ID)
+			}
+		}
+	}
+
+	list := make([]*depNode, 0, len(nodes))
+	for i := range tasks {
+		if n := nodes[tasks[i].ID]; n != nil {
+			list = append(list, n)
+		}
+	}
+	return list
+}
+
+func newDepsCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "deps",
+		Aliases: []string{"dependencies"},
+		Short:   "Show which tasks are waiting on others",
+		Long: `Tasks can wait on others: a line "blocked-by: <id>" in a task's
+description (several IDs separated by commas, or several lines) makes it
+wait until those tasks are completed. Waiting tasks are labeled @blocked so
+they stand out, and filters such as "today & !@blocked" can hide them.
+
+todoist deps shows the open tasks that block others, each followed by the
+tasks waiting on it, indented. Tasks whose blockers are all done but still
+carry the label are listed as ready. Completing a task names the tasks that
+were waiting on it and removes the label from those with nothing left to
+wait on.
+
+Change the label with "blocked_label" in ~/.todoist-cli/config.json.
+
+Examples:
+  todoist deps
+  todoist deps add 456 123        # 456 waits on 123
+  todoist deps remove 456 123
+  todoist deps --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+			label := blockedLabel()
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			tasks, err := client.GetTasks("", "")
+			if err != nil {
+				return err
+			}
+			out.TaskOrder().Sort(tasks)
+			graph := depGraph(tasks, label)
+
+			if flags.asJSON {
+				if graph == nil {
+					graph = []*depNode{}
+				}
+				return out.JSON(graph)
+			}
+			if flags.quiet {
+				for _, n := range graph {
+					if len(n.WaitingOn) > 0 {
+						out.Printf("%s\n", n.TaskID)
+					}
+				}
+				return nil
+			}
+
+			byID := make(map[string]*depNode, len(graph))
+			var roots, ready []*depNode
+			for _, n := range graph {
+				byID[n.TaskID] = n
+				switch {
+				case len(n.WaitingOn) == 0 && len(n.Blocks) > 0:
+					roots = append(roots, n)
+				case len(n.WaitingOn) == 0 && n.Labeled:
+					ready = append(ready, n)
+				}
+			}
+			waiting := 0
+			for _, n := range graph {
+				if len(n.WaitingOn) > 0 {
+					waiting++
+				}
+			}
+			if waiting == 0 && len(ready) == 0 {
+				out.Printf("%s\n", i18n.T("No dependencies found."))
+				return nil
+			}
+
+			shown := make(map[string]bool)
+			var walk func(n *depNode, depth int, path map[string]bool)
+			walk = func(n *depNode, depth int, path map[string]bool) {
+				line := strings.Repeat("  ", depth) + out.FormatTaskLine(n.task)
+				if len(n.WaitingOn) > 1 {
+					line += out.Color().Wrap(output.ANSIGray, fmt.Sprintf("  (waiting on %d)", len(n.WaitingOn)))
+				}
+				if len(n.WaitingOn) > 0 && !n.Labeled {
+					line += out.Color().Wrap(output.ANSIYellow, "  (not labeled @"+label+")")
+				}
+				if path[n.TaskID] {
+					out.Printf("%s%s\n", line, out.Color().Wrap(output.ANSIRed, "  (cycle)"))
+					return
+				}
+				out.Printf("%s\n", line)
+				shown[n.TaskID] = true
+				path[n.TaskID] = true
+				defer delete(path, n.TaskID)
+				for _, id := range n.Blocks {
+					walk(byID[id], depth+1, path)
+				}
+			}
+			for _, n := range roots {
+				walk(n, 0, map[string]bool{})
+			}
+			// Tasks in a cycle, which no unblocked task leads to
+			for _, n := range graph {
+				if !shown[n.TaskID] && len(n.WaitingOn) > 0 {
+					walk(n, 0, map[string]bool{})
+				}
+			}
+
+			if len(ready) > 0 {
+				out.Printf("\nReady (blockers done):\n")
+				for _, n := range ready {
+					out.Printf("  %s\n", out.FormatTaskLine(n.task))
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.AddCommand(newDepsAddCmd(flags))
+	cmd.AddCommand(newDepsRemoveCmd(flags))
+
+	return cmd
+}
+
+func newDepsAddCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "add <task-id> <blocker-id...>",
+		Short: "Make a task wait on others",
+		Long: `Add blocked-by lines for the blockers to the task's description and label
+it @blocked.
+
+Examples:
+  todoist deps add 456 123
+  todoist deps add 789 123 456`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			task, err := client.GetTask(args[0])
+			if err != nil {
+				return err
+			}
+			description := task.Description
+			for _, id := range args[1:] {
+				if id == task.ID {
+					return fmt.Errorf("a task cannot wait on itself")
+				}
+				blocker, err := client.GetTask(id)
+				if err != nil {
+					return err
+				}
+				description = withBlocker(description, blocker.ID)
+			}
+
+			if description != task.Description {
+				if task, err = client.UpdateTask(task.ID, api.UpdateTaskParams{Description: description}); err != nil {
+					return err
+				}
+			}
+			if task, err = client.SetTaskLabels(task.ID, addLabel(task.Labels, blockedLabel())); err != nil {
+				return err
+			}
+
+			if flags.asJSON || flags.quiet {
+				return out.WriteTask(task)
+			}
+			out.WriteSuccess(i18n.T("%s now waits on %s", task.Content, strings.Join(blockers(*task), ", ")))
+			return nil
+		},
+	}
+}
+
+func newDepsRemoveCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove <task-id> <blocker-id...>",
+		Aliases: []string{"rm"},
+		Short:   "Stop a task waiting on others",
+		Long: `Remove the blockers from the task's blocked-by lines, and its @blocked
+label once it waits on nothing.
+
+Examples:
+  todoist deps remove 456 123`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			task, err := client.GetTask(args[0])
+			if err != nil {
+				return err
+			}
+			description := task.Description
+			for _, id := range args[1:] {
+				description = withoutBlocker(description, id)
+			}
+
+			switch {
+			case description == task.Description:
+			case description == "":
+				task, err = client.ClearTaskFields(task.ID, api.ClearTaskParams{Description: true})
+			default:
+				task, err = client.UpdateTask(task.ID, api.UpdateTaskParams{Description: description})
+			}
+			if err != nil {
+				return err
+			}
+			if len(blockers(*task)) == 0 {
+				if task, err = client.SetTaskLabels(task.ID, removeLabel(task.Labels, blockedLabel())); err != nil {
+					return err
+				}
+			}
+
+			if flags.asJSON || flags.quiet {
+				return out.WriteTask(task)
+			}
+			if ids := blockers(*task); len(ids) > 0 {
+				out.WriteSuccess(i18n.T("%s still waits on %s", task.Content, strings.Join(ids, ", ")))
+			} else {
+				out.WriteSuccess(i18n.T("%s no longer waits on other tasks", task.Content))
+			}
+			return nil
+		},
+	}
+}
+
+// releaseDependents reports the tasks that were waiting on the completed
+// ones, and removes the blocked label from those with no open blockers
+// left. Waiting tasks are found by their label; failures only leave them
+// as they are, since the completion itself succeeded.
+func releaseDependents(client *api.Client, flags *rootFlags, completed []api.Task) {
+	label := blockedLabel()
+	waiting, err := client.GetTasks("", "@"+label)
+	if err != nil {
+		return
+	}
+	done := make(map[string]bool, len(completed))
+	for _, t := range completed {
+		done[t.ID] = true
+	}
+	notes := !flags.asJSON && !flags.quiet
+
+	for _, t := range waiting {
+		ids := blockers(t)
+		released := false
+		var open []string
+		for _, id := range ids {
+			switch {
+			case done[id]:
+				released = true
+			case blockerOpen(client, id):
+				open = append(open, id)
+			}
+		}
+		if !released {
+			continue
+		}
+		if len(open) > 0 {
+			if notes {
+				fmt.Fprintln(stderr, i18n.T("Still blocked: %s (waiting on %s)", t.Content, strings.Join(open, ", ")))
+			}
+			continue
+		}
+		if _, err := client.SetTaskLabels(t.ID, removeLabel(t.Labels, label)); err != nil {
+			if notes {
+				fmt.Fprintln(stderr, i18n.T("Warning: could not unblock %s: %v", t.Content, err))
+			}
+			continue
+		}
+		if notes {
+			fmt.Fprintln(stderr, i18n.T("Unblocked: %s (%s)", t.Content, t.ID))
+		}
+	}
+}
+
+// warnBlocked notes when a task being completed still waits on open tasks
+func warnBlocked(client *api.Client, flags *rootFlags, t api.Task) {
+	if flags.asJSON || flags.quiet {
+		return
+	}
+	var open []string
+	for _, id := range blockers(t) {
+		if blockerOpen(client, id) {
+			open = append(open, id)
+		}
+	}
+	if len(open) > 0 {
+		fmt.Fprintln(stderr, i18n.T("Note: %s was still waiting on %s", t.Content, strings.Join(open, ", ")))
+	}
+}
+
+// blockerOpen reports whether a blocker is an open task; deleted and
+// completed tasks no longer block. When it cannot be looked up, it is
+// taken to still be open.
+func blockerOpen(client *api.Client, id string) bool {
+	t, err := client.GetTask(id)
+	if err != nil {
+		return api.ErrorCode(err) != api.CodeNotFound
+	}
+	return !t.IsCompleted
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+)
+
+func TestBlockers(t *testing.T) {
+	for desc, want := range map[string][]string{
+		"":                                 nil,
+		"blocked-by: 123":                  {"123"},
+		"Notes first\n  Blocked-By: 1, 2 ": {"1", "2"},
+		"blocked-by: 1\nblocked-by: #2 1":  {"1", "2"},
+		"not blocked-by: 5":                nil,
+	} {
+		if got := blockers(api.Task{Description: desc}); !reflect.DeepEqual(got, want) {
+			t.Errorf("blockers(%q) = %v, want %v", desc, got, want)
+		}
+	}
+}
+
+func TestWithBlocker(t *testing.T) {
+	desc := withBlocker("Call first", "1")
+	desc = withBlocker(desc, "2")
+	desc = withBlocker(desc, "1")
+	if want := "Call first\nblocked-by: 1\nblocked-by: 2"; desc != want {
+		t.Fatalf("withBlocker: got %q, want %q", desc, want)
+	}
+
+	if got, want := withoutBlocker("Notes\nblocked-by: 1, 2", "1"), "Notes\nblocked-by: 2"; got != want {
+		t.Errorf("withoutBlocker: got %q, want %q", got, want)
+	}
+	if got, want := withoutBlocker(desc, "2"), "Call first\nblocked-by: 1"; got != want {
+		t.Errorf("withoutBlocker: got %q, want %q", got, want)
+	}
+	if got := withoutBlocker("blocked-by: 1", "1"); got != "" {
+		t.Errorf("withoutBlocker: got %q, want empty", got)
+	}
+}
+
+func TestDepGraph(t *testing.T) {
+	tasks := []api.Task{
+		{ID: "1", Content: "Design"},
+		{ID: "2", Content: "Build", Description: "blocked-by: 1", Labels: []string{"blocked"}},
+		{ID: "3", Content: "Ship", Description: "blocked-by: 1, 2, 99"},
+		{ID: "4", Content: "Unrelated"},
+	}
+	graph := depGraph(tasks, "blocked")
+	if len(graph) != 3 {
+		t.Fatalf("expected 3 tasks in the graph, got %d", len(graph))
+	}
+	design, build, ship := graph[0], graph[1], graph[2]
+	if !reflect.DeepEqual(design.Blocks, []string{"2", "3"}) || len(design.WaitingOn) != 0 {
+		t.Errorf("Design: got %+v", design)
+	}
+	if !build.Labeled || !reflect.DeepEqual(build.WaitingOn, []string{"1"}) {
+		t.Errorf("Build: got %+v", build)
+	}
+	if ship.Labeled || !reflect.DeepEqual(ship.WaitingOn, []string{"1", "2"}) || len(ship.BlockedBy) != 3 {
+		t.Errorf("Ship: got %+v", ship)
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/cache"
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+const (
+	slowLatency  = 2 * time.Second
+	maxClockSkew = time.Minute
+)
+
+func newDoctorCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose configuration and connection problems",
+		Long: `Check the config file, API token, connection to Todoist, system clock and
+local cache, and suggest a fix for each problem found.
+
+Exits non-zero when a check fails.
+
+Examples:
+  todoist doctor
+  todoist doctor --profile work`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			checks := []output.Check{checkConfigFile()}
+			token := checkToken()
+			checks = append(checks, token)
+			if token.Status != output.CheckFail {
+				checks = append(checks, checkAPI(flags)...)
+			}
+			checks = append(checks, checkCache())
+
+			if err := out.WriteChecks(checks); err != nil {
+				return err
+			}
+
+			failed := 0
+			for _, c := range checks {
+				if c.Status == output.CheckFail {
+					failed++
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// checkConfigFile verifies the config file exists and is private
+func checkConfigFile() output.Check {
+	c := output.Check{Name: "Config file"}
+	path := config.ConfigPath()
+
+	info, err := os.Stat(path)
+	switch {
+	case os.IsNotExist(err) && os.Getenv("TODOIST_API_TOKEN") != "":
+		c.Status, c.Detail = output.CheckOK, "none; using TODOIST_API_TOKEN"
+	case os.IsNotExist(err):
+		c.Status, c.Detail, c.Fix = output.CheckFail, path+" does not exist", "todoist auth"
+	case err != nil:
+		c.Status, c.Detail = output.CheckFail, err.Error()
+	case runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0:
+		c.Status = output.CheckWarn
+		c.Detail = fmt.Sprintf("%s is readable by other users (%#o)", path, info.Mode().Perm())
+		c.Fix = "chmod 600 " + path
+	default:
+		c.Status, c.Detail = output.CheckOK, path
+	}
+	return c
+}
+
+// checkToken verifies a token is configured for the active profile
+func checkToken() output.Check {
+	c := output.Check{Name: "API token"}
+	if _, err := config.GetToken(); err != nil {
+		c.Status, c.Detail, c.Fix = output.CheckFail, err.Error(), "todoist auth"
+		return c
+	}
+
+	c.Status = output.CheckOK
+	if os.Getenv("TODOIST_API_TOKEN") != "" {
+		c.Detail = "from TODOIST_API_TOKEN"
+	} else {
+		c.Detail = "profile " + config.Settings().ProfileName()
+	}
+	return c
+}
+
+// checkAPI makes one request to verify the token, connectivity and latency,
+// and compares the server's clock with ours
+func checkAPI(flags *rootFlags) []output.Check {
+	base := api.BaseURL
+	if u := config.APIBaseURL(); u != "" {
+		base = u
+	}
+	host := base
+	if u, err := url.Parse(base); err == nil && u.Host != "" {
+		host = u.Host
+	}
+
+	conn := output.Check{Name: "Connection"}
+	client, err := getClientWithFlags(flags)
+	if err != nil {
+		conn.Status, conn.Detail = output.CheckFail, err.Error()
+		return []output.Check{conn}
+	}
+
+	start := time.Now()
+	user, err := client.GetUser()
+	latency := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		conn.Status, conn.Detail = output.CheckFail, err.Error()
+		switch api.ErrorCode(err) {
+		case api.CodeAuth:
+			conn.Detail = "the token was rejected"
+			conn.Fix = "create a new token at https://todoist.com/app/settings/integrations/developer and run todoist auth"
+		case api.CodeNetwork, api.CodeTimeout:
+			conn.Detail = fmt.Sprintf("cannot reach %s: %v", host, err)
+			conn.Fix = "check your network, proxy settings (HTTPS_PROXY) and TODOIST_API_BASE_URL"
+		}
+		return []output.Check{conn}
+	}
+
+	conn.Status = output.CheckOK
+	conn.Detail = fmt.Sprintf("%s as %s in %s", host, user.Email, latency)
+	if latency > slowLatency {
+		conn.Status = output.CheckWarn
+		conn.Fix = "the API is responding slowly; check your network or proxy"
+	}
+
+	clock := output.Check{Name: "Clock", Status: output.CheckOK, Detail: "no Date header in the response"}
+	if skew, ok := client.Stats().ClockSkew(); ok {
+		clock.Detail = "in sync with the server"
+		if skew.Abs() > maxClockSkew {
+			clock.Status = output.CheckWarn
+			dir := "ahead of"
+			if skew < 0 {
+				dir = "behind"
+			}
+			clock.Detail = fmt.Sprintf("%s %s the server", skew.Abs().Round(time.Second), dir)
+			clock.Fix = "enable automatic time sync (NTP); relative dates like today and tomorrow use the local clock"
+		}
+	}
+
+	return []output.Check{conn, clock}
+}
+
+// checkCache verifies every cache entry can be read
+func checkCache() output.Check {
+	c := output.Check{Name: "Cache"}
+	n, corrupt, err := cache.Verify()
+	switch {
+	case err != nil:
+		c.Status, c.Detail = output.CheckWarn, err.Error()
+	case len(corrupt) > 0:
+		c.Status = output.CheckWarn
+		c.Detail = fmt.Sprintf("%d unreadable entries in %s", len(corrupt), cache.Dir())
+		c.Fix = "rm -r " + cache.Dir()
+	default:
+		c.Status, c.Detail = output.CheckOK, fmt.Sprintf("%d entries", n)
+	}
+	return c
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/output"
+)
+
+// trailingTimeRe matches a time of day at the end of a due string, such as
+// " at 9am", " 17:00" or " 5:30 pm". A bare number is left alone since
+// "every 15" means a day of the month.
+var trailingTimeRe = regexp.MustCompile(`(?i)\s+(?:at\s+\d{1,2}(?::\d{2})?\s*(?:am|pm)?|\d{1,2}:\d{2}\s*(?:am|pm)?|\d{1,2}\s*(?:am|pm))$`)
+
+// stripDueTime removes a trailing time of day from a due string
+func stripDueTime(s string) string {
+	return strings.TrimSpace(trailingTimeRe.ReplaceAllString(s, ""))
+}
+
+// dueTimeUpdate changes only the time of day of a task's due date. Recurring
+// tasks are rewritten through their due string so the recurrence survives;
+// other tasks get a datetime on the same date, in the task's timezone when it
+// has one and floating otherwise. A negative hour removes the time.
+func dueTimeUpdate(due *api.Due, hour, minute int) (api.UpdateTaskParams, error) {
+	if due == nil || len(due.Date) < 10 {
+		return api.UpdateTaskParams{}, fmt.Errorf("task has no due date; set one with --due")
+	}
+
+	if due.IsRecurring {
+		s := stripDueTime(due.String)
+		if hour >= 0 {
+			s += fmt.Sprintf(" at %02d:%02d", hour, minute)
+		}
+		return api.UpdateTaskParams{DueString: s}, nil
+	}
+
+	date := due.Date[:10]
+	if hour < 0 {
+		return api.UpdateTaskParams{DueDate: date}, nil
+	}
+
+	clock := fmt.Sprintf("%sT%02d:%02d:00", date, hour, minute)
+	if due.Timezone != "" && strings.HasSuffix(due.Datetime, "Z") {
+		if loc, err := time.LoadLocation(due.Timezone); err == nil {
+			t, err := time.ParseInLocation("2006-01-02T15:04:05", clock, loc)
+			if err == nil {
+				return api.UpdateTaskParams{DueDatetime: t.UTC().Format(time.RFC3339)}, nil
+			}
+		}
+	}
+	return api.UpdateTaskParams{DueDatetime: clock}, nil
+}
+
+// validateTimezone checks a --tz value before anything is changed
+func validateTimezone(tz string) error {
+	if tz == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		return fmt.Errorf("unknown timezone %q", tz)
+	}
+	return nil
+}
+
+// pinTimezone builds the update that fixes a task's timed due date to tz,
+// keeping the time of day it has now. Recurring dates are sent again as
+// their due string, which Todoist parses in tz.
+func pinTimezone(t *api.Task, tz string) (api.DueUpdate, error) {
+	if err := validateTimezone(tz); err != nil {
+		return api.DueUpdate{}, err
+	}
+	loc, _ := time.LoadLocation(tz)
+	if t.Due == nil {
+		return api.DueUpdate{}, fmt.Errorf("--tz needs a due date")
+	}
+	if t.Due.IsRecurring {
+		return api.DueUpdate{TaskID: t.ID, String: t.Due.String, Timezone: tz}, nil
+	}
+	if t.Due.Datetime == "" {
+		return api.DueUpdate{}, fmt.Errorf("--tz needs a due time, e.g. --due \"tomorrow 5pm\"")
+	}
+
+	// The wall-clock time, in the zone the task was fixed to if any
+	wall, err := time.Parse(time.RFC3339, t.Due.Datetime)
+	if err == nil {
+		zone := output.Location()
+		if l, err := time.LoadLocation(t.Due.Timezone); t.Due.Timezone != "" && err == nil {
+			zone = l
+		}
+		wall = wall.In(zone)
+	} else if wall, err = time.Parse("2006-01-02T15:04:05", t.Due.Datetime); err != nil {
+		return api.DueUpdate{}, fmt.Errorf("unrecognized due datetime %q", t.Due.Datetime)
+	}
+
+	at := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), 0, loc)
+	return api.DueUpdate{TaskID: t.ID, Date: at.UTC().Format(time.RFC3339), Timezone: tz}, nil
+}
+
+// applyTimezone fixes the task's due date to tz and returns the updated task
+func applyTimezone(client *api.Client, t *api.Task, tz string) (*api.Task, error) {
+	u, err := pinTimezone(t, tz)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.RescheduleTasks([]api.DueUpdate{u}); err != nil {
+		return nil, err
+	}
+	return client.GetTask(t.ID)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+)
+
+func TestStripDueTime(t *testing.T) {
+	tests := map[string]string{
+		"every day at 9am":      "every day",
+		"every mon 17:00":       "every mon",
+		"every weekday 5:30 pm": "every weekday",
+		"every 15":              "every 15",
+		"every 2 days":          "every 2 days",
+		"tomorrow at 18:30":     "tomorrow",
+	}
+	for in, want := range tests {
+		if got := stripDueTime(in); got != want {
+			t.Errorf("stripDueTime(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestDueTimeUpdate(t *testing.T) {
+	tests := []struct {
+		name   string
+		due    api.Due
+		hour   int
+		minute int
+		want   api.UpdateTaskParams
+	}{
+		{"date only", api.Due{Date: "2024-03-10"}, 17, 0, api.UpdateTaskParams{DueDatetime: "2024-03-10T17:00:00"}},
+		{"floating", api.Due{Date: "2024-03-10", Datetime: "2024-03-10T09:00:00"}, 8, 15, api.UpdateTaskParams{DueDatetime: "2024-03-10T08:15:00"}},
+		{"zoned", api.Due{Date: "2024-03-10", Datetime: "2024-03-10T09:00:00Z", Timezone: "UTC"}, 17, 0, api.UpdateTaskParams{DueDatetime: "2024-03-10T17:00:00Z"}},
+		{"clear", api.Due{Date: "2024-03-10", Datetime: "2024-03-10T09:00:00"}, -1, 0, api.UpdateTaskParams{DueDate: "2024-03-10"}},
+		{"recurring", api.Due{Date: "2024-03-10", String: "every day at 9am", IsRecurring: true}, 17, 0, api.UpdateTaskParams{DueString: "every day at 17:00"}},
+		{"recurring clear", api.Due{Date: "2024-03-10", String: "every day at 9am", IsRecurring: true}, -1, 0, api.UpdateTaskParams{DueString: "every day"}},
+	}
+
+	for _, tt := range tests {
+		due := tt.due
+		got, err := dueTimeUpdate(&due, tt.hour, tt.minute)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got.DueString != tt.want.DueString || got.DueDate != tt.want.DueDate || got.DueDatetime != tt.want.DueDatetime {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := dueTimeUpdate(nil, 17, 0); err == nil {
+		t.Error("expected an error for a task without a due date")
+	}
+}
+
+func TestPinTimezone(t *testing.T) {
+	task := &api.Task{ID: "1", Due: &api.Due{Date: "2024-03-10", Datetime: "2024-03-10T09:00:00"}}
+	u, err := pinTimezone(task, "America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Date != "2024-03-10T13:00:00Z" || u.Timezone != "America/New_York" {
+		t.Errorf("floating due: got %+v", u)
+	}
+
+	task.Due = &api.Due{Date: "2024-03-10", Datetime: "2024-03-10T08:00:00Z", Timezone: "Europe/London"}
+	if u, _ := pinTimezone(task, "Europe/Berlin"); u.Date != "2024-03-10T07:00:00Z" {
+		t.Errorf("fixed due: got %+v, want 08:00 Berlin time", u)
+	}
+
+	task.Due = &api.Due{Date: "2024-03-10", String: "every day at 9am", IsRecurring: true}
+	if u, _ := pinTimezone(task, "Asia/Tokyo"); u.String != "every day at 9am" || u.Timezone != "Asia/Tokyo" {
+		t.Errorf("recurring due: got %+v", u)
+	}
+
+	task.Due = &api.Due{Date: "2024-03-10"}
+	if _, err := pinTimezone(task, "Asia/Tokyo"); err == nil {
+		t.Error("expected an error for a due date without a time")
+	}
+	if _, err := pinTimezone(task, "Mars/Olympus"); err == nil {
+		t.Error("expected an error for an unknown timezone")
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/hooks"
+	"github.com/spf13/cobra"
+)
+
+func newDuplicateCmd(flags *rootFlags) *cobra.Command {
+	var (
+		toProject    string
+		withSubtasks bool
+		withComments bool
+	)
+
+	cmd := &cobra.Command{
+		Use:     "duplicate <task-id>",
+		Aliases: []string{"dup"},
+		Short:   "Copy a task, optionally with its subtasks and comments",
+		Long: `Create a copy of a task with the same content, description, priority,
+due date, labels and assignee. The copy is placed next to the original, or
+at the top of another project with --to-project.
+
+Everything is created in one batch of Sync API commands, so a whole
+template checklist is copied in a single request.
+
+Examples:
+  todoist duplicate 123
+  todoist duplicate 123 --with-subtasks --with-comments
+  todoist dup 123 --to-project "Client B" --with-subtasks`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			task, err := client.GetTask(args[0])
+			if err != nil {
+				return err
+			}
+
+			projectID, sectionID, parentID := task.ProjectID, task.SectionID, task.ParentID
+			if toProject != "" {
+				p, err := client.FindProject(toProject)
+				if err != nil {
+					return err
+				}
+				if p.ID != task.ProjectID {
+					projectID, sectionID, parentID = p.ID, "", ""
+				}
+			}
+
+			// Subtasks come from the task's project, ordered as in the app
+			children := map[string][]api.Task{}
+			if withSubtasks {
+				tasks, err := client.GetTasks(task.ProjectID, "")
+				if err != nil {
+					return err
+				}
+				for _, t := range tasks {
+					if t.ParentID != "" {
+						children[t.ParentID] = append(children[t.ParentID], t)
+					}
+				}
+				for _, list := range children {
+					sort.SliceStable(list, func(i, j int) bool { return list[i].ChildOrder < list[j].ChildOrder })
+				}
+			}
+
+			var batch api.Batch
+			var copyTree func(t api.Task, sectionID, parentID string) (string, error)
+			copyTree = func(t api.Task, sectionID, parentID string) (string, error) {
+				id := batch.AddTask(t, projectID, sectionID, parentID)
+				if withComments {
+					comments, err := client.GetComments(t.ID, "")
+					if err != nil {
+						return "", err
+					}
+					for _, c := range comments {
+						batch.AddComment(id, c.Content)
+					}
+				}
+				for _, child := range children[t.ID] {
+					if _, err := copyTree(child, "", id); err != nil {
+						return "", err
+					}
+				}
+				return id, nil
+			}
+
+			tempID, err := copyTree(*task, sectionID, parentID)
+			if err != nil {
+				return err
+			}
+
+			ids, err := client.CommitBatch(&batch)
+			if err != nil {
+				return fmt.Errorf("failed to duplicate task: %w", err)
+			}
+
+			copied, err := client.GetTask(ids[tempID])
+			if err != nil {
+				return err
+			}
+			hooks.RunPost(stderr, hooks.PostAdd, copied)
+
+			return out.WriteTask(copied)
+		},
+	}
+
+	cmd.Flags().StringVar(&toProject, "to-project", "", "create the copy in this project")
+	cmd.Flags().BoolVar(&withSubtasks, "with-subtasks", false, "copy the task's open subtasks too")
+	cmd.Flags().BoolVar(&withComments, "with-comments", false, "copy comments")
+
+	return cmd
+}
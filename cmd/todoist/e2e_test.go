@@ -0,0 +1,1993 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/apitest"
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/update"
+)
+
+// newTestServer starts a fake API and points the CLI at it with an empty
+// home directory, so no real config or cache is touched
+func newTestServer(t *testing.T) *apitest.Server {
+	t.Helper()
+	srv := apitest.NewServer()
+	t.Cleanup(srv.Close)
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("TODOIST_API_TOKEN", apitest.Token)
+	t.Setenv("TODOIST_API_BASE_URL", srv.BaseURL())
+	t.Setenv("TODOIST_PROFILE", "")
+	t.Setenv("COLUMNS", "")
+	t.Setenv("NO_COLOR", "1")
+	t.Setenv("LC_ALL", "C")
+	return srv
+}
+
+// run executes the CLI with args and returns what it wrote to stdout
+func run(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- string(data)
+	}()
+
+	err = execute(args)
+	w.Close()
+	return <-done, err
+}
+
+// mustRun is run for commands expected to succeed
+func mustRun(t *testing.T, args ...string) string {
+	t.Helper()
+	out, err := run(t, args...)
+	if err != nil {
+		t.Fatalf("todoist %s: %v", strings.Join(args, " "), err)
+	}
+	return out
+}
+
+// envelopeData decodes the data field of a JSON envelope into v
+func envelopeData(t *testing.T, out string, v interface{}) {
+	t.Helper()
+	var env struct {
+		Success bool            `json:"success"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(out), &env); err != nil {
+		t.Fatalf("invalid JSON output %q: %v", out, err)
+	}
+	if !env.Success {
+		t.Fatalf("expected success, got %s", out)
+	}
+	if err := json.Unmarshal(env.Data, v); err != nil {
+		t.Fatalf("unexpected data %s: %v", env.Data, err)
+	}
+}
+
+func TestE2E_TasksToday(t *testing.T) {
+	srv := newTestServer(t)
+	today := time.Now().Format("2006-01-02")
+	srv.AddTask(api.Task{Content: "Due today", Due: &api.Due{Date: today}})
+	srv.AddTask(api.Task{Content: "Someday"})
+
+	out := mustRun(t, "tasks")
+	if !strings.Contains(out, "Due today") || strings.Contains(out, "Someday") {
+		t.Errorf("expected only today's task, got:\n%s", out)
+	}
+
+	out = mustRun(t, "tasks", "--all")
+	if !strings.Contains(out, "Someday") {
+		t.Errorf("expected --all to list every task, got:\n%s", out)
+	}
+}
+
+func TestE2E_TasksByProject(t *testing.T) {
+	srv := newTestServer(t)
+	work := srv.AddProject(api.Project{Name: "Work"})
+	srv.AddTask(api.Task{Content: "Write report", ProjectID: work.ID})
+	srv.AddTask(api.Task{Content: "Buy milk"})
+
+	var tasks []api.Task
+	envelopeData(t, mustRun(t, "tasks", "-p", "Work", "--json"), &tasks)
+	if len(tasks) != 1 || tasks[0].Content != "Write report" {
+		t.Errorf("expected only the Work task, got %+v", tasks)
+	}
+}
+
+func TestE2E_TasksPagination(t *testing.T) {
+	srv := newTestServer(t)
+	srv.PageSize = 2
+	for _, c := range []string{"one", "two", "three", "four", "five"} {
+		srv.AddTask(api.Task{Content: c})
+	}
+
+	var tasks []api.Task
+	envelopeData(t, mustRun(t, "tasks", "--all", "--json"), &tasks)
+	if len(tasks) != 5 {
+		t.Errorf("expected every page to be fetched, got %d tasks", len(tasks))
+	}
+}
+
+func TestE2E_Projects(t *testing.T) {
+	srv := newTestServer(t)
+	srv.AddProject(api.Project{Name: "Work"})
+
+	out := mustRun(t, "projects")
+	if !strings.Contains(out, "Inbox") || !strings.Contains(out, "Work") {
+		t.Errorf("expected projects listed, got:\n%s", out)
+	}
+
+	mustRun(t, "projects", "add", "Home")
+	var projects []api.Project
+	envelopeData(t, mustRun(t, "projects", "--json"), &projects)
+	if len(projects) != 3 || projects[2].Name != "Home" {
+		t.Errorf("expected the new project to be listed, got %+v", projects)
+	}
+}
+
+func TestE2E_Add(t *testing.T) {
+	srv := newTestServer(t)
+	work := srv.AddProject(api.Project{Name: "Work"})
+	srv.AddSection(api.Section{Name: "Next", ProjectID: work.ID})
+
+	var task api.Task
+	envelopeData(t, mustRun(t, "add", "Write report", "-p", "Work", "-s", "Next", "-P", "1", "-l", "urgent", "--json"), &task)
+
+	got, ok := srv.Task(task.ID)
+	if !ok {
+		t.Fatalf("task %s not created", task.ID)
+	}
+	if got.ProjectID != work.ID || got.SectionID == "" || got.Priority != 4 {
+		t.Errorf("flags not applied: %+v", got)
+	}
+	if len(got.Labels) != 1 || got.Labels[0] != "urgent" {
+		t.Errorf("expected label urgent, got %v", got.Labels)
+	}
+}
+
+func TestE2E_UpdateAndComplete(t *testing.T) {
+	srv := newTestServer(t)
+	task := srv.AddTask(api.Task{Content: "Draft"})
+
+	mustRun(t, "update", task.ID, "--content", "Final", "-P", "2")
+	got, _ := srv.Task(task.ID)
+	if got.Content != "Final" || got.Priority != 3 {
+		t.Errorf("update not applied: %+v", got)
+	}
+
+	mustRun(t, "complete", task.ID)
+	if _, ok := srv.Task(task.ID); ok {
+		t.Error("expected the task to be completed")
+	}
+	if completed := srv.Completed(); len(completed) != 1 || completed[0].TaskID != task.ID {
+		t.Errorf("expected one completed task, got %+v", completed)
+	}
+}
+
+func TestE2E_LabelCommands(t *testing.T) {
+	srv := newTestServer(t)
+	task := srv.AddTask(api.Task{Content: "Draft", Labels: []string{"waiting"}})
+
+	mustRun(t, "label", "add", "urgent")
+	var labels []api.Label
+	envelopeData(t, mustRun(t, "labels", "--json"), &labels)
+	if len(labels) != 1 || labels[0].Name != "urgent" {
+		t.Errorf("expected 'label add' to create a label, got %+v", labels)
+	}
+
+	// Rewriting mentions needs --yes when there is no one to ask
+	note := srv.AddTask(api.Task{Content: "Chase @urgent reply"})
+	if _, err := run(t, "labels", "rename", "urgent", "asap", "--rewrite-text", "--json"); err == nil {
+		t.Error("expected --json without --yes to be refused")
+	}
+	// Unconfirmed, nothing changes and the prompt stays off stdout
+	if out, _ := run(t, "labels", "rename", "urgent", "asap", "--rewrite-text"); strings.Contains(out, "[y/N]") {
+		t.Errorf("expected the prompt on stderr, got:\n%s", out)
+	}
+	if got, _ := srv.Task(note.ID); got.Content != "Chase @urgent reply" {
+		t.Errorf("expected an unconfirmed rename to leave the text alone, got %q", got.Content)
+	}
+	mustRun(t, "labels", "rename", "urgent", "asap", "--rewrite-text", "--json", "--yes")
+	if got, _ := srv.Task(note.ID); got.Content != "Chase @asap reply" {
+		t.Errorf("expected the mention rewritten, got %q", got.Content)
+	}
+
+	mustRun(t, "task-label", "add", task.ID, "urgent")
+	mustRun(t, "task-label", "remove", task.ID, "@waiting")
+	if got, _ := srv.Task(task.ID); strings.Join(got.Labels, ",") != "urgent" {
+		t.Errorf("expected the task relabeled, got %v", got.Labels)
+	}
+}
+
+func TestE2E_Postpone(t *testing.T) {
+	srv := newTestServer(t)
+	a := srv.AddTask(api.Task{Content: "Call bank", Due: &api.Due{Date: "2024-03-12"}})
+	b := srv.AddTask(api.Task{Content: "Water plants", Due: &api.Due{Date: "2024-03-12", IsRecurring: true, String: "every day"}})
+
+	mustRun(t, "postpone", a.ID, "--by", "3d")
+	if got, _ := srv.Task(a.ID); got.Due == nil || got.Due.Date != "2024-03-15" {
+		t.Errorf("expected --by 3d to shift to 2024-03-15, got %+v", got.Due)
+	}
+	mustRun(t, "postpone", a.ID, "--to", "+1w")
+	if got, _ := srv.Task(a.ID); got.Due == nil || got.Due.Date != "2024-03-22" {
+		t.Errorf("expected --to +1w to shift to 2024-03-22, got %+v", got.Due)
+	}
+
+	mustRun(t, "postpone", b.ID, "--by", "1d")
+	if got, _ := srv.Task(b.ID); got.Due.Date != "2024-03-12" {
+		t.Errorf("expected the recurring task left alone, got %+v", got.Due)
+	}
+
+	if _, err := run(t, "postpone", a.ID, "--to", "today", "--by", "1d"); err == nil {
+		t.Error("expected --to and --by together to fail")
+	}
+	if _, err := run(t, "postpone", a.ID, "--by", "tomorrow"); err == nil {
+		t.Error("expected --by to need an offset")
+	}
+}
+
+func TestE2E_Priority(t *testing.T) {
+	srv := newTestServer(t)
+	task := srv.AddTask(api.Task{Content: "Draft"})
+
+	// p1 is the highest for users and 4 in the API
+	for _, tc := range []struct {
+		args []string
+		api  int
+	}{
+		{[]string{"priority", task.ID, "1"}, 4},
+		{[]string{"priority", task.ID, "4"}, 1},
+		{[]string{"p2", task.ID}, 3},
+		{[]string{"p3", task.ID}, 2},
+	} {
+		mustRun(t, tc.args...)
+		if got, _ := srv.Task(task.ID); got.Priority != tc.api {
+			t.Errorf("%v: expected API priority %d, got %d", tc.args, tc.api, got.Priority)
+		}
+	}
+	for _, bad := range []string{"0", "5", "high"} {
+		if _, err := run(t, "priority", task.ID, bad); err == nil {
+			t.Errorf("priority %s: expected an error", bad)
+		}
+	}
+
+	// Several tasks go through one Sync request, confirmed above the threshold
+	var ids []string
+	for i := 0; i < 3; i++ {
+		ids = append(ids, srv.AddTask(api.Task{Content: "Bulk"}).ID)
+	}
+	mustRun(t, append([]string{"p1"}, ids...)...)
+	for _, id := range ids {
+		if got, _ := srv.Task(id); got.Priority != 4 {
+			t.Errorf("task %s: expected API priority 4, got %d", id, got.Priority)
+		}
+	}
+	if err := config.Save(&config.Config{ConfirmAbove: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run(t, append([]string{"p4", "--json"}, ids...)...); err == nil {
+		t.Error("expected a bulk change above the threshold to need --yes")
+	}
+	mustRun(t, append([]string{"p4", "--yes"}, ids...)...)
+	if got, _ := srv.Task(ids[0]); got.Priority != 1 {
+		t.Errorf("expected --yes to apply p4, got %d", got.Priority)
+	}
+}
+
+func TestE2E_TaskTree(t *testing.T) {
+	srv := newTestServer(t)
+	work := srv.AddProject(api.Project{Name: "Work"})
+	home := srv.AddProject(api.Project{Name: "Home"})
+	parent := srv.AddTask(api.Task{Content: "Launch", ProjectID: work.ID})
+	first := srv.AddTask(api.Task{Content: "Write copy", ProjectID: work.ID})
+	second := srv.AddTask(api.Task{Content: "Review copy", ProjectID: work.ID})
+
+	// The first child of a task with no subtasks yet
+	mustRun(t, "task", "demote", first.ID, "--under", parent.ID)
+	if got, _ := srv.Task(first.ID); got.ParentID != parent.ID {
+		t.Errorf("expected %s under %s, got parent %q", first.ID, parent.ID, got.ParentID)
+	}
+	// ...and a grandchild under it
+	mustRun(t, "task", "demote", second.ID, "--under", first.ID)
+	if got, _ := srv.Task(second.ID); got.ParentID != first.ID {
+		t.Errorf("expected %s under %s, got parent %q", second.ID, first.ID, got.ParentID)
+	}
+
+	// Promoting goes up one level at a time, then to the top of the project
+	mustRun(t, "task", "promote", second.ID)
+	if got, _ := srv.Task(second.ID); got.ParentID != parent.ID {
+		t.Errorf("expected %s promoted under %s, got parent %q", second.ID, parent.ID, got.ParentID)
+	}
+	mustRun(t, "task", "promote", second.ID)
+	if got, _ := srv.Task(second.ID); got.ParentID != "" || got.ProjectID != work.ID {
+		t.Errorf("expected %s at the top of Work, got %+v", second.ID, got)
+	}
+	if _, err := run(t, "task", "promote", parent.ID); err == nil {
+		t.Error("expected promoting a top-level task to fail")
+	}
+
+	// Adopting tasks from another project moves them into the parent's
+	errands := srv.AddTask(api.Task{Content: "Buy paper", ProjectID: home.ID})
+	flyers := srv.AddTask(api.Task{Content: "Print flyers", ProjectID: home.ID})
+	mustRun(t, "task", "adopt", parent.ID, errands.ID, flyers.ID)
+	for _, id := range []string{errands.ID, flyers.ID} {
+		if got, _ := srv.Task(id); got.ParentID != parent.ID || got.ProjectID != work.ID {
+			t.Errorf("expected %s adopted into Work under %s, got %+v", id, parent.ID, got)
+		}
+	}
+	if _, err := run(t, "task", "adopt", parent.ID, parent.ID); err == nil {
+		t.Error("expected adopting a task under itself to fail")
+	}
+}
+
+func TestE2E_Search(t *testing.T) {
+	srv := newTestServer(t)
+	srv.AddTask(api.Task{Content: "Call mom", Labels: []string{"phone"}})
+	srv.AddTask(api.Task{Content: "Call bank"})
+	srv.AddTask(api.Task{Content: "Email boss", Labels: []string{"phone"}})
+
+	var tasks []api.Task
+	envelopeData(t, mustRun(t, "search", "call", "--label", "phone", "--json"), &tasks)
+	if len(tasks) != 1 || tasks[0].Content != "Call mom" {
+		t.Errorf("expected only Call mom, got %+v", tasks)
+	}
+
+	// The query runs as a server-side filter
+	found := false
+	for _, r := range srv.Requests() {
+		found = found || strings.Contains(r, "filter=search")
+	}
+	if !found {
+		t.Errorf("expected a filter request, got %v", srv.Requests())
+	}
+}
+
+func TestE2E_TaskFilterFlags(t *testing.T) {
+	srv := newTestServer(t)
+	work := srv.AddProject(api.Project{Name: "Work"})
+	day := func(days int) *api.Due { return &api.Due{Date: time.Now().AddDate(0, 0, days).Format("2006-01-02")} }
+	old := time.Now().AddDate(0, -1, 0).UTC().Format(time.RFC3339)
+	srv.AddTask(api.Task{Content: "Chase invoice", ProjectID: work.ID, Labels: []string{"waiting"}, Priority: 4, Due: day(0), CreatedAt: old})
+	srv.AddTask(api.Task{Content: "Plan offsite", ProjectID: work.ID, Labels: []string{"waiting"}, Due: day(3), CreatedAt: old})
+	srv.AddTask(api.Task{Content: "Read book", Labels: []string{"waiting"}, CreatedAt: time.Now().UTC().Format(time.RFC3339)})
+	srv.AddTask(api.Task{Content: "Old someday", CreatedAt: old})
+
+	contents := func(args ...string) string {
+		var tasks []api.Task
+		envelopeData(t, mustRun(t, append(args, "--json")...), &tasks)
+		var names []string
+		for _, task := range tasks {
+			names = append(names, task.Content)
+		}
+		sort.Strings(names)
+		return strings.Join(names, ",")
+	}
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"tasks", "--label", "waiting"}, "Chase invoice,Plan offsite,Read book"},
+		{[]string{"tasks", "-p", "Work", "--priority", "1"}, "Chase invoice"},
+		{[]string{"tasks", "--label", "waiting", "--due", "today"}, "Chase invoice"},
+		{[]string{"tasks", "--due", "week"}, "Chase invoice,Plan offsite"},
+		{[]string{"tasks", "--no-date"}, "Old someday,Read book"},
+		{[]string{"tasks", "--due", "none", "--label", "waiting"}, "Read book"},
+		// The fake server has no "created after", so this is matched locally
+		{[]string{"tasks", "--created-since", "-1d"}, "Read book"},
+	}
+	for _, tt := range tests {
+		if got := contents(tt.args...); got != tt.want {
+			t.Errorf("%v: got %q, want %q", tt.args, got, tt.want)
+		}
+	}
+
+	found := false
+	for _, r := range srv.Requests() {
+		found = found || strings.Contains(r, "filter=%40waiting")
+	}
+	if !found {
+		t.Errorf("expected the label to be sent as a filter, got %v", srv.Requests())
+	}
+
+	if _, err := run(t, "tasks", "--due", "soon"); err == nil {
+		t.Error("expected an unknown --due value to fail")
+	}
+}
+
+func TestE2E_CountAndSummary(t *testing.T) {
+	srv := newTestServer(t)
+	work := srv.AddProject(api.Project{Name: "Work"})
+	yesterday := &api.Due{Date: time.Now().AddDate(0, 0, -1).Format("2006-01-02")}
+	srv.AddTask(api.Task{Content: "Late report", ProjectID: work.ID, Priority: 4, Due: yesterday})
+	srv.AddTask(api.Task{Content: "Slides", ProjectID: work.ID})
+	srv.AddTask(api.Task{Content: "Groceries"})
+
+	if out := mustRun(t, "tasks", "--all", "--count"); strings.TrimSpace(out) != "3" {
+		t.Errorf("expected 3, got %q", out)
+	}
+	var counted struct {
+		Count int `json:"count"`
+	}
+	envelopeData(t, mustRun(t, "tasks", "--overdue", "--count", "--json"), &counted)
+	if counted.Count != 1 {
+		t.Errorf("expected 1 overdue task, got %d", counted.Count)
+	}
+
+	out := mustRun(t, "summary")
+	if !strings.Contains(out, "3 tasks: 1 overdue, 2 without a date") || !strings.Contains(out, "Work") {
+		t.Errorf("unexpected summary:\n%s", out)
+	}
+	var s struct {
+		Total      int            `json:"total"`
+		Overdue    int            `json:"overdue"`
+		Priorities map[string]int `json:"priorities"`
+		Projects   []struct {
+			Name  string `json:"name"`
+			Total int    `json:"total"`
+		} `json:"projects"`
+	}
+	envelopeData(t, mustRun(t, "summary", "--json"), &s)
+	if s.Total != 3 || s.Overdue != 1 || s.Priorities["p1"] != 1 || len(s.Projects) != 2 || s.Projects[0].Name != "Work" {
+		t.Errorf("unexpected JSON summary %+v", s)
+	}
+}
+
+func TestE2E_LimitAndPage(t *testing.T) {
+	srv := newTestServer(t)
+	for _, c := range []struct {
+		content  string
+		priority int
+	}{{"Low", 1}, {"Urgent", 4}, {"High", 3}, {"Medium", 2}} {
+		srv.AddTask(api.Task{Content: "Report " + c.content, Priority: c.priority})
+	}
+
+	contents := func(args ...string) string {
+		var tasks []api.Task
+		envelopeData(t, mustRun(t, append(args, "--json")...), &tasks)
+		var names []string
+		for _, task := range tasks {
+			names = append(names, strings.TrimPrefix(task.Content, "Report "))
+		}
+		return strings.Join(names, ",")
+	}
+
+	if got := contents("tasks", "--all", "--sort", "priority", "--limit", "2"); got != "Urgent,High" {
+		t.Errorf("expected the top two by priority, got %s", got)
+	}
+	if got := contents("tasks", "--all", "--sort", "priority", "--limit", "2", "--page", "2"); got != "Medium,Low" {
+		t.Errorf("expected the second page, got %s", got)
+	}
+	var env struct {
+		Meta struct {
+			NextCursor string `json:"next_cursor"`
+		} `json:"meta"`
+	}
+	json.Unmarshal([]byte(mustRun(t, "tasks", "--all", "--limit", "3", "--json")), &env)
+	if env.Meta.NextCursor != "3" {
+		t.Errorf("expected meta.next_cursor to point at the next page, got %q", env.Meta.NextCursor)
+	}
+	if got := contents("search", "report", "--limit", "1", "--offset", "3"); got == "" || strings.Contains(got, ",") {
+		t.Errorf("expected one search result, got %s", got)
+	}
+	if _, err := run(t, "tasks", "--all", "--page", "2"); err == nil {
+		t.Error("expected --page without --limit to fail")
+	}
+}
+
+func TestE2E_Sections(t *testing.T) {
+	srv := newTestServer(t)
+	work := srv.AddProject(api.Project{Name: "Work"})
+	home := srv.AddProject(api.Project{Name: "Home"})
+	srv.AddSection(api.Section{Name: "Meetings", ProjectID: work.ID})
+	srv.AddSection(api.Section{Name: "Garden", ProjectID: home.ID})
+
+	out := mustRun(t, "sections", "-p", "Work")
+	if !strings.Contains(out, "Meetings") || strings.Contains(out, "Garden") {
+		t.Errorf("expected only Work's sections, got:\n%s", out)
+	}
+}
+
+func TestE2E_Share(t *testing.T) {
+	srv := newTestServer(t)
+	work := srv.AddProject(api.Project{Name: "Work"})
+
+	mustRun(t, "projects", "share", "Work", "alice@example.com")
+	if c := srv.Collaborators(work.ID); len(c) != 1 || c[0].Email != "alice@example.com" {
+		t.Errorf("expected alice to be invited, got %+v", c)
+	}
+
+	mustRun(t, "projects", "unshare", "Work", "alice@example.com", "--force")
+	if c := srv.Collaborators(work.ID); len(c) != 0 {
+		t.Errorf("expected alice to be removed, got %+v", c)
+	}
+}
+
+func TestE2E_NotFound(t *testing.T) {
+	newTestServer(t)
+
+	if _, err := run(t, "view", "999"); api.ErrorCode(err) != api.CodeNotFound {
+		t.Errorf("expected not_found, got %v", err)
+	}
+}
+
+func TestE2E_Doctor(t *testing.T) {
+	newTestServer(t)
+
+	var checks []struct {
+		Name   string `json:"name"`
+		Status string `json:"status"`
+	}
+	envelopeData(t, mustRun(t, "doctor", "--json"), &checks)
+
+	statuses := map[string]string{}
+	for _, c := range checks {
+		statuses[c.Name] = c.Status
+	}
+	for _, name := range []string{"Config file", "API token", "Connection", "Clock", "Cache"} {
+		if statuses[name] != "ok" {
+			t.Errorf("%s: got status %q, want ok (all: %v)", name, statuses[name], statuses)
+		}
+	}
+}
+
+func TestE2E_Alias(t *testing.T) {
+	srv := newTestServer(t)
+	work := srv.AddProject(api.Project{Name: "Work"})
+	srv.AddTask(api.Task{Content: "Write report", ProjectID: work.ID})
+	srv.AddTask(api.Task{Content: "Buy milk"})
+
+	mustRun(t, "alias", "set", "work", "tasks -p 'Work'")
+
+	// Global flags before the alias and arguments after it are kept
+	var tasks []api.Task
+	envelopeData(t, mustRun(t, "--color", "never", "work", "--json"), &tasks)
+	if len(tasks) != 1 || tasks[0].Content != "Write report" {
+		t.Errorf("expected the alias to list Work tasks, got %+v", tasks)
+	}
+
+	if _, err := run(t, "alias", "set", "tasks", "projects"); err == nil {
+		t.Error("expected built-in commands to be protected")
+	}
+
+	mustRun(t, "alias", "remove", "work")
+	if _, err := run(t, "work"); err == nil {
+		t.Error("expected the removed alias to be unknown")
+	}
+
+	// An alias saved before a built-in of the same name arrived is reported
+	if err := config.Save(&config.Config{Aliases: map[string]string{"inbox": "tasks -p Work"}}); err != nil {
+		t.Fatal(err)
+	}
+	if out := mustRun(t, "alias", "list"); !strings.Contains(out, "hidden by the built-in inbox command") {
+		t.Errorf("expected the shadowed alias to be flagged, got:\n%s", out)
+	}
+
+	// A config file that doesn't parse is left alone rather than replaced
+	broken := []byte(`{"api_token": "secret",`)
+	if err := os.WriteFile(config.ConfigPath(), broken, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run(t, "alias", "set", "work", "tasks -p Work"); err == nil {
+		t.Error("expected alias set to fail on an unreadable config")
+	}
+	if data, _ := os.ReadFile(config.ConfigPath()); string(data) != string(broken) {
+		t.Errorf("expected the config file to be untouched, got %s", data)
+	}
+}
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"tasks -p Inbox", []string{"tasks", "-p", "Inbox"}},
+		{`tasks --filter "p1 & today"`, []string{"tasks", "--filter", "p1 & today"}},
+		{`add 'it''s' a\ b`, []string{"add", "its", "a b"}},
+		{`  spaced   out  `, []string{"spaced", "out"}},
+	}
+	for _, tt := range tests {
+		got, err := splitArgs(tt.in)
+		if err != nil || strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("splitArgs(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+
+	if _, err := splitArgs(`tasks "open`); err == nil {
+		t.Error("expected an error for an unterminated quote")
+	}
+}
+
+func TestE2E_Plugin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test plugin is a shell script")
+	}
+	newTestServer(t)
+
+	dir := t.TempDir()
+	log := filepath.Join(dir, "plugin.log")
+	script := "#!/bin/sh\necho \"$TODOIST_TOKEN $TODOIST_JSON $*\" > " + log + "\nexit 3\n"
+	if err := os.WriteFile(filepath.Join(dir, "todoist-hello"), []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	_, err := run(t, "--json", "hello", "world", "--loud")
+	var exit *pluginExit
+	if !errors.As(err, &exit) || exit.code != 3 {
+		t.Fatalf("expected the plugin's exit status, got %v", err)
+	}
+
+	data, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(string(data)); got != apitest.Token+" 1 world --loud" {
+		t.Errorf("plugin got %q", got)
+	}
+}
+
+func TestE2E_StatusShort(t *testing.T) {
+	srv := newTestServer(t)
+	now := time.Now()
+	srv.AddTask(api.Task{Content: "Today", Due: &api.Due{Date: now.Format("2006-01-02")}})
+	srv.AddTask(api.Task{Content: "Late", Due: &api.Due{Date: now.AddDate(0, 0, -2).Format("2006-01-02")}})
+
+	if out := mustRun(t, "status", "--short"); out != "1 due today, 1 overdue\n" {
+		t.Errorf("got %q", out)
+	}
+
+	// The second call is served from the cache
+	requests := len(srv.Requests())
+	srv.AddTask(api.Task{Content: "Also today", Due: &api.Due{Date: now.Format("2006-01-02")}})
+	if out := mustRun(t, "status", "--short"); out != "1 due today, 1 overdue\n" {
+		t.Errorf("got %q from cache", out)
+	}
+	if len(srv.Requests()) != requests {
+		t.Errorf("expected no API requests for a fresh cache, got %v", srv.Requests()[requests:])
+	}
+}
+
+func TestE2E_Snooze(t *testing.T) {
+	srv := newTestServer(t)
+	later := srv.AddTask(api.Task{Content: "Someday", Labels: []string{"home"}, Due: &api.Due{Date: "2030-01-01"}})
+	back := srv.AddTask(api.Task{Content: "Follow up"})
+
+	mustRun(t, "snooze", later.ID)
+	got, _ := srv.Task(later.ID)
+	if got.Due != nil || len(got.Labels) != 2 || got.Labels[1] != "snoozed" {
+		t.Errorf("expected no date and @snoozed, got %+v", got)
+	}
+
+	mustRun(t, "snooze", back.ID, "--until", "yesterday")
+	got, _ = srv.Task(back.ID)
+	if got.Due == nil || len(got.Labels) != 1 {
+		t.Errorf("expected a date and @snoozed, got %+v", got)
+	}
+
+	mustRun(t, "snoozed", "--wake")
+	if got, _ := srv.Task(back.ID); len(got.Labels) != 0 {
+		t.Errorf("expected the due task to be woken, got %v", got.Labels)
+	}
+
+	var tasks []api.Task
+	envelopeData(t, mustRun(t, "snoozed", "--json"), &tasks)
+	if len(tasks) != 1 || tasks[0].ID != later.ID {
+		t.Errorf("expected only the undated task to stay snoozed, got %+v", tasks)
+	}
+}
+
+func TestE2E_UpdateDueTime(t *testing.T) {
+	srv := newTestServer(t)
+	task := srv.AddTask(api.Task{Content: "Call", Due: &api.Due{Date: "2030-05-01", String: "May 1"}})
+
+	mustRun(t, "update", task.ID, "--due-time", "5pm")
+	got, _ := srv.Task(task.ID)
+	if got.Due == nil || got.Due.Datetime != "2030-05-01T17:00:00" {
+		t.Errorf("expected 17:00 on the same date, got %+v", got.Due)
+	}
+
+	mustRun(t, "update", task.ID, "--clear-due-time")
+	got, _ = srv.Task(task.ID)
+	if got.Due == nil || got.Due.Datetime != "" || got.Due.Date != "2030-05-01" {
+		t.Errorf("expected the date without a time, got %+v", got.Due)
+	}
+
+	if _, err := run(t, "update", task.ID, "--due", "today", "--due-time", "9:00"); err == nil {
+		t.Error("expected --due and --due-time to conflict")
+	}
+}
+
+func TestE2E_UpdateClearFields(t *testing.T) {
+	srv := newTestServer(t)
+	project := srv.AddProject(api.Project{Name: "Work"})
+	section := srv.AddSection(api.Section{Name: "Doing", ProjectID: project.ID})
+	task := srv.AddTask(api.Task{
+		Content:     "Report",
+		Description: "Draft first",
+		ProjectID:   project.ID,
+		SectionID:   section.ID,
+		Assignee:    "42",
+		Due:         &api.Due{Date: "2030-05-01"},
+	})
+
+	mustRun(t, "update", task.ID, "--no-due", "--no-description", "--unassign", "--no-section")
+	got, _ := srv.Task(task.ID)
+	if got.Due != nil || got.Description != "" || got.Assignee != "" {
+		t.Errorf("expected due, description and assignee cleared, got %+v", got)
+	}
+	if got.SectionID != "" || got.ProjectID != project.ID {
+		t.Errorf("expected the task at the top of %s, got project %s section %s", project.ID, got.ProjectID, got.SectionID)
+	}
+}
+
+func TestE2E_Duplicate(t *testing.T) {
+	srv := newTestServer(t)
+	other := srv.AddProject(api.Project{Name: "Client B"})
+	parent := srv.AddTask(api.Task{Content: "Release", Labels: []string{"work"}, Priority: 4})
+	srv.AddTask(api.Task{Content: "Tag", ParentID: parent.ID, ProjectID: parent.ProjectID})
+	srv.AddComment(api.Comment{TaskID: parent.ID, Content: "See runbook"})
+
+	var copied api.Task
+	envelopeData(t, mustRun(t, "duplicate", parent.ID, "--to-project", "Client B", "--with-subtasks", "--with-comments", "--json"), &copied)
+	if copied.ID == parent.ID || copied.Content != "Release" || copied.ProjectID != other.ID || copied.Priority != 4 {
+		t.Errorf("unexpected copy: %+v", copied)
+	}
+
+	var child *api.Task
+	for _, task := range srv.Tasks() {
+		if task.ParentID == copied.ID {
+			child = &task
+		}
+	}
+	if child == nil || child.Content != "Tag" || child.ProjectID != other.ID {
+		t.Errorf("expected the subtask under the copy, got %+v", child)
+	}
+
+	var comments []api.Comment
+	envelopeData(t, mustRun(t, "comment", copied.ID, "--json"), &comments)
+	if len(comments) != 1 || comments[0].Content != "See runbook" {
+		t.Errorf("expected the comment copied, got %+v", comments)
+	}
+}
+
+func TestE2E_ProjectCopy(t *testing.T) {
+	srv := newTestServer(t)
+	src := srv.AddProject(api.Project{Name: "Template", Color: "blue"})
+	sec := srv.AddSection(api.Section{Name: "Prep", ProjectID: src.ID})
+	parent := srv.AddTask(api.Task{Content: "Kickoff", ProjectID: src.ID, SectionID: sec.ID})
+	srv.AddTask(api.Task{Content: "Agenda", ProjectID: src.ID, SectionID: sec.ID, ParentID: parent.ID})
+	srv.AddTask(api.Task{Content: "Loose end", ProjectID: src.ID})
+
+	var project api.Project
+	envelopeData(t, mustRun(t, "projects", "copy", "Template", "Client C", "--json"), &project)
+	if project.Name != "Client C" || project.Color != "blue" {
+		t.Fatalf("unexpected project: %+v", project)
+	}
+
+	var sections []api.Section
+	envelopeData(t, mustRun(t, "sections", "-p", "Client C", "--json"), &sections)
+	if len(sections) != 1 || sections[0].Name != "Prep" {
+		t.Fatalf("expected the Prep section copied, got %+v", sections)
+	}
+
+	byContent := map[string]api.Task{}
+	for _, task := range srv.Tasks() {
+		if task.ProjectID == project.ID {
+			byContent[task.Content] = task
+		}
+	}
+	if len(byContent) != 3 {
+		t.Fatalf("expected 3 copied tasks, got %+v", byContent)
+	}
+	if byContent["Kickoff"].SectionID != sections[0].ID || byContent["Agenda"].ParentID != byContent["Kickoff"].ID {
+		t.Errorf("expected the section and subtask structure kept, got %+v", byContent)
+	}
+}
+
+func TestE2E_SectionCopy(t *testing.T) {
+	srv := newTestServer(t)
+	src := srv.AddProject(api.Project{Name: "Templates"})
+	dst := srv.AddProject(api.Project{Name: "Product X"})
+	sec := srv.AddSection(api.Section{Name: "Launch", ProjectID: src.ID})
+	srv.AddTask(api.Task{Content: "Announce", ProjectID: src.ID, SectionID: sec.ID})
+	srv.AddTask(api.Task{Content: "Unrelated", ProjectID: src.ID})
+
+	var copied api.Section
+	envelopeData(t, mustRun(t, "sections", "copy", "Templates/Launch", "--to", "Product X", "--json"), &copied)
+
+	var tasks []api.Task
+	for _, task := range srv.Tasks() {
+		if task.ProjectID == dst.ID {
+			tasks = append(tasks, task)
+		}
+	}
+	if len(tasks) != 1 || tasks[0].Content != "Announce" || tasks[0].SectionID != copied.ID {
+		t.Errorf("expected only the section's task copied into it, got %+v", tasks)
+	}
+}
+
+func TestE2E_AddStrictDue(t *testing.T) {
+	srv := newTestServer(t)
+
+	mustRun(t, "add", "Loose", "-d", "nxt mnday")
+	if tasks := srv.Tasks(); len(tasks) != 1 || tasks[0].Due != nil {
+		t.Fatalf("expected the task created without a due date, got %+v", tasks)
+	}
+
+	if _, err := run(t, "add", "Strict", "-d", "nxt mnday", "--strict-due"); err == nil {
+		t.Error("expected --strict-due to fail on an unrecognized date")
+	}
+	if tasks := srv.Tasks(); len(tasks) != 1 {
+		t.Errorf("expected the strict task removed again, got %+v", tasks)
+	}
+
+	out := mustRun(t, "parse-due", "2030-05-01")
+	if !strings.Contains(out, "Wed May 1 2030") {
+		t.Errorf("unexpected parse-due output %q", out)
+	}
+	if _, err := run(t, "parse-due", "nxt", "mnday"); err == nil {
+		t.Error("expected parse-due to fail on an unrecognized date")
+	}
+	if tasks := srv.Tasks(); len(tasks) != 1 {
+		t.Errorf("expected parse-due to leave no scratch tasks, got %+v", tasks)
+	}
+}
+
+func TestE2E_Language(t *testing.T) {
+	srv := newTestServer(t)
+	t.Setenv("LC_ALL", "de_DE.UTF-8")
+
+	if got := mustRun(t, "tasks", "--all"); !strings.Contains(got, "Keine Aufgaben gefunden.") {
+		t.Errorf("expected German output, got: %q", got)
+	}
+	task := srv.AddTask(api.Task{Content: "Entwurf"})
+	if got := mustRun(t, "delete", task.ID, "--force"); !strings.Contains(got, "Gelöscht: Entwurf") {
+		t.Errorf("expected a German success message, got: %q", got)
+	}
+	got := mustRun(t, "tasks", "--all", "--json")
+	if strings.Contains(got, "Keine") {
+		t.Errorf("JSON output should not be translated: %q", got)
+	}
+}
+
+func TestE2E_BulkConfirmation(t *testing.T) {
+	srv := newTestServer(t)
+	var ids []string
+	for i := 0; i < 12; i++ {
+		ids = append(ids, srv.AddTask(api.Task{Content: "Task " + string(rune('a'+i))}).ID)
+	}
+
+	// Without a terminal to confirm on, large changes need --yes
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = stdin }()
+
+	_, err = run(t, append([]string{"complete"}, ids...)...)
+	if err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("expected a confirmation error, got %v", err)
+	}
+	if n := len(srv.Completed()); n != 0 {
+		t.Fatalf("nothing should be completed without confirmation, got %d", n)
+	}
+
+	mustRun(t, append([]string{"complete", "--yes"}, ids[:10]...)...)
+	if n := len(srv.Completed()); n != 10 {
+		t.Errorf("expected 10 completed tasks, got %d", n)
+	}
+
+	mustRun(t, "delete", "--force", ids[10], ids[11])
+	if n := len(srv.Tasks()); n != 0 {
+		t.Errorf("expected no tasks left, got %d", n)
+	}
+}
+
+func TestE2E_ProjectManagement(t *testing.T) {
+	srv := newTestServer(t)
+	old := srv.AddProject(api.Project{Name: "Old"})
+	srv.AddProject(api.Project{Name: "Nested", ParentID: old.ID})
+	srv.AddSection(api.Section{Name: "Backlog", ProjectID: old.ID})
+	srv.AddTask(api.Task{Content: "Gone", ProjectID: old.ID})
+	keep := srv.AddProject(api.Project{Name: "Keep"})
+	srv.AddTask(api.Task{Content: "Stays", ProjectID: keep.ID})
+
+	var p api.Project
+	envelopeData(t, mustRun(t, "projects", "favorite", "Keep", "--json"), &p)
+	if !p.IsFavorite {
+		t.Errorf("expected Keep to be a favorite: %+v", p)
+	}
+	envelopeData(t, mustRun(t, "projects", "color", "Keep", "sky-blue", "--json"), &p)
+	if p.Color != "sky_blue" {
+		t.Errorf("expected color sky_blue, got %q", p.Color)
+	}
+	if _, err := run(t, "projects", "color", "Keep", "plaid"); err == nil {
+		t.Error("expected an error for an unknown color")
+	}
+
+	if _, err := run(t, "projects", "delete", "Old", "--json"); err == nil {
+		t.Fatal("expected delete with --json to need --force")
+	}
+	mustRun(t, "projects", "delete", "Old", "--force")
+	tasks := srv.Tasks()
+	if len(tasks) != 1 || tasks[0].Content != "Stays" {
+		t.Errorf("expected only the other project's task to remain, got %+v", tasks)
+	}
+	var projects []api.Project
+	envelopeData(t, mustRun(t, "projects", "--json"), &projects)
+	for _, p := range projects {
+		if p.Name == "Old" || p.Name == "Nested" {
+			t.Errorf("expected %s and its sub-projects to be deleted", p.Name)
+		}
+	}
+}
+
+func TestE2E_CompleteMatch(t *testing.T) {
+	srv := newTestServer(t)
+	today := time.Now().Format("2006-01-02")
+	srv.AddTask(api.Task{Content: "Call mom", Due: &api.Due{Date: today}})
+	srv.AddTask(api.Task{Content: "Call the bank", Due: &api.Due{Date: today}})
+	srv.AddTask(api.Task{Content: "Water plants"})
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = stdin }()
+
+	if _, err := run(t, "complete", "--match", "call"); err == nil || !strings.Contains(err.Error(), "matches 2 tasks") {
+		t.Fatalf("expected an ambiguity error, got %v", err)
+	}
+
+	mustRun(t, "complete", "--match", "CALL MOM")
+	mustRun(t, "done", "-m", "water")
+	var contents []string
+	for _, c := range srv.Completed() {
+		contents = append(contents, c.Content)
+	}
+	if strings.Join(contents, ",") != "Call mom,Water plants" {
+		t.Errorf("unexpected completed tasks: %v", contents)
+	}
+
+	if _, err := run(t, "complete", "--match", "nothing like this"); err == nil {
+		t.Error("expected an error when nothing matches")
+	}
+}
+
+func TestE2E_SavedViews(t *testing.T) {
+	srv := newTestServer(t)
+	work := srv.AddProject(api.Project{Name: "Work"})
+	srv.AddTask(api.Task{Content: "Write report", ProjectID: work.ID})
+	srv.AddTask(api.Task{Content: "Buy milk"})
+
+	mustRun(t, "view", "save", "kanban-work", "tasks -p Work --all")
+
+	var tasks []api.Task
+	envelopeData(t, mustRun(t, "view", "run", "kanban-work", "--json"), &tasks)
+	if len(tasks) != 1 || tasks[0].Content != "Write report" {
+		t.Errorf("expected the view to list Work tasks, got %+v", tasks)
+	}
+	envelopeData(t, mustRun(t, "--json", "kanban-work"), &tasks)
+	if len(tasks) != 1 {
+		t.Errorf("expected the view to run by name, got %+v", tasks)
+	}
+
+	if _, err := run(t, "view", "save", "tasks", "projects"); err == nil {
+		t.Error("expected built-in commands to be protected")
+	}
+	if _, err := run(t, "view", "save", "loop", "view run kanban-work"); err == nil {
+		t.Error("expected views running views to be refused")
+	}
+
+	mustRun(t, "alias", "set", "kanban-work", "projects")
+	if _, err := run(t, "kanban-work"); err == nil || !strings.Contains(err.Error(), "both an alias and a view") {
+		t.Errorf("expected an ambiguity error, got %v", err)
+	}
+
+	mustRun(t, "view", "remove", "kanban-work")
+	if _, err := run(t, "view", "run", "kanban-work"); err == nil {
+		t.Error("expected the removed view to be unknown")
+	}
+}
+
+func TestE2E_Assignees(t *testing.T) {
+	srv := newTestServer(t)
+	team := srv.AddProject(api.Project{Name: "Team", IsShared: true})
+	srv.SetCollaborators(team.ID,
+		api.Collaborator{ID: "1", Name: "Test User", Email: "test@example.com"},
+		api.Collaborator{ID: "7", Name: "Alice Smith", Email: "alice@example.com"},
+	)
+	srv.AddTask(api.Task{Content: "Review budget", ProjectID: team.ID, Assignee: "7", Assigner: "1"})
+	mine := srv.AddTask(api.Task{Content: "Book venue", ProjectID: team.ID, Assignee: "1"})
+	srv.AddTask(api.Task{Content: "Unassigned", ProjectID: team.ID})
+
+	out := mustRun(t, "tasks", "-p", "Team", "--table=false")
+	if !strings.Contains(out, "Review budget → Alice Smith") {
+		t.Errorf("expected the assignee's name, got:\n%s", out)
+	}
+
+	out = mustRun(t, "view", mine.ID)
+	if !strings.Contains(out, "Assigned: Test User") {
+		t.Errorf("expected the assignee in the detail view, got:\n%s", out)
+	}
+
+	var tasks []api.Task
+	envelopeData(t, mustRun(t, "tasks", "-p", "Team", "--mine", "--json"), &tasks)
+	if len(tasks) != 1 || tasks[0].ID != mine.ID {
+		t.Errorf("expected only my task, got %+v", tasks)
+	}
+	envelopeData(t, mustRun(t, "tasks", "--all", "--assigned-to", "alice", "--json"), &tasks)
+	if len(tasks) != 1 || tasks[0].Content != "Review budget" {
+		t.Errorf("expected only Alice's task, got %+v", tasks)
+	}
+	if _, err := run(t, "tasks", "--assigned-to", "bob"); err == nil {
+		t.Error("expected an unknown collaborator to fail")
+	}
+}
+
+func TestE2E_CommentThread(t *testing.T) {
+	srv := newTestServer(t)
+	srv.PageSize = 2
+	team := srv.AddProject(api.Project{Name: "Team", IsShared: true})
+	srv.SetCollaborators(team.ID, api.Collaborator{ID: "7", Name: "Alice Smith", Email: "alice@example.com"})
+	task := srv.AddTask(api.Task{Content: "Plan launch", ProjectID: team.ID})
+	for i, text := range []string{"Kickoff notes", "Draft ready", "Looks good", "Shipped"} {
+		c := api.Comment{TaskID: task.ID, Content: text, PostedUID: "7",
+			PostedAt: time.Now().AddDate(0, 0, i-10).UTC().Format(time.RFC3339)}
+		if i == 1 {
+			c.Attachment = &api.Attachment{FileName: "draft.pdf", ResourceType: "file"}
+		}
+		if i == 3 {
+			c.PostedUID = "1"
+			c.PostedAt = time.Now().UTC().Format(time.RFC3339)
+		}
+		srv.AddComment(c)
+	}
+
+	out := mustRun(t, "comment", task.ID)
+	for _, want := range []string{"Alice Smith: Kickoff notes", "Attachment: draft.pdf", "Test User: Shipped"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+
+	var comments []api.Comment
+	envelopeData(t, mustRun(t, "comment", task.ID, "--limit", "2", "--json"), &comments)
+	if len(comments) != 2 || comments[0].Content != "Looks good" || comments[1].Content != "Shipped" {
+		t.Errorf("expected the last two comments across pages, got %+v", comments)
+	}
+	envelopeData(t, mustRun(t, "comment", task.ID, "--since", "-1d", "--json"), &comments)
+	if len(comments) != 1 || comments[0].Content != "Shipped" {
+		t.Errorf("expected only today's comment, got %+v", comments)
+	}
+}
+
+func TestE2E_CommentReactions(t *testing.T) {
+	srv := newTestServer(t)
+	task := srv.AddTask(api.Task{Content: "Ship it"})
+	c := srv.AddComment(api.Comment{TaskID: task.ID, Content: "Done!", PostedAt: "2024-03-01T10:00:00Z",
+		Reactions: map[string][]string{"🎉": {"7"}}})
+
+	mustRun(t, "comment", "react", c.ID, "👍")
+	mustRun(t, "comment", "react", c.ID, "🎉")
+	out := mustRun(t, "comment", task.ID)
+	if !strings.Contains(out, "🎉 2  👍 1") {
+		t.Errorf("expected reaction counts, got:\n%s", out)
+	}
+
+	var updated api.Comment
+	envelopeData(t, mustRun(t, "comment", "react", c.ID, "👍", "--remove", "--json"), &updated)
+	if _, ok := updated.Reactions["👍"]; ok || len(updated.Reactions["🎉"]) != 2 {
+		t.Errorf("expected only the thumbs up removed, got %v", updated.Reactions)
+	}
+}
+
+func TestE2E_Workspaces(t *testing.T) {
+	srv := newTestServer(t)
+	srv.AddProject(api.Project{Name: "Home"})
+	out := mustRun(t, "projects")
+	if strings.Contains(out, "Personal") {
+		t.Errorf("expected a flat list without team projects, got:\n%s", out)
+	}
+
+	acme := srv.AddWorkspace(api.Workspace{Name: "Acme", Plan: "BUSINESS", Role: "ADMIN"})
+	srv.AddProject(api.Project{Name: "Roadmap", WorkspaceID: acme.ID})
+
+	out = mustRun(t, "workspaces")
+	if !strings.Contains(out, "Acme [business, admin]  1 project") {
+		t.Errorf("expected the workspace with its project count, got:\n%s", out)
+	}
+
+	out = mustRun(t, "projects")
+	personal, team := strings.Index(out, "Personal\n"), strings.Index(out, "Acme\n")
+	if personal < 0 || team < personal || strings.Index(out, "Home") > team || strings.Index(out, "Roadmap") < team {
+		t.Errorf("expected personal projects, then Acme's, got:\n%s", out)
+	}
+
+	out = mustRun(t, "projects", "--workspace", "acme")
+	if !strings.Contains(out, "Roadmap") || strings.Contains(out, "Home") {
+		t.Errorf("expected only Acme's projects, got:\n%s", out)
+	}
+
+	var p api.Project
+	envelopeData(t, mustRun(t, "projects", "add", "Hiring", "--workspace", "Acme", "--json"), &p)
+	if p.WorkspaceID != acme.ID {
+		t.Errorf("expected the project in workspace %s, got %q", acme.ID, p.WorkspaceID)
+	}
+}
+
+func TestE2E_Folders(t *testing.T) {
+	srv := newTestServer(t)
+	acme := srv.AddWorkspace(api.Workspace{Name: "Acme"})
+	roadmap := srv.AddProject(api.Project{Name: "Roadmap", WorkspaceID: acme.ID})
+	srv.AddProject(api.Project{Name: "Hiring", WorkspaceID: acme.ID})
+	home := srv.AddProject(api.Project{Name: "Home"})
+
+	var folder api.Folder
+	envelopeData(t, mustRun(t, "folders", "add", "Design", "--json"), &folder)
+	if folder.ID == "" || folder.WorkspaceID != acme.ID {
+		t.Fatalf("expected a folder in the only workspace, got %+v", folder)
+	}
+
+	mustRun(t, "folders", "move", "Roadmap", "Design")
+	if p, _ := srv.Project(roadmap.ID); p.FolderID != folder.ID {
+		t.Errorf("expected Roadmap in folder %s, got %q", folder.ID, p.FolderID)
+	}
+	if _, err := run(t, "folders", "move", home.Name, "Design"); err == nil {
+		t.Error("expected moving a personal project into a folder to fail")
+	}
+
+	out := mustRun(t, "folders")
+	if !strings.Contains(out, "Design [Acme]  1 project") {
+		t.Errorf("expected the folder with its project count, got:\n%s", out)
+	}
+
+	out = mustRun(t, "projects")
+	hiring, design, inFolder := strings.Index(out, "Hiring"), strings.Index(out, "  Design/\n"), strings.Index(out, "    "+roadmap.ID+"  Roadmap")
+	if hiring < 0 || design < hiring || inFolder < design {
+		t.Errorf("expected Roadmap listed under its folder after Hiring, got:\n%s", out)
+	}
+
+	mustRun(t, "folders", "move", "Roadmap", "--out")
+	if p, _ := srv.Project(roadmap.ID); p.FolderID != "" {
+		t.Errorf("expected Roadmap out of its folder, got %q", p.FolderID)
+	}
+}
+
+func TestE2E_ProjectWorkload(t *testing.T) {
+	srv := newTestServer(t)
+	p := srv.AddProject(api.Project{Name: "Launch", IsShared: true})
+	srv.SetCollaborators(p.ID,
+		api.Collaborator{ID: "7", Name: "Alice"},
+		api.Collaborator{ID: "8", Name: "Bob"})
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	srv.AddTask(api.Task{Content: "Write copy", ProjectID: p.ID, Assignee: "7", Due: &api.Due{Date: yesterday}})
+	srv.AddTask(api.Task{Content: "Design", ProjectID: p.ID, Assignee: "7"})
+	srv.AddTask(api.Task{Content: "Budget", ProjectID: p.ID})
+
+	out := mustRun(t, "projects", "workload", "Launch")
+	for _, want := range []string{"Launch: 3 tasks", "Alice          2  (1 overdue)", "Bob            0", "Unassigned     1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q, got:\n%s", want, out)
+		}
+	}
+
+	var loads []assigneeLoad
+	envelopeData(t, mustRun(t, "projects", "workload", "Launch", "--json"), &loads)
+	if len(loads) != 3 || loads[0].ID != "7" || loads[0].Overdue != 1 {
+		t.Errorf("unexpected workload %+v", loads)
+	}
+}
+
+func TestE2E_Changes(t *testing.T) {
+	srv := newTestServer(t)
+	shared := srv.AddProject(api.Project{Name: "Launch", IsShared: true})
+	home := srv.AddProject(api.Project{Name: "Home"})
+	srv.SetCollaborators(shared.ID, api.Collaborator{ID: "7", Name: "Alice"})
+	at := func(ago time.Duration) string { return time.Now().Add(-ago).UTC().Format(time.RFC3339) }
+	srv.AddActivity(api.Activity{ObjectType: "item", EventType: "added", ObjectID: "50", ParentProjectID: shared.ID,
+		InitiatorID: "7", EventDate: at(3 * time.Hour), ExtraData: api.ActivityData{Content: "Write copy"}})
+	srv.AddActivity(api.Activity{ObjectType: "item", EventType: "updated", ObjectID: "50", ParentProjectID: shared.ID,
+		InitiatorID: "7", EventDate: at(2 * time.Hour), ExtraData: api.ActivityData{Content: "Write copy", DueDate: "2024-03-20", LastDueDate: "2024-03-18"}})
+	srv.AddActivity(api.Activity{ObjectType: "item", EventType: "updated", ObjectID: "50", ParentProjectID: shared.ID,
+		InitiatorID: "7", EventDate: at(2 * time.Hour), ExtraData: api.ActivityData{Content: "Write better copy"}})
+	srv.AddActivity(api.Activity{ObjectType: "note", EventType: "added", ObjectID: "60", ParentItemID: "50", ParentProjectID: shared.ID,
+		InitiatorID: "1", EventDate: at(time.Hour), ExtraData: api.ActivityData{Content: "On it"}})
+	srv.AddActivity(api.Activity{ObjectType: "item", EventType: "completed", ObjectID: "51", ParentProjectID: home.ID,
+		InitiatorID: "1", EventDate: at(time.Hour), ExtraData: api.ActivityData{Content: "Laundry"}})
+	srv.AddActivity(api.Activity{ObjectType: "item", EventType: "added", ObjectID: "52", ParentProjectID: shared.ID,
+		InitiatorID: "7", EventDate: at(48 * time.Hour), ExtraData: api.ActivityData{Content: "Old news"}})
+
+	out := mustRun(t, "changes", "--peek")
+	for _, want := range []string{"1 added, 1 rescheduled, 1 comment", `Alice added "Write copy"`,
+		`Alice rescheduled "Write copy" to 2024-03-20`, `Test User commented: "On it"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Laundry") || strings.Contains(out, "Old news") || strings.Contains(out, "better") {
+		t.Errorf("expected only the last day's changes in shared projects, got:\n%s", out)
+	}
+
+	var all struct {
+		Counts changeCounts `json:"counts"`
+	}
+	envelopeData(t, mustRun(t, "changes", "--all", "--json"), &all)
+	if all.Counts.Completed != 1 || all.Counts.Added != 1 {
+		t.Errorf("expected personal projects with --all, got %+v", all.Counts)
+	}
+
+	out = mustRun(t, "changes")
+	if !strings.Contains(out, "No changes") {
+		t.Errorf("expected nothing new since the last run, got:\n%s", out)
+	}
+}
+
+func TestE2E_Events(t *testing.T) {
+	srv := newTestServer(t)
+	work := srv.AddProject(api.Project{Name: "Work"})
+	at := func(ago time.Duration) string { return time.Now().Add(-ago).UTC().Format(time.RFC3339) }
+	srv.AddActivity(api.Activity{ObjectType: "item", EventType: "added", ObjectID: "50", ParentProjectID: work.ID,
+		EventDate: at(30 * time.Minute), ExtraData: api.ActivityData{Content: "Write copy"}})
+	srv.AddActivity(api.Activity{ObjectType: "note", EventType: "added", ObjectID: "60", ParentItemID: "50", ParentProjectID: work.ID,
+		EventDate: at(20 * time.Minute), ExtraData: api.ActivityData{Content: "On it"}})
+	srv.AddActivity(api.Activity{ObjectType: "item", EventType: "completed", ObjectID: "50", ParentProjectID: work.ID,
+		EventDate: at(10 * time.Minute), ExtraData: api.ActivityData{Content: "Write copy"}})
+	srv.AddActivity(api.Activity{ObjectType: "item", EventType: "added", ObjectID: "51", ParentProjectID: "2",
+		EventDate: at(5 * time.Hour), ExtraData: api.ActivityData{Content: "Old news"}})
+
+	out := mustRun(t, "events")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 events of the last hour, got:\n%s", out)
+	}
+	var e taskEvent
+	if err := json.Unmarshal([]byte(lines[1]), &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.Type != "task.completed" || e.TaskID != "50" || e.ProjectID != work.ID || e.Content != "Write copy" {
+		t.Errorf("unexpected event %+v", e)
+	}
+
+	out = mustRun(t, "events", "--since", "-1d", "-p", "Work")
+	if n := strings.Count(out, "\n"); n != 2 {
+		t.Errorf("expected only Work's events, got:\n%s", out)
+	}
+	if out = mustRun(t, "events", "--since", "-1d"); strings.Count(out, "\n") != 3 || !strings.Contains(out, "Old news") {
+		t.Errorf("expected the last day's events, got:\n%s", out)
+	}
+}
+
+func TestE2E_API(t *testing.T) {
+	srv := newTestServer(t)
+	work := srv.AddProject(api.Project{Name: "Work"})
+	srv.AddTask(api.Task{Content: "Write copy", ProjectID: work.ID})
+	srv.AddTask(api.Task{Content: "Laundry"})
+
+	out := mustRun(t, "api", "get", "tasks", "-f", "project_id="+work.ID)
+	var page struct {
+		Results []api.Task `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(out), &page); err != nil {
+		t.Fatalf("expected the raw response, got %q: %v", out, err)
+	}
+	if len(page.Results) != 1 || page.Results[0].Content != "Write copy" {
+		t.Errorf("unexpected tasks %+v", page.Results)
+	}
+
+	var task api.Task
+	envelopeData(t, mustRun(t, "api", "POST", "/tasks", "-f", "content=Buy milk", "-F", "priority=4", "--json"), &task)
+	if task.Content != "Buy milk" || task.Priority != 4 {
+		t.Errorf("unexpected task %+v", task)
+	}
+
+	body := filepath.Join(t.TempDir(), "update.json")
+	os.WriteFile(body, []byte(`{"content": "Buy oat milk"}`), 0o644)
+	mustRun(t, "api", "POST", "tasks/"+task.ID, "--data", "@"+body)
+	if got, _ := srv.Task(task.ID); got.Content != "Buy oat milk" {
+		t.Errorf("expected the update sent, got %+v", got)
+	}
+
+	if _, err := run(t, "api", "GET", "tasks/nope"); api.ErrorCode(err) != api.CodeNotFound {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	if _, err := run(t, "api", "POST", "tasks", "--data", "{oops"); err == nil {
+		t.Error("expected invalid JSON to be refused")
+	}
+}
+
+func TestE2E_SyncCmd(t *testing.T) {
+	srv := newTestServer(t)
+	task := srv.AddTask(api.Task{Content: "Write copy", Priority: 1})
+
+	args := []string{"sync-cmd", "item_update", `{"id":"` + task.ID + `","priority":4}`,
+		"project_add", `{"name":"Trip"}`, "item_add", `{"content":"Book flights","project_id":"$2"}`}
+	out := mustRun(t, args...)
+	if !strings.Contains(out, "1 item_update: ok\n") || !strings.Contains(out, "3 item_add: ok (id ") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+	if got, _ := srv.Task(task.ID); got.Priority != 4 {
+		t.Errorf("expected the update applied, got %+v", got)
+	}
+
+	var result struct {
+		Commands []syncCommandResult `json:"commands"`
+	}
+	envelopeData(t, mustRun(t, append(args, "--json")...), &result)
+	if len(result.Commands) != 3 || result.Commands[1].ID == "" || result.Commands[2].ID == "" {
+		t.Fatalf("expected the new IDs, got %+v", result.Commands)
+	}
+	if task, _ := srv.Task(result.Commands[2].ID); task.ProjectID != result.Commands[1].ID {
+		t.Errorf("expected the task added to the new project, got %+v", task)
+	}
+
+	out, err := run(t, "sync-cmd", "item_update", `{"id":"nope"}`, "--json")
+	if err == nil {
+		t.Fatal("expected a failed command to fail")
+	}
+	envelopeData(t, out, &result)
+	if len(result.Commands) != 1 || result.Commands[0].ok() || result.Commands[0].UUID == "" {
+		t.Errorf("unexpected result %+v", result.Commands)
+	}
+}
+
+func TestE2E_Assert(t *testing.T) {
+	srv := newTestServer(t)
+	inbox := srv.AddProject(api.Project{Name: "Inbox"})
+	srv.AddTask(api.Task{Content: "Pay rent", Due: &api.Due{Date: "2020-01-01"}})
+	srv.AddTask(api.Task{Content: "Sort mail", ProjectID: inbox.ID})
+
+	if out := mustRun(t, "assert", "-p", "Inbox", "--max", "1"); out != "OK: 1 task in Inbox\n" {
+		t.Errorf("unexpected output %q", out)
+	}
+
+	out, err := run(t, "assert", "--filter", "overdue")
+	var failed *assertFailed
+	if !errors.As(err, &failed) {
+		t.Fatalf("expected a failed assertion, got %v", err)
+	}
+	if failed.msg != `1 task match "overdue", at most 0 allowed` || !strings.Contains(out, "Pay rent") {
+		t.Errorf("unexpected failure %q with output:\n%s", failed.msg, out)
+	}
+
+	var result struct {
+		Passed bool `json:"passed"`
+		Count  int  `json:"count"`
+	}
+	out, err = run(t, "assert", "-p", "Inbox", "--min", "2", "--json")
+	envelopeData(t, out, &result)
+	if !errors.As(err, &failed) || result.Passed || result.Count != 1 {
+		t.Errorf("expected too few tasks, got %+v: %v", result, err)
+	}
+}
+
+func TestE2E_DaemonInstall(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("writes a systemd unit")
+	}
+	newTestServer(t)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	var calls []string
+	ctl := serviceCtl
+	serviceCtl = func(name string, args ...string) error {
+		calls = append(calls, name+" "+strings.Join(args, " "))
+		return nil
+	}
+	defer func() { serviceCtl = ctl }()
+
+	out := mustRun(t, "daemon", "install", "--print")
+	if !strings.Contains(out, "ExecStart=/") || !strings.Contains(out, " daemon\nRestart=on-failure\n") {
+		t.Errorf("unexpected unit:\n%s", out)
+	}
+	if len(calls) != 0 {
+		t.Errorf("expected --print to leave systemd alone, got %q", calls)
+	}
+
+	mustRun(t, "--profile", "work", "daemon", "install", "--name", "events", "--", "events", "--follow")
+	unit, err := os.ReadFile(filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "systemd", "user", "todoist-events.service"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(unit), " --profile work events --follow\n") {
+		t.Errorf("unexpected unit:\n%s", unit)
+	}
+	if want := []string{"systemctl --user daemon-reload", "systemctl --user enable --now todoist-events.service"}; strings.Join(calls, "|") != strings.Join(want, "|") {
+		t.Errorf("got calls %q, want %q", calls, want)
+	}
+
+	calls = nil
+	mustRun(t, "daemon", "uninstall", "--name", "events")
+	if _, err := os.Stat(filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "systemd", "user", "todoist-events.service")); !os.IsNotExist(err) {
+		t.Errorf("expected the unit removed, got %v", err)
+	}
+	if len(calls) != 2 || calls[0] != "systemctl --user disable --now todoist-events.service" {
+		t.Errorf("unexpected calls %q", calls)
+	}
+	if _, err := run(t, "daemon", "install", "--name", "Bad Name", "--print"); err == nil {
+		t.Error("expected an invalid name to be refused")
+	}
+}
+
+func TestE2E_SelfUpdate(t *testing.T) {
+	newTestServer(t)
+	releases := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"tag_name":"v2.1.0-rc.1","prerelease":true},{"tag_name":"v2.0.0","html_url":"https://example.com/v2.0.0"}]`))
+	}))
+	defer releases.Close()
+	source, exe := releaseSource, selfExecutable
+	releaseSource = func() *update.Source { return &update.Source{URL: releases.URL, HTTP: releases.Client()} }
+	defer func() { releaseSource, selfExecutable = source, exe }()
+
+	out := mustRun(t, "version", "--check")
+	if !strings.Contains(out, "Update available: 2.0.0\n") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+	var check struct {
+		Latest    string `json:"latest"`
+		Available bool   `json:"update_available"`
+	}
+	envelopeData(t, mustRun(t, "version", "--check", "--channel", "prerelease", "--json"), &check)
+	if check.Latest != "2.1.0-rc.1" || !check.Available {
+		t.Errorf("unexpected check %+v", check)
+	}
+
+	dir := filepath.Join(t.TempDir(), "bin")
+	os.Mkdir(dir, 0o755)
+	bin := filepath.Join(dir, "todoist")
+	os.WriteFile(bin, []byte("old"), 0o755)
+	selfExecutable = func() (string, error) { return bin, nil }
+	out = mustRun(t, "self-update", "--dry-run")
+	if !strings.Contains(out, "from dev to 2.0.0") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+	if data, _ := os.ReadFile(bin); string(data) != "old" {
+		t.Error("expected --dry-run to leave the binary alone")
+	}
+	if _, err := run(t, "self-update", "--channel", "nightly"); err == nil {
+		t.Error("expected an unknown channel to be refused")
+	}
+
+	cellar := filepath.Join(t.TempDir(), "Cellar", "todoist", "1.0.0", "bin")
+	os.MkdirAll(cellar, 0o755)
+	os.WriteFile(filepath.Join(cellar, "todoist"), []byte("old"), 0o755)
+	selfExecutable = func() (string, error) { return filepath.Join(cellar, "todoist"), nil }
+	if _, err := run(t, "self-update"); err == nil || !strings.Contains(err.Error(), "brew upgrade") {
+		t.Errorf("expected a Homebrew install to be left to brew, got %v", err)
+	}
+}
+
+func TestE2E_Init(t *testing.T) {
+	srv := newTestServer(t)
+	work := srv.AddProject(api.Project{Name: "Work"})
+	now := time.Now()
+	srv.AddTask(api.Task{Content: "Today", Due: &api.Due{Date: now.Format("2006-01-02")}})
+	srv.AddTask(api.Task{Content: "Late", Due: &api.Due{Date: now.AddDate(0, 0, -2).Format("2006-01-02")}})
+	t.Setenv("SHELL", "/bin/bash")
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	// Project by name, a filter, the theme by number, and yes to completion
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte("work\ntoday\n3\ny\n"))
+	w.Close()
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = stdin }()
+
+	var setup struct {
+		DefaultProject string `json:"default_project"`
+		TodayFilter    string `json:"today_filter"`
+		Theme          string `json:"theme"`
+		Completion     string `json:"completion"`
+	}
+	envelopeData(t, mustRun(t, "init", "--json"), &setup)
+	if setup.DefaultProject != "Work" || setup.TodayFilter != "today" || setup.Theme != "solarized" {
+		t.Errorf("unexpected setup %+v", setup)
+	}
+	if data, err := os.ReadFile(setup.Completion); err != nil || !strings.Contains(string(data), "bash completion") {
+		t.Errorf("expected a bash completion script at %q: %v", setup.Completion, err)
+	}
+
+	out := mustRun(t)
+	if !strings.Contains(out, "Today") || strings.Contains(out, "Late") {
+		t.Errorf("expected the configured filter to apply, got:\n%s", out)
+	}
+	var task api.Task
+	envelopeData(t, mustRun(t, "add", "Quick capture", "--json"), &task)
+	if task.ProjectID != work.ID {
+		t.Errorf("expected the task in the default project, got project %s", task.ProjectID)
+	}
+}
+
+func TestE2E_Examples(t *testing.T) {
+	newTestServer(t)
+
+	out := mustRun(t, "examples")
+	if !strings.Contains(out, "kanban") || !strings.Contains(out, "review") {
+		t.Errorf("expected the topics, got:\n%s", out)
+	}
+	out = mustRun(t, "examples", "kanban")
+	if !strings.Contains(out, `todoist move 123 --section "In Progress"`) || strings.Contains(out, "postpone") {
+		t.Errorf("unexpected kanban recipes:\n%s", out)
+	}
+
+	var recipes []recipe
+	envelopeData(t, mustRun(t, "examples", "weekly", "review", "--json"), &recipes)
+	if len(recipes) != 2 {
+		t.Errorf("expected the two weekly review recipes, got %+v", recipes)
+	}
+	if _, err := run(t, "examples", "juggling"); err == nil {
+		t.Error("expected no match to fail")
+	}
+
+	if out := mustRun(t, "postpone", "--help"); !strings.Contains(out, "More examples: 'todoist examples bulk'") {
+		t.Errorf("expected help to point at the cookbook, got:\n%s", out)
+	}
+}
+
+func TestE2E_Inbox(t *testing.T) {
+	srv := newTestServer(t)
+	work := srv.AddProject(api.Project{Name: "Inbox Zero Ideas"})
+	srv.AddTask(api.Task{Content: "Sort mail"})
+	srv.AddTask(api.Task{Content: "Batch email", ProjectID: work.ID})
+	if err := config.Save(&config.Config{DefaultProject: "Inbox Zero Ideas"}); err != nil {
+		t.Fatal(err)
+	}
+
+	out := mustRun(t, "inbox")
+	if !strings.Contains(out, "Sort mail") || strings.Contains(out, "Batch email") {
+		t.Errorf("expected only Inbox tasks, got:\n%s", out)
+	}
+
+	var task api.Task
+	envelopeData(t, mustRun(t, "inbox", "add", "Call the plumber", "--json"), &task)
+	if got, _ := srv.Task(task.ID); got.ProjectID == work.ID {
+		t.Error("expected inbox add to ignore default_project")
+	}
+	envelopeData(t, mustRun(t, "add", "Try a weekly zero", "--json"), &task)
+	if task.ProjectID != work.ID {
+		t.Errorf("expected add to use default_project, got project %s", task.ProjectID)
+	}
+	if out := strings.TrimSpace(mustRun(t, "inbox", "--count")); out != "2" {
+		t.Errorf("expected 2 Inbox tasks, got %q", out)
+	}
+	if _, err := run(t, "inbox", "add", "Misfiled", "-p", "Work"); err == nil {
+		t.Error("expected inbox add to have no --project")
+	}
+}
+
+func TestE2E_AuthLogout(t *testing.T) {
+	newTestServer(t)
+	cfg := &config.Config{APIToken: "default-token", Aliases: map[string]string{"work": "tasks -p Work"}}
+	cfg.SetProfile("work", config.Profile{APIToken: "work-token"})
+	if err := config.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	mustRun(t, "auth", "logout")
+	cfg = config.Settings()
+	if cfg.APIToken != "" {
+		t.Error("expected the default profile's token removed")
+	}
+	if p, ok := cfg.Profile("work"); !ok || p.APIToken != "work-token" {
+		t.Errorf("expected the work profile to survive, got %+v", cfg.Profiles)
+	}
+	if cfg.Aliases["work"] == "" {
+		t.Error("expected aliases to survive logout")
+	}
+
+	mustRun(t, "--profile", "work", "auth", "logout")
+	if _, ok := config.Settings().Profile("work"); ok {
+		t.Error("expected --profile to remove that profile")
+	}
+
+	// A config file that doesn't parse is not replaced with an empty one
+	broken := []byte(`{"api_token": "default-token",`)
+	if err := os.WriteFile(config.ConfigPath(), broken, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run(t, "auth", "logout"); err == nil {
+		t.Error("expected logout to fail on an unreadable config")
+	}
+	if data, _ := os.ReadFile(config.ConfigPath()); string(data) != string(broken) {
+		t.Errorf("expected the config file to be untouched, got %s", data)
+	}
+}
+
+func TestE2E_ProfileBanner(t *testing.T) {
+	newTestServer(t)
+	t.Setenv("TODOIST_API_TOKEN", "")
+	cfg := &config.Config{APIToken: apitest.Token, Email: "me@example.com"}
+	cfg.SetProfile("work", config.Profile{APIToken: "work-token"})
+	if err := config.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	log, err := os.CreateTemp(t.TempDir(), "stderr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer log.Close()
+	stderrFile := os.Stderr
+	os.Stderr = log
+	defer func() { os.Stderr = stderrFile }()
+
+	// Single-task changes name the profile too, except under --quiet
+	mustRun(t, "add", "Buy milk")
+	mustRun(t, "--quiet", "add", "Buy bread")
+	data, err := os.ReadFile(log.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "▶ Adding Buy milk on profile default (me@example.com)") {
+		t.Errorf("expected the profile banner, got:\n%s", data)
+	}
+	if strings.Contains(string(data), "Buy bread") {
+		t.Errorf("expected --quiet to leave the banner out, got:\n%s", data)
+	}
+}
+
+func TestE2E_ProjectEmail(t *testing.T) {
+	srv := newTestServer(t)
+	p := srv.AddProject(api.Project{Name: "Work"})
+
+	out := mustRun(t, "projects", "email", "Work")
+	if want := "add.task." + p.ID + ".abc123@todoist.net\n"; out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+
+	var result struct {
+		Email string `json:"email"`
+	}
+	envelopeData(t, mustRun(t, "projects", "email", "Work", "--comments", "--json"), &result)
+	if !strings.HasPrefix(result.Email, "add.note.") {
+		t.Errorf("expected the comment address, got %q", result.Email)
+	}
+}
+
+func TestE2E_Backups(t *testing.T) {
+	srv := newTestServer(t)
+	srv.AddBackup("2024-03-01 04:37", []byte("old"))
+	srv.AddBackup("2024-03-02 04:37", []byte("new zip"))
+
+	out := mustRun(t, "backups", "list")
+	if out != "2024-03-01 04:37\n2024-03-02 04:37\n" {
+		t.Errorf("unexpected list:\n%s", out)
+	}
+
+	dir := filepath.Join(t.TempDir(), "backups")
+	path := strings.TrimSpace(mustRun(t, "backups", "download", "--latest", "--out", dir, "-q"))
+	if path != filepath.Join(dir, "todoist-backup-2024-03-02_0437.zip") {
+		t.Errorf("unexpected path %q", path)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != "new zip" {
+		t.Errorf("expected the latest backup's contents, got %q (%v)", data, err)
+	}
+
+	if _, err := run(t, "backups", "download", "2023-01-01 00:00", "--out", dir); err == nil {
+		t.Error("expected an unknown version to fail")
+	}
+}
+
+func TestE2E_LintRecurrence(t *testing.T) {
+	srv := newTestServer(t)
+	old := time.Now().AddDate(0, 0, -30).Format("2006-01-02")
+	stale := srv.AddTask(api.Task{Content: "Water plants", Due: &api.Due{Date: old, String: "every day", IsRecurring: true}})
+	srv.AddTask(api.Task{Content: "Once", Due: &api.Due{Date: old, String: old}})
+
+	var issues []recurrenceIssue
+	envelopeData(t, mustRun(t, "lint-recurrence", "--json"), &issues)
+	if len(issues) != 1 || issues[0].TaskID != stale.ID || issues[0].Rule != "stale" {
+		t.Fatalf("expected the stale daily task, got %+v", issues)
+	}
+
+	mustRun(t, "lint-recurrence", "--fix", "--yes")
+	if task, _ := srv.Task(stale.ID); task.Due == nil || task.Due.Date != time.Now().Format("2006-01-02") {
+		t.Errorf("expected the recurrence restarted today, got %+v", task.Due)
+	}
+	if out := mustRun(t, "lint-recurrence"); !strings.Contains(out, "No problems found") {
+		t.Errorf("expected no problems after fixing, got:\n%s", out)
+	}
+}
+
+func TestE2E_ViewHistory(t *testing.T) {
+	srv := newTestServer(t)
+	today := time.Now().Format("2006-01-02")
+	habit := srv.AddTask(api.Task{Content: "Stretch", Due: &api.Due{Date: today, String: "every day", IsRecurring: true}})
+	once := srv.AddTask(api.Task{Content: "File taxes"})
+	for _, ago := range []int{1, 2, 4} {
+		at := time.Now().AddDate(0, 0, -ago).UTC().Format(time.RFC3339)
+		srv.AddActivity(api.Activity{ObjectType: "item", EventType: "completed", ObjectID: habit.ID, EventDate: at})
+	}
+	srv.AddActivity(api.Activity{ObjectType: "item", EventType: "updated", ObjectID: habit.ID,
+		EventDate: time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)})
+	srv.AddActivity(api.Activity{ObjectType: "item", EventType: "completed", ObjectID: once.ID,
+		EventDate: time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)})
+
+	out := mustRun(t, "view", habit.ID, "--history")
+	if !strings.Contains(out, "History (3 completions, streak 2):") {
+		t.Errorf("expected the completions and streak, got:\n%s", out)
+	}
+
+	var detail struct {
+		History struct {
+			Completed []string `json:"completed"`
+			Streak    *int     `json:"streak"`
+		} `json:"history"`
+	}
+	envelopeData(t, mustRun(t, "view", habit.ID, "--history", "--json"), &detail)
+	h := detail.History
+	if len(h.Completed) != 3 || h.Completed[0] < h.Completed[2] || h.Streak == nil || *h.Streak != 2 {
+		t.Errorf("expected 3 completions newest first with a streak of 2, got %+v", h)
+	}
+
+	out = mustRun(t, "view", once.ID, "--history")
+	if strings.Contains(out, "History") {
+		t.Errorf("expected no history for a one-off task, got:\n%s", out)
+	}
+}
+
+func TestE2E_Habits(t *testing.T) {
+	srv := newTestServer(t)
+	today := time.Now().Format("2006-01-02")
+	created := time.Now().AddDate(0, -2, 0).UTC().Format(time.RFC3339)
+	stretch := srv.AddTask(api.Task{Content: "Stretch", Labels: []string{"habit"}, CreatedAt: created,
+		Due: &api.Due{Date: today, String: "every day", IsRecurring: true}})
+	srv.AddTask(api.Task{Content: "Read", Labels: []string{"habit"}, CreatedAt: created,
+		Due: &api.Due{Date: today, String: "every day", IsRecurring: true}})
+	srv.AddTask(api.Task{Content: "Buy shoes", Labels: []string{"habit"}})
+	for ago := 1; ago <= 15; ago++ {
+		srv.AddActivity(api.Activity{ObjectType: "item", EventType: "completed", ObjectID: stretch.ID,
+			EventDate: time.Now().AddDate(0, 0, -ago).UTC().Format(time.RFC3339)})
+	}
+
+	out := mustRun(t, "habits")
+	for _, want := range []string{"streak 15", "52% done (15/29), 14 missed", "streak 0", "0% done (0/29), 29 missed"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Buy shoes") {
+		t.Errorf("expected tasks that do not recur to be skipped, got:\n%s", out)
+	}
+
+	var habits []habit
+	envelopeData(t, mustRun(t, "habits", "--json"), &habits)
+	if len(habits) != 2 || habits[0].TaskID != stretch.ID && habits[1].TaskID != stretch.ID {
+		t.Fatalf("expected the two recurring habits, got %+v", habits)
+	}
+
+	out = mustRun(t, "habits", "--label", "@routine")
+	if !strings.Contains(out, "No habits found.") {
+		t.Errorf("expected no habits under another label, got:\n%s", out)
+	}
+}
+
+func TestE2E_Deps(t *testing.T) {
+	srv := newTestServer(t)
+	design := srv.AddTask(api.Task{Content: "Design"})
+	review := srv.AddTask(api.Task{Content: "Review"})
+	build := srv.AddTask(api.Task{Content: "Build", Description: "Spec in the doc"})
+	ship := srv.AddTask(api.Task{Content: "Ship"})
+
+	mustRun(t, "deps", "add", build.ID, design.ID, review.ID)
+	mustRun(t, "deps", "add", ship.ID, build.ID)
+	if got, _ := srv.Task(build.ID); got.Description != "Spec in the doc\nblocked-by: "+design.ID+"\nblocked-by: "+review.ID ||
+		len(got.Labels) != 1 || got.Labels[0] != "blocked" {
+		t.Fatalf("expected blocked-by lines and the label, got %q %v", got.Description, got.Labels)
+	}
+
+	out := mustRun(t, "deps")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) < 4 || !strings.Contains(lines[0], "Design") || !strings.HasPrefix(lines[1], "  ") ||
+		!strings.Contains(lines[1], "Build") || !strings.HasPrefix(lines[2], "    ") || !strings.Contains(lines[2], "Ship") {
+		t.Errorf("expected Design > Build > Ship, got:\n%s", out)
+	}
+
+	mustRun(t, "complete", design.ID)
+	if got, _ := srv.Task(build.ID); len(got.Labels) != 1 {
+		t.Errorf("expected Build still blocked by Review, got labels %v", got.Labels)
+	}
+	mustRun(t, "complete", review.ID)
+	if got, _ := srv.Task(build.ID); len(got.Labels) != 0 {
+		t.Errorf("expected Build unblocked, got labels %v", got.Labels)
+	}
+	if got, _ := srv.Task(ship.ID); len(got.Labels) != 1 {
+		t.Errorf("expected Ship still blocked by Build, got labels %v", got.Labels)
+	}
+
+	mustRun(t, "deps", "remove", ship.ID, build.ID)
+	if got, _ := srv.Task(ship.ID); got.Description != "" || len(got.Labels) != 0 {
+		t.Errorf("expected Ship to wait on nothing, got %q %v", got.Description, got.Labels)
+	}
+	if out := mustRun(t, "deps"); !strings.Contains(out, "No dependencies found.") {
+		t.Errorf("expected no dependencies left, got:\n%s", out)
+	}
+}
+
+func TestE2E_ExportDot(t *testing.T) {
+	srv := newTestServer(t)
+	p := srv.AddProject(api.Project{Name: "Launch"})
+	sec := srv.AddSection(api.Section{ProjectID: p.ID, Name: "Week 1"})
+	plan := srv.AddTask(api.Task{Content: `Plan "v2"`, ProjectID: p.ID})
+	step := srv.AddTask(api.Task{Content: "Draft", ProjectID: p.ID, ParentID: plan.ID, SectionID: sec.ID})
+	ship := srv.AddTask(api.Task{Content: "Ship", ProjectID: p.ID, Description: "blocked-by: " + step.ID + ", 999"})
+
+	out := mustRun(t, "export", "dot", "--project", "Launch")
+	for _, want := range []string{
+		`digraph "Launch" {`,
+		`"` + plan.ID + `" [label="Plan \"v2\""];`,
+		`subgraph "cluster_` + sec.ID + `" {`,
+		`label="Week 1";`,
+		`"` + plan.ID + `" -> "` + step.ID + `";`,
+		`"` + step.ID + `" -> "` + ship.ID + `" [style=dashed];`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "999") {
+		t.Errorf("expected no edges to tasks outside the project, got:\n%s", out)
+	}
+
+	file := filepath.Join(t.TempDir(), "launch.dot")
+	mustRun(t, "export", "dot", "-p", "Launch", "-o", file)
+	if data, err := os.ReadFile(file); err != nil || string(data) != out {
+		t.Errorf("expected the same graph in the file, got %q (%v)", data, err)
+	}
+}
+
+func TestE2E_ProjectExportCSV(t *testing.T) {
+	srv := newTestServer(t)
+	p := srv.AddProject(api.Project{Name: "Launch"})
+	sec := srv.AddSection(api.Section{ProjectID: p.ID, Name: "Week 1"})
+	plan := srv.AddTask(api.Task{Content: "Plan, then act", ProjectID: p.ID, Priority: 4, ChildOrder: 1})
+	srv.AddTask(api.Task{Content: "Draft", ProjectID: p.ID, ParentID: plan.ID, ChildOrder: 2,
+		Due: &api.Due{Date: "2024-03-20", String: "every friday"}})
+	srv.AddTask(api.Task{Content: "Ship", ProjectID: p.ID, SectionID: sec.ID, Description: "Tag the release"})
+
+	out := mustRun(t, "projects", "export-csv", "Launch")
+	want := `TYPE,CONTENT,DESCRIPTION,PRIORITY,INDENT,AUTHOR,RESPONSIBLE,DATE,DATE_LANG,TIMEZONE
+task,"Plan, then act",,1,1,,,,,
+task,Draft,,4,2,,,every friday,,
+section,Week 1,,,,,,,,
+task,Ship,Tag the release,4,1,,,,,
+`
+	if out != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestE2E_CalendarPush(t *testing.T) {
+	srv := newTestServer(t)
+	entries := make(map[string]string)
+	var mu sync.Mutex
+	cal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		name := path.Base(r.URL.Path)
+		switch r.Method {
+		case "PUT":
+			body, _ := io.ReadAll(r.Body)
+			entries[name] = string(body)
+			w.WriteHeader(http.StatusCreated)
+		case "DELETE":
+			delete(entries, name)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer cal.Close()
+
+	work := srv.AddProject(api.Project{Name: "Work"})
+	report := srv.AddTask(api.Task{Content: "Report", ProjectID: work.ID, Due: &api.Due{Date: "2024-03-20"}})
+	call := srv.AddTask(api.Task{Content: "Call", ProjectID: work.ID, Due: &api.Due{Date: "2024-03-21", Datetime: "2024-03-21T09:00:00Z"}})
+	srv.AddTask(api.Task{Content: "Someday", ProjectID: work.ID})
+
+	push := func() pushCounts {
+		var counts pushCounts
+		envelopeData(t, mustRun(t, "calendar", "push", "--caldav-url", cal.URL+"/tasks", "-p", "Work", "--json"), &counts)
+		return counts
+	}
+
+	if got := push(); got != (pushCounts{Created: 2}) {
+		t.Errorf("first push: got %+v", got)
+	}
+	if len(entries) != 2 || !strings.Contains(entries["todoist-"+report.ID+".ics"], "DUE;VALUE=DATE:20240320") ||
+		!strings.Contains(entries["todoist-"+call.ID+".ics"], "DUE:20240321T090000Z") {
+		t.Fatalf("expected two to-dos, got %v", entries)
+	}
+
+	if got := push(); got != (pushCounts{Unchanged: 2}) {
+		t.Errorf("second push: got %+v", got)
+	}
+
+	mustRun(t, "update", report.ID, "--content", "Quarterly report")
+	mustRun(t, "complete", call.ID)
+	if got := push(); got != (pushCounts{Updated: 1, Removed: 1}) {
+		t.Errorf("push after changes: got %+v", got)
+	}
+	if len(entries) != 1 || !strings.Contains(entries["todoist-"+report.ID+".ics"], "SUMMARY:Quarterly report") {
+		t.Errorf("expected the updated entry only, got %v", entries)
+	}
+}
+
+func TestE2E_ExportObsidian(t *testing.T) {
+	srv := newTestServer(t)
+	p := srv.AddProject(api.Project{Name: "Home"})
+	trip := srv.AddTask(api.Task{Content: "Plan trip", ProjectID: p.ID, Labels: []string{"travel"}})
+	srv.AddTask(api.Task{Content: "Book flights", ProjectID: p.ID, ParentID: trip.ID})
+	taxes := srv.AddTask(api.Task{Content: "File taxes", ProjectID: p.ID})
+
+	vault := t.TempDir()
+	dir := filepath.Join(vault, "Todoist", "Home")
+	export := func() noteCounts {
+		var counts noteCounts
+		envelopeData(t, mustRun(t, "export", "obsidian", "--vault", vault, "-p", "Home", "--json"), &counts)
+		return counts
+	}
+
+	if got := export(); got != (noteCounts{Created: 2}) {
+		t.Errorf("first export: got %+v", got)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "Plan trip.md"))
+	if err != nil || !strings.Contains(string(data), `labels: ["travel"]`) || !strings.Contains(string(data), "- [ ] Book flights") {
+		t.Fatalf("expected the trip note with its subtask, got %q (%v)", data, err)
+	}
+	os.WriteFile(filepath.Join(dir, "Plan trip.md"), append(data, "\nMy packing list\n"...), 0644)
+
+	mustRun(t, "update", trip.ID, "--content", "Plan summer trip")
+	mustRun(t, "complete", taxes.ID)
+	if got := export(); got != (noteCounts{Updated: 1, Completed: 1}) {
+		t.Errorf("second export: got %+v", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "Plan trip.md")); !os.IsNotExist(err) {
+		t.Errorf("expected the renamed task's note to be renamed")
+	}
+	data, _ = os.ReadFile(filepath.Join(dir, "Plan summer trip.md"))
+	if !strings.Contains(string(data), "# Plan summer trip") || !strings.Contains(string(data), "My packing list") {
+		t.Errorf("expected the updated note with the user's notes kept, got:\n%s", data)
+	}
+	data, _ = os.ReadFile(filepath.Join(dir, "File taxes.md"))
+	if !strings.Contains(string(data), "completed: true") {
+		t.Errorf("expected the completed task's note to be marked, got:\n%s", data)
+	}
+
+	if got := export(); got != (noteCounts{Unchanged: 1}) {
+		t.Errorf("third export: got %+v", got)
+	}
+}
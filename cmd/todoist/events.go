@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/dates"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+// eventsOverlap is how far before the previous read each read of the
+// activity log starts, for events it records late
+const eventsOverlap = 2 * time.Minute
+
+// taskEvent is one line of todoist events
+type taskEvent struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"` // task.added, task.updated, task.completed, task.uncompleted or task.deleted
+	TaskID    string `json:"task_id"`
+	ProjectID string `json:"project_id,omitempty"`
+	ParentID  string `json:"parent_id,omitempty"`
+	Content   string `json:"content"`
+	Due       string `json:"due,omitempty"`      // the new due date of an updated task
+	LastDue   string `json:"last_due,omitempty"` // its due date before the update
+	By        string `json:"by,omitempty"`       // user ID of who made the change
+	At        string `json:"at"`
+}
+
+// eventStream turns successive reads of the activity log into task events,
+// each reported once
+type eventStream struct {
+	start   time.Time            // events before this are never reported
+	from    time.Time            // when the log was last read
+	project string               // only events in this project, if set
+	seen    map[string]time.Time // IDs of events read within eventsOverlap of from
+}
+
+func newEventStream(start time.Time, project string) *eventStream {
+	return &eventStream{start: start, from: start, project: project, seen: make(map[string]time.Time)}
+}
+
+// since is where the next read of the activity log starts
+func (s *eventStream) since() time.Time {
+	if from := s.from.Add(-eventsOverlap); from.After(s.start) {
+		return from
+	}
+	return s.start
+}
+
+// next returns the task events among activities, read from the log at
+// readAt, that were not reported before
+func (s *eventStream) next(activities []api.Activity, readAt time.Time) []taskEvent {
+	events := []taskEvent{}
+	for _, a := range activities {
+		at, err := time.Parse(time.RFC3339, a.EventDate)
+		if err != nil || a.ObjectType != "item" || at.Before(s.start) {
+			continue
+		}
+		if _, ok := s.seen[a.ID]; ok {
+			continue
+		}
+		s.seen[a.ID] = at
+		if s.project != "" && a.ParentProjectID != s.project {
+			continue
+		}
+		switch a.EventType {
+		case "added", "updated", "completed", "uncompleted", "deleted":
+		default:
+			continue
+		}
+		events = append(events, taskEvent{
+			ID:        a.ID,
+			Type:      "task." + a.EventType,
+			TaskID:    a.ObjectID,
+			ProjectID: a.ParentProjectID,
+			ParentID:  a.ParentItemID,
+			Content:   a.ExtraData.Content,
+			Due:       a.ExtraData.DueDate,
+			LastDue:   a.ExtraData.LastDueDate,
+			By:        a.InitiatorID,
+			At:        a.EventDate,
+		})
+	}
+	s.from = readAt
+	for id, at := range s.seen {
+		if at.Before(s.since()) {
+			delete(s.seen, id)
+		}
+	}
+	return events
+}
+
+func newEventsCmd(flags *rootFlags) *cobra.Command {
+	var (
+		follow   bool
+		since    string
+		project  string
+		interval time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "Print task events as JSON Lines",
+		Long: `Print the tasks added, updated, completed, uncompleted and deleted, from
+the activity log, as JSON Lines: one object per event, oldest first. Pipe
+them into any automation tool.
+
+Without --follow, the events of the last hour (or --since) are printed.
+With --follow, the activity log is read every --interval and new events
+are printed as they appear, until interrupted; --since then prints the
+earlier events first.
+
+Events have an id, a type (task.added, task.updated, task.completed,
+task.uncompleted or task.deleted), the task_id, project_id and content,
+due and last_due for updates, who made the change (by) and when (at).
+
+Examples:
+  todoist events --follow
+  todoist events --follow -p Work | while read -r e; do ...; done
+  todoist events --since yesterday`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval < 5*time.Second {
+				return fmt.Errorf("--interval must be at least 5s")
+			}
+			out := newFormatter(flags)
+			out.SetJSONLines(true)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+			var projectID string
+			if project != "" {
+				p, err := client.FindProject(project)
+				if err != nil {
+					return err
+				}
+				projectID = p.ID
+			}
+
+			now := time.Now()
+			start := now
+			switch {
+			case since != "":
+				if start, err = dates.Parse(since, now); err != nil {
+					return fmt.Errorf("invalid --since: %w", err)
+				}
+			case !follow:
+				start = now.Add(-time.Hour)
+			}
+
+			stream := newEventStream(start, projectID)
+			poll := func() error {
+				readAt := time.Now()
+				activities, err := client.GetActivities(stream.since())
+				if err != nil {
+					return fmt.Errorf("failed to read activity log: %w", err)
+				}
+				return out.JSON(stream.next(activities, readAt))
+			}
+			if start.Before(now) {
+				if err := poll(); err != nil {
+					return err
+				}
+			}
+			if !follow {
+				return nil
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+				}
+				// Keep following through outages; the next poll catches up
+				if err := poll(); err != nil && !flags.quiet {
+					fmt.Fprintln(stderr, i18n.T("Warning: %v", err))
+				}
+			}
+		},
+	}
+
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "keep printing new events until interrupted")
+	cmd.Flags().StringVar(&since, "since", "", "print events from this time (e.g. yesterday, 9am, -2d)")
+	cmd.Flags().StringVarP(&project, "project", "p", "", "only events in this project")
+	cmd.Flags().DurationVar(&interval, "interval", 30*time.Second, "how often --follow reads the activity log")
+
+	return cmd
+}
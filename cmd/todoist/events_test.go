@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+)
+
+func TestEventStream(t *testing.T) {
+	start := time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC)
+	at := func(min int) string { return start.Add(time.Duration(min) * time.Minute).Format(time.RFC3339) }
+	item := func(id, event string, min int) api.Activity {
+		return api.Activity{ID: id, ObjectType: "item", EventType: event, ObjectID: "t" + id, ParentProjectID: "P", EventDate: at(min)}
+	}
+
+	s := newEventStream(start, "")
+	if got := s.since(); !got.Equal(start) {
+		t.Errorf("first read from %v, want %v", got, start)
+	}
+	events := s.next([]api.Activity{
+		item("1", "added", -1), // before the start
+		item("2", "added", 1),
+		{ID: "3", ObjectType: "note", EventType: "added", EventDate: at(2)},
+		item("4", "completed", 3),
+		item("5", "moved", 4),
+	}, start.Add(10*time.Minute))
+	if len(events) != 2 || events[0].Type != "task.added" || events[0].TaskID != "t2" || events[1].Type != "task.completed" {
+		t.Fatalf("unexpected events %+v", events)
+	}
+	if got, want := s.since(), start.Add(8*time.Minute); !got.Equal(want) {
+		t.Errorf("next read from %v, want %v", got, want)
+	}
+
+	// The overlap reads late events again; only new ones are reported
+	events = s.next([]api.Activity{item("6", "updated", 9), item("7", "uncompleted", 19)}, start.Add(20*time.Minute))
+	if len(events) != 2 || events[0].ID != "6" || events[1].ID != "7" {
+		t.Fatalf("unexpected events %+v", events)
+	}
+	events = s.next([]api.Activity{item("7", "uncompleted", 19), item("8", "deleted", 25)}, start.Add(30*time.Minute))
+	if len(events) != 1 || events[0].Type != "task.deleted" {
+		t.Fatalf("expected only the new event, got %+v", events)
+	}
+
+	s = newEventStream(start, "Q")
+	if events := s.next([]api.Activity{item("9", "added", 1)}, start.Add(time.Minute)); len(events) != 0 {
+		t.Errorf("expected events of other projects left out, got %+v", events)
+	}
+}
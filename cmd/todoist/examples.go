@@ -0,0 +1,262 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// recipe is a cookbook entry: a task and the command lines that do it
+type recipe struct {
+	Topic    string   `json:"topic"`
+	Title    string   `json:"title"`
+	Commands []string `json:"commands"`
+	Note     string   `json:"note,omitempty"`
+}
+
+// exampleTopic groups recipes under a name for 'todoist examples <topic>'
+type exampleTopic struct {
+	Name    string `json:"name"`
+	Summary string `json:"summary"`
+	Count   int    `json:"count"`
+}
+
+var exampleTopics = []exampleTopic{
+	{Name: "capture", Summary: "Getting tasks in quickly"},
+	{Name: "lists", Summary: "Finding the tasks you want to see"},
+	{Name: "kanban", Summary: "Working a project's sections as a board"},
+	{Name: "bulk", Summary: "Changing many tasks at once"},
+	{Name: "review", Summary: "Daily and weekly reviews"},
+	{Name: "scripting", Summary: "JSON, exit codes and raw API access"},
+}
+
+// cookbook holds real invocations; every command line must run as written
+// apart from placeholder IDs and names
+var cookbook = []recipe{
+	{Topic: "capture", Title: "Add a task with a date, priority and labels", Commands: []string{
+		`todoist add "Renew passport" -d "next friday 9am" -P 1 -l errands`,
+	}},
+	{Topic: "capture", Title: "Capture to the Inbox now, sort it out later", Commands: []string{
+		`todoist inbox add "Call the plumber"`,
+		`todoist inbox`,
+		`todoist move 123 --project Home`,
+	}, Note: "Set default_project in the config to make plain 'add' land somewhere else."},
+	{Topic: "capture", Title: "Add a task with notes from the clipboard", Commands: []string{
+		`pbpaste | todoist add "Follow up with Sam" --description -`,
+	}},
+	{Topic: "capture", Title: "Check how Todoist will read a due date", Commands: []string{
+		`todoist parse-due "every other friday"`,
+		`todoist add "Pay rent" -d "1st of month" --strict-due`,
+	}, Note: "--strict-due refuses to create the task when the date is not understood."},
+	{Topic: "lists", Title: "List a project's tasks", Commands: []string{
+		`todoist tasks -p Work`,
+		`todoist tasks -p Work --priority 1 --due week`,
+	}, Note: "With -p, all of the project's tasks are listed, not just today's."},
+	{Topic: "lists", Title: "List tasks with a Todoist filter", Commands: []string{
+		`todoist tasks --filter "p1 & overdue"`,
+		`todoist tasks --filter "#Work & @waiting"`,
+	}},
+	{Topic: "lists", Title: "Find tasks by text", Commands: []string{
+		`todoist search "invoice" --project Work`,
+		`todoist search --regex '^(call|email) '`,
+	}},
+	{Topic: "lists", Title: "Save a list you check often", Commands: []string{
+		`todoist view save waiting 'tasks --label waiting --sort due'`,
+		`todoist waiting`,
+	}},
+	{Topic: "kanban", Title: "Show a project as a board", Commands: []string{
+		`todoist view save board 'tasks -p Work --table --columns id,section,content'`,
+		`todoist board`,
+	}, Note: "Sections are the board's columns."},
+	{Topic: "kanban", Title: "Move a card to another column", Commands: []string{
+		`todoist move 123 --section "In Progress"`,
+		`todoist move 123 --project Work --section Backlog`,
+	}},
+	{Topic: "kanban", Title: "Finish a card", Commands: []string{
+		`todoist move 123 --section Done`,
+		`todoist complete 123`,
+	}},
+	{Topic: "bulk", Title: "Move every overdue task to today", Commands: []string{
+		`todoist postpone --filter overdue --to today`,
+	}},
+	{Topic: "bulk", Title: "Push several tasks back a few days", Commands: []string{
+		`todoist postpone 123 456 789 --to +3d`,
+	}},
+	{Topic: "bulk", Title: "Raise the priority of several tasks", Commands: []string{
+		`todoist p1 123 456`,
+		`todoist priority 123 456 789 2`,
+	}},
+	{Topic: "bulk", Title: "Complete tasks by name", Commands: []string{
+		`todoist complete --match "call mom"`,
+	}},
+	{Topic: "bulk", Title: "Set aside tasks with no date in mind", Commands: []string{
+		`todoist snooze 123 456`,
+		`todoist snoozed --wake`,
+	}, Note: "Bulk changes above confirm_threshold tasks ask first; scripts pass --yes."},
+	{Topic: "review", Title: "Plan the day", Commands: []string{
+		`todoist next --explain --top 5`,
+		`todoist focus 123 --minutes 50`,
+	}},
+	{Topic: "review", Title: "Weekly review: what got done", Commands: []string{
+		`todoist completed --since "last monday"`,
+		`todoist heatmap --weeks 4`,
+	}},
+	{Topic: "review", Title: "Weekly review: what needs attention", Commands: []string{
+		`todoist summary`,
+		`todoist tasks --no-date --created-since -7d`,
+		`todoist changes --since monday`,
+		`todoist lint-recurrence`,
+	}},
+	{Topic: "review", Title: "Write a standup", Commands: []string{
+		`todoist standup -p Work | pbcopy`,
+	}},
+	{Topic: "scripting", Title: "Get tasks as JSON for jq", Commands: []string{
+		`todoist tasks --all --json | jq '.data[].content'`,
+		`todoist tasks --jsonl --fields id,content,due.date`,
+	}},
+	{Topic: "scripting", Title: "Fail a CI job when work piles up", Commands: []string{
+		`todoist assert --filter overdue --max 0`,
+	}, Note: "assert exits with code 5 when the count is out of bounds."},
+	{Topic: "scripting", Title: "Call an API endpoint the CLI has no command for", Commands: []string{
+		`todoist api GET projects`,
+		`todoist sync-cmd item_add '{"content":"From a script"}'`,
+	}},
+	{Topic: "scripting", Title: "Pick a task interactively in a script", Commands: []string{
+		`todoist complete $(todoist pick)`,
+	}},
+}
+
+// matchRecipes returns the recipes of a topic, or else those containing
+// every keyword
+func matchRecipes(words []string) []recipe {
+	if len(words) == 1 {
+		var topic []recipe
+		for _, r := range cookbook {
+			if strings.EqualFold(r.Topic, words[0]) {
+				topic = append(topic, r)
+			}
+		}
+		if len(topic) > 0 {
+			return topic
+		}
+	}
+
+	var matches []recipe
+	for _, r := range cookbook {
+		text := strings.ToLower(r.Topic + " " + r.Title + " " + strings.Join(r.Commands, " ") + " " + r.Note)
+		all := true
+		for _, w := range words {
+			if !strings.Contains(text, strings.ToLower(w)) {
+				all = false
+				break
+			}
+		}
+		if all {
+			matches = append(matches, r)
+		}
+	}
+	return matches
+}
+
+// linkExamples ends the help of every command used in a recipe with the
+// topics to look in
+func linkExamples(root *cobra.Command) {
+	topics := make(map[*cobra.Command][]string)
+	var order []*cobra.Command
+	for _, r := range cookbook {
+		for _, line := range r.Commands {
+			words := strings.Fields(line)
+			for i, w := range words {
+				if w != "todoist" {
+					continue
+				}
+				cmd, _, err := root.Find(words[i+1:])
+				if err != nil || cmd == root {
+					continue
+				}
+				if _, seen := topics[cmd]; !seen {
+					order = append(order, cmd)
+				}
+				if !contains(topics[cmd], r.Topic) {
+					topics[cmd] = append(topics[cmd], r.Topic)
+				}
+			}
+		}
+	}
+	for _, cmd := range order {
+		long := cmd.Long
+		if long == "" {
+			long = cmd.Short
+		}
+		refs := make([]string, len(topics[cmd]))
+		for i, t := range topics[cmd] {
+			refs[i] = "'todoist examples " + t + "'"
+		}
+		cmd.Long = long + "\n\nMore examples: " + strings.Join(refs, ", ")
+	}
+}
+
+func newExamplesCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "examples [topic|keyword...]",
+		Short: "Show a cookbook of example commands",
+		Long: `Show worked examples of common jobs, from quick capture to weekly reviews.
+
+Without arguments, list the topics. With a topic, show its recipes; other
+words search every recipe's title, commands and notes, and all of them must
+match.
+
+Examples:
+  todoist examples
+  todoist examples kanban
+  todoist examples postpone
+  todoist examples weekly review`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+			color := out.Color()
+
+			if len(args) == 0 {
+				topics := make([]exampleTopic, len(exampleTopics))
+				copy(topics, exampleTopics)
+				for i := range topics {
+					topics[i].Count = len(matchRecipes([]string{topics[i].Name}))
+				}
+				if flags.asJSON {
+					return out.JSON(topics)
+				}
+				out.Printf("%s\n", i18n.T("Topics:"))
+				for _, t := range topics {
+					out.Printf("  %-10s %s %s\n", t.Name, t.Summary, color.Wrap(output.ANSIGray, fmt.Sprintf("(%d)", t.Count)))
+				}
+				out.Printf("\n%s\n", i18n.T("Run 'todoist examples <topic>', or give keywords to search."))
+				return nil
+			}
+
+			recipes := matchRecipes(args)
+			if len(recipes) == 0 {
+				return fmt.Errorf("no examples match %q; run 'todoist examples' for the topics", strings.Join(args, " "))
+			}
+			if flags.asJSON {
+				return out.JSON(recipes)
+			}
+			for i, r := range recipes {
+				if i > 0 {
+					out.Printf("\n")
+				}
+				out.Printf("%s %s\n", color.Wrap(output.ANSIBold, r.Title), color.Wrap(output.ANSIGray, "["+r.Topic+"]"))
+				for _, c := range r.Commands {
+					out.Printf("  %s\n", color.Wrap(output.ANSICyan, c))
+				}
+				if r.Note != "" {
+					out.Printf("  %s\n", color.Wrap(output.ANSIGray, r.Note))
+				}
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
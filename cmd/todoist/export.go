@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+func newExportCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export tasks in other formats",
+	}
+
+	cmd.AddCommand(newExportDotCmd(flags))
+	cmd.AddCommand(newExportObsidianCmd(flags))
+	cmd.AddCommand(newExportRemindersCmd(flags))
+
+	return cmd
+}
+
+func newExportDotCmd(flags *rootFlags) *cobra.Command {
+	var project, file string
+
+	cmd := &cobra.Command{
+		Use:   "dot",
+		Short: "Export a project's task structure as a Graphviz graph",
+		Long: `Write a project's open tasks as a Graphviz DOT graph: sections are boxed
+clusters, solid arrows run from a task to its subtasks, and dashed arrows
+from a blocker to the tasks waiting on it (see todoist deps). Only
+relations between the project's open tasks are drawn.
+
+Examples:
+  todoist export dot --project Launch > launch.dot
+  todoist export dot -p Launch | dot -Tsvg -o launch.svg
+  todoist export dot -p Launch -o launch.dot`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			p, err := client.FindProject(project)
+			if err != nil {
+				return err
+			}
+			tasks, err := client.GetTasks(p.ID, "")
+			if err != nil {
+				return err
+			}
+			sections, err := client.GetSections(p.ID)
+			if err != nil {
+				return err
+			}
+
+			dot := dotGraph(p.Name, tasks, sections)
+			if file == "" {
+				out.Printf("%s", dot)
+				return nil
+			}
+			if err := os.WriteFile(file, []byte(dot), 0644); err != nil {
+				return fmt.Errorf("failed to write graph: %w", err)
+			}
+			out.WriteSuccess(i18n.T("Wrote %d tasks to %s", len(tasks), file))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&project, "project", "p", "", "project to export (required)")
+	cmd.Flags().StringVarP(&file, "output", "o", "", "write the graph to this file")
+	cmd.MarkFlagRequired("project")
+
+	return cmd
+}
+
+// dotGraph renders tasks as a Graphviz digraph named title. Each section is
+// a cluster; solid edges run from parent to subtask and dashed ones from a
+// blocker to the task waiting on it.
+func dotGraph(title string, tasks []api.Task, sections []api.Section) string {
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].ChildOrder < tasks[j].ChildOrder })
+	sort.SliceStable(sections, func(i, j int) bool { return sections[i].SectionOrder < sections[j].SectionOrder })
+
+	open := make(map[string]bool, len(tasks))
+	bySection := make(map[string][]api.Task)
+	for _, t := range tasks {
+		open[t.ID] = true
+		bySection[t.SectionID] = append(bySection[t.SectionID], t)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(title))
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=rounded];\n")
+
+	writeNodes := func(indent string, tasks []api.Task) {
+		for _, t := range tasks {
+			attrs := "label=" + dotQuote(t.Content)
+			if t.Priority > 1 {
+				attrs += fmt.Sprintf(", penwidth=%d", t.Priority)
+			}
+			fmt.Fprintf(&b, "%s%s [%s];\n", indent, dotQuote(t.ID), attrs)
+		}
+	}
+	writeNodes("  ", bySection[""])
+	for _, s := range sections {
+		if len(bySection[s.ID]) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "  subgraph %s {\n", dotQuote("cluster_"+s.ID))
+		fmt.Fprintf(&b, "    label=%s;\n", dotQuote(s.Name))
+		writeNodes("    ", bySection[s.ID])
+		b.WriteString("  }\n")
+	}
+
+	for _, t := range tasks {
+		if open[t.ParentID] {
+			fmt.Fprintf(&b, "  %s -> %s;\n", dotQuote(t.ParentID), dotQuote(t.ID))
+		}
+	}
+	for _, t := range tasks {
+		for _, id := range blockers(t) {
+			if open[id] && id != t.ID {
+				fmt.Fprintf(&b, "  %s -> %s [style=dashed];\n", dotQuote(id), dotQuote(t.ID))
+			}
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotQuote writes s as a DOT quoted string
+func dotQuote(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r", "", "\n", `\n`).Replace(s)
+	return `"` + s + `"`
+}
+
+// csvColumns are the columns of Todoist's CSV template format, which the
+// web importer reads
+var csvColumns = []string{"TYPE", "CONTENT", "DESCRIPTION", "PRIORITY", "INDENT", "AUTHOR", "RESPONSIBLE", "DATE", "DATE_LANG", "TIMEZONE"}
+
+func newProjectExportCSVCmd(flags *rootFlags) *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "export-csv <project>",
+		Short: "Export a project in Todoist's CSV template format",
+		Long: `Write a project's sections and open tasks as CSV in the format of
+Todoist's templates, which the web importer reads back ("Import from
+template"), so a project can be copied to another account or shared as a
+template. Subtasks are indented; priorities, descriptions and due dates as
+entered are kept. In shared projects the author and assignee are written
+as "Name (id)".
+
+Examples:
+  todoist projects export-csv Launch > launch.csv
+  todoist projects export-csv Launch -o launch.csv`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			p, err := client.FindProject(args[0])
+			if err != nil {
+				return err
+			}
+			tasks, err := client.GetTasks(p.ID, "")
+			if err != nil {
+				return err
+			}
+			sections, err := client.GetSections(p.ID)
+			if err != nil {
+				return err
+			}
+			var names map[string]string
+			if p.IsShared {
+				if names, err = people(client); err != nil {
+					return err
+				}
+			}
+
+			data, err := templateCSV(tasks, sections, names)
+			if err != nil {
+				return err
+			}
+			if file == "" {
+				out.Printf("%s", data)
+				return nil
+			}
+			if err := os.WriteFile(file, data, 0644); err != nil {
+				return fmt.Errorf("failed to write CSV: %w", err)
+			}
+			out.WriteSuccess(i18n.T("Wrote %d tasks to %s", len(tasks), file))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "output", "o", "", "write the CSV to this file")
+
+	return cmd
+}
+
+// templateCSV writes tasks in Todoist's CSV template format: tasks outside
+// sections first, then each section followed by its tasks, subtasks after
+// their parents with a deeper indent. names maps user IDs to names for the
+// author and assignee columns, which are left empty without one.
+func templateCSV(tasks []api.Task, sections []api.Section, names map[string]string) ([]byte, error) {
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].ChildOrder < tasks[j].ChildOrder })
+	sort.SliceStable(sections, func(i, j int) bool { return sections[i].SectionOrder < sections[j].SectionOrder })
+
+	open := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		open[t.ID] = true
+	}
+	children := make(map[string][]api.Task)
+	bySection := make(map[string][]api.Task)
+	for _, t := range tasks {
+		if open[t.ParentID] {
+			children[t.ParentID] = append(children[t.ParentID], t)
+		} else {
+			bySection[t.SectionID] = append(bySection[t.SectionID], t)
+		}
+	}
+	person := func(id string) string {
+		if name := names[id]; name != "" {
+			return fmt.Sprintf("%s (%s)", name, id)
+		}
+		return ""
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write(csvColumns)
+	var write func(t api.Task, indent int)
+	write = func(t api.Task, indent int) {
+		var date, lang, zone string
+		if t.Due != nil {
+			date, zone = t.Due.String, t.Due.Timezone
+			if date == "" {
+				date = t.Due.Date
+			}
+			json.Unmarshal(t.Due.Extra["lang"], &lang)
+		}
+		w.Write([]string{"task", t.Content, t.Description, strconv.Itoa(5 - max(t.Priority, 1)), strconv.Itoa(indent),
+			person(t.CreatorID), person(t.Assignee), date, lang, zone})
+		for _, c := range children[t.ID] {
+			write(c, indent+1)
+		}
+	}
+	for _, t := range bySection[""] {
+		write(t, 1)
+	}
+	for _, s := range sections {
+		w.Write([]string{"section", s.Name, "", "", "", "", "", "", "", ""})
+		for _, t := range bySection[s.ID] {
+			write(t, 1)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
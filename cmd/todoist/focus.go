@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/hooks"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/buddyh/todoist-cli/internal/picker"
+	"github.com/spf13/cobra"
+)
+
+func newFocusCmd(flags *rootFlags) *cobra.Command {
+	var (
+		minutes   int
+		noComment bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "focus [task-id]",
+		Short: "Work on a task with a Pomodoro-style countdown",
+		Long: `Start a countdown for a task. When it ends you can complete the task,
+start another round, or stop. The time spent is added to the task as a
+comment, e.g. "Focused for 50m (2 rounds)".
+
+Ctrl-C ends a round early. Without an ID, pick from today's tasks.
+
+Examples:
+  todoist focus 123
+  todoist focus 123 --minutes 50
+  todoist focus --no-comment`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			if minutes < 1 {
+				return fmt.Errorf("--minutes must be at least 1")
+			}
+			if !picker.IsTerminal(os.Stdin) {
+				return fmt.Errorf("focus needs an interactive terminal")
+			}
+
+			taskID, err := taskIDArg(flags, args)
+			if err != nil {
+				return err
+			}
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+			task, err := client.GetTask(taskID)
+			if err != nil {
+				return err
+			}
+
+			reader := bufio.NewReader(os.Stdin)
+			var spent time.Duration
+			rounds := 0
+			completed := false
+
+		session:
+			for {
+				rounds++
+				spent += countdown(task.Content, time.Duration(minutes)*time.Minute)
+
+				fmt.Fprint(os.Stderr, "\a"+i18n.T("Round %d done, %s in total. [c]omplete, [a]nother round, [s]top? ", rounds, formatSpent(spent)))
+				input, err := reader.ReadString('\n')
+				if err != nil {
+					break
+				}
+				switch strings.ToLower(strings.TrimSpace(input)) {
+				case "c", "complete":
+					completed = true
+					break session
+				case "a", "another":
+					continue
+				default:
+					break session
+				}
+			}
+
+			if !noComment && spent >= time.Minute {
+				if _, err := client.AddComment(spentComment(spent, rounds), taskID, ""); err != nil {
+					return err
+				}
+			}
+
+			if completed {
+				if err := client.CompleteTask(taskID); err != nil {
+					return err
+				}
+				hooks.RunPost(stderr, hooks.PostComplete, task)
+			}
+
+			if flags.asJSON {
+				return out.JSON(map[string]interface{}{
+					"task_id":   taskID,
+					"rounds":    rounds,
+					"minutes":   int(spent.Minutes()),
+					"completed": completed,
+				})
+			}
+			if completed {
+				out.WriteSuccess(i18n.T("Completed: %s (%s)", task.Content, formatSpent(spent)))
+			} else {
+				out.WriteSuccess(i18n.T("Focused on %s for %s", task.Content, formatSpent(spent)))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&minutes, "minutes", "m", 25, "length of each round")
+	cmd.Flags().BoolVar(&noComment, "no-comment", false, "do not log the time spent as a comment")
+
+	return cmd
+}
+
+// countdown shows the time left on stderr until d has passed or Ctrl-C is
+// pressed, and returns how long it ran
+func countdown(label string, d time.Duration) time.Duration {
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	start := time.Now()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	for {
+		left := (d - time.Since(start)).Round(time.Second)
+		fmt.Fprintf(os.Stderr, "\r  %02d:%02d  %s\033[K", int(left.Minutes()), int(left.Seconds())%60, label)
+
+		select {
+		case <-ticker.C:
+		case <-timer.C:
+			fmt.Fprintln(os.Stderr)
+			return d
+		case <-interrupt:
+			fmt.Fprintln(os.Stderr)
+			return time.Since(start)
+		}
+	}
+}
+
+// formatSpent renders a duration in whole minutes, e.g. "25m" or "1h 40m"
+func formatSpent(d time.Duration) string {
+	m := int(d.Round(time.Minute).Minutes())
+	if m < 60 {
+		return fmt.Sprintf("%dm", m)
+	}
+	return fmt.Sprintf("%dh %dm", m/60, m%60)
+}
+
+// spentComment is the comment logged on the task after a focus session
+func spentComment(d time.Duration, rounds int) string {
+	if rounds == 1 {
+		return fmt.Sprintf("Focused for %s", formatSpent(d))
+	}
+	return fmt.Sprintf("Focused for %s (%d rounds)", formatSpent(d), rounds)
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSpentComment(t *testing.T) {
+	tests := []struct {
+		d      time.Duration
+		rounds int
+		want   string
+	}{
+		{25 * time.Minute, 1, "Focused for 25m"},
+		{50*time.Minute + 20*time.Second, 2, "Focused for 50m (2 rounds)"},
+		{100 * time.Minute, 4, "Focused for 1h 40m (4 rounds)"},
+	}
+	for _, tt := range tests {
+		if got := spentComment(tt.d, tt.rounds); got != tt.want {
+			t.Errorf("spentComment(%s, %d) = %q, want %q", tt.d, tt.rounds, got, tt.want)
+		}
+	}
+}
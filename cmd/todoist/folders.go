@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+func newFoldersCmd(flags *rootFlags) *cobra.Command {
+	var workspace string
+
+	cmd := &cobra.Command{
+		Use:     "folders",
+		Aliases: []string{"folder"},
+		Short:   "List and manage workspace project folders",
+		Long: `List the project folders of your team workspaces, with how many projects
+each holds. Folders only exist in workspaces; todoist projects shows
+workspace projects grouped by folder.
+
+Examples:
+  todoist folders
+  todoist folders --workspace Acme
+  todoist folders add Design --workspace Acme
+  todoist folders move Roadmap Design`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			folders, err := client.GetFolders()
+			if err != nil {
+				return err
+			}
+			workspaces, err := client.GetWorkspaces()
+			if err != nil {
+				return err
+			}
+			if workspace != "" {
+				w, err := api.MatchWorkspace(workspaces, workspace)
+				if err != nil {
+					return err
+				}
+				var kept []api.Folder
+				for _, f := range folders {
+					if f.WorkspaceID == w.ID {
+						kept = append(kept, f)
+					}
+				}
+				folders = kept
+			}
+			var projects []api.Project
+			if len(folders) > 0 && !flags.asJSON {
+				if projects, err = client.GetProjects(); err != nil {
+					return err
+				}
+			}
+
+			return out.WriteFolders(folders, workspaces, projects)
+		},
+	}
+
+	cmd.Flags().StringVarP(&workspace, "workspace", "w", "", "list only this workspace's folders")
+
+	cmd.AddCommand(newFolderAddCmd(flags))
+	cmd.AddCommand(newFolderMoveCmd(flags))
+
+	return cmd
+}
+
+func newFolderAddCmd(flags *rootFlags) *cobra.Command {
+	var workspace string
+
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Create a folder in a workspace",
+		Long: `Create a project folder. --workspace may be left out when you belong to
+only one workspace.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			workspaces, err := client.GetWorkspaces()
+			if err != nil {
+				return err
+			}
+			w, err := chooseWorkspace(workspaces, workspace)
+			if err != nil {
+				return err
+			}
+
+			folder, err := client.AddFolder(args[0], w.ID)
+			if err != nil {
+				return err
+			}
+
+			if flags.asJSON {
+				return out.JSON(folder)
+			}
+			if flags.quiet {
+				out.Printf("%s\n", folder.ID)
+				return nil
+			}
+			out.WriteSuccess(i18n.T("Created folder %s in %s (%s)", folder.Name, w.Name, folder.ID))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&workspace, "workspace", "w", "", "workspace to create the folder in")
+
+	return cmd
+}
+
+func newFolderMoveCmd(flags *rootFlags) *cobra.Command {
+	var takeOut bool
+
+	cmd := &cobra.Command{
+		Use:   "move <project> [folder]",
+		Short: "Put a workspace project in a folder",
+		Long: `Put a workspace project in one of its workspace's folders, or take it out
+of its folder with --out.
+
+Examples:
+  todoist folders move Roadmap Design
+  todoist folders move Roadmap --out`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if takeOut == (len(args) == 2) {
+				return fmt.Errorf("give a folder or --out")
+			}
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			projects, err := client.GetProjects()
+			if err != nil {
+				return err
+			}
+			p, err := api.MatchProject(projects, args[0])
+			if err != nil {
+				return err
+			}
+			if p.WorkspaceID == "" {
+				return fmt.Errorf("%s is a personal project; only workspace projects go in folders", p.Name)
+			}
+
+			folder := &api.Folder{}
+			if !takeOut {
+				folders, err := client.GetFolders()
+				if err != nil {
+					return err
+				}
+				if folder, err = api.MatchFolder(folders, args[1]); err != nil {
+					return err
+				}
+				if folder.WorkspaceID != p.WorkspaceID {
+					return fmt.Errorf("folder %s is in a different workspace than %s", folder.Name, p.Name)
+				}
+			}
+
+			if err := client.MoveProjectToFolder(p.ID, folder.ID); err != nil {
+				return fmt.Errorf("failed to move project: %w", err)
+			}
+			p.FolderID = folder.ID
+
+			if flags.asJSON {
+				return out.JSON(p)
+			}
+			if flags.quiet {
+				out.Printf("%s\n", p.ID)
+				return nil
+			}
+			if takeOut {
+				out.WriteSuccess(i18n.T("Took %s out of its folder", p.Name))
+			} else {
+				out.WriteSuccess(i18n.T("Moved %s to %s", p.Name, folder.Name))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&takeOut, "out", false, "take the project out of its folder")
+
+	return cmd
+}
+
+// chooseWorkspace finds the workspace named by name, or the only one when
+// name is empty
+func chooseWorkspace(workspaces []api.Workspace, name string) (*api.Workspace, error) {
+	if name != "" {
+		return api.MatchWorkspace(workspaces, name)
+	}
+	switch len(workspaces) {
+	case 0:
+		return nil, fmt.Errorf("you are not in any workspace")
+	case 1:
+		return &workspaces[0], nil
+	}
+	names := make([]string, len(workspaces))
+	for i, w := range workspaces {
+		names[i] = w.Name
+	}
+	return nil, fmt.Errorf("choose a workspace with --workspace: %s", strings.Join(names, ", "))
+}
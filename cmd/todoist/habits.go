@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+// defaultHabitLabel marks habits unless "habit_label" is configured
+const defaultHabitLabel = "habit"
+
+// habitDays is how far back todoist habits counts completions and misses
+const habitDays = 30
+
+func habitLabel() string {
+	if l := strings.TrimPrefix(config.Settings().HabitLabel, "@"); l != "" {
+		return l
+	}
+	return defaultHabitLabel
+}
+
+// habit is how well a recurring task kept as a habit is going
+type habit struct {
+	TaskID   string  `json:"task_id"`
+	Content  string  `json:"content"`
+	Due      string  `json:"due"`        // the recurrence
+	Every    int     `json:"every_days"` // days between occurrences; 0 if the recurrence is not understood
+	Streak   int     `json:"streak"`
+	Done     int     `json:"done"`     // occurrences completed in the last 30 days
+	Expected int     `json:"expected"` // occurrences due in the last 30 days
+	Missed   int     `json:"missed"`
+	Rate     float64 `json:"rate"` // done / expected, from 0 to 1
+	Last     string  `json:"last_completed,omitempty"`
+}
+
+// habitFrom scores a recurring task from its completion times as of today,
+// counting the occurrences that fall wholly within the last days days.
+// Occurrences before the task was created are not expected, and the current
+// one only once it is done.
+func habitFrom(t api.Task, completed []time.Time, today time.Time, days int) habit {
+	h := habit{TaskID: t.ID, Content: t.Content, Due: t.Due.String, Every: recurrenceDays(t.Due.String)}
+	for _, at := range completed {
+		if s := at.UTC().Format(time.RFC3339); s > h.Last {
+			h.Last = s
+		}
+	}
+	if h.Every == 0 {
+		return h
+	}
+
+	h.Streak = streak(completed, h.Every, today)
+	age := days
+	if created, err := time.Parse(time.RFC3339, t.CreatedAt); err == nil {
+		created = created.In(today.Location())
+		day := time.Date(created.Year(), created.Month(), created.Day(), 0, 0, 0, 0, today.Location())
+		age = min(age, int(today.Sub(day).Hours()/24+0.5)+1)
+	}
+	o := newOccurrences(completed, h.Every, today)
+	for period := 0; (period+1)*h.Every <= age; period++ {
+		switch {
+		case o.done(period):
+			h.Done++
+			h.Expected++
+		case period > 0:
+			h.Expected++
+		}
+	}
+	h.Missed = h.Expected - h.Done
+	if h.Expected > 0 {
+		h.Rate = float64(h.Done) / float64(h.Expected)
+	}
+	return h
+}
+
+func newHabitsCmd(flags *rootFlags) *cobra.Command {
+	var label string
+
+	cmd := &cobra.Command{
+		Use:     "habits",
+		Aliases: []string{"habit"},
+		Short:   "Show streaks and completion rates of habit tasks",
+		Long: `Treat recurring tasks labeled @habit as habits and show, for each, the
+current streak of occurrences done in a row, and how many of the last 30
+days' occurrences were done or missed, from the activity log.
+
+Change the label with --label, or "habit_label" in
+~/.todoist-cli/config.json. Labeled tasks that do not recur are skipped.
+
+Examples:
+  todoist habits
+  todoist habits --label routine
+  todoist habits --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+			notes := !flags.asJSON && !flags.quiet
+			if label == "" {
+				label = habitLabel()
+			}
+			label = strings.TrimPrefix(label, "@")
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			tasks, err := client.GetTasks("", "@"+label)
+			if err != nil {
+				return err
+			}
+			out.TaskOrder().Sort(tasks)
+			var recurring []api.Task
+			for _, t := range tasks {
+				if t.Due == nil || !t.Due.IsRecurring {
+					if notes {
+						fmt.Fprintln(stderr, i18n.T("Skipping %q: not recurring", t.Content))
+					}
+					continue
+				}
+				recurring = append(recurring, t)
+			}
+
+			// Read completions concurrently (bounded to 5)
+			completed := make([][]time.Time, len(recurring))
+			g := new(errgroup.Group)
+			g.SetLimit(5)
+			for i, t := range recurring {
+				i, t := i, t
+				g.Go(func() error {
+					events, err := client.GetTaskCompletions(t.ID)
+					if err != nil {
+						return fmt.Errorf("failed to read completions of %s: %w", t.ID, err)
+					}
+					var times []time.Time
+					for _, e := range events {
+						if at, err := time.Parse(time.RFC3339, e.EventDate); err == nil {
+							times = append(times, at)
+						}
+					}
+					completed[i] = times
+					return nil
+				})
+			}
+			if err := g.Wait(); err != nil {
+				return err
+			}
+
+			now := time.Now().In(output.Location())
+			today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+			habits := make([]habit, len(recurring))
+			for i, t := range recurring {
+				habits[i] = habitFrom(t, completed[i], today, habitDays)
+			}
+
+			if flags.asJSON {
+				return out.JSON(habits)
+			}
+			if flags.quiet {
+				for _, h := range habits {
+					out.Printf("%s\n", h.TaskID)
+				}
+				return nil
+			}
+			if len(habits) == 0 {
+				out.Printf("%s\n", i18n.T("No habits found."))
+				fmt.Fprintln(stderr, i18n.T("Label recurring tasks @%s to track them as habits", label))
+				return nil
+			}
+
+			nameWidth, dueWidth := 0, 0
+			for _, h := range habits {
+				nameWidth = max(nameWidth, len([]rune(h.Content)))
+				dueWidth = max(dueWidth, len([]rune(h.Due)))
+			}
+			for _, h := range habits {
+				line := fmt.Sprintf("%-*s  %-*s  ", nameWidth, h.Content, dueWidth, h.Due)
+				switch {
+				case h.Every == 0:
+					line += out.Color().Wrap(output.ANSIGray, "recurrence not understood")
+				case h.Expected == 0:
+					line += fmt.Sprintf("streak %-3d  %s", h.Streak, out.Color().Wrap(output.ANSIGray, "nothing due yet"))
+				default:
+					rate := fmt.Sprintf("%3.0f%%", h.Rate*100)
+					if h.Rate < 0.5 {
+						rate = out.Color().Wrap(output.ANSIRed, rate)
+					}
+					line += fmt.Sprintf("streak %-3d  %s done (%d/%d)", h.Streak, rate, h.Done, h.Expected)
+					if h.Missed > 0 {
+						line += fmt.Sprintf(", %d missed", h.Missed)
+					}
+				}
+				out.Printf("%s\n", line)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&label, "label", "", `label that marks habits (default "habit", or "habit_label" from the config)`)
+
+	return cmd
+}
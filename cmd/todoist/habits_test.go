@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+)
+
+func TestHabitFrom(t *testing.T) {
+	today := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	days := func(ago ...int) []time.Time {
+		var ts []time.Time
+		for _, d := range ago {
+			ts = append(ts, today.AddDate(0, 0, -d).Add(8*time.Hour))
+		}
+		return ts
+	}
+	task := func(recurrence string, createdAgo int) api.Task {
+		created := today.AddDate(0, 0, -createdAgo).Format(time.RFC3339)
+		return api.Task{ID: "1", Content: "x", CreatedAt: created, Due: &api.Due{String: recurrence, IsRecurring: true}}
+	}
+
+	for _, tc := range []struct {
+		name                          string
+		task                          api.Task
+		done                          []time.Time
+		streak, got, expected, missed int
+	}{
+		{"daily, today open", task("every day", 100), days(1, 2, 3, 10), 3, 4, 29, 25},
+		{"daily, done today", task("every day", 100), days(0, 1), 2, 2, 30, 28},
+		{"new habit", task("every day", 2), days(1), 1, 1, 2, 1},
+		{"created today", task("every day", 0), nil, 0, 0, 0, 0},
+		{"weekly", task("every monday", 100), days(2, 9, 23), 2, 3, 4, 1},
+		{"not understood", task("every 3rd friday", 100), days(1), 0, 0, 0, 0},
+	} {
+		h := habitFrom(tc.task, tc.done, today, 30)
+		if h.Streak != tc.streak || h.Done != tc.got || h.Expected != tc.expected || h.Missed != tc.missed {
+			t.Errorf("%s: got streak %d, %d/%d done, %d missed; want streak %d, %d/%d done, %d missed",
+				tc.name, h.Streak, h.Done, h.Expected, h.Missed, tc.streak, tc.got, tc.expected, tc.missed)
+		}
+	}
+}
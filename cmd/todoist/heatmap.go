@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// heatmapShades are the glyphs and 256-color greens for no completions up
+// to the busiest days; the glyphs keep levels apart without color
+var heatmapShades = []struct{ glyph, code string }{
+	{"·", output.ANSIGray},
+	{"░", "\033[38;5;22m"},
+	{"▒", "\033[38;5;28m"},
+	{"▓", "\033[38;5;34m"},
+	{"█", "\033[38;5;40m"},
+}
+
+func newHeatmapCmd(flags *rootFlags) *cobra.Command {
+	var (
+		project string
+		weeks   int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "heatmap",
+		Short: "Show a contribution-style heatmap of completed tasks",
+		Long: `Render completed tasks per day as a GitHub-style heatmap: one column per
+week, one row per weekday, darker for busier days. Below it are the
+total, the best day and the current streak.
+
+Examples:
+  todoist heatmap
+  todoist heatmap --weeks 26 -p Work`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			if weeks < 1 || weeks > 53 {
+				return fmt.Errorf("--weeks must be between 1 and 53")
+			}
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			var projectID string
+			if project != "" {
+				p, err := client.FindProject(project)
+				if err != nil {
+					return err
+				}
+				projectID = p.ID
+			}
+
+			today := startOfDay(time.Now().In(output.Location()))
+			first := heatmapStart(today, weeks)
+
+			// The completed tasks endpoint takes UTC times
+			const apiTime = "2006-01-02T15:04"
+			resp, err := fetchCompleted(client, projectID, first.UTC().Format(apiTime), "", "", 0, true)
+			if err != nil {
+				return err
+			}
+
+			counts := make(map[string]int)
+			for _, t := range resp.Items {
+				if at, err := time.Parse(time.RFC3339, t.CompletedAt); err == nil {
+					counts[at.In(output.Location()).Format("2006-01-02")]++
+				}
+			}
+
+			stats := heatmapStats(counts, first, today)
+			if flags.asJSON {
+				return out.JSON(map[string]interface{}{
+					"since":    first.Format("2006-01-02"),
+					"days":     counts,
+					"total":    stats.total,
+					"best_day": stats.bestDay,
+					"best":     stats.best,
+					"streak":   stats.streak,
+				})
+			}
+
+			out.Printf("%s", renderHeatmap(counts, today, weeks, out.Color()))
+			out.Printf("\n%d tasks completed since %s", stats.total, output.FormatDate(first, "Jan 2, 2006"))
+			if stats.best > 0 {
+				out.Printf(" · best day %s (%d)", stats.bestDay, stats.best)
+			}
+			out.Printf(" · current streak %d days\n", stats.streak)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&project, "project", "p", "", "only count tasks in this project")
+	cmd.Flags().IntVarP(&weeks, "weeks", "w", 52, "number of weeks to show (1-53)")
+
+	return cmd
+}
+
+// heatmapStart returns the Monday the heatmap's first column begins on, so
+// the last column holds today's week
+func heatmapStart(today time.Time, weeks int) time.Time {
+	return weekStart(today).AddDate(0, 0, -7*(weeks-1))
+}
+
+// renderHeatmap draws weekday rows by week columns ending with today's week,
+// with month names above the columns where a month begins
+func renderHeatmap(counts map[string]int, today time.Time, weeks int, c *output.Color) string {
+	first := heatmapStart(today, weeks)
+
+	most := 0
+	for _, n := range counts {
+		most = max(most, n)
+	}
+
+	var b strings.Builder
+	b.WriteString("    ")
+	for w := 0; w < weeks; {
+		monday := first.AddDate(0, 0, 7*w)
+		if w == 0 || monday.Day() <= 7 {
+			name := monday.Format("Jan")
+			b.WriteString(name)
+			w += len(name)
+			continue
+		}
+		b.WriteByte(' ')
+		w++
+	}
+	b.WriteString("\n")
+
+	for day := 0; day < 7; day++ {
+		label := "   "
+		if day%2 == 0 {
+			label = first.AddDate(0, 0, day).Format("Mon")
+		}
+		b.WriteString(label + " ")
+		for w := 0; w < weeks; w++ {
+			d := first.AddDate(0, 0, 7*w+day)
+			if d.After(today) {
+				break
+			}
+			n := counts[d.Format("2006-01-02")]
+			level := 0
+			if n > 0 {
+				level = (4*n + most - 1) / most
+			}
+			shade := heatmapShades[level]
+			b.WriteString(c.Wrap(shade.code, shade.glyph))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n    Less ")
+	for _, shade := range heatmapShades {
+		b.WriteString(c.Wrap(shade.code, shade.glyph))
+	}
+	b.WriteString(" More\n")
+	return b.String()
+}
+
+type heatmapSummary struct {
+	total   int
+	best    int
+	bestDay string
+	streak  int
+}
+
+// heatmapStats totals completions from first to today, finds the busiest
+// day and counts consecutive days with completions ending today, or
+// yesterday when nothing is done yet today
+func heatmapStats(counts map[string]int, first, today time.Time) heatmapSummary {
+	var s heatmapSummary
+	for d := first; !d.After(today); d = d.AddDate(0, 0, 1) {
+		n := counts[d.Format("2006-01-02")]
+		s.total += n
+		if n > s.best {
+			s.best, s.bestDay = n, d.Format("2006-01-02")
+		}
+	}
+
+	d := today
+	if counts[d.Format("2006-01-02")] == 0 {
+		d = d.AddDate(0, 0, -1)
+	}
+	for counts[d.Format("2006-01-02")] > 0 {
+		s.streak++
+		d = d.AddDate(0, 0, -1)
+	}
+	return s
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/output"
+)
+
+func TestRenderHeatmap(t *testing.T) {
+	// A Wednesday, so the last column stops after three days
+	today := time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC)
+	counts := map[string]int{"2024-03-04": 1, "2024-03-12": 4, "2024-03-13": 2}
+
+	want := "    Feb\n" +
+		"Mon ·░·\n" +
+		"    ··█\n" +
+		"Wed ··▒\n" +
+		"    ··\n" +
+		"Fri ··\n" +
+		"    ··\n" +
+		"Sun ··\n" +
+		"\n    Less ·░▒▓█ More\n"
+	if got := renderHeatmap(counts, today, 3, output.NewColor(output.ColorNever)); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestHeatmapStats(t *testing.T) {
+	today := time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC)
+	counts := map[string]int{"2024-03-10": 1, "2024-03-11": 3, "2024-03-12": 2, "2024-01-01": 9}
+
+	s := heatmapStats(counts, heatmapStart(today, 3), today)
+	if s.total != 6 || s.best != 3 || s.bestDay != "2024-03-11" || s.streak != 3 {
+		t.Errorf("unexpected stats: %+v", s)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// findInbox returns the account's Inbox, whatever it is called in the
+// user's language
+func findInbox(client *api.Client) (*api.Project, error) {
+	projects, err := client.GetProjects()
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range projects {
+		if p.IsInboxProject {
+			return &p, nil
+		}
+	}
+	return nil, fmt.Errorf("no Inbox project found")
+}
+
+func newInboxCmd(flags *rootFlags) *cobra.Command {
+	var (
+		details bool
+		sortBy  string
+		table   bool
+		columns string
+		count   bool
+		pages   paging
+	)
+
+	cmd := &cobra.Command{
+		Use:   "inbox",
+		Short: "List tasks in the Inbox",
+		Long: `List every task in the Inbox, dated or not: the list to work through when
+processing quick captures. 'todoist inbox add' captures a new one.
+
+Examples:
+  todoist inbox
+  todoist inbox --count
+  todoist inbox --sort created --limit 10
+  todoist inbox add "Call the plumber"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+			inbox, err := findInbox(client)
+			if err != nil {
+				return err
+			}
+			return runTasks(cmd, flags, false, "", inbox.ID, details, sortBy, &searchCriteria{}, &pages)
+		},
+	}
+
+	cmd.Flags().BoolVar(&details, "details", false, "show task descriptions and comments")
+	cmd.Flags().StringVar(&sortBy, "sort", "", "sort by fields with optional :asc or :desc, e.g. priority:desc,due (priority, due, name, created)")
+	cmd.Flags().BoolVar(&table, "table", false, "show an aligned table (default when output is a terminal)")
+	cmd.Flags().StringVar(&columns, "columns", "", "table columns: "+strings.Join(output.TaskColumns, ","))
+	cmd.Flags().BoolVarP(&count, "count", "c", false, "print only the number of tasks")
+	addPagingFlags(cmd, &pages)
+
+	cmd.AddCommand(newAddTaskCmd(flags, true))
+
+	return cmd
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// wizard asks the questions of 'todoist init' on stderr. An answer left
+// empty, or missing because input ended, takes the default.
+type wizard struct {
+	in *bufio.Reader
+	w  io.Writer
+}
+
+// ask reads a line of free text
+func (z *wizard) ask(prompt, def string) string {
+	if def != "" {
+		fmt.Fprintf(z.w, "%s [%s]: ", prompt, def)
+	} else {
+		fmt.Fprintf(z.w, "%s: ", prompt)
+	}
+	input, err := z.in.ReadString('\n')
+	if err != nil && input == "" {
+		fmt.Fprintln(z.w)
+	}
+	if input = strings.TrimSpace(input); input != "" {
+		return input
+	}
+	return def
+}
+
+// confirm asks a yes/no question
+func (z *wizard) confirm(prompt string, def bool) bool {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	switch strings.ToLower(z.ask(prompt+" ["+hint+"]", "")) {
+	case "y", "yes":
+		return true
+	case "n", "no":
+		return false
+	}
+	return def
+}
+
+// choose lists options and returns the index picked by number or name
+func (z *wizard) choose(prompt string, options []string, def int) int {
+	fmt.Fprintln(z.w, prompt)
+	for i, o := range options {
+		fmt.Fprintf(z.w, "  %d) %s\n", i+1, o)
+	}
+	for {
+		answer := z.ask("Choice", strconv.Itoa(def+1))
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(options) {
+			return n - 1
+		}
+		for i, o := range options {
+			if strings.EqualFold(o, answer) {
+				return i
+			}
+		}
+		fmt.Fprintf(z.w, "Pick 1-%d.\n", len(options))
+	}
+}
+
+// completionFile is where a shell loads completion scripts from without
+// further setup, or "" for shells without such a place
+func completionFile(shell string) string {
+	home, _ := os.UserHomeDir()
+	switch shell {
+	case "bash":
+		data := os.Getenv("XDG_DATA_HOME")
+		if data == "" {
+			data = filepath.Join(home, ".local", "share")
+		}
+		return filepath.Join(data, "bash-completion", "completions", "todoist")
+	case "zsh":
+		return filepath.Join(home, ".zfunc", "_todoist")
+	case "fish":
+		conf := os.Getenv("XDG_CONFIG_HOME")
+		if conf == "" {
+			conf = filepath.Join(home, ".config")
+		}
+		return filepath.Join(conf, "fish", "completions", "todoist.fish")
+	}
+	return ""
+}
+
+// writeCompletion generates the shell's completion script into path
+func writeCompletion(root *cobra.Command, shell, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	switch shell {
+	case "bash":
+		return root.GenBashCompletionV2(f, true)
+	case "zsh":
+		return root.GenZshCompletion(f)
+	}
+	return root.GenFishCompletion(f, true)
+}
+
+// initSetup is what the wizard chose, for --json
+type initSetup struct {
+	Config         string `json:"config"`
+	Profile        string `json:"profile"`
+	Email          string `json:"email,omitempty"`
+	DefaultProject string `json:"default_project,omitempty"`
+	TodayFilter    string `json:"today_filter"`
+	Theme          string `json:"theme"`
+	Completion     string `json:"completion,omitempty"`
+}
+
+func newInitCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Set up the CLI step by step",
+		Long: `Walk through first-time setup: sign in with an API token, pick the project
+new tasks go to, the filter the default task list shows and a color theme,
+install shell completion, and save it all to the config file.
+
+Every question shows its default in brackets; press Enter to keep it. Run it
+again at any time to change your answers. With --profile, the token is
+stored under that profile.
+
+Examples:
+  todoist init
+  todoist init --profile work`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+			cfg, err := config.LoadForUpdate()
+			if err != nil {
+				return err
+			}
+			z := &wizard{in: bufio.NewReader(os.Stdin), w: os.Stderr}
+			setup := initSetup{Config: config.ConfigPath(), Profile: cfg.ProfileName()}
+
+			// 1. Authentication
+			fmt.Fprintln(z.w, "Step 1 of 5: API token")
+			var token string
+			if env := os.Getenv("TODOIST_API_TOKEN"); env != "" {
+				fmt.Fprintln(z.w, "Using the token in TODOIST_API_TOKEN; it is not saved to the config.")
+				token = env
+			} else if p, ok := cfg.Profile(setup.Profile); ok && p.APIToken != "" {
+				label := p.Email
+				if label == "" {
+					label = "profile " + setup.Profile
+				}
+				if z.confirm("Keep the token for "+label+"?", true) {
+					token = p.APIToken
+				}
+			}
+			newToken := token == ""
+			if newToken {
+				fmt.Fprintln(z.w, "Copy your token from https://todoist.com/app/settings/integrations/developer")
+				if token = z.ask("API token", ""); token == "" {
+					return fmt.Errorf("token cannot be empty")
+				}
+			}
+			client, err := newAPIClient(token)
+			if err != nil {
+				return err
+			}
+			user, err := client.GetUser()
+			if err != nil {
+				return fmt.Errorf("invalid token: %w", err)
+			}
+			setup.Email = user.Email
+			if newToken {
+				cfg.SetProfile(setup.Profile, config.Profile{APIToken: token, Email: user.Email})
+			}
+			fmt.Fprintf(z.w, "Signed in as %s.\n\n", user.Email)
+
+			// 2. Default project for 'todoist add'
+			projects, err := client.GetProjects()
+			if err != nil {
+				return err
+			}
+			names := make([]string, len(projects))
+			def := 0
+			for i, p := range projects {
+				names[i] = p.Name
+				if (cfg.DefaultProject == "" && p.IsInboxProject) || strings.EqualFold(p.Name, cfg.DefaultProject) {
+					def = i
+				}
+			}
+			if len(projects) > 0 {
+				picked := projects[z.choose("Step 2 of 5: project for new tasks when 'add' gets no -p", names, def)]
+				cfg.DefaultProject = ""
+				if !picked.IsInboxProject {
+					cfg.DefaultProject = picked.Name
+				}
+			}
+			setup.DefaultProject = cfg.DefaultProject
+			fmt.Fprintln(z.w)
+
+			// 3. Filter of the default task list
+			fmt.Fprintln(z.w, "Step 3 of 5: filter for 'todoist' with no arguments, e.g. \"today\" or \"(today | overdue) & !@waiting\"")
+			for tries := 1; ; tries++ {
+				filter := z.ask("Filter", todayFilter())
+				if _, err := client.GetTasks("", filter); err != nil {
+					if tries == 3 {
+						return fmt.Errorf("invalid filter %q: %w", filter, err)
+					}
+					fmt.Fprintf(z.w, "Todoist did not accept that filter: %v\n", err)
+					continue
+				}
+				cfg.TodayFilter = ""
+				if filter != defaultTodayFilter {
+					cfg.TodayFilter = filter
+				}
+				setup.TodayFilter = filter
+				break
+			}
+			fmt.Fprintln(z.w)
+
+			// 4. Color theme
+			themes := output.ThemeNames()
+			def = 0
+			current := cfg.Theme["base"]
+			if current == "" {
+				current = "default"
+			}
+			for i, name := range themes {
+				if strings.EqualFold(name, current) {
+					def = i
+				}
+			}
+			setup.Theme = themes[z.choose("Step 4 of 5: color theme", themes, def)]
+			if setup.Theme == "default" {
+				delete(cfg.Theme, "base")
+				if len(cfg.Theme) == 0 {
+					cfg.Theme = nil
+				}
+			} else {
+				if cfg.Theme == nil {
+					cfg.Theme = make(map[string]string)
+				}
+				cfg.Theme["base"] = setup.Theme
+			}
+			fmt.Fprintln(z.w)
+
+			// 5. Shell completion
+			fmt.Fprintln(z.w, "Step 5 of 5: shell completion")
+			shell := filepath.Base(os.Getenv("SHELL"))
+			if path := completionFile(shell); path == "" {
+				fmt.Fprintln(z.w, "No completion to install for this shell; see 'todoist completion --help'.")
+			} else if z.confirm(fmt.Sprintf("Install %s completion to %s?", shell, path), true) {
+				if err := writeCompletion(cmd.Root(), shell, path); err != nil {
+					return fmt.Errorf("failed to write completion: %w", err)
+				}
+				setup.Completion = path
+				if shell == "zsh" {
+					fmt.Fprintln(z.w, "Add 'fpath+=~/.zfunc' before compinit in ~/.zshrc to load it.")
+				}
+			}
+			fmt.Fprintln(z.w)
+
+			if err := config.Save(cfg); err != nil {
+				return err
+			}
+
+			if flags.asJSON {
+				return out.JSON(setup)
+			}
+			out.WriteSuccess(i18n.T("Config saved to %s", setup.Config))
+			if !flags.quiet {
+				out.Printf("\nTry next:\n")
+				out.Printf("  todoist                       # tasks matching your filter\n")
+				out.Printf("  todoist tasks -p %-12s # a project's tasks\n", strconv.Quote(firstProject(projects)))
+				out.Printf("  todoist add \"Buy milk\" -d tomorrow\n")
+				out.Printf("  todoist examples              # more recipes\n")
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// firstProject names a project other than the Inbox, for the tips
+func firstProject(projects []api.Project) string {
+	for _, p := range projects {
+		if !p.IsInboxProject {
+			return p.Name
+		}
+	}
+	return "Inbox"
+}
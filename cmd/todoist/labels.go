@@ -1,10 +1,17 @@
 package main
 
 import (
-	"os"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
 
-	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 )
 
 func newLabelsCmd(flags *rootFlags) *cobra.Command {
@@ -13,9 +20,9 @@ func newLabelsCmd(flags *rootFlags) *cobra.Command {
 		Aliases: []string{"label", "tags"},
 		Short:   "List all labels",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			out := output.NewFormatter(os.Stdout, flags.asJSON)
+			out := newFormatter(flags)
 
-			client, err := getClient()
+			client, err := getClientWithFlags(flags)
 			if err != nil {
 				return err
 			}
@@ -31,6 +38,7 @@ func newLabelsCmd(flags *rootFlags) *cobra.Command {
 
 	// Add label add subcommand
 	cmd.AddCommand(newLabelAddCmd(flags))
+	cmd.AddCommand(newLabelRenameCmd(flags))
 
 	return cmd
 }
@@ -43,9 +51,9 @@ func newLabelAddCmd(flags *rootFlags) *cobra.Command {
 		Short: "Create a new label",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			out := output.NewFormatter(os.Stdout, flags.asJSON)
+			out := newFormatter(flags)
 
-			client, err := getClient()
+			client, err := getClientWithFlags(flags)
 			if err != nil {
 				return err
 			}
@@ -58,7 +66,7 @@ func newLabelAddCmd(flags *rootFlags) *cobra.Command {
 			if flags.asJSON {
 				return out.JSON(label)
 			}
-			out.WriteSuccess("Created label: @" + label.Name)
+			out.WriteSuccess(i18n.T("Created label: @%s", label.Name))
 			return nil
 		},
 	}
@@ -67,3 +75,312 @@ func newLabelAddCmd(flags *rootFlags) *cobra.Command {
 
 	return cmd
 }
+
+// textRewrite is a pending replacement of label mentions in a task or comment
+type textRewrite struct {
+	TaskID    string `json:"task_id"`
+	CommentID string `json:"comment_id,omitempty"`
+	Field     string `json:"field"`
+	Before    string `json:"before"`
+	After     string `json:"after"`
+}
+
+func newLabelRenameCmd(flags *rootFlags) *cobra.Command {
+	var (
+		rewriteText bool
+		dryRun      bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rename <old> <new>",
+		Short: "Rename a label",
+		Long: `Rename a label. Tasks carrying the label are relabeled by Todoist.
+
+With --rewrite-text, literal "@old" mentions in task contents,
+descriptions and comments are rewritten to "@new" as well. The
+rewrites are previewed and confirmed before anything changes; with
+--json or without a terminal, which cannot ask, pass --yes.
+
+Examples:
+  todoist labels rename waiting blocked
+  todoist labels rename @old @new --rewrite-text
+  todoist labels rename @old @new --rewrite-text --dry-run`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+			oldName := strings.TrimPrefix(args[0], "@")
+			newName := strings.TrimPrefix(args[1], "@")
+			if oldName == "" || newName == "" {
+				return fmt.Errorf("label names cannot be empty")
+			}
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			labels, err := client.GetLabels()
+			if err != nil {
+				return err
+			}
+			var label *api.Label
+			for i := range labels {
+				if strings.EqualFold(labels[i].Name, oldName) {
+					label = &labels[i]
+					break
+				}
+			}
+			if label == nil {
+				return fmt.Errorf("label not found: @%s", oldName)
+			}
+
+			var rewrites []textRewrite
+			if rewriteText {
+				rewrites, err = findMentionRewrites(client, label.Name, newName)
+				if err != nil {
+					return err
+				}
+			}
+
+			if !flags.asJSON {
+				out.Printf("%s\n", i18n.T("Rename @%s → @%s", label.Name, newName))
+				if rewriteText {
+					out.Printf("%s\n", i18n.T("Text rewrites (%d):", len(rewrites)))
+					for _, r := range rewrites {
+						where := i18n.T("task %s", r.TaskID)
+						if r.CommentID != "" {
+							where = i18n.T("comment %s on task %s", r.CommentID, r.TaskID)
+						}
+						out.Printf("  %s %s: %q → %q\n", where, r.Field, r.Before, r.After)
+					}
+				}
+			}
+
+			if dryRun {
+				if flags.asJSON {
+					return out.JSON(map[string]interface{}{"label": label, "rewrites": rewrites, "dry_run": true})
+				}
+				return nil
+			}
+
+			// Confirm unless --yes; without a terminal or with --json
+			// there is no one to ask
+			if len(rewrites) > 0 && !flags.yes {
+				if !canAsk(flags) {
+					return fmt.Errorf("refusing to rewrite %d mentions without confirmation; pass --yes to proceed", len(rewrites))
+				}
+				if !askYes(i18n.T("Rewrite %d mention(s)%s? [y/N] ", len(rewrites), confirmSuffix())) {
+					out.WriteSuccess(i18n.T("Cancelled"))
+					return nil
+				}
+			}
+
+			if len(rewrites) > 0 {
+				profileBanner(flags, i18n.T("Rewriting %d mention(s)", len(rewrites)))
+			}
+
+			renamed, err := client.UpdateLabel(label.ID, newName)
+			if err != nil {
+				return err
+			}
+
+			for _, r := range rewrites {
+				if r.CommentID != "" {
+					_, err = client.UpdateComment(r.CommentID, r.After)
+				} else if r.Field == "content" {
+					_, err = client.UpdateTask(r.TaskID, api.UpdateTaskParams{Content: r.After})
+				} else {
+					_, err = client.UpdateTask(r.TaskID, api.UpdateTaskParams{Description: r.After})
+				}
+				if err != nil {
+					return fmt.Errorf("label renamed, but rewriting %s failed: %w", r.TaskID, err)
+				}
+			}
+
+			if flags.asJSON {
+				return out.JSON(map[string]interface{}{"label": renamed, "rewrites": rewrites})
+			}
+			out.WriteSuccess(i18n.T("Renamed @%s to @%s (%d text rewrite(s))", label.Name, renamed.Name, len(rewrites)))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&rewriteText, "rewrite-text", false, "also rewrite @mentions in task text and comments")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "preview changes without applying them")
+
+	return cmd
+}
+
+// findMentionRewrites scans active tasks and their comments for @oldName
+func findMentionRewrites(client *api.Client, oldName, newName string) ([]textRewrite, error) {
+	tasks, err := client.GetTasks("", "")
+	if err != nil {
+		return nil, err
+	}
+
+	var rewrites []textRewrite
+	for _, t := range tasks {
+		if after, n := rewriteMention(t.Content, oldName, newName); n > 0 {
+			rewrites = append(rewrites, textRewrite{TaskID: t.ID, Field: "content", Before: t.Content, After: after})
+		}
+		if after, n := rewriteMention(t.Description, oldName, newName); n > 0 {
+			rewrites = append(rewrites, textRewrite{TaskID: t.ID, Field: "description", Before: t.Description, After: after})
+		}
+	}
+
+	// Fetch comments concurrently (bounded to 5), only for tasks that have any
+	var mu sync.Mutex
+	g, ctx := errgroup.WithContext(context.Background())
+	g.SetLimit(5)
+
+	for _, t := range tasks {
+		t := t
+		if t.NoteCount == 0 {
+			continue
+		}
+		g.Go(func() error {
+			comments, err := client.GetCommentsCtx(ctx, t.ID, "")
+			if err != nil {
+				return err
+			}
+			for _, c := range comments {
+				if after, n := rewriteMention(c.Content, oldName, newName); n > 0 {
+					mu.Lock()
+					rewrites = append(rewrites, textRewrite{TaskID: t.ID, CommentID: c.ID, Field: "comment", Before: c.Content, After: after})
+					mu.Unlock()
+				}
+			}
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return rewrites, nil
+}
+
+// rewriteMention replaces whole-word, case-insensitive "@old" mentions with
+// "@new" and returns the new text with the number of replacements.
+func rewriteMention(text, oldName, newName string) (string, int) {
+	mention := "@" + oldName
+	if !strings.Contains(strings.ToLower(text), strings.ToLower(mention)) {
+		return text, 0
+	}
+
+	var b strings.Builder
+	n := 0
+	for i := 0; i < len(text); {
+		end := i + len(mention)
+		if text[i] == '@' && end <= len(text) && strings.EqualFold(text[i:end], mention) {
+			prev, _ := utf8.DecodeLastRuneInString(text[:i])
+			next, _ := utf8.DecodeRuneInString(text[end:])
+			if (i == 0 || !isLabelRune(prev)) && (end == len(text) || !isLabelRune(next)) {
+				b.WriteString("@" + newName)
+				i = end
+				n++
+				continue
+			}
+		}
+		b.WriteByte(text[i])
+		i++
+	}
+	return b.String(), n
+}
+
+func isLabelRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-'
+}
+
+// newTaskLabelCmd groups commands that add or remove labels on a task
+// without replacing the rest of its label set.
+func newTaskLabelCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "task-label",
+		Short: "Add or remove labels on a task",
+		Long: `Add or remove labels on a task, keeping its other labels.
+
+To create or list labels, use 'todoist labels'. The command is not
+'todoist label add <task> <label>': 'label' is short for 'labels', where
+'label add <name>' creates a label.
+
+Examples:
+  todoist task-label add 123 urgent followup
+  todoist task-label remove 123 @waiting`,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:               "add <task-id> <label...>",
+		Short:             "Add labels to a task",
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: completeTaskLabelArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTaskLabelDelta(flags, args[0], args[1:], nil)
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:               "remove <task-id> <label...>",
+		Aliases:           []string{"rm"},
+		Short:             "Remove labels from a task",
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: completeTaskLabelArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTaskLabelDelta(flags, args[0], nil, args[1:])
+		},
+	})
+
+	return cmd
+}
+
+func runTaskLabelDelta(flags *rootFlags, taskID string, add, remove []string) error {
+	out := newFormatter(flags)
+
+	client, err := getClientWithFlags(flags)
+	if err != nil {
+		return err
+	}
+
+	task, err := client.GetTask(taskID)
+	if err != nil {
+		return err
+	}
+
+	updated, err := client.SetTaskLabels(taskID, applyLabelDelta(task.Labels, add, remove))
+	if err != nil {
+		return err
+	}
+
+	return out.WriteTask(updated)
+}
+
+// applyLabelDelta adds and removes labels (case-insensitive, leading @ optional)
+// while keeping the order of existing labels.
+func applyLabelDelta(current, add, remove []string) []string {
+	removeSet := make(map[string]bool, len(remove))
+	for _, l := range remove {
+		removeSet[strings.ToLower(strings.TrimPrefix(l, "@"))] = true
+	}
+
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(current)+len(add))
+	for _, l := range current {
+		key := strings.ToLower(l)
+		if removeSet[key] || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, l)
+	}
+	for _, l := range add {
+		l = strings.TrimPrefix(l, "@")
+		key := strings.ToLower(l)
+		if l == "" || removeSet[key] || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, l)
+	}
+	return result
+}
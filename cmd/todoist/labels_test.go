@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestRewriteMention(t *testing.T) {
+	for _, tc := range []struct {
+		text, old, new string
+		want           string
+		n              int
+	}{
+		{"Ask @waiting today", "waiting", "blocked", "Ask @blocked today", 1},
+		{"@Waiting on Sam", "waiting", "blocked", "@blocked on Sam", 1},
+		{"Ask @WAITING", "Waiting", "blocked", "Ask @blocked", 1},
+		{"@waiting, then @waiting.", "waiting", "blocked", "@blocked, then @blocked.", 2},
+		{"(@waiting)", "waiting", "blocked", "(@blocked)", 1},
+		{"@waitingroom", "waiting", "blocked", "@waitingroom", 0},
+		{"@waiting-list", "waiting", "blocked", "@waiting-list", 0},
+		{"@waiting_for", "waiting", "blocked", "@waiting_for", 0},
+		{"mail me@waiting", "waiting", "blocked", "mail me@waiting", 0},
+		{"waiting without a mention", "waiting", "blocked", "waiting without a mention", 0},
+		{"Block @deep work time", "deep work", "focus", "Block @focus time", 1},
+		{"@Deep Work", "deep work", "focus", "@focus", 1},
+		{"@deep workout", "deep work", "focus", "@deep workout", 0},
+		{"@deep", "deep work", "focus", "@deep", 0},
+		{"@café and @cafés", "café", "coffee", "@coffee and @cafés", 1},
+	} {
+		got, n := rewriteMention(tc.text, tc.old, tc.new)
+		if got != tc.want || n != tc.n {
+			t.Errorf("rewriteMention(%q, %q, %q) = %q, %d; want %q, %d", tc.text, tc.old, tc.new, got, n, tc.want, tc.n)
+		}
+	}
+}
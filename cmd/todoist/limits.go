@@ -0,0 +1,77 @@
+package main
+
+import (
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func newLimitsCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "limits",
+		Short: "Show the remaining API request budget",
+		Long: `Show how many API requests are left in the current rate limit window.
+
+Todoist limits each user to a fixed number of requests per 15 minutes. When
+the budget runs low, commands that make many requests slow down so the rest
+is spread over the time until the window resets, rather than failing with
+HTTP 429.
+
+Examples:
+  todoist limits
+  todoist limits --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			// Any request returns the current budget in its headers
+			if _, err := client.GetUser(); err != nil {
+				return err
+			}
+			limit, reported := client.RateLimit()
+
+			if flags.asJSON {
+				data := map[string]interface{}{
+					"reported": reported,
+					"window":   api.RateWindow.String(),
+					"documented": map[string]int{
+						"requests":      api.RequestBudget,
+						"full_syncs":    api.FullSyncBudget,
+						"partial_syncs": api.PartialSyncBudget,
+					},
+				}
+				if reported {
+					data["limit"] = limit.Limit
+					data["remaining"] = limit.Remaining
+					data["reset"] = limit.Reset.Format(time.RFC3339)
+				}
+				return out.JSON(data)
+			}
+
+			if reported {
+				if limit.Limit > 0 {
+					out.Printf("%s\n", i18n.T("Requests: %d of %d left", limit.Remaining, limit.Limit))
+				} else {
+					out.Printf("%s\n", i18n.T("Requests: %d left", limit.Remaining))
+				}
+				out.Printf("%s\n", i18n.T("Resets:   in %s (%s)", time.Until(limit.Reset).Round(time.Second), output.FormatClock(limit.Reset.In(output.Location()))))
+			} else {
+				out.Printf("%s\n", i18n.T("Todoist did not report a rate limit for this token."))
+			}
+			out.Printf("%s\n", i18n.T("Budget:   %d requests, %d full syncs and %d partial syncs per %d minutes",
+				api.RequestBudget, api.FullSyncBudget, api.PartialSyncBudget, int(api.RateWindow.Minutes())))
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -1,11 +1,20 @@
 package main
 
 import (
+	"errors"
 	"os"
 )
 
 func main() {
 	if err := execute(os.Args[1:]); err != nil {
+		var plugin *pluginExit
+		if errors.As(err, &plugin) {
+			os.Exit(plugin.code)
+		}
+		var assert *assertFailed
+		if errors.As(err, &assert) {
+			os.Exit(assertExitCode)
+		}
 		os.Exit(1)
 	}
 }
@@ -2,10 +2,10 @@ package main
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
 	"github.com/buddyh/todoist-cli/internal/output"
 	"github.com/spf13/cobra"
 )
@@ -17,72 +17,126 @@ func newMoveCmd(flags *rootFlags) *cobra.Command {
 	)
 
 	cmd := &cobra.Command{
-		Use:   "move <task-id>",
+		Use:   "move [task-id]",
 		Short: "Move a task to a different section or project",
 		Long: `Move a task to a different section or project.
 
 This is useful for Kanban-style workflows where tasks move between sections.
 
+--section is looked up in the task's current project, or in the target
+project when --project is also given. "Project/Section" names both.
+Without an ID, pick from today's tasks interactively.
+
 Examples:
   todoist move 123 --section "In Progress"
   todoist move 123 -s "Done"
-  todoist move 123 --project "Work"`,
-		Args: cobra.ExactArgs(1),
+  todoist move 123 --project "Work"
+  todoist move 123 --project "Work" --section "Backlog"
+  todoist move 123 --section "Work/Backlog"`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			out := output.NewFormatter(os.Stdout, flags.asJSON)
-			taskID := args[0]
+			out := newFormatter(flags)
 
 			if section == "" && project == "" {
 				return fmt.Errorf("must specify either --section or --project")
 			}
 
-			client, err := getClient()
+			taskID, err := taskIDArg(flags, args)
 			if err != nil {
 				return err
 			}
 
-			var sectionID, projectID string
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
 
-			if section != "" {
-				// Need to find section by name - first get the task to know its project
-				task, err := client.GetTask(taskID)
+			// Capture the current state to report what changed
+			before, err := client.GetTask(taskID)
+			if err != nil {
+				return fmt.Errorf("failed to get task: %w", err)
+			}
+
+			projects, err := client.GetProjects()
+			if err != nil {
+				return err
+			}
+			names := make(map[string]string)
+			for _, p := range projects {
+				names[p.ID] = p.Name
+			}
+
+			// "Project/Section" names both the project and the section
+			projectName, sectionName := project, section
+			if sectionName != "" && projectName == "" {
+				if p, sec, ok := strings.Cut(sectionName, "/"); ok && p != "" && sec != "" {
+					projectName, sectionName = p, sec
+				}
+			}
+
+			var projectID string
+			if projectName != "" {
+				p, err := api.MatchProject(projects, projectName)
 				if err != nil {
-					return fmt.Errorf("failed to get task: %w", err)
+					return err
 				}
+				projectID = p.ID
+			}
+
+			// Sections are looked up in the target project, or the task's
+			// current project when only --section is given
+			sectionProject := before.ProjectID
+			if projectID != "" {
+				sectionProject = projectID
+			}
 
-				sections, err := client.GetSections(task.ProjectID)
+			var sections []api.Section
+			if sectionName != "" {
+				sections, err = client.GetSections(sectionProject)
 				if err != nil {
 					return fmt.Errorf("failed to get sections: %w", err)
 				}
-
-				sectionID = findSectionID(sections, section)
-				if sectionID == "" {
-					return fmt.Errorf("section not found: %s", section)
+				for _, s := range sections {
+					names[s.ID] = s.Name
 				}
 			}
-
-			if project != "" {
-				p, err := client.FindProject(project)
+			// Name the task's current section in the change summary
+			if before.SectionID != "" && (sectionName == "" || sectionProject != before.ProjectID) {
+				current, err := client.GetSections(before.ProjectID)
 				if err != nil {
-					return err
+					return fmt.Errorf("failed to get sections: %w", err)
+				}
+				for _, s := range current {
+					names[s.ID] = s.Name
+				}
+			}
+
+			var sectionID string
+			if sectionName != "" {
+				sectionID = findSectionID(sections, sectionName)
+				if sectionID == "" {
+					if projectName != "" {
+						return fmt.Errorf("section not found in %s: %s", names[sectionProject], sectionName)
+					}
+					return fmt.Errorf("section not found: %s", sectionName)
 				}
-				projectID = p.ID
 			}
 
+			profileBanner(flags, i18n.T("Moving %s", before.Content))
 			if err := client.MoveTask(taskID, sectionID, projectID); err != nil {
 				return err
 			}
 
-			if section != "" {
-				out.WriteSuccess(fmt.Sprintf("Moved task to section: %s", section))
-			} else {
-				out.WriteSuccess(fmt.Sprintf("Moved task to project: %s", project))
+			after, err := client.GetTask(taskID)
+			if err != nil {
+				return err
 			}
-			return nil
+
+			return out.WriteTaskChanges(after, output.DiffTasks(before, after, names))
 		},
 	}
 
-	cmd.Flags().StringVarP(&section, "section", "s", "", "target section name")
+	cmd.Flags().StringVarP(&section, "section", "s", "", "target section name, or Project/Section")
 	cmd.Flags().StringVarP(&project, "project", "p", "", "target project name")
 
 	return cmd
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// defaultNextWeights score tasks for 'todoist next'. Keys set in the
+// config's "next_weights" override these; "@label" and "#Project" keys add
+// points to tasks with that label or in that project.
+var defaultNextWeights = map[string]float64{
+	"priority": 10,  // per level above p4
+	"overdue":  3,   // per day overdue, up to 30 days
+	"today":    15,  // due today
+	"soon":     10,  // due within two hours, or its time today has passed
+	"age":      0.5, // per week since the task was added, up to a year
+}
+
+// defaultStartLabel marks the task picked with next --start
+const defaultStartLabel = "in-progress"
+
+// scoreReason is one contribution to a task's score
+type scoreReason struct {
+	Reason string  `json:"reason"`
+	Points float64 `json:"points"`
+}
+
+// scoredTask is a candidate for the next action
+type scoredTask struct {
+	api.Task
+	Score   float64       `json:"score"`
+	Reasons []scoreReason `json:"reasons"`
+}
+
+// MarshalJSON adds the score to the embedded task's fields
+func (t scoredTask) MarshalJSON() ([]byte, error) {
+	return api.MarshalWith(t.Task, map[string]interface{}{"score": t.Score, "reasons": t.Reasons})
+}
+
+// scorer ranks tasks by how much they deserve attention now
+type scorer struct {
+	weights  map[string]float64
+	projects map[string]string // project ID -> name
+	now      time.Time
+}
+
+func newScorer(projects map[string]string, now time.Time) scorer {
+	weights := make(map[string]float64, len(defaultNextWeights))
+	for k, v := range defaultNextWeights {
+		weights[k] = v
+	}
+	for k, v := range config.Settings().NextWeights {
+		weights[strings.ToLower(k)] = v
+	}
+	return scorer{weights: weights, projects: projects, now: now}
+}
+
+// score adds up the weighted factors that apply to t
+func (s scorer) score(t api.Task) scoredTask {
+	st := scoredTask{Task: t}
+	add := func(reason string, points float64) {
+		if points != 0 {
+			st.Reasons = append(st.Reasons, scoreReason{reason, math.Round(points*10) / 10})
+			st.Score += points
+		}
+	}
+
+	if t.Priority > 1 {
+		add(fmt.Sprintf("priority p%d", 5-t.Priority), s.weights["priority"]*float64(t.Priority-1))
+	}
+
+	if t.Due != nil {
+		if due, timed, err := output.DueTime(t.Due); err == nil {
+			today := startOfDay(s.now)
+			day := startOfDay(due)
+			switch {
+			case day.Before(today):
+				days := math.Min(math.Round(today.Sub(day).Hours()/24), 30)
+				add(fmt.Sprintf("%.0f days overdue", days), s.weights["overdue"]*days)
+			case day.Equal(today):
+				add("due today", s.weights["today"])
+			}
+			if timed && day.Equal(today) && due.Sub(s.now) < 2*time.Hour {
+				add("due at "+output.FormatClock(due), s.weights["soon"])
+			}
+		}
+	}
+
+	if added, err := time.Parse(time.RFC3339, t.CreatedAt); err == nil {
+		weeks := math.Min(math.Floor(s.now.Sub(added).Hours()/(24*7)), 52)
+		if weeks > 0 {
+			add(fmt.Sprintf("added %.0f weeks ago", weeks), s.weights["age"]*weeks)
+		}
+	}
+
+	for _, l := range t.Labels {
+		add("@"+l, s.weights["@"+strings.ToLower(l)])
+	}
+	if name := s.projects[t.ProjectID]; name != "" {
+		add("#"+name, s.weights["#"+strings.ToLower(name)])
+	}
+
+	return st
+}
+
+// rank scores tasks that are actionable now, highest first. Tasks due after
+// today are left out.
+func (s scorer) rank(tasks []api.Task) []scoredTask {
+	tomorrow := startOfDay(s.now).AddDate(0, 0, 1)
+	var ranked []scoredTask
+	for _, t := range tasks {
+		if t.Due != nil {
+			if due, _, err := output.DueTime(t.Due); err == nil && !due.Before(tomorrow) {
+				continue
+			}
+		}
+		ranked = append(ranked, s.score(t))
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].Score > ranked[j].Score })
+	return ranked
+}
+
+func newNextCmd(flags *rootFlags) *cobra.Command {
+	var (
+		filter  string
+		start   bool
+		explain bool
+		top     int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "next",
+		Short: "Suggest the single most relevant task to work on",
+		Long: `Suggest what to work on next: open tasks due today, overdue or undated
+are scored and the best one is shown.
+
+The score adds points for priority, days overdue, being due today, a due
+time within two hours, and age. Tune the weights, or give labels and
+projects extra points, with "next_weights" in ~/.todoist-cli/config.json:
+
+  "next_weights": {"priority": 20, "age": 0, "@quick": 5, "#Work": 10}
+
+--start labels the suggestion @in-progress ("start_label" in the config
+changes the label).
+
+Examples:
+  todoist next
+  todoist next --explain --top 5
+  todoist next -f "#Work" --start`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			tasks, err := client.GetTasks("", filter)
+			if err != nil {
+				return err
+			}
+			projects, err := client.GetProjects()
+			if err != nil {
+				return err
+			}
+			names := make(map[string]string, len(projects))
+			for _, p := range projects {
+				names[p.ID] = p.Name
+			}
+
+			ranked := newScorer(names, time.Now().In(output.Location())).rank(tasks)
+			if len(ranked) == 0 {
+				out.WriteSuccess(i18n.T("Nothing to do right now."))
+				return nil
+			}
+			if top < 1 {
+				top = 1
+			}
+			ranked = ranked[:min(top, len(ranked))]
+
+			if start {
+				label := strings.TrimPrefix(config.Settings().StartLabel, "@")
+				if label == "" {
+					label = defaultStartLabel
+				}
+				task, err := client.SetTaskLabels(ranked[0].ID, addLabel(ranked[0].Labels, label))
+				if err != nil {
+					return err
+				}
+				ranked[0].Task = *task
+			}
+
+			if flags.asJSON {
+				if top == 1 {
+					return out.JSON(ranked[0])
+				}
+				return out.JSON(ranked)
+			}
+
+			if err := loadNames(client, out); err != nil {
+				return err
+			}
+			for _, st := range ranked {
+				out.Printf("%s\n", out.FormatTaskLine(&st.Task))
+				if explain {
+					var parts []string
+					for _, r := range st.Reasons {
+						parts = append(parts, fmt.Sprintf("%s %+g", r.Reason, r.Points))
+					}
+					if len(parts) == 0 {
+						parts = append(parts, "no factors apply")
+					}
+					out.Printf("    score %.1f: %s\n", st.Score, strings.Join(parts, ", "))
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&filter, "filter", "f", "", "only consider tasks matching a Todoist filter")
+	cmd.Flags().BoolVar(&start, "start", false, "label the suggested task as in progress")
+	cmd.Flags().BoolVar(&explain, "explain", false, "show how each score was made up")
+	cmd.Flags().IntVarP(&top, "top", "n", 1, "show this many suggestions")
+
+	return cmd
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+)
+
+func TestScorerRank(t *testing.T) {
+	now := time.Date(2024, 3, 10, 14, 0, 0, 0, time.Local)
+	s := scorer{
+		weights:  map[string]float64{"priority": 10, "overdue": 3, "today": 15, "soon": 10, "age": 0.5, "#work": 8},
+		projects: map[string]string{"w": "Work"},
+		now:      now,
+	}
+
+	tasks := []api.Task{
+		{ID: "later", Priority: 4, Due: &api.Due{Date: "2024-03-12"}},
+		{ID: "undated", Priority: 1, CreatedAt: "2024-02-10T00:00:00Z"},
+		{ID: "overdue", Priority: 1, Due: &api.Due{Date: "2024-03-05"}},
+		{ID: "soon", Priority: 2, Due: &api.Due{Date: "2024-03-10", Datetime: "2024-03-10T15:00:00"}},
+		{ID: "work", Priority: 3, ProjectID: "w"},
+	}
+
+	ranked := s.rank(tasks)
+	var order []string
+	for _, st := range ranked {
+		order = append(order, st.ID)
+	}
+	want := []string{"soon", "work", "overdue", "undated"}
+	if len(order) != len(want) {
+		t.Fatalf("got %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("got %v, want %v", order, want)
+		}
+	}
+
+	// soon: p3 (10) + due today (15) + due at 15:00 (10)
+	if ranked[0].Score != 35 || len(ranked[0].Reasons) != 3 {
+		t.Errorf("unexpected score for soon: %+v", ranked[0])
+	}
+}
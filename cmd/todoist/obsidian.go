@@ -0,0 +1,422 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+// The part of a note between these markers is rewritten on every export;
+// anything around it is the user's own
+const (
+	noteBegin = "<!-- todoist:begin -->"
+	noteEnd   = "<!-- todoist:end -->"
+)
+
+// noteKeys are the front-matter keys the export writes; others are kept
+var noteKeys = map[string]bool{
+	"todoist_id": true, "project": true, "section": true, "due": true,
+	"labels": true, "priority": true, "url": true, "completed": true,
+}
+
+// noteCounts counts what an Obsidian export did
+type noteCounts struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Completed int `json:"completed"` // notes of tasks completed or deleted since
+}
+
+// noteFileName makes a file name from a task's content, leaving out
+// characters that are not allowed in file names or Obsidian links
+func noteFileName(content string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`\/:*?"<>|#^[]`, r) || r < ' ' {
+			return -1
+		}
+		return r
+	}, content)
+	name = strings.Join(strings.Fields(name), " ")
+	if r := []rune(name); len(r) > 100 {
+		name = strings.TrimSpace(string(r[:100]))
+	}
+	name = strings.TrimLeft(name, ".")
+	if name == "" {
+		name = "Untitled"
+	}
+	return name + ".md"
+}
+
+// yamlString writes s as a quoted YAML string
+func yamlString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// splitNote separates a note into its front-matter lines and body
+func splitNote(note string) (front []string, body string) {
+	rest, ok := strings.CutPrefix(note, "---\n")
+	if !ok {
+		return nil, note
+	}
+	if body, ok := strings.CutPrefix(rest, "---\n"); ok {
+		return []string{}, body
+	}
+	if i := strings.Index(rest, "\n---\n"); i >= 0 {
+		return strings.Split(rest[:i], "\n"), rest[i+len("\n---\n"):]
+	}
+	if front, ok := strings.CutSuffix(rest, "\n---"); ok {
+		return strings.Split(front, "\n"), ""
+	}
+	return nil, note
+}
+
+// noteID returns the todoist_id in a note's front matter, or ""
+func noteID(note string) string {
+	front, _ := splitNote(note)
+	for _, line := range front {
+		if v, ok := strings.CutPrefix(line, "todoist_id:"); ok {
+			var id string
+			v = strings.TrimSpace(v)
+			if json.Unmarshal([]byte(v), &id) != nil {
+				id = strings.Trim(v, `'"`)
+			}
+			return id
+		}
+	}
+	return ""
+}
+
+// keptFrontMatter returns the front-matter lines of keys the export does
+// not write, with the indented or list lines that belong to them
+func keptFrontMatter(front []string) []string {
+	var kept []string
+	ours := false
+	for _, line := range front {
+		if line != "" && line[0] != ' ' && line[0] != '\t' && line[0] != '-' {
+			key, _, _ := strings.Cut(line, ":")
+			ours = noteKeys[strings.TrimSpace(key)]
+		}
+		if !ours {
+			kept = append(kept, line)
+		}
+	}
+	return kept
+}
+
+// setCompleted marks a note's task as completed in its front matter
+func setCompleted(note string) string {
+	front, body := splitNote(note)
+	if front == nil {
+		return note
+	}
+	lines := make([]string, 0, len(front)+1)
+	for _, line := range front {
+		if !strings.HasPrefix(line, "completed:") {
+			lines = append(lines, line)
+		}
+	}
+	lines = append(lines, "completed: true")
+	return "---\n" + strings.Join(lines, "\n") + "\n---\n" + body
+}
+
+// taskNote is the export of one task and its open subtasks
+type taskNote struct {
+	Task     api.Task
+	Project  string
+	Section  string
+	Subtasks []*taskNoteItem
+}
+
+// taskNoteItem is a subtask in a note's checklist
+type taskNoteItem struct {
+	Content  string
+	Children []*taskNoteItem
+}
+
+// render writes the note for n, keeping the user's front-matter keys and
+// everything outside the managed block of the existing note, if any
+func (n taskNote) render(existing string) string {
+	t := n.Task
+	front, body := splitNote(existing)
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "todoist_id: %s\n", yamlString(t.ID))
+	fmt.Fprintf(&b, "project: %s\n", yamlString(n.Project))
+	if n.Section != "" {
+		fmt.Fprintf(&b, "section: %s\n", yamlString(n.Section))
+	}
+	if d := dueDate(t); d != "" {
+		fmt.Fprintf(&b, "due: %s\n", d)
+	}
+	if len(t.Labels) > 0 {
+		labels := make([]string, len(t.Labels))
+		for i, l := range t.Labels {
+			labels[i] = yamlString(l)
+		}
+		fmt.Fprintf(&b, "labels: [%s]\n", strings.Join(labels, ", "))
+	}
+	if t.Priority > 1 {
+		fmt.Fprintf(&b, "priority: p%d\n", 5-t.Priority)
+	}
+	fmt.Fprintf(&b, "url: %s\n", api.TaskURL(t.ID))
+	for _, line := range keptFrontMatter(front) {
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("---\n")
+
+	var m strings.Builder
+	m.WriteString(noteBegin + "\n")
+	fmt.Fprintf(&m, "# %s\n", t.Content)
+	if t.Description != "" {
+		fmt.Fprintf(&m, "\n%s\n", strings.TrimRight(t.Description, "\n"))
+	}
+	if len(n.Subtasks) > 0 {
+		m.WriteString("\n")
+		var list func(items []*taskNoteItem, depth int)
+		list = func(items []*taskNoteItem, depth int) {
+			for _, it := range items {
+				fmt.Fprintf(&m, "%s- [ ] %s\n", strings.Repeat("  ", depth), it.Content)
+				list(it.Children, depth+1)
+			}
+		}
+		list(n.Subtasks, 0)
+	}
+	m.WriteString(noteEnd + "\n")
+
+	start, end := strings.Index(body, noteBegin), strings.Index(body, noteEnd)
+	if start < 0 || end < start {
+		if body = strings.TrimLeft(body, "\n"); body != "" {
+			body = "\n" + body
+		}
+		return b.String() + m.String() + body
+	}
+	after := strings.TrimPrefix(body[end+len(noteEnd):], "\n")
+	return b.String() + body[:start] + m.String() + after
+}
+
+// buildTaskNotes groups a project's tasks into one note per top-level task,
+// in project order, with the open subtasks as a checklist
+func buildTaskNotes(project string, tasks []api.Task, sections []api.Section) []taskNote {
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].ChildOrder < tasks[j].ChildOrder })
+	names := make(map[string]string, len(sections))
+	for _, s := range sections {
+		names[s.ID] = s.Name
+	}
+	open := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		open[t.ID] = true
+	}
+	children := make(map[string][]api.Task)
+	for _, t := range tasks {
+		if open[t.ParentID] {
+			children[t.ParentID] = append(children[t.ParentID], t)
+		}
+	}
+	var items func(id string, seen map[string]bool) []*taskNoteItem
+	items = func(id string, seen map[string]bool) []*taskNoteItem {
+		var list []*taskNoteItem
+		for _, c := range children[id] {
+			if seen[c.ID] {
+				continue
+			}
+			seen[c.ID] = true
+			list = append(list, &taskNoteItem{Content: c.Content, Children: items(c.ID, seen)})
+		}
+		return list
+	}
+
+	var notes []taskNote
+	for _, t := range tasks {
+		if open[t.ParentID] {
+			continue
+		}
+		notes = append(notes, taskNote{
+			Task:     t,
+			Project:  project,
+			Section:  names[t.SectionID],
+			Subtasks: items(t.ID, map[string]bool{t.ID: true}),
+		})
+	}
+	return notes
+}
+
+// readNotes maps the todoist_id of each note in dir to its file name
+func readNotes(dir string) (map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	ids := make(map[string]string)
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".md" {
+			continue
+		}
+		f, err := os.Open(filepath.Join(dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		// The ID is near the top; do not read whole notes
+		var head strings.Builder
+		sc := bufio.NewScanner(f)
+		for i := 0; i < 50 && sc.Scan(); i++ {
+			head.WriteString(sc.Text() + "\n")
+		}
+		f.Close()
+		if id := noteID(head.String()); id != "" {
+			ids[id] = e.Name()
+		}
+	}
+	return ids, nil
+}
+
+func newExportObsidianCmd(flags *rootFlags) *cobra.Command {
+	var vault, project, folder string
+
+	cmd := &cobra.Command{
+		Use:   "obsidian",
+		Short: "Write a project's tasks as notes in an Obsidian vault",
+		Long: `Write one markdown note per task of a project into an Obsidian vault (or
+any folder of markdown notes), in <vault>/<folder>/<project>/. Each note has
+front matter with the task's ID, project, due date, labels and priority, then
+the task's title and description and a checklist of its open subtasks.
+
+Running the export again updates the notes, found by their todoist_id, and
+renames them when a task was renamed. Only the part between the
+<!-- todoist:begin --> and <!-- todoist:end --> markers and the front-matter
+keys the export writes are replaced, so your own notes and keys are kept.
+Notes of tasks completed or deleted since are marked completed: true.
+
+Examples:
+  todoist export obsidian --vault ~/notes --project Work
+  todoist export obsidian --vault ~/notes -p Work --folder Tasks`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			p, err := client.FindProject(project)
+			if err != nil {
+				return err
+			}
+			tasks, err := client.GetTasks(p.ID, "")
+			if err != nil {
+				return err
+			}
+			sections, err := client.GetSections(p.ID)
+			if err != nil {
+				return err
+			}
+
+			dir := filepath.Join(vault, folder, strings.TrimSuffix(noteFileName(p.Name), ".md"))
+			existing, err := readNotes(dir)
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return fmt.Errorf("failed to create %s: %w", dir, err)
+			}
+
+			notes := buildTaskNotes(p.Name, tasks, sections)
+			taken := make(map[string]string, len(existing)) // file name -> task ID
+			for id, name := range existing {
+				taken[strings.ToLower(name)] = id
+			}
+
+			var counts noteCounts
+			exported := make(map[string]bool, len(notes))
+			for _, n := range notes {
+				id := n.Task.ID
+				exported[id] = true
+				name := noteFileName(n.Task.Content)
+				if other, ok := taken[strings.ToLower(name)]; ok && other != id {
+					name = strings.TrimSuffix(name, ".md") + " (" + id + ").md"
+				}
+
+				var old []byte
+				if prev, ok := existing[id]; ok {
+					if old, err = os.ReadFile(filepath.Join(dir, prev)); err != nil {
+						return fmt.Errorf("failed to read note: %w", err)
+					}
+					if prev != name {
+						if err := os.Rename(filepath.Join(dir, prev), filepath.Join(dir, name)); err != nil {
+							return fmt.Errorf("failed to rename note: %w", err)
+						}
+						delete(taken, strings.ToLower(prev))
+					}
+				}
+				taken[strings.ToLower(name)] = id
+
+				note := n.render(string(old))
+				switch {
+				case old == nil:
+					counts.Created++
+				case note == string(old) && existing[id] == name:
+					counts.Unchanged++
+					continue
+				default:
+					counts.Updated++
+				}
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(note), 0644); err != nil {
+					return fmt.Errorf("failed to write note: %w", err)
+				}
+			}
+
+			// Notes whose task is no longer open in the project
+			for id, name := range existing {
+				if exported[id] {
+					continue
+				}
+				t, err := client.GetTask(id)
+				switch {
+				case err != nil && api.ErrorCode(err) != api.CodeNotFound:
+					return err
+				case err == nil && !t.IsCompleted:
+					continue // moved elsewhere or made a subtask
+				}
+				path := filepath.Join(dir, name)
+				old, err := os.ReadFile(path)
+				if err != nil {
+					return fmt.Errorf("failed to read note: %w", err)
+				}
+				if note := setCompleted(string(old)); note != string(old) {
+					if err := os.WriteFile(path, []byte(note), 0644); err != nil {
+						return fmt.Errorf("failed to write note: %w", err)
+					}
+					counts.Completed++
+				}
+			}
+
+			if flags.asJSON {
+				return out.JSON(counts)
+			}
+			out.WriteSuccess(i18n.T("Wrote notes to %s: %d created, %d updated, %d unchanged, %d completed",
+				dir, counts.Created, counts.Updated, counts.Unchanged, counts.Completed))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&vault, "vault", "", "path of the vault (required)")
+	cmd.Flags().StringVarP(&project, "project", "p", "", "project to export (required)")
+	cmd.Flags().StringVar(&folder, "folder", "Todoist", "folder in the vault for the notes")
+	cmd.MarkFlagRequired("vault")
+	cmd.MarkFlagRequired("project")
+
+	return cmd
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+)
+
+func TestNoteFileName(t *testing.T) {
+	for content, want := range map[string]string{
+		"Call mom":               "Call mom.md",
+		"Fix #12: crash / hang?": "Fix 12 crash hang.md",
+		"  [[link]]  ":           "link.md",
+		"...":                    "Untitled.md",
+		strings.Repeat("a", 120): strings.Repeat("a", 100) + ".md",
+	} {
+		if got := noteFileName(content); got != want {
+			t.Errorf("noteFileName(%q) = %q, want %q", content, got, want)
+		}
+	}
+}
+
+func TestTaskNoteRender(t *testing.T) {
+	n := taskNote{
+		Task:    api.Task{ID: "7", Content: "Plan trip", Description: "Book early", Labels: []string{"travel"}, Priority: 4, Due: &api.Due{Date: "2024-03-20"}},
+		Project: "Home",
+		Subtasks: []*taskNoteItem{
+			{Content: "Flights", Children: []*taskNoteItem{{Content: "Compare prices"}}},
+			{Content: "Hotel"},
+		},
+	}
+	note := n.render("")
+	want := `---
+todoist_id: "7"
+project: "Home"
+due: 2024-03-20
+labels: ["travel"]
+priority: p1
+url: https://app.todoist.com/app/task/7
+---
+<!-- todoist:begin -->
+# Plan trip
+
+Book early
+
+- [ ] Flights
+  - [ ] Compare prices
+- [ ] Hotel
+<!-- todoist:end -->
+`
+	if note != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", note, want)
+	}
+	if id := noteID(note); id != "7" {
+		t.Errorf("noteID = %q, want 7", id)
+	}
+
+	// The user's keys and notes survive a re-export
+	edited := strings.Replace(note, "url:", "aliases:\n  - trip\nurl:", 1) + "\nMy notes\n"
+	n.Task.Content, n.Task.Labels = "Plan the trip", nil
+	again := n.render(setCompleted(edited))
+	for _, s := range []string{"aliases:\n  - trip\n", "# Plan the trip\n", "<!-- todoist:end -->\n\nMy notes\n"} {
+		if !strings.Contains(again, s) {
+			t.Errorf("expected %q in:\n%s", s, again)
+		}
+	}
+	for _, s := range []string{"labels:", "completed:", "# Plan trip\n"} {
+		if strings.Contains(again, s) {
+			t.Errorf("expected no %q in:\n%s", s, again)
+		}
+	}
+}
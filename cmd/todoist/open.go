@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+func newOpenCmd(flags *rootFlags) *cobra.Command {
+	var (
+		app       bool
+		printOnly bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "open [task-id|project]",
+		Short: "Open a task or project in the browser or desktop app",
+		Long: `Open a task or project in the default browser.
+
+The argument is tried as a task ID first, then as a project ID or name.
+Without an argument, pick from today's tasks interactively. With --app the
+todoist:// deep link opens the desktop app instead.
+
+Examples:
+  todoist open 123456
+  todoist open Work
+  todoist open Work --app
+  todoist open 123456 --print`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+			target, err := taskIDArg(flags, args)
+			if err != nil {
+				return err
+			}
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			url, name, err := resolveOpenTarget(client, target, app)
+			if err != nil {
+				return err
+			}
+
+			if !printOnly {
+				if err := openURL(url); err != nil {
+					return err
+				}
+			}
+
+			if flags.asJSON {
+				return out.JSON(map[string]string{"url": url, "name": name})
+			}
+			if printOnly {
+				out.Printf("%s\n", url)
+				return nil
+			}
+			out.WriteSuccess(i18n.T("Opened %s: %s", name, url))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&app, "app", false, "open in the desktop app via a todoist:// link")
+	cmd.Flags().BoolVar(&printOnly, "print", false, "print the URL instead of opening it")
+
+	return cmd
+}
+
+// resolveOpenTarget returns the URL and display name for a task ID, or
+// failing that a project ID or name
+func resolveOpenTarget(client *api.Client, target string, app bool) (string, string, error) {
+	task, err := client.GetTask(target)
+	if err == nil {
+		if app {
+			return api.TaskAppURL(task.ID), task.Content, nil
+		}
+		return api.TaskURL(task.ID), task.Content, nil
+	}
+	if code := api.ErrorCode(err); code != api.CodeNotFound && code != api.CodeBadRequest {
+		return "", "", err
+	}
+
+	projects, err := client.GetProjects()
+	if err != nil {
+		return "", "", err
+	}
+	var project *api.Project
+	for i := range projects {
+		if projects[i].ID == target {
+			project = &projects[i]
+			break
+		}
+	}
+	if project == nil {
+		if project, err = api.MatchProject(projects, target); err != nil {
+			return "", "", fmt.Errorf("no task or project matches %q", target)
+		}
+	}
+
+	if app {
+		return api.ProjectAppURL(project.ID), "#" + project.Name, nil
+	}
+	return api.ProjectURL(project.ID), "#" + project.Name, nil
+}
+
+// openURL hands a URL to the platform's default handler
+func openURL(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open %s: %w", url, err)
+	}
+	return cmd.Process.Release()
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+// paging holds the --limit, --offset and --page flags of task listings
+type paging struct {
+	limit  int
+	offset int
+	page   int
+	next   int // --offset of the page after the last one applied, or 0
+}
+
+// addPagingFlags registers the paging flags on cmd
+func addPagingFlags(cmd *cobra.Command, p *paging) {
+	cmd.Flags().IntVar(&p.limit, "limit", 0, "show at most this many tasks, counting a task with its subtasks as one")
+	cmd.Flags().IntVar(&p.offset, "offset", 0, "skip this many tasks first")
+	cmd.Flags().IntVar(&p.page, "page", 0, "show this page of --limit tasks, starting at 1")
+	cmd.MarkFlagsMutuallyExclusive("offset", "page")
+}
+
+// active reports whether any paging flag is set
+func (p *paging) active() bool {
+	return p.limit != 0 || p.offset != 0 || p.page != 0
+}
+
+// bounds returns how many tasks to skip and the most to keep; a limit of 0
+// keeps the rest
+func (p *paging) bounds() (skip, limit int, err error) {
+	switch {
+	case p.limit < 0:
+		return 0, 0, fmt.Errorf("--limit must not be negative")
+	case p.offset < 0:
+		return 0, 0, fmt.Errorf("--offset must not be negative")
+	case p.page < 0:
+		return 0, 0, fmt.Errorf("--page must be 1 or more")
+	case p.page > 0 && p.limit == 0:
+		return 0, 0, fmt.Errorf("--page needs --limit")
+	case p.page > 0:
+		return (p.page - 1) * p.limit, p.limit, nil
+	}
+	return p.offset, p.limit, nil
+}
+
+// apply returns the requested page of tasks, which must already be in the
+// order they are shown. Subtasks are grouped under their parents, so each
+// task in the list counts once along with the subtasks listed with it.
+func (p *paging) apply(tasks []api.Task, notes bool) ([]api.Task, error) {
+	skip, limit, err := p.bounds()
+	if err != nil || !p.active() {
+		return tasks, err
+	}
+
+	listed := make(map[string]bool, len(tasks))
+	parents := make(map[string]string, len(tasks))
+	for _, t := range tasks {
+		listed[t.ID] = true
+		parents[t.ID] = t.ParentID
+	}
+	// top returns the outermost listed ancestor of a task
+	top := func(id string) string {
+		for listed[parents[id]] {
+			id = parents[id]
+		}
+		return id
+	}
+
+	var roots []string
+	for _, t := range tasks {
+		if !listed[t.ParentID] {
+			roots = append(roots, t.ID)
+		}
+	}
+	end := len(roots)
+	if limit > 0 && skip+limit < end {
+		end = skip + limit
+	}
+	keep := make(map[string]bool)
+	for _, id := range roots[min(skip, len(roots)):end] {
+		keep[id] = true
+	}
+
+	var page []api.Task
+	for _, t := range tasks {
+		if keep[top(t.ID)] {
+			page = append(page, t)
+		}
+	}
+	p.next = 0
+	if end < len(roots) {
+		p.next = end
+	}
+	if notes && end < len(roots) {
+		fmt.Fprintln(stderr, i18n.T("Showing %d-%d of %d tasks", min(skip, len(roots))+1, end, len(roots)))
+	}
+	return page, nil
+}
+
+// cursor is the --offset that continues after the last page applied, for
+// meta.next_cursor, or "" when nothing is left
+func (p *paging) cursor() string {
+	if p.next == 0 {
+		return ""
+	}
+	return strconv.Itoa(p.next)
+}
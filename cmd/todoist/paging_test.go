@@ -0,0 +1,64 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+)
+
+func TestPagingApply(t *testing.T) {
+	tasks := []api.Task{
+		{ID: "1"},
+		{ID: "2"},
+		{ID: "3", ParentID: "2"},
+		{ID: "4", ParentID: "3"},
+		{ID: "5"},
+		{ID: "6", ParentID: "99"}, // parent not listed: counts on its own
+	}
+	ids := func(p paging) string {
+		page, err := p.apply(tasks, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var b []string
+		for _, t := range page {
+			b = append(b, t.ID)
+		}
+		return strings.Join(b, ",")
+	}
+
+	tests := []struct {
+		p    paging
+		want string
+	}{
+		{paging{}, "1,2,3,4,5,6"},
+		{paging{limit: 2}, "1,2,3,4"},
+		{paging{limit: 2, offset: 1}, "2,3,4,5"},
+		{paging{limit: 2, page: 2}, "5,6"},
+		{paging{offset: 3}, "6"},
+		{paging{limit: 2, page: 5}, ""},
+	}
+	for _, tt := range tests {
+		if got := ids(tt.p); got != tt.want {
+			t.Errorf("%+v: got %q, want %q", tt.p, got, tt.want)
+		}
+	}
+
+	p := paging{limit: 2}
+	p.apply(tasks, false)
+	if got := p.cursor(); got != "2" {
+		t.Errorf("expected the next page at offset 2, got %q", got)
+	}
+	p = paging{limit: 2, page: 2}
+	p.apply(tasks, false)
+	if got := p.cursor(); got != "" {
+		t.Errorf("expected no cursor after the last page, got %q", got)
+	}
+
+	for _, bad := range []paging{{limit: -1}, {offset: -1}, {page: 2}} {
+		if _, err := bad.apply(tasks, false); err == nil {
+			t.Errorf("%+v: expected an error", bad)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// parseDueContent names the scratch task parse-due creates and removes
+const parseDueContent = "todoist-cli due date check"
+
+func newParseDueCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "parse-due <due string>",
+		Short: "Show how Todoist interprets a due date string",
+		Long: `Show the date Todoist resolves a due string to, before using it on a
+real task. Exits with an error when Todoist does not understand it.
+
+Todoist has no way to parse a date on its own, so a scratch task is
+created in the Inbox and deleted straight away.
+
+Examples:
+  todoist parse-due "next monday 5pm"
+  todoist parse-due "every other friday"`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+			input := strings.Join(args, " ")
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			task, err := client.AddTask(api.AddTaskParams{Content: parseDueContent, DueString: input})
+			if err != nil {
+				return err
+			}
+			if err := client.DeleteTask(task.ID); err != nil {
+				return fmt.Errorf("failed to remove scratch task %s: %w", task.ID, err)
+			}
+
+			if task.Due == nil {
+				return fmt.Errorf("Todoist did not understand due date %q", input)
+			}
+
+			if flags.asJSON {
+				return out.JSON(map[string]interface{}{"input": input, "due": task.Due})
+			}
+			out.Printf("%s\n", describeDue(task.Due))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// describeDue spells out a resolved due date, e.g. "Mon Mar 11 2024 17:00
+// (recurring)"
+func describeDue(d *api.Due) string {
+	t, timed, err := output.DueTime(d)
+	if err != nil {
+		return d.Date
+	}
+	s := output.FormatDate(t, "Mon Jan 2 2006")
+	if timed {
+		s += " " + output.FormatClock(t)
+	}
+	if d.IsRecurring {
+		s += " (recurring)"
+	}
+	return s
+}
+
+// checkDue compares a created task's due date with the requested string.
+// When Todoist dropped it, strict removes the task and fails; otherwise a
+// warning is printed. Understood dates are echoed unless output is JSON or
+// quiet.
+func checkDue(flags *rootFlags, client *api.Client, task *api.Task, requested string, strict bool) error {
+	if requested == "" {
+		return nil
+	}
+	if task.Due == nil {
+		if strict {
+			if err := client.DeleteTask(task.ID); err != nil {
+				return fmt.Errorf("failed to remove task %s after an unrecognized due date: %w", task.ID, err)
+			}
+			return fmt.Errorf("Todoist did not understand due date %q; task not created", requested)
+		}
+		fmt.Fprintln(stderr, i18n.T("Warning: Todoist did not understand due date %q; the task has no due date", requested))
+		return nil
+	}
+	if !flags.asJSON && !flags.quiet {
+		fmt.Fprintln(stderr, i18n.T("Due %s", describeDue(task.Due)))
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/cache"
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/output"
+)
+
+// peopleCacheAge is how long collaborator names are reused before the API
+// is asked again
+const peopleCacheAge = 10 * time.Minute
+
+// people returns the names of everyone sharing a project with the user,
+// keyed by user ID, from the cache while it is fresh
+func people(client *api.Client) (map[string]string, error) {
+	key := config.Settings().ProfileName() + "-people"
+	var names map[string]string
+	if cache.Load(key, peopleCacheAge, &names) {
+		return names, nil
+	}
+
+	collaborators, err := client.GetAllCollaborators()
+	if err != nil {
+		return nil, err
+	}
+	names = make(map[string]string, len(collaborators))
+	for _, c := range collaborators {
+		names[c.ID] = c.Name
+		if c.Name == "" {
+			names[c.ID] = c.Email
+		}
+	}
+	cache.Save(key, names)
+	return names, nil
+}
+
+// loadPeople sets the assignee names a task list shows. Nothing is fetched
+// unless a task is assigned.
+func loadPeople(client *api.Client, out *output.Formatter, tasks []api.Task) error {
+	if !out.NeedsPeople(tasks) {
+		return nil
+	}
+	names, err := people(client)
+	if err != nil {
+		return err
+	}
+	out.SetPeople(names)
+	return nil
+}
+
+// loadAuthors sets the names of who posted comments: collaborators, and
+// the user, whose comments in unshared projects have no collaborator entry
+func loadAuthors(client *api.Client, out *output.Formatter, comments []api.Comment) error {
+	if !out.NeedsAuthors(comments) {
+		return nil
+	}
+	names, err := people(client)
+	if err != nil {
+		return err
+	}
+	if names == nil {
+		names = make(map[string]string)
+	}
+	for _, c := range comments {
+		if _, ok := names[c.PostedUID]; !ok && c.PostedUID != "" {
+			user, err := client.GetUser()
+			if err != nil {
+				return err
+			}
+			names[user.ID] = user.FullName
+			break
+		}
+	}
+	out.SetPeople(names)
+	return nil
+}
+
+// resolveAssignee returns the user ID for --mine, or for an --assigned-to
+// name or email, matched exactly first and then as a prefix
+func resolveAssignee(client *api.Client, mine bool, name string) (string, error) {
+	if mine {
+		user, err := client.GetUser()
+		if err != nil {
+			return "", err
+		}
+		return user.ID, nil
+	}
+
+	names, err := people(client)
+	if err != nil {
+		return "", err
+	}
+	query := strings.ToLower(name)
+	var matches []string
+	for id, n := range names {
+		if strings.ToLower(n) == query || id == name {
+			return id, nil
+		}
+		if strings.HasPrefix(strings.ToLower(n), query) {
+			matches = append(matches, id)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no collaborator named %q", name)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("%q matches %d collaborators; be more specific", name, len(matches))
+	}
+}
+
+// assignedTo keeps the tasks assigned to userID
+func assignedTo(tasks []api.Task, userID string) []api.Task {
+	var kept []api.Task
+	for _, t := range tasks {
+		if t.Assignee == userID {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/picker"
+	"github.com/spf13/cobra"
+)
+
+// taskIDArg returns the task ID argument, or lets the user pick one of
+// today's tasks when none was given on an interactive terminal.
+func taskIDArg(flags *rootFlags, args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	if flags.asJSON || !picker.IsTerminal(os.Stdin) {
+		return "", fmt.Errorf("task ID required")
+	}
+
+	client, err := getClientWithFlags(flags)
+	if err != nil {
+		return "", err
+	}
+
+	tasks, err := client.GetTasks("", "today | overdue")
+	if err != nil {
+		return "", err
+	}
+	if len(tasks) == 0 {
+		return "", fmt.Errorf("no tasks due today; pass a task ID")
+	}
+
+	item, err := picker.Run(os.Stdin, os.Stderr, taskItems(tasks))
+	if err != nil {
+		return "", err
+	}
+	return item.ID, nil
+}
+
+// maxListedMatches caps the tasks named when a --match is ambiguous
+const maxListedMatches = 5
+
+// matchTaskID finds the one task whose content matches query, looking at
+// today's and overdue tasks before all tasks. When several match, the user
+// picks one on a terminal; otherwise the matches are listed in the error.
+func matchTaskID(flags *rootFlags, query string) (string, error) {
+	client, err := getClientWithFlags(flags)
+	if err != nil {
+		return "", err
+	}
+
+	var matches []api.Task
+	for _, filter := range []string{"today | overdue", ""} {
+		tasks, err := client.GetTasks("", filter)
+		if err != nil {
+			return "", err
+		}
+		if matches = matchTasks(tasks, query); len(matches) > 0 {
+			break
+		}
+	}
+
+	switch {
+	case len(matches) == 0:
+		return "", fmt.Errorf("no task matches %q", query)
+	case len(matches) == 1:
+		return matches[0].ID, nil
+	case !flags.asJSON && picker.IsTerminal(os.Stdin):
+		item, err := picker.Run(os.Stdin, os.Stderr, taskItems(matches))
+		if err != nil {
+			return "", err
+		}
+		return item.ID, nil
+	}
+
+	var names []string
+	for i, t := range matches {
+		if i == maxListedMatches {
+			names = append(names, fmt.Sprintf("and %d more", len(matches)-i))
+			break
+		}
+		names = append(names, fmt.Sprintf("%s (%s)", t.Content, t.ID))
+	}
+	return "", fmt.Errorf("%q matches %d tasks: %s; be more specific or pass an ID", query, len(matches), strings.Join(names, ", "))
+}
+
+// matchTasks returns the tasks whose content contains query, ignoring case.
+// A task whose whole content equals query wins outright. Without a
+// substring match, the picker's fuzzy matching is used.
+func matchTasks(tasks []api.Task, query string) []api.Task {
+	query = strings.TrimSpace(query)
+	lower := strings.ToLower(query)
+
+	var matches []api.Task
+	for _, t := range tasks {
+		if strings.EqualFold(t.Content, query) {
+			return []api.Task{t}
+		}
+		if strings.Contains(strings.ToLower(t.Content), lower) {
+			matches = append(matches, t)
+		}
+	}
+	if len(matches) > 0 {
+		return matches
+	}
+
+	byID := make(map[string]api.Task, len(tasks))
+	items := make([]picker.Item, len(tasks))
+	for i, t := range tasks {
+		byID[t.ID] = t
+		items[i] = picker.Item{ID: t.ID, Label: t.Content}
+	}
+	for _, it := range picker.Filter(items, query) {
+		matches = append(matches, byID[it.ID])
+	}
+	return matches
+}
+
+// taskItems converts tasks to picker entries labelled with plain, uncolored text
+func taskItems(tasks []api.Task) []picker.Item {
+	items := make([]picker.Item, len(tasks))
+	for i, t := range tasks {
+		label := t.Content
+		if t.Due != nil {
+			due := t.Due.String
+			if due == "" {
+				due = t.Due.Date
+			}
+			label += " (" + due + ")"
+		}
+		if len(t.Labels) > 0 {
+			label += " @" + strings.Join(t.Labels, " @")
+		}
+		items[i] = picker.Item{ID: t.ID, Label: label}
+	}
+	return items
+}
+
+func newPickCmd(flags *rootFlags) *cobra.Command {
+	var (
+		filter  string
+		project string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "pick",
+		Short: "Choose a task with the built-in fuzzy finder and print its ID",
+		Long: `Choose a task with the built-in fuzzy finder and print its ID.
+
+Tasks come from the API (today's tasks unless --filter or --project is
+given), or from stdin as ID<TAB>text lines such as those printed by
+'todoist tasks --fzf-format'. The picker talks to the terminal directly,
+so the chosen ID can be captured by the shell.
+
+Typing narrows the list as you go; the arrow keys or Ctrl-N/Ctrl-P move
+the selection, Enter chooses and Esc cancels.
+
+Examples:
+  todoist complete $(todoist pick)
+  todoist pick --filter "p1"
+  todoist tasks --all --fzf-format | todoist pick`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var items []picker.Item
+			input := os.Stdin
+
+			if !picker.IsTerminal(os.Stdin) {
+				// Items are piped in; interact through the controlling terminal
+				scanner := bufio.NewScanner(os.Stdin)
+				for scanner.Scan() {
+					id, label, ok := strings.Cut(scanner.Text(), "\t")
+					if !ok {
+						label = id
+					}
+					if id != "" {
+						items = append(items, picker.Item{ID: id, Label: label})
+					}
+				}
+				if err := scanner.Err(); err != nil {
+					return fmt.Errorf("failed to read stdin: %w", err)
+				}
+
+				tty, err := os.Open("/dev/tty")
+				if err != nil {
+					return fmt.Errorf("no terminal available for picking: %w", err)
+				}
+				defer tty.Close()
+				input = tty
+			} else {
+				client, err := getClientWithFlags(flags)
+				if err != nil {
+					return err
+				}
+
+				var projectID string
+				if project != "" {
+					p, err := client.FindProject(project)
+					if err != nil {
+						return err
+					}
+					projectID = p.ID
+				}
+				if filter == "" && project == "" {
+					filter = "today | overdue"
+				}
+
+				tasks, err := client.GetTasks(projectID, filter)
+				if err != nil {
+					return err
+				}
+				items = taskItems(tasks)
+			}
+
+			if len(items) == 0 {
+				return fmt.Errorf("no tasks to pick from")
+			}
+
+			item, err := picker.Run(input, os.Stderr, items)
+			if err != nil {
+				return err
+			}
+
+			out := newFormatter(flags)
+			if flags.asJSON {
+				return out.JSON(map[string]string{"id": item.ID, "label": item.Label})
+			}
+			out.Printf("%s\n", item.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&filter, "filter", "f", "", "Todoist filter string")
+	cmd.Flags().StringVarP(&project, "project", "p", "", "limit to a project")
+
+	return cmd
+}
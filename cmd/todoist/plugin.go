@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// pluginPrefix is prepended to an unknown command name to find a plugin
+// executable on PATH, as git does for git-<name>
+const pluginPrefix = "todoist-"
+
+// pluginExit carries a plugin's exit status back to main
+type pluginExit struct {
+	code int
+}
+
+func (e *pluginExit) Error() string {
+	return fmt.Sprintf("plugin exited with status %d", e.code)
+}
+
+// findPlugin returns the plugin executable and its arguments when the
+// command in args is not built in but todoist-<name> is on PATH. The global
+// flags before the name are parsed so the plugin sees the chosen profile.
+func findPlugin(root *cobra.Command, flags *rootFlags, args []string) (string, []string, bool) {
+	i := commandIndex(root, args)
+	if i >= len(args) {
+		return "", nil, false
+	}
+	if c, _, err := root.Find(args[i : i+1]); err == nil && c != root {
+		return "", nil, false
+	}
+
+	path, err := exec.LookPath(pluginPrefix + args[i])
+	if err != nil {
+		return "", nil, false
+	}
+	if err := root.PersistentFlags().Parse(args[:i]); err != nil {
+		return "", nil, false
+	}
+	return path, args[i+1:], true
+}
+
+// runPlugin runs a plugin with the terminal's stdio. Its environment adds:
+//
+//	TODOIST_TOKEN         API token of the active profile, if configured
+//	TODOIST_PROFILE       the active profile
+//	TODOIST_JSON          "1" when --json or --jsonl was given
+//	TODOIST_API_BASE_URL  the API root, when overridden
+func runPlugin(flags *rootFlags, path string, args []string) error {
+	config.UseProfile(flags.profile)
+
+	env := os.Environ()
+	if token, err := config.GetToken(); err == nil {
+		env = append(env, "TODOIST_TOKEN="+token)
+	}
+	env = append(env, "TODOIST_PROFILE="+config.Settings().ProfileName())
+	if flags.asJSON || flags.jsonl {
+		env = append(env, "TODOIST_JSON=1")
+	}
+	if base := config.APIBaseURL(); base != "" {
+		env = append(env, "TODOIST_API_BASE_URL="+base)
+	}
+
+	cmd := exec.Command(path, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.Env = env
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return &pluginExit{code: exitErr.ExitCode()}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to run plugin %s: %w", path, err)
+	}
+	return nil
+}
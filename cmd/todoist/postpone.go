@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+// relativeShiftRe matches "+3d" / "+2w" style offsets from the current due date.
+var relativeShiftRe = regexp.MustCompile(`^\+(\d+)([dw])$`)
+
+func newPostponeCmd(flags *rootFlags) *cobra.Command {
+	var (
+		to     string
+		by     string
+		filter string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "postpone [task-id...]",
+		Aliases: []string{"reschedule"},
+		Short:   "Move tasks to a later due date",
+		Long: `Postpone one or more tasks, or every task matching a filter.
+
+--to accepts anything Todoist understands as a due date ("tomorrow",
+"next monday", "jan 20"), or an offset from the task's current due
+date such as +3d or +2w. --by 3d is the same as --to +3d. Tasks
+without a due date are shifted from today, and timed tasks keep their
+time. Recurring tasks are skipped so their schedule is not replaced.
+
+Examples:
+  todoist postpone 123                        # Move to tomorrow
+  todoist postpone 123 456 --to "next monday"
+  todoist postpone 123 --by 3d
+  todoist postpone --filter overdue --to today`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			if len(args) == 0 && filter == "" {
+				return fmt.Errorf("specify task IDs or --filter")
+			}
+			if len(args) > 0 && filter != "" {
+				return fmt.Errorf("cannot combine task IDs with --filter")
+			}
+			if cmd.Flags().Changed("by") {
+				to = "+" + strings.TrimPrefix(strings.TrimSpace(by), "+")
+				if !relativeShiftRe.MatchString(to) {
+					return fmt.Errorf("--by must be an offset such as 3d or 2w, got %q", by)
+				}
+			}
+			if strings.TrimSpace(to) == "" {
+				return fmt.Errorf("--to cannot be empty")
+			}
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			var tasks []api.Task
+			if filter != "" {
+				tasks, err = client.GetTasks("", filter)
+				if err != nil {
+					return err
+				}
+			} else {
+				for _, id := range args {
+					task, err := client.GetTask(id)
+					if err != nil {
+						return err
+					}
+					tasks = append(tasks, *task)
+				}
+			}
+
+			updates, postponed, skipped := planPostpone(tasks, to, time.Now())
+			if !flags.quiet {
+				for _, t := range skipped {
+					fmt.Fprintln(stderr, i18n.T("Skipped recurring task: %s", t.Content))
+				}
+			}
+
+			if len(updates) == 0 {
+				out.WriteSuccess(i18n.T("No tasks to postpone."))
+				return nil
+			}
+
+			// A single task goes through the REST endpoint so the updated task can be shown
+			if len(updates) == 1 && filter == "" {
+				u := updates[0]
+				params := api.UpdateTaskParams{DueString: u.String}
+				if u.String == "" {
+					if strings.Contains(u.Date, "T") {
+						params.DueDatetime = u.Date
+					} else {
+						params.DueDate = u.Date
+					}
+				}
+				task, err := client.UpdateTask(u.TaskID, params)
+				if err != nil {
+					return err
+				}
+				return out.WriteTask(task)
+			}
+
+			if ok, err := confirmBulk(flags, i18n.T("Postpone"), postponed); err != nil || !ok {
+				if err == nil {
+					out.WriteSuccess(i18n.T("Cancelled"))
+				}
+				return err
+			}
+			profileBanner(flags, i18n.T("Postponing %d tasks", len(updates)))
+			if err := client.RescheduleTasks(updates); err != nil {
+				return err
+			}
+
+			out.WriteSuccess(i18n.T("Postponed %d task(s) to %s", len(updates), to))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&to, "to", "tomorrow", "new due date or offset (+3d, +1w)")
+	cmd.Flags().StringVar(&by, "by", "", "shift the current due date by an offset (3d, 1w)")
+	cmd.MarkFlagsMutuallyExclusive("to", "by")
+	cmd.Flags().StringVarP(&filter, "filter", "f", "", "postpone every task matching a Todoist filter")
+
+	return cmd
+}
+
+// planPostpone builds the due changes for tasks, skipping recurring ones
+func planPostpone(tasks []api.Task, to string, today time.Time) (updates []api.DueUpdate, postponed, skipped []api.Task) {
+	for _, t := range tasks {
+		if t.Due != nil && t.Due.IsRecurring {
+			skipped = append(skipped, t)
+			continue
+		}
+		updates = append(updates, postponeUpdate(t, to, today))
+		postponed = append(postponed, t)
+	}
+	return updates, postponed, skipped
+}
+
+// postponeUpdate builds the due change for a task. Offsets shift the existing
+// due date (keeping any time of day); tasks without a due date shift from today.
+func postponeUpdate(t api.Task, to string, today time.Time) api.DueUpdate {
+	to = strings.TrimSpace(to)
+	m := relativeShiftRe.FindStringSubmatch(to)
+	if m == nil {
+		return api.DueUpdate{TaskID: t.ID, String: to}
+	}
+
+	days, _ := strconv.Atoi(m[1])
+	if m[2] == "w" {
+		days *= 7
+	}
+
+	current := today.Format("2006-01-02")
+	if t.Due != nil {
+		current = taskDueDate(t)
+	}
+	if shifted, ok := shiftDate(current, days); ok {
+		return api.DueUpdate{TaskID: t.ID, Date: shifted}
+	}
+	return api.DueUpdate{TaskID: t.ID, String: fmt.Sprintf("in %d days", days)}
+}
+
+// shiftDate adds days to the YYYY-MM-DD prefix of a date or datetime string,
+// leaving any time and zone suffix untouched.
+func shiftDate(s string, days int) (string, bool) {
+	if len(s) < 10 {
+		return "", false
+	}
+	d, err := time.Parse("2006-01-02", s[:10])
+	if err != nil {
+		return "", false
+	}
+	return d.AddDate(0, 0, days).Format("2006-01-02") + s[10:], true
+}
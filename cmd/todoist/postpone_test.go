@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+)
+
+func TestPostponeUpdate(t *testing.T) {
+	today := time.Date(2024, 3, 10, 15, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		name string
+		due  *api.Due
+		to   string
+		want api.DueUpdate
+	}{
+		{"natural language", &api.Due{Date: "2024-03-01"}, "next monday", api.DueUpdate{String: "next monday"}},
+		{"days from the due date", &api.Due{Date: "2024-03-12"}, "+3d", api.DueUpdate{Date: "2024-03-15"}},
+		{"weeks", &api.Due{Date: "2024-03-12"}, "+2w", api.DueUpdate{Date: "2024-03-26"}},
+		{"overdue shifts from its own date", &api.Due{Date: "2024-03-01"}, "+3d", api.DueUpdate{Date: "2024-03-04"}},
+		{"no due date shifts from today", nil, "+1d", api.DueUpdate{Date: "2024-03-11"}},
+		{"timed keeps the time", &api.Due{Date: "2024-03-12", Datetime: "2024-03-12T09:30:00"}, "+1d", api.DueUpdate{Date: "2024-03-13T09:30:00"}},
+		{"timed keeps the zone", &api.Due{Date: "2024-03-31", Datetime: "2024-03-31T22:00:00Z"}, "+1d", api.DueUpdate{Date: "2024-04-01T22:00:00Z"}},
+		{"unparsable due date", &api.Due{Date: "soon"}, "+2d", api.DueUpdate{String: "in 2 days"}},
+	} {
+		tc.want.TaskID = "1"
+		if got := postponeUpdate(api.Task{ID: "1", Due: tc.due}, tc.to, today); got != tc.want {
+			t.Errorf("%s: got %+v, want %+v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestPlanPostponeSkipsRecurring(t *testing.T) {
+	tasks := []api.Task{
+		{ID: "1", Due: &api.Due{Date: "2024-03-01", IsRecurring: true, String: "every day"}},
+		{ID: "2", Due: &api.Due{Date: "2024-03-01"}},
+	}
+	updates, postponed, skipped := planPostpone(tasks, "+1d", time.Now())
+	if len(updates) != 1 || updates[0].TaskID != "2" || len(postponed) != 1 {
+		t.Errorf("expected only the one-off task postponed, got %+v", updates)
+	}
+	if len(skipped) != 1 || skipped[0].ID != "1" {
+		t.Errorf("expected the recurring task skipped, got %+v", skipped)
+	}
+}
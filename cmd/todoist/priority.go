@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+func newPriorityCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "priority <task-id...> <1-4>",
+		Aliases: []string{"prio"},
+		Short:   "Set the priority of one or more tasks",
+		Long: `Set task priority without going through update.
+
+Priority 1 is the highest, 4 the lowest. The shortcuts p1-p4 take the
+priority from the command name.
+
+Examples:
+  todoist priority 123 1
+  todoist priority 123 456 789 2
+  todoist p1 123
+  todoist p3 123 456`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			level := args[len(args)-1]
+			priority, err := strconv.Atoi(level)
+			if err != nil || priority < 1 || priority > 4 {
+				return fmt.Errorf("priority must be 1-4, got %q", level)
+			}
+			return runPriority(flags, args[:len(args)-1], priority)
+		},
+	}
+
+	return cmd
+}
+
+// newPriorityShortcutCmd builds the p1-p4 shortcut commands
+func newPriorityShortcutCmd(flags *rootFlags, priority int) *cobra.Command {
+	return &cobra.Command{
+		Use:   fmt.Sprintf("p%d <task-id...>", priority),
+		Short: fmt.Sprintf("Set tasks to priority %d", priority),
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPriority(flags, args, priority)
+		},
+	}
+}
+
+func runPriority(flags *rootFlags, taskIDs []string, priority int) error {
+	out := newFormatter(flags)
+
+	client, err := getClientWithFlags(flags)
+	if err != nil {
+		return err
+	}
+
+	// Convert priority (user: 1=highest, API: 4=highest)
+	apiPriority := 5 - priority
+
+	if len(taskIDs) == 1 {
+		task, err := client.UpdateTask(taskIDs[0], api.UpdateTaskParams{Priority: apiPriority})
+		if err != nil {
+			return err
+		}
+		return out.WriteTask(task)
+	}
+
+	if needsConfirm(flags, len(taskIDs)) {
+		tasks, err := fetchTasks(client, taskIDs)
+		if err != nil {
+			return err
+		}
+		if ok, err := askBulk(flags, i18n.T("Set p%d on", priority), tasks); err != nil || !ok {
+			if err == nil {
+				out.WriteSuccess(i18n.T("Cancelled"))
+			}
+			return err
+		}
+	}
+	profileBanner(flags, i18n.T("Setting %d tasks to p%d", len(taskIDs), priority))
+	if err := client.SetTaskPriorities(taskIDs, apiPriority); err != nil {
+		return err
+	}
+
+	out.WriteSuccess(i18n.T("Set %d tasks to p%d", len(taskIDs), priority))
+	return nil
+}
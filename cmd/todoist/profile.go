@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/buddyh/todoist-cli/internal/output"
+)
+
+// accountLabel names the active account, e.g. "work (me@example.com)", when
+// more than one profile is configured. It returns "" for single-account
+// setups and when TODOIST_API_TOKEN bypasses profiles.
+func accountLabel() string {
+	if os.Getenv("TODOIST_API_TOKEN") != "" {
+		return ""
+	}
+	cfg := config.Settings()
+	if len(cfg.ProfileNames()) < 2 {
+		return ""
+	}
+
+	name := cfg.ProfileName()
+	p, _ := cfg.Profile(name)
+	if p.Email != "" {
+		return fmt.Sprintf("%s (%s)", name, p.Email)
+	}
+	return name
+}
+
+// confirmSuffix returns a " [profile ...]" note for confirmation prompts
+func confirmSuffix() string {
+	if label := accountLabel(); label != "" {
+		return fmt.Sprintf(" [profile %s]", label)
+	}
+	return ""
+}
+
+// profileBanner prints a highlighted note on stderr naming the account a
+// change is about to run against. Like any note it is left out under
+// --quiet.
+func profileBanner(flags *rootFlags, action string) {
+	label := accountLabel()
+	if label == "" {
+		return
+	}
+	mode, _ := parseColorMode(flags.color)
+	c := output.NewColor(mode)
+	fmt.Fprintln(stderr, c.Wrap(output.ANSIYellow, i18n.T("▶ %s on profile %s", action, label)))
+}
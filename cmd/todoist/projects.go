@@ -1,22 +1,35 @@
 package main
 
 import (
+	"bufio"
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/buddyh/todoist-cli/internal/api"
-	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/buddyh/todoist-cli/internal/picker"
 	"github.com/spf13/cobra"
 )
 
 func newProjectsCmd(flags *rootFlags) *cobra.Command {
+	var workspace string
+
 	cmd := &cobra.Command{
 		Use:     "projects",
 		Aliases: []string{"project", "proj"},
 		Short:   "List all projects",
+		Long: `List all projects. Team projects are listed under the name of their
+workspace, after your personal projects, and grouped by folder.
+
+Examples:
+  todoist projects
+  todoist projects --workspace Acme
+  todoist projects --workspace personal`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			out := output.NewFormatter(os.Stdout, flags.asJSON)
+			out := newFormatter(flags)
 
-			client, err := getClient()
+			client, err := getClientWithFlags(flags)
 			if err != nil {
 				return err
 			}
@@ -26,20 +39,52 @@ func newProjectsCmd(flags *rootFlags) *cobra.Command {
 				return err
 			}
 
-			return out.WriteProjects(projects)
+			var (
+				workspaces []api.Workspace
+				folders    []api.Folder
+			)
+			if workspace != "" || hasTeamProjects(projects) {
+				if workspaces, err = client.GetWorkspaces(); err != nil {
+					return err
+				}
+				if folders, err = client.GetFolders(); err != nil {
+					return err
+				}
+			}
+			if workspace != "" {
+				if projects, err = projectsInWorkspace(projects, workspaces, workspace); err != nil {
+					return err
+				}
+			}
+
+			return out.WriteProjectsByWorkspace(projects, workspaces, folders)
 		},
 	}
 
+	cmd.Flags().StringVarP(&workspace, "workspace", "w", "", `list only this workspace's projects ("personal" for your own)`)
+
 	// Add project add subcommand
 	cmd.AddCommand(newProjectAddCmd(flags))
+	cmd.AddCommand(newProjectCollaboratorsCmd(flags))
+	cmd.AddCommand(newProjectWorkloadCmd(flags))
+	cmd.AddCommand(newProjectShareCmd(flags))
+	cmd.AddCommand(newProjectUnshareCmd(flags))
+	cmd.AddCommand(newProjectCopyCmd(flags))
+	cmd.AddCommand(newProjectDeleteCmd(flags))
+	cmd.AddCommand(newProjectFavoriteCmd(flags, true))
+	cmd.AddCommand(newProjectFavoriteCmd(flags, false))
+	cmd.AddCommand(newProjectColorCmd(flags))
+	cmd.AddCommand(newProjectEmailCmd(flags))
+	cmd.AddCommand(newProjectExportCSVCmd(flags))
 
 	return cmd
 }
 
 func newProjectAddCmd(flags *rootFlags) *cobra.Command {
 	var (
-		color    string
-		favorite bool
+		color     string
+		favorite  bool
+		workspace string
 	)
 
 	cmd := &cobra.Command{
@@ -47,9 +92,9 @@ func newProjectAddCmd(flags *rootFlags) *cobra.Command {
 		Short: "Create a new project",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			out := output.NewFormatter(os.Stdout, flags.asJSON)
+			out := newFormatter(flags)
 
-			client, err := getClient()
+			client, err := getClientWithFlags(flags)
 			if err != nil {
 				return err
 			}
@@ -59,6 +104,17 @@ func newProjectAddCmd(flags *rootFlags) *cobra.Command {
 				Color:      color,
 				IsFavorite: favorite,
 			}
+			if workspace != "" {
+				workspaces, err := client.GetWorkspaces()
+				if err != nil {
+					return err
+				}
+				w, err := api.MatchWorkspace(workspaces, workspace)
+				if err != nil {
+					return err
+				}
+				params.WorkspaceID = w.ID
+			}
 
 			project, err := client.AddProject(params)
 			if err != nil {
@@ -71,6 +127,235 @@ func newProjectAddCmd(flags *rootFlags) *cobra.Command {
 
 	cmd.Flags().StringVar(&color, "color", "", "project color")
 	cmd.Flags().BoolVar(&favorite, "favorite", false, "mark as favorite")
+	cmd.Flags().StringVarP(&workspace, "workspace", "w", "", "create a team project in this workspace")
+
+	return cmd
+}
+
+func newProjectDeleteCmd(flags *rootFlags) *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:     "delete <project>",
+		Aliases: []string{"rm"},
+		Short:   "Delete a project with its tasks and sections",
+		Long: `Delete a project permanently, together with its sub-projects, sections
+and tasks. The prompt says how many tasks, sections and sub-projects go with
+it. Without a terminal to ask on, or with --json, pass --force.
+
+Examples:
+  todoist projects delete "Old Project"
+  todoist projects delete "Old Project" --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			projects, err := client.GetProjects()
+			if err != nil {
+				return err
+			}
+			p, err := api.MatchProject(projects, args[0])
+			if err != nil {
+				return err
+			}
+			if p.IsInboxProject {
+				return fmt.Errorf("the Inbox cannot be deleted")
+			}
+
+			if !force && !flags.yes {
+				if flags.asJSON || !picker.IsTerminal(os.Stdin) {
+					return fmt.Errorf("refusing to delete project %s without confirmation; pass --force to proceed", p.Name)
+				}
+				tasks, err := client.GetTasks(p.ID, "")
+				if err != nil {
+					return err
+				}
+				sections, err := client.GetSections(p.ID)
+				if err != nil {
+					return err
+				}
+				fmt.Print(i18n.T("Delete project %s with %s%s\nThis cannot be undone. Continue? [y/N] ",
+					p.Name, projectContents(len(tasks), len(sections), len(subprojects(projects, p.ID))), confirmSuffix()))
+				input, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+				if strings.ToLower(strings.TrimSpace(input)) != "y" {
+					out.WriteSuccess(i18n.T("Cancelled"))
+					return nil
+				}
+			}
+
+			if err := client.DeleteProject(p.ID); err != nil {
+				return err
+			}
+
+			out.WriteSuccess(i18n.T("Deleted project: %s", p.Name))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "skip confirmation")
 
 	return cmd
 }
+
+// subprojects returns the IDs of every project nested under parentID
+func subprojects(projects []api.Project, parentID string) []string {
+	var ids []string
+	for _, p := range projects {
+		if p.ParentID == parentID {
+			ids = append(ids, p.ID)
+			ids = append(ids, subprojects(projects, p.ID)...)
+		}
+	}
+	return ids
+}
+
+// projectContents describes what deleting a project takes with it, e.g.
+// "12 tasks, 3 sections and 1 sub-project"
+func projectContents(tasks, sections, children int) string {
+	plural := func(n int, word string) string {
+		if n == 1 {
+			return fmt.Sprintf("1 %s", word)
+		}
+		return fmt.Sprintf("%d %ss", n, word)
+	}
+	parts := []string{plural(tasks, "task")}
+	if sections > 0 {
+		parts = append(parts, plural(sections, "section"))
+	}
+	if children > 0 {
+		parts = append(parts, plural(children, "sub-project"))
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return strings.Join(parts[:len(parts)-1], ", ") + " and " + parts[len(parts)-1]
+}
+
+// newProjectFavoriteCmd builds the favorite and unfavorite commands
+func newProjectFavoriteCmd(flags *rootFlags, favorite bool) *cobra.Command {
+	use, short := "favorite <project>", "Add a project to favorites"
+	if !favorite {
+		use, short = "unfavorite <project>", "Remove a project from favorites"
+	}
+
+	return &cobra.Command{
+		Use:               use,
+		Short:             short,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeProjects,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runProjectUpdate(flags, args[0], api.UpdateProjectParams{IsFavorite: &favorite})
+		},
+	}
+}
+
+func newProjectColorCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "color <project> <color>",
+		Short: "Change a project's color",
+		Long: `Change a project's color to one of Todoist's color names:
+
+  ` + strings.Join(api.ProjectColors, ", ") + `
+
+Examples:
+  todoist projects color Work blue
+  todoist projects color Home olive_green`,
+		Args: cobra.ExactArgs(2),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return completeProjects(cmd, args, toComplete)
+			}
+			return matching(api.ProjectColors, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			color := strings.ReplaceAll(strings.ToLower(args[1]), "-", "_")
+			if !validProjectColor(color) {
+				return fmt.Errorf("unknown color %q (use %s)", args[1], strings.Join(api.ProjectColors, ", "))
+			}
+			return runProjectUpdate(flags, args[0], api.UpdateProjectParams{Color: color})
+		},
+	}
+}
+
+func newProjectEmailCmd(flags *rootFlags) *cobra.Command {
+	var comments bool
+
+	cmd := &cobra.Command{
+		Use:   "email <project>",
+		Short: "Print the address that adds tasks to a project by email",
+		Long: `Print the project's add-by-email address, creating it if the project has
+none yet. Mail sent to it becomes a task in the project, which makes it
+easy to wire up mail rules and other email integrations.
+
+With --comments the address posts the mail as a project comment instead.
+
+Examples:
+  todoist projects email Inbox
+  todoist projects email Work --comments`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeProjects,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			p, err := client.FindProject(args[0])
+			if err != nil {
+				return err
+			}
+			email, err := client.ProjectEmail(p.ID, comments)
+			if err != nil {
+				return fmt.Errorf("failed to get project email: %w", err)
+			}
+
+			if flags.asJSON {
+				return out.JSON(map[string]string{"project_id": p.ID, "email": email})
+			}
+			out.Printf("%s\n", email)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&comments, "comments", false, "the address that adds comments instead of tasks")
+
+	return cmd
+}
+
+func validProjectColor(color string) bool {
+	for _, c := range api.ProjectColors {
+		if c == color {
+			return true
+		}
+	}
+	return false
+}
+
+// runProjectUpdate applies params to the project matching name and shows it
+func runProjectUpdate(flags *rootFlags, name string, params api.UpdateProjectParams) error {
+	out := newFormatter(flags)
+
+	client, err := getClientWithFlags(flags)
+	if err != nil {
+		return err
+	}
+
+	p, err := client.FindProject(name)
+	if err != nil {
+		return err
+	}
+
+	project, err := client.UpdateProject(p.ID, params)
+	if err != nil {
+		return err
+	}
+
+	return out.WriteProject(project)
+}
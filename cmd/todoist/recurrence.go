@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/buddyh/todoist-cli/internal/picker"
+	"github.com/spf13/cobra"
+)
+
+// recurrenceIssue is a suspicious recurring due date found by
+// lint-recurrence
+type recurrenceIssue struct {
+	TaskID  string `json:"task_id"`
+	Content string `json:"content"`
+	Due     string `json:"due"`    // the recurrence as written
+	Rule    string `json:"rule"`   // stale or drift
+	Detail  string `json:"detail"` // what looks wrong
+	Fix     string `json:"fix"`    // the suggested recurrence
+	Fixed   bool   `json:"fixed,omitempty"`
+}
+
+var (
+	recurEveryUnit  = regexp.MustCompile(`^every (other |(\d+) )?(day|week|month|year)s?\b`)
+	recurEveryDay   = regexp.MustCompile(`^every (monday|tuesday|wednesday|thursday|friday|saturday|sunday|mon|tues?|wed|thu(rs?)?|fri|sat|sun)\b`)
+	recurDayMonth   = regexp.MustCompile(`^every (\d{1,2})(?:st|nd|rd|th)? (jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\b`)
+	recurMonthDay   = regexp.MustCompile(`^every (jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]* (\d{1,2})(?:st|nd|rd|th)?\b`)
+	recurDayOfMonth = regexp.MustCompile(`^every (\d{1,2})(?:st|nd|rd|th)?(?: day)?$`)
+)
+
+var unitDays = map[string]int{"day": 1, "week": 7, "month": 30, "year": 365}
+
+// normalizeRecurrence lowercases a due string and reads "every!" (repeat
+// from completion) as "every"
+func normalizeRecurrence(s string) string {
+	s = strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	return strings.Replace(s, "every!", "every", 1)
+}
+
+// recurrenceDays estimates the days between occurrences of a recurring due
+// string, or returns 0 when it is not understood
+func recurrenceDays(s string) int {
+	s = normalizeRecurrence(s)
+	switch {
+	case strings.HasPrefix(s, "daily"), strings.HasPrefix(s, "every weekday"), strings.HasPrefix(s, "every workday"):
+		return 1
+	case strings.HasPrefix(s, "weekly"):
+		return 7
+	case strings.HasPrefix(s, "monthly"), strings.HasPrefix(s, "every last day"):
+		return 30
+	case strings.HasPrefix(s, "yearly"), strings.HasPrefix(s, "annually"):
+		return 365
+	case recurDayMonth.MatchString(s), recurMonthDay.MatchString(s):
+		return 365
+	case recurDayOfMonth.MatchString(s):
+		return 30
+	case recurEveryDay.MatchString(s):
+		return 7
+	}
+	if m := recurEveryUnit.FindStringSubmatch(s); m != nil {
+		n := 1
+		if m[1] == "other " {
+			n = 2
+		} else if m[2] != "" {
+			n, _ = strconv.Atoi(m[2])
+		}
+		return n * unitDays[m[3]]
+	}
+	return 0
+}
+
+// recurrenceAnchor returns the month (0 for every month) and day of the
+// month a due string names, e.g. 1 and 1 for "every 1 jan", or 0, 0
+func recurrenceAnchor(s string) (month, day int) {
+	s = normalizeRecurrence(s)
+	months := "janfebmaraprmayjunjulaugsepoctnovdec"
+	if m := recurDayMonth.FindStringSubmatch(s); m != nil {
+		day, _ = strconv.Atoi(m[1])
+		return strings.Index(months, m[2])/3 + 1, day
+	}
+	if m := recurMonthDay.FindStringSubmatch(s); m != nil {
+		day, _ = strconv.Atoi(m[2])
+		return strings.Index(months, m[1])/3 + 1, day
+	}
+	if m := recurDayOfMonth.FindStringSubmatch(s); m != nil {
+		day, _ = strconv.Atoi(m[1])
+		return 0, day
+	}
+	return 0, 0
+}
+
+// lintRecurrence checks a recurring task as of today for a date left
+// overdue for several occurrences (stale), and for a date that no longer
+// falls on the day its recurrence names (drift)
+func lintRecurrence(t api.Task, today time.Time) []recurrenceIssue {
+	if t.Due == nil || !t.Due.IsRecurring {
+		return nil
+	}
+	due, err := time.ParseInLocation("2006-01-02", dueDate(t), today.Location())
+	if err != nil {
+		return nil
+	}
+	issue := func(rule, detail string) recurrenceIssue {
+		return recurrenceIssue{TaskID: t.ID, Content: t.Content, Due: t.Due.String, Rule: rule, Detail: detail, Fix: t.Due.String}
+	}
+
+	var issues []recurrenceIssue
+	overdue := int(today.Sub(due).Hours() / 24)
+	if every := recurrenceDays(t.Due.String); every > 0 && overdue >= max(3*every, 7) {
+		issues = append(issues, issue("stale", fmt.Sprintf(
+			"overdue by %d days, %d occurrences; completing it only moves it to the next missed one", overdue, overdue/every)))
+	}
+
+	month, day := recurrenceAnchor(t.Due.String)
+	lastDay := time.Date(due.Year(), due.Month()+1, 0, 0, 0, 0, 0, due.Location()).Day()
+	switch {
+	case day == 0:
+	case month != 0 && (int(due.Month()) != month || due.Day() != day):
+		issues = append(issues, issue("drift", fmt.Sprintf("due %s, not on the day the recurrence names", due.Format("Jan 2"))))
+	case month == 0 && due.Day() != day && !(day > lastDay && due.Day() == lastDay):
+		issues = append(issues, issue("drift", fmt.Sprintf("due on the %s, not the day of the month the recurrence names", ordinal(due.Day()))))
+	}
+	return issues
+}
+
+// occurrences records which occurrences of a recurring task were done,
+// counting periods of every days back from today: period 0 is the current
+// one, ending today
+type occurrences struct {
+	days  map[int]bool // days before today with a completion
+	every int
+}
+
+func newOccurrences(completed []time.Time, every int, today time.Time) occurrences {
+	days := make(map[int]bool, len(completed))
+	for _, at := range completed {
+		at = at.In(today.Location())
+		day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, today.Location())
+		days[int(today.Sub(day).Hours()/24+0.5)] = true
+	}
+	return occurrences{days: days, every: every}
+}
+
+// done reports whether the task was completed in the given period
+func (o occurrences) done(period int) bool {
+	for d := period * o.every; d < (period+1)*o.every; d++ {
+		if o.days[d] {
+			return true
+		}
+	}
+	return false
+}
+
+// streak counts the occurrences in a row, every days apart, completed up to
+// today. The current occurrence only counts once it is done, so a streak
+// is not broken before the day is over.
+func streak(completed []time.Time, every int, today time.Time) int {
+	if every <= 0 {
+		return 0
+	}
+	o := newOccurrences(completed, every, today)
+	n, period := 0, 0
+	if !o.done(0) {
+		period = 1
+	}
+	for ; o.done(period); period++ {
+		n++
+	}
+	return n
+}
+
+// ordinal writes a day of the month as 1st, 2nd, 3rd, 4th, ...
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+func newLintRecurrenceCmd(flags *rootFlags) *cobra.Command {
+	var (
+		project string
+		fix     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "lint-recurrence",
+		Short: "Find recurring tasks with suspicious due dates",
+		Long: `Check recurring tasks for two common problems:
+
+  stale  a short recurrence left overdue for several occurrences, such as an
+         "every day" task three weeks late; completing it only moves it to
+         the next missed day
+  drift  a date that no longer falls on the day the recurrence names, such
+         as "every 1 jan" due on January 3rd after being postponed
+
+Setting the recurrence again restarts it from today, which fixes both. With
+--fix each problem asks for the recurrence to set, suggesting the current
+one; --yes applies the suggestions without asking.
+
+Examples:
+  todoist lint-recurrence
+  todoist lint-recurrence -p Home --json
+  todoist lint-recurrence --fix`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			if fix && !flags.yes && (flags.asJSON || !picker.IsTerminal(os.Stdin)) {
+				return fmt.Errorf("--fix asks before each change; pass --yes to apply the suggestions without a terminal")
+			}
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			var projectID string
+			if project != "" {
+				p, err := client.FindProject(project)
+				if err != nil {
+					return err
+				}
+				projectID = p.ID
+			}
+			tasks, err := client.GetTasks(projectID, "recurring")
+			if api.ErrorCode(err) == api.CodeBadRequest {
+				// Filter rejected: fetch every task; the checks skip the rest
+				tasks, err = client.GetTasks(projectID, "")
+			}
+			if err != nil {
+				return err
+			}
+
+			now := time.Now().In(output.Location())
+			today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+			issues := []recurrenceIssue{}
+			out.TaskOrder().Sort(tasks)
+			for _, t := range tasks {
+				issues = append(issues, lintRecurrence(t, today)...)
+			}
+
+			if fix {
+				reader := bufio.NewReader(os.Stdin)
+				done := make(map[string]bool)
+			fixing:
+				for i := range issues {
+					is := &issues[i]
+					if done[is.TaskID] {
+						is.Fixed = true
+						continue
+					}
+					due := is.Fix
+					if !flags.yes {
+						fmt.Fprintf(os.Stderr, "%q (%s): %s\nNew recurrence [%s], - to skip: ", is.Content, is.Due, is.Detail, is.Fix)
+						input, err := reader.ReadString('\n')
+						if err != nil && input == "" {
+							// Input ended: leave the rest as they are
+							fmt.Fprintln(os.Stderr)
+							break fixing
+						}
+						switch input = strings.TrimSpace(input); input {
+						case "-":
+							continue
+						case "":
+						default:
+							due = input
+						}
+					}
+					t, err := client.UpdateTask(is.TaskID, api.UpdateTaskParams{DueString: due})
+					if err != nil {
+						return fmt.Errorf("failed to update %s: %w", is.TaskID, err)
+					}
+					is.Fixed, is.Fix, done[is.TaskID] = true, due, true
+					if !flags.asJSON && !flags.quiet {
+						fmt.Fprintln(stderr, i18n.T("Set %q to %s, next due %s", t.Content, due, dueDate(*t)))
+					}
+				}
+			}
+
+			if flags.asJSON {
+				return out.JSON(issues)
+			}
+			if len(issues) == 0 {
+				out.Printf("%s\n", i18n.T("No problems found in recurring tasks."))
+				return nil
+			}
+			checks := make([]output.Check, len(issues))
+			for i, is := range issues {
+				checks[i] = output.Check{
+					Name:   is.Content,
+					Status: output.CheckWarn,
+					Detail: fmt.Sprintf("%s (%s): %s", is.Due, is.Rule, is.Detail),
+					Fix:    fmt.Sprintf("todoist update %s --due %q", is.TaskID, is.Fix),
+				}
+				if is.Fixed {
+					checks[i].Status, checks[i].Fix = output.CheckOK, ""
+				}
+			}
+			return out.WriteChecks(checks)
+		},
+	}
+
+	cmd.Flags().StringVarP(&project, "project", "p", "", "check only this project")
+	cmd.Flags().BoolVar(&fix, "fix", false, "set a new recurrence for each problem, asking first")
+
+	return cmd
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+)
+
+func TestRecurrenceDays(t *testing.T) {
+	for s, want := range map[string]int{
+		"every day":          1,
+		"Every! Day":         1,
+		"daily":              1,
+		"every weekday":      1,
+		"every other day":    2,
+		"every 3 days":       3,
+		"every monday":       7,
+		"every mon, fri":     7,
+		"every 2 weeks":      14,
+		"every month":        30,
+		"every 15th":         30,
+		"every 1 jan":        365,
+		"every march 3rd":    365,
+		"every year":         365,
+		"after 3 days":       0,
+		"every morning 9am?": 0,
+	} {
+		if got := recurrenceDays(s); got != want {
+			t.Errorf("recurrenceDays(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func TestLintRecurrence(t *testing.T) {
+	today := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	task := func(date, recurrence string) api.Task {
+		return api.Task{ID: "1", Content: "x", Due: &api.Due{Date: date, String: recurrence, IsRecurring: true}}
+	}
+	rules := func(t api.Task) []string {
+		var r []string
+		for _, is := range lintRecurrence(t, today) {
+			r = append(r, is.Rule)
+		}
+		return r
+	}
+
+	for _, tc := range []struct {
+		task api.Task
+		want []string
+	}{
+		{task("2024-03-19", "every day"), nil},
+		{task("2024-03-01", "every day"), []string{"stale"}},
+		{task("2024-03-10", "every week"), nil},
+		{task("2024-02-20", "every week"), []string{"stale"}},
+		{task("2024-01-01", "every 1 jan"), nil},
+		{task("2024-01-03", "every 1 jan"), []string{"drift"}},
+		{task("2024-03-25", "every 15th"), []string{"drift"}},
+		{task("2024-04-30", "every 31st"), nil},
+		{task("2024-02-14", "every 14"), nil},
+		{api.Task{Due: &api.Due{Date: "2024-01-01", String: "every day"}}, nil},
+	} {
+		got := rules(tc.task)
+		if len(got) != len(tc.want) || len(got) > 0 && got[0] != tc.want[0] {
+			t.Errorf("%s %q: got %v, want %v", tc.task.Due.Date, tc.task.Due.String, got, tc.want)
+		}
+	}
+}
+
+func TestStreak(t *testing.T) {
+	today := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	days := func(ago ...int) []time.Time {
+		var ts []time.Time
+		for _, d := range ago {
+			ts = append(ts, today.AddDate(0, 0, -d).Add(9*time.Hour))
+		}
+		return ts
+	}
+
+	for _, tc := range []struct {
+		name  string
+		done  []time.Time
+		every int
+		want  int
+	}{
+		{"done today", days(0, 1, 2), 1, 3},
+		{"today still open", days(1, 2), 1, 2},
+		{"broken", days(0, 2, 3), 1, 1},
+		{"missed yesterday", days(2, 3), 1, 0},
+		{"weekly", days(1, 9, 15), 7, 3},
+		{"weekly gap", days(1, 16), 7, 1},
+		{"twice in one period", days(0, 0, 1), 1, 2},
+		{"unknown recurrence", days(0), 0, 0},
+		{"none", nil, 1, 0},
+	} {
+		if got := streak(tc.done, tc.every, today); got != tc.want {
+			t.Errorf("%s: streak = %d, want %d", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/cache"
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/buddyh/todoist-cli/internal/reminders"
+	"github.com/spf13/cobra"
+)
+
+// remindersState is what has been copied between a Reminders list and
+// Todoist, so repeated runs do not copy anything twice
+type remindersState struct {
+	Imported map[string]string `json:"imported"` // reminder ID -> task ID
+	Exported map[string]string `json:"exported"` // task ID -> reminder ID
+}
+
+// remindersStateAge is how long copies to and from Reminders are remembered
+const remindersStateAge = 10 * 365 * 24 * time.Hour
+
+func loadRemindersState(list string) (string, remindersState) {
+	sum := sha256.Sum256([]byte(list))
+	key := config.Settings().ProfileName() + "-reminders-" + hex.EncodeToString(sum[:6])
+	state := remindersState{Imported: map[string]string{}, Exported: map[string]string{}}
+	cache.Load(key, remindersStateAge, &state)
+	if state.Imported == nil {
+		state.Imported = map[string]string{}
+	}
+	if state.Exported == nil {
+		state.Exported = map[string]string{}
+	}
+	return key, state
+}
+
+// copied reports whether a reminder or task ID was copied either way
+func (s remindersState) copied(id string) bool {
+	if _, ok := s.Imported[id]; ok {
+		return true
+	}
+	_, ok := s.Exported[id]
+	return ok
+}
+
+// reminderTask makes the new task for a reminder. Reminders priorities run
+// from 1 (high) to 9 (low), with 0 for none.
+func reminderTask(r reminders.Reminder, projectID string) api.AddTaskParams {
+	params := api.AddTaskParams{Content: r.Name, Description: r.Body, ProjectID: projectID, Priority: 1}
+	switch {
+	case r.Priority >= 1 && r.Priority <= 4:
+		params.Priority = 4
+	case r.Priority == 5:
+		params.Priority = 3
+	case r.Priority >= 6:
+		params.Priority = 2
+	}
+	switch {
+	case r.Due.IsZero():
+	case r.AllDay:
+		params.DueDate = r.Due.Format("2006-01-02")
+	default:
+		params.DueDatetime = r.Due.UTC().Format(time.RFC3339)
+	}
+	return params
+}
+
+// plannedTask shows a task about to be added in the confirmation summary
+func plannedTask(params api.AddTaskParams) api.Task {
+	t := api.Task{Content: params.Content, Priority: params.Priority}
+	switch {
+	case params.DueDate != "":
+		t.Due = &api.Due{Date: params.DueDate}
+	case params.DueDatetime != "":
+		t.Due = &api.Due{Date: params.DueDatetime[:10], Datetime: params.DueDatetime}
+	}
+	return t
+}
+
+// taskReminder makes the new reminder for a task
+func taskReminder(t api.Task) reminders.Reminder {
+	r := reminders.Reminder{Name: t.Content, Body: t.Description, Priority: map[int]int{4: 1, 3: 5, 2: 9}[t.Priority]}
+	if e, ok := calendarEntry(t, false); ok {
+		r.Due, r.AllDay = e.Due, e.AllDay
+		if r.AllDay {
+			// Midnight where the reminder is shown, not in UTC
+			r.Due = time.Date(e.Due.Year(), e.Due.Month(), e.Due.Day(), 0, 0, 0, 0, time.Local)
+		}
+	}
+	return r
+}
+
+func newImportCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import tasks from other apps",
+	}
+
+	cmd.AddCommand(newImportRemindersCmd(flags))
+
+	return cmd
+}
+
+func newImportRemindersCmd(flags *rootFlags) *cobra.Command {
+	var list, project string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "apple-reminders",
+		Short: "Import a Reminders list as tasks (macOS)",
+		Long: `Add the open reminders of an Apple Reminders list as tasks, with their
+notes, due dates and priorities, to the inbox or --project. Reminders that
+were imported before, or that came from Todoist with todoist export
+apple-reminders, are skipped, so the import can run again as the list grows.
+
+The first run asks to let your terminal control Reminders.
+
+Examples:
+  todoist import apple-reminders --list Groceries
+  todoist import apple-reminders --list Work -p Work --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+			notes := !flags.asJSON && !flags.quiet
+
+			items, err := reminders.List(list, false)
+			if err != nil {
+				return err
+			}
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+			var projectID string
+			if project != "" {
+				p, err := client.FindProject(project)
+				if err != nil {
+					return err
+				}
+				projectID = p.ID
+			}
+
+			key, state := loadRemindersState(list)
+			var pending []reminders.Reminder
+			var planned []api.Task
+			skipped := 0
+			for _, r := range items {
+				if state.copied(r.ID) {
+					skipped++
+					continue
+				}
+				pending = append(pending, r)
+				planned = append(planned, plannedTask(reminderTask(r, projectID)))
+			}
+
+			var added []api.Task
+			if dryRun {
+				added = planned
+				pending = nil
+			} else if ok, err := confirmBulk(flags, i18n.T("Import"), planned); err != nil || !ok {
+				if err == nil {
+					out.WriteSuccess(i18n.T("Cancelled"))
+				}
+				return err
+			}
+			for _, r := range pending {
+				t, err := client.AddTask(reminderTask(r, projectID))
+				if err != nil {
+					if serr := cache.Save(key, state); serr != nil && notes {
+						fmt.Fprintln(stderr, i18n.T("Warning: could not remember the imported reminders: %v", serr))
+					}
+					return fmt.Errorf("failed to import %q: %w", r.Name, err)
+				}
+				state.Imported[r.ID] = t.ID
+				added = append(added, *t)
+			}
+			if !dryRun {
+				if err := cache.Save(key, state); err != nil && notes {
+					fmt.Fprintln(stderr, i18n.T("Warning: could not remember the imported reminders: %v", err))
+				}
+			}
+
+			if flags.asJSON {
+				return out.JSON(map[string]interface{}{"imported": len(added), "skipped": skipped})
+			}
+			if flags.quiet {
+				for _, t := range added {
+					out.Printf("%s\n", t.ID)
+				}
+				return nil
+			}
+			if dryRun {
+				out.WriteSuccess(i18n.T("Would import %d reminders from %s (%d skipped)", len(added), list, skipped))
+				return nil
+			}
+			out.WriteSuccess(i18n.T("Imported %d reminders from %s (%d skipped)", len(added), list, skipped))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&list, "list", "", "Reminders list to import (required)")
+	cmd.Flags().StringVarP(&project, "project", "p", "", "project for the new tasks (default inbox)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "count the reminders without adding tasks")
+	cmd.MarkFlagRequired("list")
+
+	return cmd
+}
+
+func newExportRemindersCmd(flags *rootFlags) *cobra.Command {
+	var list, project, filter string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "apple-reminders",
+		Short: "Export tasks to a Reminders list (macOS)",
+		Long: `Add open tasks, of --project or matching --filter, to an Apple Reminders
+list with their descriptions, due dates and priorities. Tasks that were
+exported before, or that came from Reminders with todoist import
+apple-reminders, are skipped, so the export can run again.
+
+Examples:
+  todoist export apple-reminders --list Groceries -p Shopping
+  todoist export apple-reminders --list Today --filter today`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+			notes := !flags.asJSON && !flags.quiet
+			if project == "" && filter == "" {
+				return fmt.Errorf("give a --project or --filter to export")
+			}
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+			var projectID string
+			if project != "" {
+				p, err := client.FindProject(project)
+				if err != nil {
+					return err
+				}
+				projectID = p.ID
+			}
+			tasks, err := client.GetTasks(projectID, filter)
+			if err != nil {
+				return err
+			}
+			out.TaskOrder().Sort(tasks)
+
+			key, state := loadRemindersState(list)
+			exported, skipped := 0, 0
+			for _, t := range tasks {
+				if state.copied(t.ID) {
+					skipped++
+					continue
+				}
+				exported++
+				if dryRun {
+					continue
+				}
+				id, err := reminders.Add(list, taskReminder(t))
+				if err != nil {
+					if serr := cache.Save(key, state); serr != nil && notes {
+						fmt.Fprintln(stderr, i18n.T("Warning: could not remember the exported tasks: %v", serr))
+					}
+					return err
+				}
+				state.Exported[t.ID] = id
+			}
+			if !dryRun {
+				if err := cache.Save(key, state); err != nil && notes {
+					fmt.Fprintln(stderr, i18n.T("Warning: could not remember the exported tasks: %v", err))
+				}
+			}
+
+			if flags.asJSON {
+				return out.JSON(map[string]interface{}{"exported": exported, "skipped": skipped})
+			}
+			if dryRun {
+				out.WriteSuccess(i18n.T("Would export %d tasks to %s (%d skipped)", exported, list, skipped))
+				return nil
+			}
+			out.WriteSuccess(i18n.T("Exported %d tasks to %s (%d skipped)", exported, list, skipped))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&list, "list", "", "Reminders list to add to (required)")
+	cmd.Flags().StringVarP(&project, "project", "p", "", "export this project's tasks")
+	cmd.Flags().StringVarP(&filter, "filter", "f", "", "export the tasks matching this Todoist filter")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "count the tasks without adding reminders")
+	cmd.MarkFlagRequired("list")
+
+	return cmd
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/reminders"
+)
+
+func TestReminderTask(t *testing.T) {
+	at := time.Date(2024, 3, 20, 14, 30, 0, 0, time.FixedZone("PDT", -7*3600))
+	for _, tc := range []struct {
+		r        reminders.Reminder
+		priority int
+		date     string
+		datetime string
+	}{
+		{reminders.Reminder{Name: "a"}, 1, "", ""},
+		{reminders.Reminder{Name: "b", Priority: 1, Due: at}, 4, "", "2024-03-20T21:30:00Z"},
+		{reminders.Reminder{Name: "c", Priority: 5, Due: at, AllDay: true}, 3, "2024-03-20", ""},
+		{reminders.Reminder{Name: "d", Priority: 9}, 2, "", ""},
+	} {
+		p := reminderTask(tc.r, "P1")
+		if p.Content != tc.r.Name || p.ProjectID != "P1" || p.Priority != tc.priority || p.DueDate != tc.date || p.DueDatetime != tc.datetime {
+			t.Errorf("%s: unexpected params %+v", tc.r.Name, p)
+		}
+	}
+}
+
+func TestTaskReminder(t *testing.T) {
+	r := taskReminder(api.Task{Content: "Pay rent", Description: "online", Priority: 3, Due: &api.Due{Date: "2024-03-20"}})
+	if r.Name != "Pay rent" || r.Body != "online" || r.Priority != 5 || !r.AllDay {
+		t.Errorf("unexpected reminder %+v", r)
+	}
+	if want := time.Date(2024, 3, 20, 0, 0, 0, 0, time.Local); !r.Due.Equal(want) {
+		t.Errorf("due = %v, want %v", r.Due, want)
+	}
+
+	r = taskReminder(api.Task{Content: "Anytime", Priority: 1})
+	if r.Priority != 0 || !r.Due.IsZero() {
+		t.Errorf("unexpected reminder %+v", r)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+)
+
+// completedReport renders completed tasks as a changelog-style markdown
+// document: newest week first, then by section, then by completion time.
+type completedReport struct {
+	title    string
+	since    string
+	until    string
+	sections map[string]string // section ID -> heading
+	loc      *time.Location
+}
+
+// render returns the markdown report for items
+func (r completedReport) render(items []api.CompletedTask, generated time.Time) string {
+	type entry struct {
+		at   time.Time
+		task api.CompletedTask
+	}
+
+	weeks := make(map[string]map[string][]entry)
+	for _, t := range items {
+		at, err := time.Parse(time.RFC3339, t.CompletedAt)
+		if err != nil {
+			continue
+		}
+		at = at.In(r.loc)
+		week := weekStart(at).Format("2006-01-02")
+		if weeks[week] == nil {
+			weeks[week] = make(map[string][]entry)
+		}
+		heading := r.sections[t.SectionID]
+		if heading == "" {
+			heading = "No section"
+		}
+		weeks[week][heading] = append(weeks[week][heading], entry{at, t})
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", r.title)
+	period := "All completed tasks"
+	switch {
+	case r.since != "" && r.until != "":
+		period = fmt.Sprintf("Completed %s to %s", r.since, r.until)
+	case r.since != "":
+		period = "Completed since " + r.since
+	case r.until != "":
+		period = "Completed until " + r.until
+	}
+	fmt.Fprintf(&b, "%s · %d tasks · generated %s\n", period, len(items), generated.In(r.loc).Format("2006-01-02"))
+
+	if len(weeks) == 0 {
+		b.WriteString("\nNo completed tasks.\n")
+		return b.String()
+	}
+
+	var weekKeys []string
+	for w := range weeks {
+		weekKeys = append(weekKeys, w)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(weekKeys)))
+
+	for _, w := range weekKeys {
+		fmt.Fprintf(&b, "\n## Week of %s\n", w)
+
+		var headings []string
+		for h := range weeks[w] {
+			headings = append(headings, h)
+		}
+		// Tasks outside any section come last
+		sort.Slice(headings, func(i, j int) bool {
+			if (headings[i] == "No section") != (headings[j] == "No section") {
+				return headings[j] == "No section"
+			}
+			return headings[i] < headings[j]
+		})
+
+		for _, h := range headings {
+			entries := weeks[w][h]
+			sort.SliceStable(entries, func(i, j int) bool { return entries[i].at.Before(entries[j].at) })
+			fmt.Fprintf(&b, "\n### %s\n\n", h)
+			for _, e := range entries {
+				fmt.Fprintf(&b, "- %s %s\n", e.at.Format("2006-01-02"), e.task.Content)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// weekStart returns midnight on the Monday of t's week
+func weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return startOfDay(t).AddDate(0, 0, -offset)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+)
+
+func TestCompletedReport(t *testing.T) {
+	r := completedReport{
+		title:    "Work: completed tasks",
+		since:    "2024-01-01",
+		sections: map[string]string{"s1": "Backend"},
+		loc:      time.UTC,
+	}
+	items := []api.CompletedTask{
+		{Content: "Ship API", SectionID: "s1", CompletedAt: "2024-01-10T09:00:00Z"},
+		{Content: "Fix login", CompletedAt: "2024-01-03T12:00:00Z"},
+		{Content: "Write docs", CompletedAt: "2024-01-11T08:00:00Z"},
+		{Content: "Plan sprint", SectionID: "s1", CompletedAt: "2024-01-08T16:00:00Z"},
+	}
+
+	want := `# Work: completed tasks
+
+Completed since 2024-01-01 · 4 tasks · generated 2024-01-12
+
+## Week of 2024-01-08
+
+### Backend
+
+- 2024-01-08 Plan sprint
+- 2024-01-10 Ship API
+
+### No section
+
+- 2024-01-11 Write docs
+
+## Week of 2024-01-01
+
+### No section
+
+- 2024-01-03 Fix login
+`
+	if got := r.render(items, time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -1,18 +1,48 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"net/url"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/buddyh/todoist-cli/internal/api"
 	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/daemon"
+	"github.com/buddyh/todoist-cli/internal/i18n"
 	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/buddyh/todoist-cli/internal/picker"
+	"github.com/buddyh/todoist-cli/internal/progress"
 	"github.com/spf13/cobra"
 )
 
 var version = "dev"
 
+// commandStart is when the current invocation began, for JSON metadata
+var commandStart time.Time
+
+// stderr receives notes, warnings and errors, formatted as --log-format and
+// --log-timestamps ask and with notes left out under --quiet
+var stderr = output.NewLogWriter(os.Stderr, output.LogOptions{})
+
 type rootFlags struct {
-	asJSON bool
+	asJSON     bool
+	jsonl      bool
+	fields     string
+	profile    string
+	color      string
+	quiet      bool
+	debug      bool
+	debugHTTP  string
+	record     string
+	replay     string
+	dateFormat string
+	theme      string
+	yes        bool
+	logFormat  string
+	logTimes   bool
 }
 
 func execute(args []string) error {
@@ -24,40 +54,289 @@ func execute(args []string) error {
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		Version:       version,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			config.UseProfile(flags.profile)
+			i18n.SetLanguage(i18n.Detect(config.Settings().Language))
+			if flags.jsonl {
+				flags.asJSON = true
+			}
+			if _, err := parseColorMode(flags.color); err != nil {
+				return err
+			}
+			logJSON, err := output.ParseLogFormat(flags.logFormat)
+			if err != nil {
+				return err
+			}
+			stderr = output.NewLogWriter(os.Stderr, output.LogOptions{JSON: logJSON, Timestamps: flags.logTimes, Quiet: flags.quiet})
+			if _, err := output.Strftime(flags.dateFormat); err != nil {
+				return fmt.Errorf("invalid --date-format: %w", err)
+			}
+			if _, err := output.ParseTheme(flags.theme, nil); err != nil {
+				return fmt.Errorf("invalid --theme: %w", err)
+			}
+			if flags.record != "" && flags.replay != "" {
+				return fmt.Errorf("--record and --replay cannot be used together")
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Default: show today's tasks
-			return runTasks(cmd, &flags, true, "", "", false)
+			return runTasks(cmd, &flags, true, "", "", false, "", &searchCriteria{}, &paging{})
 		},
 	}
 	rootCmd.SetVersionTemplate("todoist {{.Version}}\n")
 
 	rootCmd.PersistentFlags().BoolVar(&flags.asJSON, "json", false, "output JSON instead of human-readable text")
+	rootCmd.PersistentFlags().BoolVar(&flags.jsonl, "jsonl", false, "output JSON Lines: one object per line, no envelope")
+	rootCmd.PersistentFlags().StringVar(&flags.fields, "fields", "", "limit JSON output to these fields, e.g. id,content,due.date")
+	rootCmd.PersistentFlags().StringVar(&flags.profile, "profile", "", "account profile to use for this command")
+	rootCmd.PersistentFlags().StringVar(&flags.color, "color", "auto", "color output: auto, always, never")
+	rootCmd.PersistentFlags().BoolVarP(&flags.quiet, "quiet", "q", false, "suppress informational output; print only IDs of changed items")
+	rootCmd.PersistentFlags().BoolVar(&flags.debug, "debug", false, "trace HTTP requests and responses on stderr")
+	rootCmd.PersistentFlags().StringVar(&flags.debugHTTP, "debug-http", "", "append full HTTP requests and responses, with credentials redacted, to this file")
+	rootCmd.PersistentFlags().StringVar(&flags.record, "record", "", "save every API response as a fixture in this directory")
+	rootCmd.PersistentFlags().StringVar(&flags.replay, "replay", "", "answer API requests from fixtures in this directory instead of the network")
+	rootCmd.PersistentFlags().BoolVar(&flags.yes, "yes", false, "skip the confirmation bulk changes ask for above confirm_threshold tasks")
+	rootCmd.PersistentFlags().StringVar(&flags.theme, "theme", "", "color theme: "+strings.Join(output.ThemeNames(), ", ")+"; styles come from the config's theme section")
+	rootCmd.PersistentFlags().StringVar(&flags.dateFormat, "date-format", "", "show dates in this strftime format, e.g. %Y-%m-%d, or iso, us, eu")
+	rootCmd.PersistentFlags().StringVar(&flags.logFormat, "log-format", "text", "format of notes, warnings and errors on stderr: text or json")
+	rootCmd.PersistentFlags().BoolVar(&flags.logTimes, "log-timestamps", false, "prefix notes, warnings and errors on stderr with the time")
 
 	// Add subcommands
+	rootCmd.AddCommand(newInitCmd(&flags))
+	rootCmd.AddCommand(newExamplesCmd(&flags))
 	rootCmd.AddCommand(newAuthCmd(&flags))
+	rootCmd.AddCommand(newWhoamiCmd(&flags))
+	rootCmd.AddCommand(newVersionCmd(&flags))
+	rootCmd.AddCommand(newSelfUpdateCmd(&flags))
+	rootCmd.AddCommand(newLimitsCmd(&flags))
+	rootCmd.AddCommand(newAPICmd(&flags))
+	rootCmd.AddCommand(newSyncCmdCmd(&flags))
+	rootCmd.AddCommand(newDoctorCmd(&flags))
+	rootCmd.AddCommand(newDaemonCmd(&flags))
+	rootCmd.AddCommand(newAliasCmd(&flags))
 	rootCmd.AddCommand(newTasksCmd(&flags))
 	rootCmd.AddCommand(newAddCmd(&flags))
+	rootCmd.AddCommand(newInboxCmd(&flags))
 	rootCmd.AddCommand(newCompleteCmd(&flags))
 	rootCmd.AddCommand(newDoneCmd(&flags)) // alias for complete
 	rootCmd.AddCommand(newDeleteCmd(&flags))
 	rootCmd.AddCommand(newUpdateCmd(&flags))
 	rootCmd.AddCommand(newProjectsCmd(&flags))
+	rootCmd.AddCommand(newWorkspacesCmd(&flags))
+	rootCmd.AddCommand(newFoldersCmd(&flags))
 	rootCmd.AddCommand(newLabelsCmd(&flags))
+	rootCmd.AddCommand(newTaskLabelCmd(&flags))
 	rootCmd.AddCommand(newSectionsCmd(&flags))
 	rootCmd.AddCommand(newSearchCmd(&flags))
+	rootCmd.AddCommand(newPickCmd(&flags))
 	rootCmd.AddCommand(newViewCmd(&flags))
+	rootCmd.AddCommand(newOpenCmd(&flags))
 	rootCmd.AddCommand(newCompletedCmd(&flags))
+	rootCmd.AddCommand(newStandupCmd(&flags))
+	rootCmd.AddCommand(newStatusCmd(&flags))
+	rootCmd.AddCommand(newSummaryCmd(&flags))
+	rootCmd.AddCommand(newChangesCmd(&flags))
+	rootCmd.AddCommand(newBackupsCmd(&flags))
+	rootCmd.AddCommand(newLintRecurrenceCmd(&flags))
+	rootCmd.AddCommand(newHabitsCmd(&flags))
+	rootCmd.AddCommand(newDepsCmd(&flags))
+	rootCmd.AddCommand(newExportCmd(&flags))
+	rootCmd.AddCommand(newCalendarCmd(&flags))
+	rootCmd.AddCommand(newImportCmd(&flags))
+	rootCmd.AddCommand(newEventsCmd(&flags))
+	rootCmd.AddCommand(newAssertCmd(&flags))
+	rootCmd.AddCommand(newWidgetCmd(&flags))
 	rootCmd.AddCommand(newReopenCmd(&flags))
 	rootCmd.AddCommand(newCommentCmd(&flags))
 	rootCmd.AddCommand(newMoveCmd(&flags))
+	rootCmd.AddCommand(newTaskCmd(&flags))
+	rootCmd.AddCommand(newPostponeCmd(&flags))
+	rootCmd.AddCommand(newSnoozeCmd(&flags))
+	rootCmd.AddCommand(newSnoozedCmd(&flags))
+	rootCmd.AddCommand(newDuplicateCmd(&flags))
+	rootCmd.AddCommand(newNextCmd(&flags))
+	rootCmd.AddCommand(newFocusCmd(&flags))
+	rootCmd.AddCommand(newHeatmapCmd(&flags))
+	rootCmd.AddCommand(newParseDueCmd(&flags))
+	rootCmd.AddCommand(newPriorityCmd(&flags))
+	for p := 1; p <= 4; p++ {
+		rootCmd.AddCommand(newPriorityShortcutCmd(&flags, p))
+	}
+
+	registerAllCompletions(rootCmd)
+	linkExamples(rootCmd)
+
+	commandStart = time.Now()
+	stderr = output.NewLogWriter(os.Stderr, output.LogOptions{})
+	expanded, err := expandAlias(rootCmd, args)
+	if err == nil {
+		if path, pluginArgs, ok := findPlugin(rootCmd, &flags, expanded); ok {
+			err := runPlugin(&flags, path, pluginArgs)
+			var exit *pluginExit
+			if err != nil && !errors.As(err, &exit) {
+				writeError(&flags, err)
+			}
+			return err
+		}
+		rootCmd.SetArgs(expanded)
+		err = rootCmd.Execute()
+	}
+	if httpLogFile != nil {
+		httpLogFile.Close()
+	}
+	reporter = nil
+	if !flags.quiet {
+		reportSlow(stderr, time.Since(commandStart), slowThreshold())
+	}
+	if err != nil {
+		writeError(&flags, err)
+	}
+	stderr.Flush()
+	return err
+}
+
+// writeError reports a failed command on stderr, as JSON with --json
+func writeError(flags *rootFlags, err error) {
+	mode, _ := parseColorMode(flags.color)
+	out := output.NewFormatterWithColor(stderr.Level(output.LevelError), flags.asJSON, mode)
+	out.SetJSONLines(flags.jsonl)
+	out.SetStart(commandStart)
+	out.SetRequestCounter(requestCount)
+	out.WriteError(err)
+}
+
+// parseColorMode converts a --color value to an output.ColorMode
+func parseColorMode(s string) (output.ColorMode, error) {
+	switch strings.ToLower(s) {
+	case "", "auto":
+		return output.ColorAuto, nil
+	case "always":
+		return output.ColorAlways, nil
+	case "never":
+		return output.ColorNever, nil
+	default:
+		return output.ColorAuto, fmt.Errorf("invalid --color value %q (use auto, always, or never)", s)
+	}
+}
 
-	rootCmd.SetArgs(args)
-	if err := rootCmd.Execute(); err != nil {
-		out := output.NewFormatter(os.Stderr, flags.asJSON)
-		out.WriteError(err)
-		return err
+// newFormatter returns a stdout formatter honoring the global output flags
+// and the display preferences in the config file
+func newFormatter(flags *rootFlags) *output.Formatter {
+	mode, _ := parseColorMode(flags.color)
+	out := output.NewFormatterWithColor(os.Stdout, flags.asJSON, mode)
+	out.SetQuiet(flags.quiet)
+	out.SetJSONLines(flags.jsonl)
+	out.SetFields(output.ParseFields(flags.fields))
+	out.SetStart(commandStart)
+	out.SetRequestCounter(requestCount)
+
+	cfg := config.Settings()
+	if links, err := output.ParseHyperlinkMode(cfg.Hyperlinks); err == nil {
+		out.SetHyperlinks(links)
+	}
+	out.ShowPlaces(cfg.ShowProjects != "off")
+	if cfg.DisplayTZ != "" {
+		if loc, err := time.LoadLocation(cfg.DisplayTZ); err == nil {
+			output.SetLocation(loc)
+		}
+	}
+	dateFormat := cfg.DateFormat
+	if flags.dateFormat != "" {
+		dateFormat = flags.dateFormat
+	}
+	if output.SetDateFormat(dateFormat) != nil {
+		output.SetDateFormat("")
+	}
+	if output.SetTimeFormat(cfg.TimeFormat) != nil {
+		output.SetTimeFormat("")
+	}
+	base := cfg.Theme["base"]
+	if flags.theme != "" {
+		base = flags.theme
+	}
+	theme, err := output.ParseTheme(base, cfg.Theme)
+	if err != nil {
+		// A bad theme section falls back to the built-in themes
+		if theme, err = output.ParseTheme(base, nil); err != nil {
+			theme, _ = output.ParseTheme("", nil)
+		}
+	}
+	output.SetTheme(theme)
+	if output.SetIcons(cfg.Icons) != nil {
+		output.SetIcons("")
 	}
-	return nil
+	return out
+}
+
+// getClientWithFlags returns an authenticated API client honoring --debug,
+// --debug-http, --record and --replay
+func getClientWithFlags(flags *rootFlags) (*api.Client, error) {
+	var client *api.Client
+	var err error
+	if flags.replay != "" {
+		// Replayed responses need no account
+		client, err = newAPIClient("")
+		if err == nil {
+			trackClient(client)
+			err = client.Replay(flags.replay)
+		}
+	} else {
+		client, err = getClient()
+		if err == nil && flags.record != "" {
+			err = client.Record(flags.record)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	client.SetDebug(flags.debug)
+	client.SetDebugOutput(stderr)
+	if flags.debugHTTP != "" {
+		f, err := openHTTPLog(flags.debugHTTP)
+		if err != nil {
+			return nil, err
+		}
+		client.SetHTTPLog(f)
+	}
+	if !flags.quiet && !flags.asJSON {
+		client.SetProgress(progressReporter().Report)
+	}
+	return client, nil
+}
+
+// reporter shows progress on stderr for every client in this invocation.
+// It stays nil, and so silent, under --quiet and --json.
+var reporter *progress.Reporter
+
+// progressReporter creates the shared reporter on first use
+func progressReporter() *progress.Reporter {
+	if reporter == nil {
+		if opts := stderr.Options(); opts.JSON || opts.Timestamps {
+			// A bar redrawn in place cannot be logged line by line
+			reporter = progress.New(stderr, false)
+		} else {
+			reporter = progress.New(os.Stderr, picker.IsTerminal(os.Stderr))
+		}
+	}
+	return reporter
+}
+
+// httpLogFile is the --debug-http file, shared by every client in this
+// invocation and closed when the command finishes
+var httpLogFile *os.File
+
+// openHTTPLog opens the --debug-http file for appending on first use
+func openHTTPLog(path string) (*os.File, error) {
+	if httpLogFile != nil {
+		return httpLogFile, nil
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open HTTP log: %w", err)
+	}
+	httpLogFile = f
+	return f, nil
 }
 
 // getClient returns an authenticated API client
@@ -66,5 +345,26 @@ func getClient() (*api.Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	return api.NewClient(token), nil
+	client, err := newAPIClient(token)
+	if err != nil {
+		return nil, err
+	}
+	return trackClient(client), nil
+}
+
+// newAPIClient returns a client for token, pointed at the configured API
+// base URL if there is one
+func newAPIClient(token string) (*api.Client, error) {
+	client := api.NewClient(token)
+	if base := config.APIBaseURL(); base != "" {
+		u, err := url.Parse(base)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			return nil, fmt.Errorf("invalid API base URL %q: want http(s)://host/path", base)
+		}
+		client.SetBaseURL(base)
+	}
+	if socket := daemon.SocketPath(); daemon.Running(socket) {
+		client.SetTransport(daemon.Transport(socket, api.Transport()))
+	}
+	return client, nil
 }
@@ -1,50 +1,294 @@
 package main
 
 import (
-	"os"
+	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/buddyh/todoist-cli/internal/api"
-	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/buddyh/todoist-cli/internal/dates"
+	"github.com/buddyh/todoist-cli/internal/filter"
 	"github.com/spf13/cobra"
 )
 
+// searchCriteria are the predicates a task must satisfy to match a search
+type searchCriteria struct {
+	text      string         // case-insensitive substring of content or description
+	pattern   *regexp.Regexp // replaces text with --regex
+	labels    []string       // every label must be present
+	priority  int            // API priority (4 = p1); 0 matches any
+	dueBefore string         // YYYY-MM-DD, exclusive
+	dueAfter  string         // YYYY-MM-DD, exclusive
+	noDate    bool           // only tasks without a due date
+	createdOn string         // YYYY-MM-DD, inclusive lower bound of the creation day
+}
+
+// empty reports whether the criteria match every task
+func (c *searchCriteria) empty() bool {
+	return c.text == "" && c.pattern == nil && len(c.labels) == 0 && c.priority == 0 &&
+		c.dueBefore == "" && c.dueAfter == "" && !c.noDate && c.createdOn == ""
+}
+
+// query translates the predicates Todoist filters support into a filter
+// query; the regex can only be matched locally
+func (c *searchCriteria) query(project string) *filter.Query {
+	q := &filter.Query{}
+	if c.pattern == nil && c.text != "" {
+		q.Search(c.text)
+	}
+	if project != "" {
+		q.Project(project)
+	}
+	for _, l := range c.labels {
+		q.Label(l)
+	}
+	if c.priority != 0 {
+		q.Priority(5 - c.priority)
+	}
+	if c.dueBefore != "" {
+		q.DueBefore(c.dueBefore)
+	}
+	if c.dueAfter != "" {
+		q.DueAfter(c.dueAfter)
+	}
+	if c.noDate {
+		q.NoDate()
+	}
+	if c.createdOn != "" {
+		q.CreatedAfter(dayBefore(c.createdOn))
+	}
+	return q
+}
+
+// matchesPattern applies the predicate that has no filter equivalent
+func (c *searchCriteria) matchesPattern(t api.Task) bool {
+	return c.pattern == nil || c.pattern.MatchString(t.Content) || c.pattern.MatchString(t.Description)
+}
+
+// matches applies every predicate locally
+func (c *searchCriteria) matches(t api.Task) bool {
+	switch {
+	case c.pattern != nil:
+		if !c.matchesPattern(t) {
+			return false
+		}
+	case c.text != "":
+		if !containsCI(t.Content, c.text) && !containsCI(t.Description, c.text) {
+			return false
+		}
+	}
+
+	for _, want := range c.labels {
+		found := false
+		for _, l := range t.Labels {
+			found = found || strings.EqualFold(l, want)
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if c.priority != 0 && t.Priority != c.priority {
+		return false
+	}
+
+	if c.dueBefore != "" || c.dueAfter != "" {
+		if t.Due == nil || len(t.Due.Date) < 10 {
+			return false
+		}
+		due := t.Due.Date[:10]
+		if c.dueBefore != "" && due >= c.dueBefore {
+			return false
+		}
+		if c.dueAfter != "" && due <= c.dueAfter {
+			return false
+		}
+	}
+
+	if c.noDate && t.Due != nil {
+		return false
+	}
+	if c.createdOn != "" && createdDay(t) < c.createdOn {
+		return false
+	}
+
+	return true
+}
+
 func newSearchCmd(flags *rootFlags) *cobra.Command {
+	var (
+		regex     bool
+		labels    []string
+		priority  string
+		project   string
+		dueBefore string
+		dueAfter  string
+		pages     paging
+	)
+
 	cmd := &cobra.Command{
-		Use:   "search <query>",
+		Use:   "search [query]",
 		Short: "Search tasks by content",
 		Long: `Search tasks by content (case-insensitive).
 
+The query and flags are translated into a Todoist filter and run on the
+server, e.g. search: report & #Work & p1. With --regex the query is a regular
+expression, matched locally and case-insensitively against content and
+description. A query is optional when any filter flag is given.
+
+If the server rejects the filter, all tasks are fetched and matched locally.
+
 Examples:
   todoist search "meeting"
-  todoist search "buy"`,
-		Args: cobra.ExactArgs(1),
+  todoist search "buy"
+  todoist search --regex '^(call|email) '
+  todoist search report --label work --priority 1
+  todoist search --project Work --due-before tomorrow
+  todoist search --due-after today --due-before 2024-02-01
+  todoist search report --limit 10 --page 2`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			out := output.NewFormatter(os.Stdout, flags.asJSON)
-			query := strings.ToLower(args[0])
+			out := newFormatter(flags)
+
+			var criteria searchCriteria
+			if len(args) == 1 {
+				if regex {
+					re, err := regexp.Compile("(?i)" + args[0])
+					if err != nil {
+						return fmt.Errorf("invalid regex: %w", err)
+					}
+					criteria.pattern = re
+				} else {
+					criteria.text = args[0]
+				}
+			}
+			for _, l := range labels {
+				criteria.labels = append(criteria.labels, strings.TrimPrefix(l, "@"))
+			}
+			var err error
+			if criteria.priority, err = flagPriority(priority); err != nil {
+				return err
+			}
+			if criteria.dueBefore, err = flagDay(dueBefore); err != nil {
+				return fmt.Errorf("invalid --due-before: %w", err)
+			}
+			if criteria.dueAfter, err = flagDay(dueAfter); err != nil {
+				return fmt.Errorf("invalid --due-after: %w", err)
+			}
+
+			if _, _, err := pages.bounds(); err != nil {
+				return err
+			}
+
+			if len(args) == 0 && len(labels) == 0 && priority == "" && project == "" && dueBefore == "" && dueAfter == "" {
+				return fmt.Errorf("give a query or at least one filter flag")
+			}
 
-			client, err := getClient()
+			client, err := getClientWithFlags(flags)
 			if err != nil {
 				return err
 			}
 
-			// Get all tasks and filter locally
-			tasks, err := client.GetTasks("", "")
+			var projectID, projectName string
+			if project != "" {
+				p, err := client.FindProject(project)
+				if err != nil {
+					return err
+				}
+				projectID, projectName = p.ID, p.Name
+			}
+
+			// Run what Todoist filters can express on the server
+			query := criteria.query(projectName).String()
+			match := criteria.matchesPattern
+			tasks, err := client.GetTasks("", query)
+			if api.ErrorCode(err) == api.CodeBadRequest {
+				// Filter rejected: fetch everything and match locally
+				match = criteria.matches
+				tasks, err = client.GetTasks(projectID, "")
+			}
 			if err != nil {
 				return err
 			}
 
 			var matches []api.Task
 			for _, t := range tasks {
-				if strings.Contains(strings.ToLower(t.Content), query) ||
-					strings.Contains(strings.ToLower(t.Description), query) {
+				if match(t) {
 					matches = append(matches, t)
 				}
 			}
 
+			if err := loadNames(client, out); err != nil {
+				return err
+			}
+			if err := loadPeople(client, out, matches); err != nil {
+				return err
+			}
+			out.TaskOrder().Sort(matches)
+			if matches, err = pages.apply(matches, !flags.asJSON && !flags.jsonl && !flags.quiet); err != nil {
+				return err
+			}
+			out.SetNextCursor(pages.cursor())
 			return out.WriteTasks(matches)
 		},
 	}
 
+	cmd.Flags().BoolVarP(&regex, "regex", "r", false, "treat the query as a regular expression")
+	cmd.Flags().StringSliceVarP(&labels, "label", "l", nil, "require a label (repeatable)")
+	cmd.Flags().StringVar(&priority, "priority", "", "require a priority (1-4)")
+	cmd.Flags().StringVarP(&project, "project", "p", "", "search only this project")
+	cmd.Flags().StringVar(&dueBefore, "due-before", "", "due before this date (e.g. 2024-02-01, tomorrow)")
+	cmd.Flags().StringVar(&dueAfter, "due-after", "", "due after this date")
+	addPagingFlags(cmd, &pages)
+
 	return cmd
 }
+
+// flagPriority converts a user-facing priority flag ("1" or "p1" is highest)
+// to the API's priority, where 4 is highest; empty gives 0
+func flagPriority(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	p, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(s), "p"))
+	if err != nil || p < 1 || p > 4 {
+		return 0, fmt.Errorf("priority must be 1-4, got %q", s)
+	}
+	return 5 - p, nil
+}
+
+// flagDay resolves a date flag such as --due-before or --since, in any form
+// dates.Parse accepts ("last monday", "-7d"), to YYYY-MM-DD
+func flagDay(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	t, err := dates.Parse(s, time.Now())
+	if err != nil {
+		return "", err
+	}
+	return t.Format("2006-01-02"), nil
+}
+
+// dayBefore returns the day before date (YYYY-MM-DD)
+func dayBefore(date string) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	return t.AddDate(0, 0, -1).Format("2006-01-02")
+}
+
+// createdDay returns the local day a task was created, or "" if unknown
+func createdDay(t api.Task) string {
+	created, err := time.Parse(time.RFC3339, t.CreatedAt)
+	if err != nil {
+		if len(t.CreatedAt) >= 10 {
+			return t.CreatedAt[:10]
+		}
+		return ""
+	}
+	return created.Local().Format("2006-01-02")
+}
@@ -1,9 +1,7 @@
 package main
 
 import (
-	"os"
-
-	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/buddyh/todoist-cli/internal/i18n"
 	"github.com/spf13/cobra"
 )
 
@@ -20,9 +18,9 @@ Examples:
   todoist sections
   todoist sections -p Work`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			out := output.NewFormatter(os.Stdout, flags.asJSON)
+			out := newFormatter(flags)
 
-			client, err := getClient()
+			client, err := getClientWithFlags(flags)
 			if err != nil {
 				return err
 			}
@@ -49,6 +47,7 @@ Examples:
 
 	// Add section add subcommand
 	cmd.AddCommand(newSectionAddCmd(flags))
+	cmd.AddCommand(newSectionCopyCmd(flags))
 
 	return cmd
 }
@@ -61,9 +60,9 @@ func newSectionAddCmd(flags *rootFlags) *cobra.Command {
 		Short: "Create a new section in a project",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			out := output.NewFormatter(os.Stdout, flags.asJSON)
+			out := newFormatter(flags)
 
-			client, err := getClient()
+			client, err := getClientWithFlags(flags)
 			if err != nil {
 				return err
 			}
@@ -81,7 +80,7 @@ func newSectionAddCmd(flags *rootFlags) *cobra.Command {
 			if flags.asJSON {
 				return out.JSON(section)
 			}
-			out.WriteSuccess("Created section: " + section.Name)
+			out.WriteSuccess(i18n.T("Created section: %s", section.Name))
 			return nil
 		},
 	}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/buddyh/todoist-cli/internal/update"
+	"github.com/spf13/cobra"
+)
+
+// releaseSource finds releases to update to; replaced in tests
+var releaseSource = update.NewSource
+
+// selfExecutable locates the running binary; replaced in tests
+var selfExecutable = os.Executable
+
+// parseChannel checks a --channel value, returning whether it includes
+// prereleases
+func parseChannel(channel string) (bool, error) {
+	switch strings.ToLower(channel) {
+	case "stable":
+		return false, nil
+	case "prerelease":
+		return true, nil
+	}
+	return false, fmt.Errorf("invalid channel %q (use stable or prerelease)", channel)
+}
+
+// latestRelease returns the newest release on the channel
+func latestRelease(channel string) (*update.Release, error) {
+	prerelease, err := parseChannel(channel)
+	if err != nil {
+		return nil, err
+	}
+	release, err := releaseSource().Latest(prerelease)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for updates: %w", err)
+	}
+	return release, nil
+}
+
+// managedInstall names the package manager that owns exe, if any; updating
+// behind its back would leave it confused about what is installed
+func managedInstall(exe string) string {
+	switch {
+	case strings.Contains(exe, "/Cellar/"):
+		return "Homebrew: run 'brew upgrade todoist'"
+	case strings.Contains(exe, "/nix/store/"):
+		return "Nix: update it through your Nix configuration"
+	}
+	return ""
+}
+
+func newVersionCmd(flags *rootFlags) *cobra.Command {
+	var (
+		check   bool
+		channel string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Show the CLI version",
+		Long: `Show the version of the CLI. With --check, also ask GitHub for the latest
+release and report whether an update is available, without installing it.
+
+Examples:
+  todoist version
+  todoist version --check
+  todoist version --check --channel prerelease`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			if !check {
+				if flags.asJSON {
+					return out.JSON(map[string]interface{}{"version": version})
+				}
+				out.Printf("todoist %s\n", version)
+				return nil
+			}
+
+			release, err := latestRelease(channel)
+			if err != nil {
+				return err
+			}
+			available := update.Compare(release.Version(), version) > 0
+
+			if flags.asJSON {
+				return out.JSON(map[string]interface{}{
+					"version":          version,
+					"latest":           release.Version(),
+					"update_available": available,
+					"url":              release.URL,
+				})
+			}
+
+			out.Printf("todoist %s\n", version)
+			if available {
+				out.Printf("%s\n", i18n.T("Update available: %s", release.Version()))
+				out.Printf("%s\n", i18n.T("Run 'todoist self-update' to install it, or see %s", release.URL))
+			} else {
+				out.Printf("%s\n", i18n.T("Up to date (latest is %s)", release.Version()))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&check, "check", false, "check GitHub for a newer release")
+	cmd.Flags().StringVar(&channel, "channel", "stable", "releases to consider: stable or prerelease")
+
+	return cmd
+}
+
+func newSelfUpdateCmd(flags *rootFlags) *cobra.Command {
+	var (
+		channel string
+		force   bool
+		dryRun  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "self-update",
+		Short: "Update the CLI to the latest release",
+		Long: `Download the latest release for this platform from GitHub and replace the
+running binary with it.
+
+The archive is checked against the SHA-256 listed in the release's
+checksums.txt before anything is replaced; a release without checksums, or
+one that does not match, is refused. Releases are not signed, so this guards
+against corrupted and tampered downloads as far as GitHub itself is trusted.
+
+Binaries installed with a package manager such as Homebrew are left to it.
+Set GITHUB_TOKEN to avoid GitHub's anonymous rate limit.
+
+Examples:
+  todoist self-update
+  todoist self-update --channel prerelease
+  todoist self-update --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			exe, err := selfExecutable()
+			if err != nil {
+				return fmt.Errorf("failed to find the todoist executable: %w", err)
+			}
+			if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+				exe = resolved
+			}
+			if manager := managedInstall(exe); manager != "" {
+				return fmt.Errorf("%s is managed by %s", exe, manager)
+			}
+
+			release, err := latestRelease(channel)
+			if err != nil {
+				return err
+			}
+			if update.Compare(release.Version(), version) <= 0 && !force {
+				out.WriteSuccess(i18n.T("Already up to date: %s", version))
+				return nil
+			}
+
+			if dryRun {
+				if flags.asJSON {
+					return out.JSON(map[string]interface{}{
+						"dry_run": true,
+						"version": version,
+						"latest":  release.Version(),
+						"path":    exe,
+					})
+				}
+				out.Printf("%s\n", i18n.T("Would update %s from %s to %s", exe, version, release.Version()))
+				return nil
+			}
+
+			source := releaseSource()
+			binary, err := source.Download(release, runtime.GOOS, runtime.GOARCH)
+			if err != nil {
+				return err
+			}
+			if err := update.Replace(exe, binary); err != nil {
+				return fmt.Errorf("failed to replace %s: %w", exe, err)
+			}
+
+			if flags.asJSON {
+				return out.JSON(map[string]interface{}{
+					"previous": version,
+					"version":  release.Version(),
+					"path":     exe,
+				})
+			}
+			out.WriteSuccess(i18n.T("Updated todoist %s -> %s", version, release.Version()))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&channel, "channel", "stable", "releases to consider: stable or prerelease")
+	cmd.Flags().BoolVar(&force, "force", false, "reinstall even when already up to date")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show what would be installed without downloading it")
+
+	return cmd
+}
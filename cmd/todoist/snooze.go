@@ -0,0 +1,196 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+// defaultSnoozeLabel marks snoozed tasks unless "snooze_label" is configured
+const defaultSnoozeLabel = "snoozed"
+
+func snoozeLabel() string {
+	if l := strings.TrimPrefix(config.Settings().SnoozeLabel, "@"); l != "" {
+		return l
+	}
+	return defaultSnoozeLabel
+}
+
+func newSnoozeCmd(flags *rootFlags) *cobra.Command {
+	var until string
+
+	cmd := &cobra.Command{
+		Use:   "snooze <task-id...>",
+		Short: "Put tasks aside until later",
+		Long: `Snooze tasks: label them @snoozed and either move them to a later date
+with --until, or remove their due date so they wait until you wake them.
+
+List snoozed tasks with 'todoist snoozed'. Change the label with
+"snooze_label" in ~/.todoist-cli/config.json.
+
+Recurring tasks cannot be snoozed, since that would replace their schedule.
+
+Examples:
+  todoist snooze 123 --until "next monday"
+  todoist snooze 123 456                     # someday/waiting, no date
+  todoist snoozed --wake                     # wake tasks whose date arrived`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+			label := snoozeLabel()
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			for _, id := range args {
+				task, err := client.GetTask(id)
+				if err != nil {
+					return err
+				}
+				if task.Due != nil && task.Due.IsRecurring {
+					return fmt.Errorf("task %s is recurring; use postpone to move one occurrence", id)
+				}
+
+				params := api.UpdateTaskParams{
+					DueString: until,
+					Labels:    addLabel(task.Labels, label),
+				}
+				if until == "" {
+					params.DueString = "no date"
+				}
+				task, err = client.UpdateTask(id, params)
+				if err != nil {
+					return err
+				}
+
+				if len(args) == 1 {
+					return out.WriteTask(task)
+				}
+				if !flags.asJSON && !flags.quiet {
+					fmt.Fprintln(stderr, i18n.T("Snoozed: %s", task.Content))
+				}
+			}
+
+			out.WriteSuccess(i18n.T("Snoozed %d task(s)", len(args)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&until, "until", "u", "", "snooze until this date (e.g. 'next monday'); default removes the due date")
+
+	return cmd
+}
+
+func newSnoozedCmd(flags *rootFlags) *cobra.Command {
+	var wake bool
+
+	cmd := &cobra.Command{
+		Use:   "snoozed [task-id...]",
+		Short: "List snoozed tasks, or wake them",
+		Long: `List tasks labelled @snoozed.
+
+With --wake, the label is removed from the given tasks, or without IDs from
+every snoozed task whose date has arrived, returning them to your lists.
+
+Examples:
+  todoist snoozed
+  todoist snoozed --wake
+  todoist snoozed --wake 123`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+			label := snoozeLabel()
+
+			if len(args) > 0 && !wake {
+				return fmt.Errorf("task IDs are only used with --wake")
+			}
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			tasks, err := client.GetTasks("", "@"+label)
+			if err != nil {
+				return err
+			}
+
+			if !wake {
+				if err := loadNames(client, out); err != nil {
+					return err
+				}
+				if err := loadPeople(client, out, tasks); err != nil {
+					return err
+				}
+				return out.WriteTasks(tasks)
+			}
+
+			// Wake the tasks named, or those whose snooze date has arrived
+			today := time.Now().Format("2006-01-02")
+			var due []api.Task
+			for _, t := range tasks {
+				if len(args) > 0 {
+					for _, id := range args {
+						if t.ID == id {
+							due = append(due, t)
+						}
+					}
+				} else if d := taskDueDate(t); d != "" && d <= today {
+					due = append(due, t)
+				}
+			}
+			if len(args) > 0 && len(due) < len(args) {
+				return fmt.Errorf("not all tasks given are snoozed")
+			}
+
+			var woken []api.Task
+			for _, t := range due {
+				task, err := client.SetTaskLabels(t.ID, removeLabel(t.Labels, label))
+				if err != nil {
+					return err
+				}
+				woken = append(woken, *task)
+				if !flags.asJSON && !flags.quiet {
+					fmt.Fprintln(stderr, i18n.T("Woke: %s", t.Content))
+				}
+			}
+
+			if flags.asJSON {
+				return out.JSON(woken)
+			}
+			out.WriteSuccess(i18n.T("Woke %d task(s)", len(woken)))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&wake, "wake", "w", false, "remove the snooze label from tasks that are due, or from the given tasks")
+
+	return cmd
+}
+
+// addLabel returns labels with label added if missing
+func addLabel(labels []string, label string) []string {
+	for _, l := range labels {
+		if strings.EqualFold(l, label) {
+			return labels
+		}
+	}
+	return append(append([]string{}, labels...), label)
+}
+
+// removeLabel returns labels without label
+func removeLabel(labels []string, label string) []string {
+	result := []string{}
+	for _, l := range labels {
+		if !strings.EqualFold(l, label) {
+			result = append(result, l)
+		}
+	}
+	return result
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+func newStandupCmd(flags *rootFlags) *cobra.Command {
+	var project string
+
+	cmd := &cobra.Command{
+		Use:   "standup",
+		Short: "Print a yesterday/today report for a daily standup",
+		Long: `Print what you completed on the previous working day and what is due
+today, as a markdown list ready to paste into chat.
+
+On Mondays the previous working day is Friday.
+
+Examples:
+  todoist standup
+  todoist standup -p Work
+  todoist standup | pbcopy`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			var projectID string
+			if project != "" {
+				p, err := client.FindProject(project)
+				if err != nil {
+					return err
+				}
+				projectID = p.ID
+			}
+
+			today := startOfDay(time.Now())
+			previous := previousWorkday(today)
+
+			// The completed tasks endpoint takes UTC times
+			const apiTime = "2006-01-02T15:04"
+			done, err := fetchCompleted(client, projectID, previous.UTC().Format(apiTime), today.UTC().Format(apiTime), "", 0, true)
+			if err != nil {
+				return err
+			}
+
+			planned, err := client.GetTasks(projectID, "today | overdue")
+			if err != nil {
+				return err
+			}
+			(&api.TaskOrder{ByDay: true}).Sort(planned)
+			sortTasksBy(planned, []sortKey{{field: "priority", desc: true}})
+
+			if flags.asJSON {
+				return out.JSON(map[string]interface{}{
+					"since":     previous.Format("2006-01-02"),
+					"completed": done.Items,
+					"planned":   planned,
+				})
+			}
+
+			day := "Yesterday"
+			if !previous.AddDate(0, 0, 1).Equal(today) {
+				day = previous.Format("Monday")
+			}
+
+			out.Printf("%s I completed:\n", day)
+			if len(done.Items) == 0 {
+				out.Printf("- (nothing)\n")
+			}
+			for _, t := range done.Items {
+				out.Printf("- %s\n", t.Content)
+			}
+
+			out.Printf("\nToday I plan to:\n")
+			if len(planned) == 0 {
+				out.Printf("- (nothing scheduled)\n")
+			}
+			for _, t := range planned {
+				out.Printf("- %s%s\n", t.Content, standupNote(t, today))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&project, "project", "p", "", "limit the report to a project")
+
+	return cmd
+}
+
+// startOfDay returns local midnight on t's day
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// previousWorkday returns the start of the working day before day, skipping
+// weekends
+func previousWorkday(day time.Time) time.Time {
+	prev := day.AddDate(0, 0, -1)
+	for prev.Weekday() == time.Saturday || prev.Weekday() == time.Sunday {
+		prev = prev.AddDate(0, 0, -1)
+	}
+	return prev
+}
+
+// standupNote marks overdue tasks in the plan
+func standupNote(t api.Task, today time.Time) string {
+	if t.Due == nil || len(t.Due.Date) < 10 {
+		return ""
+	}
+	if t.Due.Date[:10] < today.Format("2006-01-02") {
+		return " (overdue)"
+	}
+	return ""
+}
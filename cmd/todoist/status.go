@@ -0,0 +1,247 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/cache"
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// agendaFreshAge is how long cached due tasks are shown without
+	// starting a background refresh
+	agendaFreshAge = time.Minute
+	// agendaMaxAge is the oldest cache entry still shown at all
+	agendaMaxAge = 24 * time.Hour
+	// refreshLockAge keeps concurrent prompts from starting several
+	// background refreshes at once
+	refreshLockAge = 30 * time.Second
+)
+
+// agenda is the cached list of tasks due today or overdue, read by the
+// status and widget commands
+type agenda struct {
+	FetchedAt time.Time    `json:"fetched_at"`
+	Tasks     []agendaTask `json:"tasks"`
+}
+
+// agendaTask keeps only what status lines show
+type agendaTask struct {
+	ID       string   `json:"id"`
+	Content  string   `json:"content"`
+	Priority int      `json:"priority"`
+	Due      *api.Due `json:"due"`
+}
+
+func agendaCacheKey() string {
+	return config.Settings().ProfileName() + "-agenda"
+}
+
+// loadAgenda returns the cached agenda, starting a background refresh when
+// it is stale. Only when there is no usable cache is the API asked directly.
+func loadAgenda(flags *rootFlags) (*agenda, error) {
+	var a agenda
+	if cache.Load(agendaCacheKey(), agendaMaxAge, &a) {
+		if time.Since(a.FetchedAt) > agendaFreshAge {
+			refreshInBackground(flags)
+		}
+		return &a, nil
+	}
+	return refreshAgenda(flags)
+}
+
+// refreshAgenda fetches today's and overdue tasks and caches them
+func refreshAgenda(flags *rootFlags) (*agenda, error) {
+	client, err := getClientWithFlags(flags)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := client.GetTasks("", "today | overdue")
+	if err != nil {
+		return nil, err
+	}
+
+	a := &agenda{FetchedAt: time.Now(), Tasks: make([]agendaTask, len(tasks))}
+	for i, t := range tasks {
+		a.Tasks[i] = agendaTask{ID: t.ID, Content: t.Content, Priority: t.Priority, Due: t.Due}
+	}
+	if err := cache.Save(agendaCacheKey(), a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// refreshInBackground re-runs this binary with status --refresh, detached,
+// unless another refresh started recently
+func refreshInBackground(flags *rootFlags) {
+	lock := filepath.Join(cache.Dir(), agendaCacheKey()+".lock")
+	if info, err := os.Stat(lock); err == nil && time.Since(info.ModTime()) < refreshLockAge {
+		return
+	}
+	if err := os.WriteFile(lock, nil, 0600); err != nil {
+		return
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return
+	}
+	args := []string{"status", "--refresh"}
+	if flags.profile != "" {
+		args = append(args, "--profile", flags.profile)
+	}
+	cmd := exec.Command(self, args...)
+	if cmd.Start() == nil {
+		cmd.Process.Release()
+	}
+}
+
+// counts returns how many cached tasks are due today and overdue as of now
+func (a *agenda) counts(now time.Time) (today, overdue int) {
+	day := now.Format("2006-01-02")
+	for _, t := range a.Tasks {
+		switch due := agendaDate(t); {
+		case due == "":
+		case due < day:
+			overdue++
+		case due == day:
+			today++
+		}
+	}
+	return today, overdue
+}
+
+// next returns the most pressing task: the earliest due, then the highest
+// priority. It returns nil when nothing is due.
+func (a *agenda) next(now time.Time) *agendaTask {
+	day := now.Format("2006-01-02")
+	var due []agendaTask
+	for _, t := range a.Tasks {
+		if d := agendaDate(t); d != "" && d <= day {
+			due = append(due, t)
+		}
+	}
+	if len(due) == 0 {
+		return nil
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		if ki, kj := agendaSortKey(due[i]), agendaSortKey(due[j]); ki != kj {
+			return ki < kj
+		}
+		return due[i].Priority > due[j].Priority
+	})
+	return &due[0]
+}
+
+func agendaDate(t agendaTask) string {
+	if t.Due == nil || len(t.Due.Date) < 10 {
+		return ""
+	}
+	return t.Due.Date[:10]
+}
+
+// agendaSortKey orders by date, timed tasks before untimed ones that day
+func agendaSortKey(t agendaTask) string {
+	if t.Due.Datetime != "" {
+		return t.Due.Datetime
+	}
+	return agendaDate(t) + "T99"
+}
+
+// summary describes the counts, e.g. "3 due today, 1 overdue". It is empty
+// when nothing is due.
+func summary(today, overdue int) string {
+	var parts []string
+	if today > 0 {
+		parts = append(parts, fmt.Sprintf("%d due today", today))
+	}
+	if overdue > 0 {
+		parts = append(parts, fmt.Sprintf("%d overdue", overdue))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func newStatusCmd(flags *rootFlags) *cobra.Command {
+	var (
+		short   bool
+		refresh bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Summarize what is due, for shell prompts and status bars",
+		Long: `Summarize today's and overdue tasks from the local cache.
+
+Status reads cached data so it returns in milliseconds; when the cache is
+more than a minute old it is refreshed in the background for the next call.
+Only the very first call waits for the API.
+
+With --short a single line like "3 due today, 1 overdue" is printed, or
+nothing when no tasks are due.
+
+Examples:
+  todoist status
+  todoist status --short
+  PS1='$(todoist status --short) \$ '`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			if refresh {
+				_, err := refreshAgenda(flags)
+				os.Remove(filepath.Join(cache.Dir(), agendaCacheKey()+".lock"))
+				return err
+			}
+
+			a, err := loadAgenda(flags)
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			today, overdue := a.counts(now)
+			next := a.next(now)
+
+			if flags.asJSON {
+				return out.JSON(map[string]interface{}{
+					"today":      today,
+					"overdue":    overdue,
+					"next":       next,
+					"fetched_at": a.FetchedAt.Format(time.RFC3339),
+				})
+			}
+
+			if short {
+				if s := summary(today, overdue); s != "" {
+					out.Printf("%s\n", s)
+				}
+				return nil
+			}
+
+			if s := summary(today, overdue); s != "" {
+				out.Printf("%s\n", s)
+			} else {
+				out.Printf("%s\n", i18n.T("Nothing due today"))
+			}
+			if next != nil {
+				out.Printf("%s\n", i18n.T("Next: %s", next.Content))
+			}
+			out.Printf("%s\n", i18n.T("Updated %s ago", time.Since(a.FetchedAt).Round(time.Second)))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&short, "short", "s", false, "print a single line, or nothing when no tasks are due")
+	cmd.Flags().BoolVar(&refresh, "refresh", false, "update the cache from the API and exit")
+	cmd.Flags().MarkHidden("refresh")
+
+	return cmd
+}
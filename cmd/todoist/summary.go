@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// taskCounts splits a number of tasks by when they are due
+type taskCounts struct {
+	Total    int `json:"total"`
+	Overdue  int `json:"overdue"`
+	Today    int `json:"today"`
+	Upcoming int `json:"upcoming"`
+	NoDate   int `json:"no_date"`
+}
+
+func (c *taskCounts) add(t api.Task, today string) {
+	c.Total++
+	switch due := dueDate(t); {
+	case due == "":
+		c.NoDate++
+	case due < today:
+		c.Overdue++
+	case due == today:
+		c.Today++
+	default:
+		c.Upcoming++
+	}
+}
+
+// projectCounts are the counts of one project
+type projectCounts struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	taskCounts
+}
+
+// taskSummary aggregates a task list by due date, priority and project
+type taskSummary struct {
+	taskCounts
+	Priorities map[string]int  `json:"priorities"` // "p1" (highest) to "p4"
+	Projects   []projectCounts `json:"projects"`   // busiest first
+}
+
+// summarizeTasks counts tasks as of today (YYYY-MM-DD), naming projects
+// from names
+func summarizeTasks(tasks []api.Task, today string, names map[string]string) taskSummary {
+	s := taskSummary{Priorities: map[string]int{"p1": 0, "p2": 0, "p3": 0, "p4": 0}}
+	projects := make(map[string]*projectCounts)
+	for _, t := range tasks {
+		s.add(t, today)
+		if t.Priority >= 1 && t.Priority <= 4 {
+			s.Priorities[fmt.Sprintf("p%d", 5-t.Priority)]++
+		}
+		p := projects[t.ProjectID]
+		if p == nil {
+			p = &projectCounts{ID: t.ProjectID, Name: names[t.ProjectID]}
+			if p.Name == "" {
+				p.Name = t.ProjectID
+			}
+			projects[t.ProjectID] = p
+		}
+		p.add(t, today)
+	}
+
+	s.Projects = make([]projectCounts, 0, len(projects))
+	for _, p := range projects {
+		s.Projects = append(s.Projects, *p)
+	}
+	sort.Slice(s.Projects, func(i, j int) bool {
+		a, b := s.Projects[i], s.Projects[j]
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+	return s
+}
+
+// line describes the counts, e.g. "12 tasks: 1 overdue, 3 due today, 8 later"
+func (c taskCounts) line() string {
+	noun := "tasks"
+	if c.Total == 1 {
+		noun = "task"
+	}
+	var parts []string
+	for _, p := range []struct {
+		n    int
+		text string
+	}{
+		{c.Overdue, "overdue"},
+		{c.Today, "due today"},
+		{c.Upcoming, "later"},
+		{c.NoDate, "without a date"},
+	} {
+		if p.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", p.n, p.text))
+		}
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("%d %s", c.Total, noun)
+	}
+	return fmt.Sprintf("%d %s: %s", c.Total, noun, strings.Join(parts, ", "))
+}
+
+// dueDate returns the day a task is due, or "" if it has no date
+func dueDate(t api.Task) string {
+	if t.Due == nil || len(t.Due.Date) < 10 {
+		return ""
+	}
+	return t.Due.Date[:10]
+}
+
+func newSummaryCmd(flags *rootFlags) *cobra.Command {
+	var (
+		project string
+		filter  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "summary",
+		Short: "Count active tasks by due date, priority and project",
+		Long: `Print how many active tasks there are instead of listing them: overdue,
+due today, due later and without a date, then per priority and per project.
+
+Use --json for scripts and status checks, and todoist tasks --count for a
+single number.
+
+Examples:
+  todoist summary
+  todoist summary -p Work
+  todoist summary --filter "@waiting"
+  todoist summary --json --fields overdue,today`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			var projectID string
+			if project != "" {
+				p, err := client.FindProject(project)
+				if err != nil {
+					return err
+				}
+				projectID = p.ID
+			}
+
+			tasks, err := client.GetTasks(projectID, filter)
+			if err != nil {
+				return err
+			}
+			projects, err := client.GetProjects()
+			if err != nil {
+				return err
+			}
+			names := make(map[string]string, len(projects))
+			for _, p := range projects {
+				names[p.ID] = p.Name
+			}
+
+			today := time.Now().In(output.Location()).Format("2006-01-02")
+			s := summarizeTasks(tasks, today, names)
+			if flags.asJSON {
+				return out.JSON(s)
+			}
+
+			out.Printf("%s\n", s.line())
+			if s.Total == 0 {
+				return nil
+			}
+
+			out.Printf("\nBy priority\n")
+			for _, p := range []string{"p1", "p2", "p3", "p4"} {
+				out.Printf("  %s  %4d\n", p, s.Priorities[p])
+			}
+
+			width := 0
+			for _, p := range s.Projects {
+				width = max(width, len([]rune(p.Name)))
+			}
+			out.Printf("\nBy project\n")
+			for _, p := range s.Projects {
+				line := fmt.Sprintf("  %-*s  %4d", width, p.Name, p.Total)
+				if p.Overdue > 0 || p.Today > 0 {
+					line += fmt.Sprintf("  (%s)", summary(p.Today, p.Overdue))
+				}
+				out.Printf("%s\n", line)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&project, "project", "p", "", "count only this project")
+	cmd.Flags().StringVarP(&filter, "filter", "f", "", "count only tasks matching this Todoist filter")
+
+	return cmd
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+)
+
+func TestSummarizeTasks(t *testing.T) {
+	due := func(date string) *api.Due { return &api.Due{Date: date} }
+	tasks := []api.Task{
+		{ProjectID: "1", Priority: 4, Due: due("2024-03-12")},
+		{ProjectID: "1", Priority: 1, Due: due("2024-03-13T09:00:00")},
+		{ProjectID: "2", Priority: 1, Due: due("2024-03-20")},
+		{ProjectID: "2", Priority: 2},
+		{ProjectID: "3", Priority: 1},
+		{ProjectID: "2", Priority: 3},
+	}
+
+	s := summarizeTasks(tasks, "2024-03-13", map[string]string{"1": "Work", "2": "Home"})
+	want := taskCounts{Total: 6, Overdue: 1, Today: 1, Upcoming: 1, NoDate: 3}
+	if s.taskCounts != want {
+		t.Errorf("got counts %+v, want %+v", s.taskCounts, want)
+	}
+	if s.Priorities["p1"] != 1 || s.Priorities["p2"] != 1 || s.Priorities["p3"] != 1 || s.Priorities["p4"] != 3 {
+		t.Errorf("unexpected priorities %v", s.Priorities)
+	}
+	if len(s.Projects) != 3 || s.Projects[0].Name != "Home" || s.Projects[1].Name != "Work" || s.Projects[2].Name != "3" {
+		t.Fatalf("expected projects busiest first, got %+v", s.Projects)
+	}
+	if s.Projects[1].Overdue != 1 || s.Projects[1].Today != 1 {
+		t.Errorf("unexpected Work counts %+v", s.Projects[1])
+	}
+	if got := s.line(); got != "6 tasks: 1 overdue, 1 due today, 1 later, 3 without a date" {
+		t.Errorf("unexpected line %q", got)
+	}
+}
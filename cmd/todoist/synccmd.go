@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// tempRef is an argument value standing for the temp ID of an earlier
+// command, numbered from 1: "$1", "$2", ...
+var tempRef = regexp.MustCompile(`^\$([1-9][0-9]*)$`)
+
+// syncCommandResult is what happened to one command of todoist sync-cmd
+type syncCommandResult struct {
+	Type   string          `json:"type"`
+	UUID   string          `json:"uuid"`
+	TempID string          `json:"temp_id,omitempty"`
+	ID     string          `json:"id,omitempty"` // the real ID of a created object
+	Status json.RawMessage `json:"status"`       // "ok" or an error object
+}
+
+// ok reports whether the command succeeded
+func (r syncCommandResult) ok() bool {
+	return string(r.Status) == `"ok"`
+}
+
+// resolveTempRefs replaces "$N" string values anywhere in args with the
+// temp ID of the Nth command
+func resolveTempRefs(args json.RawMessage, commands []api.SyncCommand) (json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(args))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	var walk func(v interface{}) (interface{}, error)
+	walk = func(v interface{}) (interface{}, error) {
+		switch v := v.(type) {
+		case string:
+			m := tempRef.FindStringSubmatch(v)
+			if m == nil {
+				return v, nil
+			}
+			n, _ := strconv.Atoi(m[1])
+			if n > len(commands) || commands[n-1].TempID == "" {
+				return nil, fmt.Errorf("%s does not refer to an earlier command that adds an object", v)
+			}
+			return commands[n-1].TempID, nil
+		case map[string]interface{}:
+			for k, e := range v {
+				r, err := walk(e)
+				if err != nil {
+					return nil, err
+				}
+				v[k] = r
+			}
+		case []interface{}:
+			for i, e := range v {
+				r, err := walk(e)
+				if err != nil {
+					return nil, err
+				}
+				v[i] = r
+			}
+		}
+		return v, nil
+	}
+	v, err := walk(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// syncCommandError describes a failed command's status, e.g.
+// `{"error_code":20,"error":"Project not found"}`
+func syncCommandError(status json.RawMessage) string {
+	var e struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(status, &e) == nil && e.Error != "" {
+		return e.Error
+	}
+	return string(status)
+}
+
+func newSyncCmdCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync-cmd <type> <args> [<type> <args>]...",
+		Short: "Send raw Sync API commands",
+		Long: `Send any Sync API write command, with its arguments as a JSON object, and
+print each command's sync_status. Arguments can also be read from a file
+with @file.json, or from standard input with @-.
+
+Several commands go in one request, in order. Each gets a UUID, and
+commands that add an object (types ending in _add) get a temp ID; a
+string argument "$N" in a later command stands for the temp ID of the Nth
+command. The real IDs of created objects are printed.
+
+The command fails when any of the commands does.
+
+Examples:
+  todoist sync-cmd item_update '{"id":"6X7rM8997g3RQmvh","priority":4}'
+  todoist sync-cmd project_add '{"name":"Trip"}' section_add '{"name":"Bookings","project_id":"$1"}'
+  todoist sync-cmd item_add @task.json --json`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 || len(args)%2 != 0 {
+				return fmt.Errorf("give each command's type followed by its arguments as JSON")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			var commands []api.SyncCommand
+			for i := 0; i < len(args); i += 2 {
+				cmdArgs, err := readJSONArg(args[i+1])
+				if err != nil {
+					return err
+				}
+				if cmdArgs, err = resolveTempRefs(cmdArgs, commands); err != nil {
+					return fmt.Errorf("%s: %w", args[i], err)
+				}
+				commands = append(commands, api.NewSyncCommand(args[i], cmdArgs))
+			}
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+			result, err := client.SyncCommands(commands)
+			if err != nil {
+				return err
+			}
+
+			results := make([]syncCommandResult, len(commands))
+			failed := 0
+			for i, c := range commands {
+				results[i] = syncCommandResult{Type: c.Type, UUID: c.UUID, TempID: c.TempID, ID: result.TempIDMapping[c.TempID], Status: result.SyncStatus[c.UUID]}
+				if results[i].Status == nil {
+					results[i].Status = json.RawMessage(`null`)
+				}
+				if !results[i].ok() {
+					failed++
+				}
+			}
+
+			if flags.asJSON {
+				if err := out.JSON(map[string]interface{}{
+					"commands":        results,
+					"sync_status":     result.SyncStatus,
+					"temp_id_mapping": result.TempIDMapping,
+				}); err != nil {
+					return err
+				}
+			} else {
+				for i, r := range results {
+					switch {
+					case !r.ok():
+						out.Printf("%d %s: %s\n", i+1, r.Type, syncCommandError(r.Status))
+					case flags.quiet && r.ID != "":
+						out.Printf("%s\n", r.ID)
+					case flags.quiet:
+					case r.ID != "":
+						out.Printf("%d %s: ok (id %s)\n", i+1, r.Type, r.ID)
+					default:
+						out.Printf("%d %s: ok\n", i+1, r.Type)
+					}
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d of %d commands failed", failed, len(commands))
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+)
+
+func TestResolveTempRefs(t *testing.T) {
+	commands := []api.SyncCommand{
+		{Type: "project_add", TempID: "tmp-a"},
+		{Type: "item_update"},
+	}
+
+	got, err := resolveTempRefs([]byte(`{"project_id":"$1","order":12345678901234567,"labels":["$1","$x"]}`), commands)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"labels":["tmp-a","$x"],"order":12345678901234567,"project_id":"tmp-a"}`; string(got) != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	for _, args := range []string{`{"id":"$2"}`, `{"id":"$3"}`} {
+		if _, err := resolveTempRefs([]byte(args), commands); err == nil {
+			t.Errorf("%s: expected an error", args)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func newTaskCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "task",
+		Short: "Rearrange subtasks",
+		Long: `Rearrange the subtask tree.
+
+Examples:
+  todoist task promote 456              # Move up one level
+  todoist task demote 456 --under 123   # Nest under another task
+  todoist task adopt 123 456 789        # Nest several tasks under 123`,
+	}
+
+	cmd.AddCommand(newTaskPromoteCmd(flags))
+	cmd.AddCommand(newTaskDemoteCmd(flags))
+	cmd.AddCommand(newTaskAdoptCmd(flags))
+
+	return cmd
+}
+
+func newTaskPromoteCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "promote <task-id>",
+		Short: "Move a subtask up one level",
+		Long: `Move a subtask up one level: under its grandparent, or to the top
+level of its section or project when its parent is a top-level task.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			before, err := client.GetTask(args[0])
+			if err != nil {
+				return err
+			}
+			if before.ParentID == "" {
+				return fmt.Errorf("task %s is not a subtask", before.ID)
+			}
+
+			parent, err := client.GetTask(before.ParentID)
+			if err != nil {
+				return err
+			}
+			names := map[string]string{parent.ID: parent.Content}
+
+			switch {
+			case parent.ParentID != "":
+				if grandparent, err := client.GetTask(parent.ParentID); err == nil {
+					names[grandparent.ID] = grandparent.Content
+				}
+				err = client.SetTaskParent(parent.ParentID, before.ID)
+			case before.SectionID != "":
+				err = client.MoveTask(before.ID, before.SectionID, "")
+			default:
+				err = client.MoveTask(before.ID, "", before.ProjectID)
+			}
+			if err != nil {
+				return err
+			}
+
+			after, err := client.GetTask(before.ID)
+			if err != nil {
+				return err
+			}
+
+			return out.WriteTaskChanges(after, output.DiffTasks(before, after, names))
+		},
+	}
+}
+
+func newTaskDemoteCmd(flags *rootFlags) *cobra.Command {
+	var under string
+
+	cmd := &cobra.Command{
+		Use:   "demote <task-id> --under <parent-id>",
+		Short: "Nest a task under another task",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAdopt(flags, under, args)
+		},
+	}
+
+	cmd.Flags().StringVar(&under, "under", "", "parent task ID (required)")
+	cmd.MarkFlagRequired("under")
+
+	return cmd
+}
+
+func newTaskAdoptCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "adopt <parent-id> <task-id...>",
+		Short: "Nest one or more tasks under a parent",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAdopt(flags, args[0], args[1:])
+		},
+	}
+}
+
+// runAdopt moves tasks under parentID and reports the change for each task
+func runAdopt(flags *rootFlags, parentID string, taskIDs []string) error {
+	out := newFormatter(flags)
+
+	client, err := getClientWithFlags(flags)
+	if err != nil {
+		return err
+	}
+
+	parent, err := client.GetTask(parentID)
+	if err != nil {
+		return err
+	}
+	names := map[string]string{parent.ID: parent.Content}
+
+	befores := make([]*api.Task, 0, len(taskIDs))
+	for _, id := range taskIDs {
+		if id == parent.ID {
+			return fmt.Errorf("cannot nest task %s under itself", id)
+		}
+		t, err := client.GetTask(id)
+		if err != nil {
+			return err
+		}
+		if t.ParentID != "" {
+			if _, ok := names[t.ParentID]; !ok {
+				if p, err := client.GetTask(t.ParentID); err == nil {
+					names[p.ID] = p.Content
+				}
+			}
+		}
+		befores = append(befores, t)
+	}
+
+	if len(taskIDs) > 1 {
+		tasks := make([]api.Task, len(befores))
+		for i, t := range befores {
+			tasks[i] = *t
+		}
+		if ok, err := confirmBulk(flags, i18n.T("Move"), tasks); err != nil || !ok {
+			if err == nil {
+				out.WriteSuccess(i18n.T("Cancelled"))
+			}
+			return err
+		}
+		profileBanner(flags, i18n.T("Moving %d tasks under %s", len(taskIDs), parent.Content))
+	}
+	if err := client.SetTaskParent(parent.ID, taskIDs...); err != nil {
+		return err
+	}
+
+	if len(befores) == 1 {
+		after, err := client.GetTask(befores[0].ID)
+		if err != nil {
+			return err
+		}
+		return out.WriteTaskChanges(after, output.DiffTasks(befores[0], after, names))
+	}
+
+	out.WriteSuccess(i18n.T("Moved %d tasks under: %s", len(taskIDs), parent.Content))
+	return nil
+}
@@ -3,16 +3,28 @@ package main
 import (
 	"context"
 	"fmt"
-	"os"
 	"sort"
 	"strings"
-	"sync"
+	"time"
 
 	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/output"
 	"github.com/spf13/cobra"
 	"golang.org/x/sync/errgroup"
 )
 
+// defaultTodayFilter is what the default task list shows
+const defaultTodayFilter = "today | overdue"
+
+// todayFilter returns today_filter from the config, or the default
+func todayFilter() string {
+	if f := config.Settings().TodayFilter; f != "" {
+		return f
+	}
+	return defaultTodayFilter
+}
+
 func newTasksCmd(flags *rootFlags) *cobra.Command {
 	var (
 		today   bool
@@ -22,6 +34,19 @@ func newTasksCmd(flags *rootFlags) *cobra.Command {
 		all     bool
 		details bool
 		sortBy  string
+		fzf     bool
+		table   bool
+		columns string
+		mine    bool
+		person  string
+		count   bool
+		pages   paging
+
+		labels       []string
+		priority     string
+		due          string
+		noDate       bool
+		createdSince string
 	)
 
 	cmd := &cobra.Command{
@@ -30,6 +55,12 @@ func newTasksCmd(flags *rootFlags) *cobra.Command {
 		Short:   "List tasks",
 		Long: `List tasks with optional filters.
 
+--label, --priority, --due, --no-date and --created-since combine with each
+other and with -p, --filter, --today, --overdue and --all. They are sent to
+the server as part of the Todoist filter; if the server rejects it, the
+tasks are fetched without them and matched locally. Like -p, they list all
+matching tasks rather than just today's unless --today is given.
+
 Examples:
   todoist tasks              # Today's tasks (default)
   todoist tasks --all        # All active tasks
@@ -37,46 +68,127 @@ Examples:
   todoist tasks --filter "overdue"  # Overdue tasks
   todoist tasks -p Work      # Tasks in Work project
   todoist tasks --overdue    # Shortcut for overdue filter
-  todoist tasks --sort priority     # Sort by priority`,
+  todoist tasks --label waiting     # Tasks labeled @waiting
+  todoist tasks -p Work --priority 1 --due week
+  todoist tasks --no-date --created-since -7d
+  todoist tasks --sort priority     # Sort by priority
+  todoist tasks --sort priority:desc,due:asc
+  todoist tasks --all --mine        # Tasks assigned to you
+  todoist tasks -p Team --assigned-to alice
+  todoist tasks --overdue --count   # Just the number of tasks
+  todoist tasks --all --sort priority --limit 5   # Top 5 by priority
+  todoist tasks --all --limit 20 --page 2
+  todoist tasks --columns id,due,content,project
+  todoist tasks --table=false       # One plain line per task
+  todoist tasks --fzf-format | fzf | cut -f1   # Pick with external fzf`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runTasks(cmd, flags, today, filter, project, details, sortBy)
+			criteria, err := taskCriteria(labels, priority, due, noDate, createdSince)
+			if err != nil {
+				return err
+			}
+			var projectID string
+			if project != "" {
+				client, err := getClientWithFlags(flags)
+				if err != nil {
+					return err
+				}
+				p, err := client.FindProject(project)
+				if err != nil {
+					return err
+				}
+				projectID = p.ID
+			}
+			return runTasks(cmd, flags, today, filter, projectID, details, sortBy, criteria, &pages)
 		},
 	}
 
-	cmd.Flags().BoolVarP(&today, "today", "t", true, "show today's tasks (including overdue)")
+	cmd.Flags().BoolVarP(&today, "today", "t", true, "show today's tasks (including overdue, or the config's today_filter)")
 	cmd.Flags().StringVarP(&filter, "filter", "f", "", "Todoist filter string")
 	cmd.Flags().StringVarP(&project, "project", "p", "", "filter by project name")
 	cmd.Flags().BoolVar(&overdue, "overdue", false, "show only overdue tasks")
 	cmd.Flags().BoolVarP(&all, "all", "a", false, "show all active tasks")
 	cmd.Flags().BoolVar(&details, "details", false, "show task descriptions and comments")
-	cmd.Flags().StringVar(&sortBy, "sort", "", "sort tasks: priority, due, name, created")
+	cmd.Flags().StringVar(&sortBy, "sort", "", "sort by fields with optional :asc or :desc, e.g. priority:desc,due (priority, due, name, created)")
+	cmd.Flags().BoolVar(&fzf, "fzf-format", false, "print ID<TAB>text lines for fzf and 'todoist pick'")
+	cmd.Flags().BoolVar(&table, "table", false, "show an aligned table (default when output is a terminal)")
+	cmd.Flags().StringVar(&columns, "columns", "", "table columns: "+strings.Join(output.TaskColumns, ","))
+	cmd.Flags().BoolVar(&mine, "mine", false, "show only tasks assigned to you")
+	cmd.Flags().StringVar(&person, "assigned-to", "", "show only tasks assigned to this collaborator (name or email)")
+	cmd.MarkFlagsMutuallyExclusive("mine", "assigned-to")
+	cmd.Flags().StringSliceVarP(&labels, "label", "l", nil, "show only tasks with this label (repeatable)")
+	cmd.Flags().StringVar(&priority, "priority", "", "show only tasks with this priority (1-4, 1 is highest)")
+	cmd.Flags().StringVar(&due, "due", "", "show only tasks due: today, week (the next 7 days) or none")
+	cmd.Flags().BoolVar(&noDate, "no-date", false, "show only tasks without a due date (same as --due none)")
+	cmd.Flags().StringVar(&createdSince, "created-since", "", "show only tasks created on or after this date (e.g. 2024-01-01, -7d)")
+	cmd.MarkFlagsMutuallyExclusive("due", "no-date")
+	cmd.Flags().BoolVarP(&count, "count", "c", false, "print only the number of matching tasks")
+	addPagingFlags(cmd, &pages)
 
 	return cmd
 }
 
-func runTasks(cmd *cobra.Command, flags *rootFlags, today bool, filter, project string, details bool, sortBy string) error {
+// taskCriteria builds the predicates of the tasks filter flags
+func taskCriteria(labels []string, priority, due string, noDate bool, createdSince string) (*searchCriteria, error) {
+	c := &searchCriteria{noDate: noDate}
+	for _, l := range labels {
+		c.labels = append(c.labels, strings.TrimPrefix(l, "@"))
+	}
+
+	var err error
+	if c.priority, err = flagPriority(priority); err != nil {
+		return nil, err
+	}
+	if c.createdOn, err = flagDay(createdSince); err != nil {
+		return nil, fmt.Errorf("invalid --created-since: %w", err)
+	}
+
+	now := time.Now()
+	day := func(days int) string { return now.AddDate(0, 0, days).Format("2006-01-02") }
+	switch strings.ToLower(due) {
+	case "":
+	case "today":
+		c.dueAfter, c.dueBefore = day(-1), day(1)
+	case "week":
+		c.dueAfter, c.dueBefore = day(-1), day(7)
+	case "none":
+		c.noDate = true
+	default:
+		return nil, fmt.Errorf("--due must be today, week or none, got %q", due)
+	}
+	return c, nil
+}
+
+func runTasks(cmd *cobra.Command, flags *rootFlags, today bool, filter, projectID string, details bool, sortBy string, criteria *searchCriteria, pages *paging) error {
 	out := newFormatter(flags)
+	if err := configureTable(cmd, out); err != nil {
+		return err
+	}
+	if _, _, err := pages.bounds(); err != nil {
+		return err
+	}
 
-	client, err := getClientWithFlags(flags)
+	// Without --sort the config's default_sort applies; --sort= keeps the
+	// order the apps use
+	keys, err := parseSort(sortBy)
 	if err != nil {
 		return err
 	}
-
-	// Determine project ID if project name given
-	var projectID string
-	if project != "" {
-		p, err := client.FindProject(project)
-		if err != nil {
-			return err
+	if !cmd.Flags().Changed("sort") {
+		if keys, err = parseSort(config.Settings().DefaultSort); err != nil {
+			return fmt.Errorf("invalid default_sort in config: %w", err)
 		}
-		projectID = p.ID
+	}
+
+	client, err := getClientWithFlags(flags)
+	if err != nil {
+		return err
 	}
 
 	// Build filter
 	if filter == "" {
-		// If project is specified and no explicit filter flags were set,
-		// default to all active tasks in that project.
-		if project != "" && cmd.Flag("today") != nil && !cmd.Flag("today").Changed &&
+		// If a project or filter flags are given and no explicit list flags
+		// were set, default to all active tasks that match.
+		if (projectID != "" || !criteria.empty()) && cmd.Flag("today") != nil && !cmd.Flag("today").Changed &&
 			cmd.Flag("overdue") != nil && !cmd.Flag("overdue").Changed &&
 			cmd.Flag("all") != nil && !cmd.Flag("all").Changed {
 			today = false
@@ -89,46 +201,109 @@ func runTasks(cmd *cobra.Command, flags *rootFlags, today bool, filter, project
 			// No filter - get all tasks
 			filter = ""
 		} else if today {
-			filter = "today | overdue"
+			filter = todayFilter()
 		}
 	}
 
-	tasks, err := client.GetTasks(projectID, filter)
+	mine := cmd.Flags().Changed("mine")
+	person, _ := cmd.Flags().GetString("assigned-to")
+	count, _ := cmd.Flags().GetBool("count")
+
+	// The filter flags narrow the list on the server
+	query := criteria.query("").Within(filter)
+
+	// JSON Lines are written as each page arrives unless the whole list is
+	// needed first
+	if flags.jsonl && keys == nil && !pages.active() && !details && !cmd.Flags().Changed("fzf-format") && !mine && person == "" && !count {
+		err := client.GetTasksPages(projectID, query, func(page []api.Task) error {
+			return out.JSON(page)
+		})
+		if criteria.empty() || api.ErrorCode(err) != api.CodeBadRequest {
+			return err
+		}
+		// Rejected before any page arrived: match locally below
+	}
+
+	tasks, err := client.GetTasks(projectID, query)
+	if !criteria.empty() && api.ErrorCode(err) == api.CodeBadRequest {
+		// Filter rejected: fetch the list without the flags and match locally
+		tasks, err = client.GetTasks(projectID, filter)
+		if err == nil {
+			var matches []api.Task
+			for _, t := range tasks {
+				if criteria.matches(t) {
+					matches = append(matches, t)
+				}
+			}
+			tasks = matches
+		}
+	}
 	if err != nil {
 		return err
 	}
 
-	// Apply client-side sort if requested
-	if sortBy != "" {
-		sortTasksBy(tasks, sortBy)
+	// Filter strings name people, not IDs, so assignment is checked here
+	if mine || person != "" {
+		userID, err := resolveAssignee(client, mine, person)
+		if err != nil {
+			return err
+		}
+		tasks = assignedTo(tasks, userID)
 	}
 
-	if !flags.asJSON && details {
-		if len(tasks) == 0 {
-			fmt.Fprintln(os.Stdout, "No tasks found.")
-			return nil
+	if count {
+		if flags.asJSON || flags.jsonl {
+			return out.JSON(map[string]int{"count": len(tasks)})
 		}
+		out.Printf("%d\n", len(tasks))
+		return nil
+	}
 
-		// Fetch comments concurrently (bounded to 5)
-		type taskComments struct {
-			comments []api.Comment
+	fzf := cmd.Flag("fzf-format")
+	if fzf == nil || !fzf.Changed {
+		if err := loadNames(client, out); err != nil {
+			return err
+		}
+		if err := loadPeople(client, out, tasks); err != nil {
+			return err
+		}
+	}
+
+	// Lists of what is due go in day order, like Today in the apps
+	order := out.TaskOrder()
+	order.ByDay = filter == defaultTodayFilter || filter == todayFilter() || filter == "overdue"
+	order.Sort(tasks)
+	if keys != nil {
+		sortTasksBy(tasks, keys)
+		out.SetTaskOrder(nil)
+	}
+	if tasks, err = pages.apply(tasks, !flags.asJSON && !flags.jsonl && !flags.quiet); err != nil {
+		return err
+	}
+	out.SetNextCursor(pages.cursor())
+
+	if fzf != nil && fzf.Changed {
+		for _, item := range taskItems(tasks) {
+			out.Printf("%s\t%s\n", item.ID, item.Label)
 		}
-		commentsMap := make(map[string]taskComments)
-		var mu sync.Mutex
+		return nil
+	}
 
+	if details {
+		// Fetch comments concurrently (bounded to 5)
+		detailed := make([]output.TaskWithComments, len(tasks))
 		g, ctx := errgroup.WithContext(context.Background())
 		g.SetLimit(5)
 
-		for _, t := range tasks {
-			t := t
+		for i, t := range tasks {
+			i, t := i, t
+			detailed[i].Task = t
 			g.Go(func() error {
 				comments, err := client.GetCommentsCtx(ctx, t.ID, "")
 				if err != nil {
 					return err
 				}
-				mu.Lock()
-				commentsMap[t.ID] = taskComments{comments: comments}
-				mu.Unlock()
+				detailed[i].Comments = comments
 				return nil
 			})
 		}
@@ -137,63 +312,152 @@ func runTasks(cmd *cobra.Command, flags *rootFlags, today bool, filter, project
 			return err
 		}
 
-		for i, t := range tasks {
-			fmt.Fprintln(os.Stdout, out.FormatTaskLine(&t))
-			if t.Description != "" {
-				fmt.Fprintf(os.Stdout, "    %s\n", out.Color().Wrap("\033[90m", t.Description))
-			}
+		var comments []api.Comment
+		for _, d := range detailed {
+			comments = append(comments, d.Comments...)
+		}
+		if err := loadAuthors(client, out, comments); err != nil {
+			return err
+		}
 
-			if tc, ok := commentsMap[t.ID]; ok && len(tc.comments) > 0 {
-				fmt.Fprintf(os.Stdout, "    Comments (%d):\n", len(tc.comments))
-				for _, c := range tc.comments {
-					date := c.PostedAt
-					if len(date) >= 10 {
-						date = date[:10]
-					}
-					fmt.Fprintf(os.Stdout, "      [%s] %s\n", date, c.Content)
-				}
-			}
+		return out.WriteTasksWithComments(detailed)
+	}
 
-			if i < len(tasks)-1 {
-				fmt.Fprintln(os.Stdout)
-			}
+	return out.WriteTasks(tasks)
+}
+
+// configureTable applies --table and --columns to a formatter. --columns
+// implies a table unless --table=false is given.
+func configureTable(cmd *cobra.Command, out *output.Formatter) error {
+	if f := cmd.Flag("columns"); f != nil && f.Changed {
+		columns, err := output.ParseColumns(f.Value.String())
+		if err != nil {
+			return err
 		}
+		out.SetTable(columns)
+	}
+	if f := cmd.Flag("table"); f != nil && f.Changed {
+		switch {
+		case f.Value.String() == "false":
+			out.SetTable(nil)
+		case out.TableColumns() == nil:
+			out.SetTable(output.DefaultTaskColumns)
+		}
+	}
+	return nil
+}
 
+// loadNames fetches the project and section names a task list shows, in
+// one batched request, along with their positions for ordering the list
+func loadNames(client *api.Client, out *output.Formatter) error {
+	if !out.NeedsNames() {
 		return nil
 	}
 
-	return out.WriteTasks(tasks)
+	projects, sections, err := client.GetProjectsAndSections()
+	if err != nil {
+		return err
+	}
+	names := make(map[string]string, len(projects)+len(sections))
+	for _, p := range projects {
+		names[p.ID] = p.Name
+	}
+	for _, s := range sections {
+		names[s.ID] = s.Name
+	}
+	out.SetNames(names)
+	if o := out.TaskOrder(); o != nil {
+		o.SetPlaces(projects, sections)
+	}
+	return nil
 }
 
-// sortTasksBy sorts tasks by the given field.
-func sortTasksBy(tasks []api.Task, field string) {
-	sort.Slice(tasks, func(i, j int) bool {
-		switch field {
-		case "priority":
-			return tasks[i].Priority > tasks[j].Priority // higher priority first
-		case "due":
-			di := taskDueDate(tasks[i])
-			dj := taskDueDate(tasks[j])
-			if di == dj {
-				return tasks[i].ChildOrder < tasks[j].ChildOrder
-			}
-			if di == "" {
-				return false // no due date sorts last
-			}
-			if dj == "" {
-				return true
-			}
-			return di < dj
-		case "name":
-			return strings.ToLower(tasks[i].Content) < strings.ToLower(tasks[j].Content)
-		case "created":
-			return tasks[i].CreatedAt < tasks[j].CreatedAt
+// sortFields are the fields --sort accepts, with their default direction
+var sortFields = map[string]bool{ // field -> descending by default
+	"priority": true, // highest first
+	"due":      false,
+	"name":     false,
+	"created":  false,
+}
+
+// sortKey is one field of a --sort spec
+type sortKey struct {
+	field string
+	desc  bool
+}
+
+// parseSort parses a --sort spec: comma-separated fields, each optionally
+// followed by :asc or :desc, e.g. "priority:desc,due:asc"
+func parseSort(spec string) ([]sortKey, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+	var keys []sortKey
+	for _, part := range strings.Split(spec, ",") {
+		field, dir, _ := strings.Cut(strings.ToLower(strings.TrimSpace(part)), ":")
+		desc, ok := sortFields[field]
+		if !ok {
+			return nil, fmt.Errorf("unknown sort field %q (use priority, due, name or created)", field)
+		}
+		switch dir {
+		case "":
+		case "asc":
+			desc = false
+		case "desc":
+			desc = true
 		default:
-			return tasks[i].ChildOrder < tasks[j].ChildOrder
+			return nil, fmt.Errorf("unknown sort direction %q in %q (use asc or desc)", dir, part)
 		}
+		keys = append(keys, sortKey{field: field, desc: desc})
+	}
+	return keys, nil
+}
+
+// sortTasksBy sorts tasks by each key in turn. The sort is stable, so ties
+// keep the order the tasks are already in. Tasks without a due date sort
+// last by due in either direction.
+func sortTasksBy(tasks []api.Task, keys []sortKey) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		for _, k := range keys {
+			if c := compareTasks(tasks[i], tasks[j], k.field); c != 0 {
+				if k.field == "due" && (taskDueDate(tasks[i]) == "" || taskDueDate(tasks[j]) == "") {
+					return c < 0
+				}
+				if k.desc {
+					return c > 0
+				}
+				return c < 0
+			}
+		}
+		return false
 	})
 }
 
+// compareTasks compares a and b by field, ascending, returning -1, 0 or 1.
+// A missing due date compares after any date.
+func compareTasks(a, b api.Task, field string) int {
+	switch field {
+	case "priority":
+		return a.Priority - b.Priority
+	case "due":
+		da, db := taskDueDate(a), taskDueDate(b)
+		switch {
+		case da == db:
+			return 0
+		case da == "":
+			return 1
+		case db == "":
+			return -1
+		}
+		return strings.Compare(da, db)
+	case "name":
+		return strings.Compare(strings.ToLower(a.Content), strings.ToLower(b.Content))
+	case "created":
+		return strings.Compare(a.CreatedAt, b.CreatedAt)
+	}
+	return 0
+}
+
 func taskDueDate(t api.Task) string {
 	if t.Due == nil {
 		return ""
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+)
+
+func TestParseSort(t *testing.T) {
+	keys, err := parseSort("priority, due:desc,Name:ASC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []sortKey{{"priority", true}, {"due", true}, {"name", false}}
+	if len(keys) != len(want) {
+		t.Fatalf("got %+v, want %+v", keys, want)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Errorf("key %d: got %+v, want %+v", i, keys[i], want[i])
+		}
+	}
+
+	for _, bad := range []string{"size", "due:up", "priority,"} {
+		if _, err := parseSort(bad); err == nil {
+			t.Errorf("parseSort(%q): expected an error", bad)
+		}
+	}
+	if keys, err := parseSort(""); err != nil || keys != nil {
+		t.Errorf("empty spec should give no keys, got %+v, %v", keys, err)
+	}
+}
+
+func TestSortTasksBy(t *testing.T) {
+	due := func(date string) *api.Due { return &api.Due{Date: date} }
+	tasks := []api.Task{
+		{Content: "a", Priority: 1, Due: due("2024-03-02")},
+		{Content: "b", Priority: 4},
+		{Content: "c", Priority: 4, Due: due("2024-03-01")},
+		{Content: "d", Priority: 1, Due: due("2024-03-03")},
+		{Content: "e", Priority: 1},
+	}
+	order := func() string {
+		var b strings.Builder
+		for _, t := range tasks {
+			b.WriteString(t.Content)
+		}
+		return b.String()
+	}
+
+	keys, _ := parseSort("priority:desc,due:asc")
+	sortTasksBy(tasks, keys)
+	if got := order(); got != "cbade" {
+		t.Errorf("priority:desc,due:asc gave %s", got)
+	}
+
+	// Undated tasks stay last when due is descending
+	keys, _ = parseSort("due:desc")
+	sortTasksBy(tasks, keys)
+	if got := order(); got != "dacbe" {
+		t.Errorf("due:desc gave %s", got)
+	}
+}
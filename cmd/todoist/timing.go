@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+)
+
+const defaultSlowThreshold = 3 * time.Second
+
+// clients holds every API client created during this invocation so their
+// request stats can be reported once the command finishes.
+var clients []*api.Client
+
+// trackClient registers a client for the slow-command report
+func trackClient(c *api.Client) *api.Client {
+	clients = append(clients, c)
+	return c
+}
+
+// requestCount returns the number of API requests made so far
+func requestCount() int {
+	n := 0
+	for _, c := range clients {
+		n += c.Stats().Requests()
+	}
+	return n
+}
+
+// slowThreshold returns the configured slow-command threshold. A value of
+// "0" or "off" in the config disables the warning.
+func slowThreshold() time.Duration {
+	s := strings.TrimSpace(config.Settings().SlowThreshold)
+	switch s {
+	case "":
+		return defaultSlowThreshold
+	case "off", "0":
+		return 0
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return defaultSlowThreshold
+	}
+	return d
+}
+
+// reportSlow prints a hint to w when a command took longer than threshold,
+// naming the endpoint that consumed the most time.
+func reportSlow(w io.Writer, elapsed, threshold time.Duration) {
+	if threshold <= 0 || elapsed < threshold {
+		return
+	}
+
+	var (
+		requests, retries int
+		endpoints         []api.EndpointStats
+	)
+	for _, c := range clients {
+		s := c.Stats()
+		requests += s.Requests()
+		retries += s.Retries()
+		endpoints = append(endpoints, s.Endpoints()...)
+	}
+
+	msg := i18n.T("Slow command: took %s", elapsed.Round(100*time.Millisecond))
+	if requests > 0 {
+		msg = i18n.T("Slow command: took %s across %d API request(s)", elapsed.Round(100*time.Millisecond), requests)
+	}
+
+	if len(endpoints) > 0 {
+		slowest := endpoints[0]
+		for _, e := range endpoints[1:] {
+			if e.Elapsed > slowest.Elapsed {
+				slowest = e
+			}
+		}
+		msg += fmt.Sprintf("; %s", describeEndpoint(slowest))
+		if tip := endpointTip(slowest.Endpoint); tip != "" {
+			msg += " — " + tip
+		}
+	}
+	if retries > 0 {
+		msg += " " + i18n.T("(rate limited %d time(s))", retries)
+	}
+
+	fmt.Fprintln(w, msg)
+}
+
+// describeEndpoint renders an endpoint's totals, e.g. "fetching 14 pages of tasks (2.9s)"
+func describeEndpoint(e api.EndpointStats) string {
+	method, resource, _ := strings.Cut(e.Endpoint, " ")
+	elapsed := e.Elapsed.Round(100 * time.Millisecond)
+	if method == "GET" {
+		if e.Calls == 1 {
+			return i18n.T("fetching %s took %s", resource, elapsed)
+		}
+		return i18n.T("fetching %d pages of %s took %s", e.Calls, resource, elapsed)
+	}
+	return i18n.T("%d %s call(s) took %s", e.Calls, e.Endpoint, elapsed)
+}
+
+// endpointTip suggests how to avoid the slow call
+func endpointTip(endpoint string) string {
+	switch endpoint {
+	case "GET tasks":
+		return i18n.T("consider --filter or --project to fetch fewer tasks")
+	case "GET comments":
+		return i18n.T("comments are fetched per task; drop --details for faster listings")
+	}
+	return ""
+}
@@ -1,21 +1,34 @@
 package main
 
 import (
-	"os"
+	"fmt"
 	"strings"
 
 	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/dates"
+	"github.com/buddyh/todoist-cli/internal/hooks"
+	"github.com/buddyh/todoist-cli/internal/i18n"
 	"github.com/buddyh/todoist-cli/internal/output"
 	"github.com/spf13/cobra"
 )
 
 func newUpdateCmd(flags *rootFlags) *cobra.Command {
 	var (
-		content     string
-		description string
-		due         string
-		priority    int
-		labels      []string
+		content         string
+		description     string
+		descriptionFile string
+		due             string
+		dueTime         string
+		clearDueTime    bool
+		noDue           bool
+		noDescription   bool
+		unassign        bool
+		noSection       bool
+		tz              string
+		priority        int
+		labels          []string
+		addLabels       []string
+		removeLabels    []string
 	)
 
 	cmd := &cobra.Command{
@@ -27,14 +40,56 @@ func newUpdateCmd(flags *rootFlags) *cobra.Command {
 Examples:
   todoist update 123 --content "New title"
   todoist update 123 --due "tomorrow"
+  todoist update 123 --due-time 17:00       # Keep the date, change the time
+  todoist update 123 --clear-due-time
+  todoist update 123 --tz America/New_York  # Fix the due time to a timezone
+  todoist update 123 --no-due --no-section  # Remove the date, leave the section
   todoist update 123 -P 1
-  todoist update 123 --labels "urgent,important"`,
+  todoist update 123 --labels "urgent,important"
+  todoist update 123 --add-label urgent --remove-label someday
+  todoist update 123 --description-file notes.md`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			out := output.NewFormatter(os.Stdout, flags.asJSON)
+			out := newFormatter(flags)
 			taskID := args[0]
+			if (dueTime != "" || clearDueTime) && due != "" {
+				return fmt.Errorf("--due cannot be combined with --due-time or --clear-due-time")
+			}
+			if dueTime != "" && clearDueTime {
+				return fmt.Errorf("--due-time and --clear-due-time are mutually exclusive")
+			}
+			if noDue && (due != "" || dueTime != "" || clearDueTime) {
+				return fmt.Errorf("--no-due cannot be combined with other due options")
+			}
+			if noDescription && (description != "" || descriptionFile != "") {
+				return fmt.Errorf("--no-description cannot be combined with --description")
+			}
+			if tz != "" && (noDue || clearDueTime) {
+				return fmt.Errorf("--tz needs a due time")
+			}
+			if err := validateTimezone(tz); err != nil {
+				return err
+			}
+			hour, minute := -1, 0
+			if dueTime != "" {
+				h, m, err := dates.TimeOfDay(dueTime)
+				if err != nil {
+					return err
+				}
+				hour, minute = h, m
+			}
+			description, err := readDescription(description, descriptionFile)
+			if err != nil {
+				return err
+			}
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
 
-			client, err := getClient()
+			// Capture the current state to report what changed
+			before, err := client.GetTask(taskID)
 			if err != nil {
 				return err
 			}
@@ -45,6 +100,17 @@ Examples:
 				DueString:   due,
 			}
 
+			// Rewrite only the time of day, keeping the date and recurrence
+			if dueTime != "" || clearDueTime {
+				dueParams, err := dueTimeUpdate(before.Due, hour, minute)
+				if err != nil {
+					return err
+				}
+				params.DueString = dueParams.DueString
+				params.DueDate = dueParams.DueDate
+				params.DueDatetime = dueParams.DueDatetime
+			}
+
 			// Convert priority
 			if priority > 0 {
 				params.Priority = 5 - priority
@@ -55,20 +121,77 @@ Examples:
 				params.Labels = labels
 			}
 
+			// Apply label additions/removals on top of --labels or the current set
+			clearLabels := false
+			if len(addLabels) > 0 || len(removeLabels) > 0 {
+				base := labels
+				if !cmd.Flags().Changed("labels") {
+					base = before.Labels
+				}
+				params.Labels = applyLabelDelta(base, addLabels, removeLabels)
+				clearLabels = len(params.Labels) == 0
+			}
+
+			profileBanner(flags, i18n.T("Updating %s", before.Content))
 			task, err := client.UpdateTask(taskID, params)
 			if err != nil {
 				return err
 			}
 
-			return out.WriteTask(task)
+			// An empty label list is dropped from UpdateTask's payload
+			if clearLabels {
+				task, err = client.SetTaskLabels(taskID, nil)
+				if err != nil {
+					return err
+				}
+			}
+
+			clearParams := api.ClearTaskParams{Due: noDue, Description: noDescription, Assignee: unassign}
+			if clearParams != (api.ClearTaskParams{}) {
+				task, err = client.ClearTaskFields(taskID, clearParams)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Moving to the project itself takes the task out of its section
+			if noSection && task.SectionID != "" {
+				if err := client.MoveTask(taskID, "", task.ProjectID); err != nil {
+					return err
+				}
+				task, err = client.GetTask(taskID)
+				if err != nil {
+					return err
+				}
+			}
+
+			if tz != "" {
+				if task, err = applyTimezone(client, task, tz); err != nil {
+					return err
+				}
+			}
+
+			hooks.RunPost(stderr, hooks.PostUpdate, task)
+
+			return out.WriteTaskChanges(task, output.DiffTasks(before, task, nil))
 		},
 	}
 
 	cmd.Flags().StringVar(&content, "content", "", "new task content")
-	cmd.Flags().StringVar(&description, "description", "", "new description")
+	cmd.Flags().StringVar(&description, "description", "", "new description (- reads stdin)")
+	cmd.Flags().StringVar(&descriptionFile, "description-file", "", "read the new description from a file (- for stdin)")
 	cmd.Flags().StringVarP(&due, "due", "d", "", "new due date")
+	cmd.Flags().StringVar(&dueTime, "due-time", "", "change only the time of day of the due date (e.g. 17:00, 5pm)")
+	cmd.Flags().StringVar(&tz, "tz", "", "fix the due time to this timezone, e.g. Europe/Berlin")
+	cmd.Flags().BoolVar(&clearDueTime, "clear-due-time", false, "remove the time of day, keeping the due date")
+	cmd.Flags().BoolVar(&noDue, "no-due", false, "remove the due date")
 	cmd.Flags().IntVarP(&priority, "priority", "P", 0, "new priority 1-4")
 	cmd.Flags().StringSliceVarP(&labels, "labels", "l", nil, "replace labels (comma-separated)")
+	cmd.Flags().StringArrayVar(&addLabels, "add-label", nil, "add a label, keeping existing ones (can be repeated)")
+	cmd.Flags().StringArrayVar(&removeLabels, "remove-label", nil, "remove a label (can be repeated)")
+	cmd.Flags().BoolVar(&noDescription, "no-description", false, "remove the description")
+	cmd.Flags().BoolVar(&unassign, "unassign", false, "remove the assignee")
+	cmd.Flags().BoolVar(&noSection, "no-section", false, "move the task out of its section, keeping the project")
 
 	return cmd
 }
@@ -79,10 +202,10 @@ func newReopenCmd(flags *rootFlags) *cobra.Command {
 		Short: "Reopen a completed task",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			out := output.NewFormatter(os.Stdout, flags.asJSON)
+			out := newFormatter(flags)
 			taskID := args[0]
 
-			client, err := getClient()
+			client, err := getClientWithFlags(flags)
 			if err != nil {
 				return err
 			}
@@ -91,7 +214,7 @@ func newReopenCmd(flags *rootFlags) *cobra.Command {
 				return err
 			}
 
-			out.WriteSuccess("Task reopened")
+			out.WriteSuccess(i18n.T("Task reopened"))
 			return nil
 		},
 	}
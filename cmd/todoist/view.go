@@ -1,22 +1,53 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"sync"
+	"time"
 
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/buddyh/todoist-cli/internal/output"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 )
 
 func newViewCmd(flags *rootFlags) *cobra.Command {
+	var history bool
+
 	cmd := &cobra.Command{
-		Use:     "view <task-id>",
+		Use:     "view [task-id]",
 		Aliases: []string{"show", "get"},
-		Short:   "View a single task in detail",
-		Args:    cobra.ExactArgs(1),
+		Short:   "View a single task in detail, or run saved views",
+		Long: `View a single task in detail.
+
+Without an ID, pick from today's tasks interactively. For a recurring task,
+--history lists when it was completed, newest first, and how many
+occurrences in a row have been done.
+
+Saved views are named command lines kept in the config file. Run one with
+'todoist view run <name>', or as 'todoist <name>' when no built-in command
+or alias has that name. Arguments after the name are appended.
+
+Examples:
+  todoist view 1234567890
+  todoist view 1234567890 --history
+  todoist view save kanban-work 'tasks -p Work --table --columns id,section,content'
+  todoist view run kanban-work
+  todoist kanban-work --json
+  todoist view list
+  todoist view remove kanban-work`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			out := newFormatter(flags)
-			taskID := args[0]
+			taskID, err := taskIDArg(flags, args)
+			if err != nil {
+				return err
+			}
 
-			client, err := getClient()
+			client, err := getClientWithFlags(flags)
 			if err != nil {
 				return err
 			}
@@ -26,52 +57,162 @@ func newViewCmd(flags *rootFlags) *cobra.Command {
 				return err
 			}
 
-			if flags.asJSON {
-				return out.JSON(task)
+			detail := &output.TaskDetail{Task: task, Path: taskBreadcrumb(client, task)}
+			if err := loadPeople(client, out, []api.Task{*task}); err != nil {
+				return err
 			}
 
-			// Detailed human output
-			fmt.Printf("ID:       %s\n", task.ID)
-			fmt.Printf("Content:  %s\n", task.Content)
-			if task.Description != "" {
-				fmt.Printf("Notes:    %s\n", task.Description)
+			// Include the subtask tree (with completed subtasks) and comments;
+			// failures only leave them out
+			if subtasks, err := loadSubtaskTree(client, task); err == nil {
+				detail.Subtasks = subtasks
 			}
-			if task.Due != nil {
-				dueStr := task.Due.String
-				if dueStr == "" {
-					dueStr = task.Due.Date
+			if comments, err := client.GetComments(taskID, ""); err == nil {
+				detail.Comments = comments
+				if err := loadAuthors(client, out, comments); err != nil {
+					return err
 				}
-				fmt.Printf("Due:      %s\n", dueStr)
-			}
-			if task.Priority > 1 {
-				fmt.Printf("Priority: p%d\n", 5-task.Priority)
 			}
-			if len(task.Labels) > 0 {
-				fmt.Printf("Labels:   @%s\n", joinLabels(task.Labels))
-			}
-			// Show comments if any
-			comments, err := client.GetComments(taskID, "")
-			if err == nil && len(comments) > 0 {
-				fmt.Printf("\nComments (%d):\n", len(comments))
-				for _, c := range comments {
-					fmt.Printf("  [%s] %s\n", c.PostedAt[:10], c.Content)
+
+			if history {
+				if task.Due == nil || !task.Due.IsRecurring {
+					if !flags.asJSON && !flags.quiet {
+						fmt.Fprintln(stderr, i18n.T("Note: not a recurring task; --history skipped"))
+					}
+				} else if detail.History, err = taskHistory(client, task); err != nil {
+					return err
 				}
 			}
 
-			return nil
+			return out.WriteTaskDetail(detail)
 		},
 	}
 
+	cmd.Flags().BoolVar(&history, "history", false, "list past completions of a recurring task")
+
+	cmd.AddCommand(newViewSaveCmd(flags))
+	cmd.AddCommand(newViewRunCmd(flags))
+	cmd.AddCommand(newViewListCmd(flags))
+	cmd.AddCommand(newViewRemoveCmd(flags))
+
 	return cmd
 }
 
-func joinLabels(labels []string) string {
-	if len(labels) == 0 {
-		return ""
+// taskBreadcrumb returns the project, section and parent task names above a
+// task, outermost first. Lookups that fail are left out.
+func taskBreadcrumb(client *api.Client, task *api.Task) []string {
+	var path []string
+
+	if p, err := client.GetProject(task.ProjectID); err == nil {
+		path = append(path, p.Name)
+	}
+	if task.SectionID != "" {
+		if sections, err := client.GetSections(task.ProjectID); err == nil {
+			for _, s := range sections {
+				if s.ID == task.SectionID {
+					path = append(path, s.Name)
+					break
+				}
+			}
+		}
+	}
+
+	// Walk up the parent chain; guard against cycles in malformed data
+	var parents []string
+	seen := map[string]bool{task.ID: true}
+	for id := task.ParentID; id != "" && !seen[id]; {
+		seen[id] = true
+		parent, err := client.GetTask(id)
+		if err != nil {
+			break
+		}
+		parents = append([]string{parent.Content}, parents...)
+		id = parent.ParentID
+	}
+
+	return append(path, parents...)
+}
+
+// loadSubtaskTree builds the subtask tree of a task from the active tasks in
+// its project, adding completed children of every node in the tree.
+func loadSubtaskTree(client *api.Client, task *api.Task) ([]*output.SubtaskNode, error) {
+	tasks, err := client.GetTasks(task.ProjectID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[string][]*output.SubtaskNode)
+	for i := range tasks {
+		t := &tasks[i]
+		if t.ParentID != "" {
+			children[t.ParentID] = append(children[t.ParentID], &output.SubtaskNode{ID: t.ID, Task: t, Content: t.Content})
+		}
+	}
+	for _, nodes := range children {
+		sort.Slice(nodes, func(i, j int) bool {
+			return nodes[i].Task.ChildOrder < nodes[j].Task.ChildOrder
+		})
+	}
+
+	// Link the active nodes reachable from the task
+	root := &output.SubtaskNode{ID: task.ID}
+	index := map[string]*output.SubtaskNode{task.ID: root}
+	var walk func(n *output.SubtaskNode)
+	walk = func(n *output.SubtaskNode) {
+		n.Children = children[n.ID]
+		for _, c := range n.Children {
+			index[c.ID] = c
+			walk(c)
+		}
+	}
+	walk(root)
+
+	// Fetch completed children concurrently (bounded to 5); failures only
+	// hide completed subtasks
+	var mu sync.Mutex
+	g, ctx := errgroup.WithContext(context.Background())
+	g.SetLimit(5)
+	for id, node := range index {
+		id, node := id, node
+		g.Go(func() error {
+			done, err := client.GetCompletedSubtasks(ctx, id)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, c := range done {
+				node.Children = append(node.Children, &output.SubtaskNode{ID: c.TaskKey(), Content: c.Content, Completed: true})
+			}
+			return nil
+		})
+	}
+	g.Wait()
+
+	return root.Children, nil
+}
+
+// taskHistory reads when a recurring task was completed from the activity
+// log, with its current streak
+func taskHistory(client *api.Client, task *api.Task) (*output.TaskHistory, error) {
+	events, err := client.GetTaskCompletions(task.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read completion history: %w", err)
+	}
+
+	h := &output.TaskHistory{Completed: make([]string, 0, len(events))}
+	var times []time.Time
+	for i := len(events) - 1; i >= 0; i-- {
+		h.Completed = append(h.Completed, events[i].EventDate)
+		if at, err := time.Parse(time.RFC3339, events[i].EventDate); err == nil {
+			times = append(times, at)
+		}
 	}
-	result := labels[0]
-	for _, l := range labels[1:] {
-		result += " @" + l
+	if every := recurrenceDays(task.Due.String); every > 0 {
+		now := time.Now().In(output.Location())
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		n := streak(times, every, today)
+		h.Streak = &n
 	}
-	return result
+	return h, nil
 }
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+// checkShortcutName rejects alias and view names that cannot be typed as a
+// command or would hide a built-in one
+func checkShortcutName(root *cobra.Command, kind, name string) error {
+	if name == "" || strings.HasPrefix(name, "-") || strings.ContainsAny(name, " \t") {
+		return fmt.Errorf("invalid %s name %q", kind, name)
+	}
+	if isBuiltin(root, name) {
+		return fmt.Errorf("%s is a built-in command and cannot be used as a %s name", name, kind)
+	}
+	return nil
+}
+
+func newViewSaveCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "save <name> <command>",
+		Short: "Save a command line as a named view",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+			name, command := args[0], args[1]
+
+			if err := checkShortcutName(cmd.Root(), "view", name); err != nil {
+				return err
+			}
+			words, err := splitArgs(command)
+			if err != nil {
+				return err
+			}
+			if len(words) == 0 {
+				return fmt.Errorf("view %s needs a command", name)
+			}
+			target, _, err := cmd.Root().Find(words)
+			if err != nil || target == cmd.Root() {
+				return fmt.Errorf("unknown command %q in view %s", words[0], name)
+			}
+			if target.Parent() == cmd.Parent() {
+				return fmt.Errorf("a view cannot run another view command")
+			}
+
+			cfg, err := config.LoadForUpdate()
+			if err != nil {
+				return err
+			}
+			if cfg.Views == nil {
+				cfg.Views = make(map[string]string)
+			}
+			cfg.Views[name] = command
+			if err := config.Save(cfg); err != nil {
+				return err
+			}
+
+			out.WriteSuccess(i18n.T("View %s = %s", name, command))
+			return nil
+		},
+	}
+}
+
+func newViewRunCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:                "run <name> [args...]",
+		Short:              "Run a saved view, with any extra arguments appended",
+		Args:               cobra.MinimumNArgs(1),
+		DisableFlagParsing: true,
+		// Saved views are expanded before the command line is parsed (see
+		// expandAlias), so only unknown names get here
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fmt.Errorf("view not found: %s", args[0])
+		},
+	}
+}
+
+func newViewListCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List saved views",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+			views := config.Settings().Views
+
+			if flags.asJSON {
+				if views == nil {
+					views = map[string]string{}
+				}
+				return out.JSON(views)
+			}
+
+			if len(views) == 0 {
+				out.Printf("%s\n", i18n.T("No views saved."))
+				return nil
+			}
+
+			names := make([]string, 0, len(views))
+			for name := range views {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				out.Printf("%s = %s\n", name, views[name])
+			}
+			return nil
+		},
+	}
+}
+
+func newViewRemoveCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove <name>",
+		Aliases: []string{"rm"},
+		Short:   "Remove a saved view",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			cfg, err := config.LoadForUpdate()
+			if err != nil {
+				return err
+			}
+			if _, ok := cfg.Views[args[0]]; !ok {
+				return fmt.Errorf("view not found: %s", args[0])
+			}
+			delete(cfg.Views, args[0])
+			if err := config.Save(cfg); err != nil {
+				return err
+			}
+
+			out.WriteSuccess(i18n.T("Removed view %s", args[0]))
+			return nil
+		},
+	}
+}
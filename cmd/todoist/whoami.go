@@ -0,0 +1,38 @@
+package main
+
+import (
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+func newWhoamiCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "whoami",
+		Short: "Show the account behind the active token",
+		Long: `Show the account the active profile's token belongs to: email, name,
+plan, timezone and productivity goals.
+
+Examples:
+  todoist whoami
+  todoist whoami --profile work`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			user, err := client.GetUser()
+			if err != nil {
+				return err
+			}
+
+			return out.WriteUser(&output.UserInfo{User: user, Profile: config.Settings().ProfileName()})
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// Status bar colors for overdue and due-today counts
+const (
+	widgetRed    = "#ff5555"
+	widgetYellow = "#f1c40f"
+)
+
+// widgetStyles lists the supported --style values
+var widgetStyles = []string{"plain", "tmux", "polybar", "waybar", "i3blocks"}
+
+func newWidgetCmd(flags *rootFlags) *cobra.Command {
+	var (
+		style   string
+		icon    string
+		maxLen  int
+		noColor bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "widget",
+		Short: "Print a status bar line with the next task and counts",
+		Long: `Print one line for a status bar: the next due task and how many tasks are
+due today and overdue, formatted for the chosen tool.
+
+Like status, this reads the local cache and refreshes it in the background.
+
+Styles:
+  plain     text only (default)
+  tmux      #[fg=...] color markup, for status-right
+  polybar   %{F...} color markup, for custom/script modules
+  waybar    JSON with text, tooltip and class, for return-type json
+  i3blocks  full text, short text and color lines
+
+Examples:
+  todoist widget --style tmux --icon ✓
+  todoist widget --style waybar --max-length 30`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			valid := false
+			for _, s := range widgetStyles {
+				valid = valid || s == style
+			}
+			if !valid {
+				return fmt.Errorf("invalid --style %q (use %s)", style, strings.Join(widgetStyles, ", "))
+			}
+
+			a, err := loadAgenda(flags)
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			today, overdue := a.counts(now)
+			w := widget{today: today, overdue: overdue, icon: icon, color: !noColor}
+			if next := a.next(now); next != nil {
+				w.next = output.Truncate(next.Content, maxLen)
+			}
+
+			out.Printf("%s\n", w.render(style))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&style, "style", "plain", "output format: "+strings.Join(widgetStyles, ", "))
+	cmd.Flags().StringVar(&icon, "icon", "", "text or icon to show before the line")
+	cmd.Flags().IntVar(&maxLen, "max-length", 40, "truncate the next task to this many characters")
+	cmd.Flags().BoolVar(&noColor, "no-color", false, "leave out color markup")
+
+	return cmd
+}
+
+// widget holds what a status bar line shows
+type widget struct {
+	next           string
+	today, overdue int
+	icon           string
+	color          bool
+}
+
+// render formats the line for a status bar tool
+func (w widget) render(style string) string {
+	switch style {
+	case "tmux":
+		return w.line(func(color, s string) string { return "#[fg=" + color + "]" + s + "#[default]" })
+	case "polybar":
+		return w.line(func(color, s string) string { return "%{F" + color + "}" + s + "%{F-}" })
+	case "waybar":
+		class := "clear"
+		switch {
+		case w.overdue > 0:
+			class = "overdue"
+		case w.today > 0:
+			class = "due"
+		}
+		tooltip := summary(w.today, w.overdue)
+		if tooltip == "" {
+			tooltip = "Nothing due today"
+		}
+		data, _ := json.Marshal(map[string]string{
+			"text":    w.line(nil),
+			"tooltip": tooltip,
+			"class":   class,
+		})
+		return string(data)
+	case "i3blocks":
+		short := w.counts(nil)
+		color := ""
+		switch {
+		case w.overdue > 0:
+			color = widgetRed
+		case w.today > 0:
+			color = widgetYellow
+		}
+		if !w.color {
+			color = ""
+		}
+		return w.line(nil) + "\n" + short + "\n" + color
+	}
+	return w.line(nil)
+}
+
+// line joins the icon, the next task and the counts. paint wraps colored
+// parts in the tool's markup; nil leaves them plain.
+func (w widget) line(paint func(color, s string) string) string {
+	if !w.color {
+		paint = nil
+	}
+	var parts []string
+	if w.icon != "" {
+		parts = append(parts, w.icon)
+	}
+	if w.next != "" {
+		parts = append(parts, w.next)
+	}
+	if c := w.counts(paint); c != "" {
+		parts = append(parts, c)
+	}
+	if len(parts) == 0 || (len(parts) == 1 && w.icon != "") {
+		parts = append(parts, "all clear")
+	}
+	return strings.Join(parts, " ")
+}
+
+// counts formats "(3 today, 1 overdue)", empty when nothing is due
+func (w widget) counts(paint func(color, s string) string) string {
+	if paint == nil {
+		paint = func(_, s string) string { return s }
+	}
+	var parts []string
+	if w.today > 0 {
+		parts = append(parts, paint(widgetYellow, fmt.Sprintf("%d today", w.today)))
+	}
+	if w.overdue > 0 {
+		parts = append(parts, paint(widgetRed, fmt.Sprintf("%d overdue", w.overdue)))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
@@ -0,0 +1,24 @@
+package main
+
+import "testing"
+
+func TestWidgetRender(t *testing.T) {
+	w := widget{next: "Pay rent", today: 2, overdue: 1, color: true}
+
+	tests := map[string]string{
+		"plain":    "Pay rent (2 today, 1 overdue)",
+		"tmux":     "Pay rent (#[fg=#f1c40f]2 today#[default], #[fg=#ff5555]1 overdue#[default])",
+		"polybar":  "Pay rent (%{F#f1c40f}2 today%{F-}, %{F#ff5555}1 overdue%{F-})",
+		"waybar":   `{"class":"overdue","text":"Pay rent (2 today, 1 overdue)","tooltip":"2 due today, 1 overdue"}`,
+		"i3blocks": "Pay rent (2 today, 1 overdue)\n(2 today, 1 overdue)\n#ff5555",
+	}
+	for style, want := range tests {
+		if got := w.render(style); got != want {
+			t.Errorf("%s: got %q, want %q", style, got, want)
+		}
+	}
+
+	if got := (widget{icon: "✓", color: true}).render("tmux"); got != "✓ all clear" {
+		t.Errorf("empty widget: got %q", got)
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// assigneeLoad counts the open tasks of one person in a project
+type assigneeLoad struct {
+	ID   string `json:"id"` // empty for unassigned tasks
+	Name string `json:"name"`
+	taskCounts
+}
+
+// workload counts tasks per assignee as of today (YYYY-MM-DD). Every
+// collaborator is listed, busiest first, so people without tasks show up
+// too; unassigned tasks come last.
+func workload(tasks []api.Task, collaborators []api.Collaborator, today string) []assigneeLoad {
+	loads := make(map[string]*assigneeLoad)
+	for _, c := range collaborators {
+		name := c.Name
+		if name == "" {
+			name = c.Email
+		}
+		loads[c.ID] = &assigneeLoad{ID: c.ID, Name: name}
+	}
+	for _, t := range tasks {
+		l := loads[t.Assignee]
+		if l == nil {
+			l = &assigneeLoad{ID: t.Assignee, Name: t.Assignee}
+			if t.Assignee == "" {
+				l.Name = "Unassigned"
+			}
+			loads[t.Assignee] = l
+		}
+		l.add(t, today)
+	}
+
+	list := make([]assigneeLoad, 0, len(loads))
+	for _, l := range loads {
+		list = append(list, *l)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if (a.ID == "") != (b.ID == "") {
+			return b.ID == ""
+		}
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+	return list
+}
+
+func newProjectWorkloadCmd(flags *rootFlags) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "workload <project>",
+		Short: "Count a shared project's open tasks per assignee",
+		Long: `Count the open tasks of each person a project is shared with, busiest
+first, with how many are overdue or due today. Unassigned tasks are counted
+last.
+
+Examples:
+  todoist projects workload Launch
+  todoist projects workload Launch --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			p, err := client.FindProject(args[0])
+			if err != nil {
+				return err
+			}
+			tasks, err := client.GetTasks(p.ID, "")
+			if err != nil {
+				return err
+			}
+			collaborators, err := client.GetCollaborators(p.ID)
+			if err != nil {
+				return err
+			}
+
+			today := time.Now().In(output.Location()).Format("2006-01-02")
+			loads := workload(tasks, collaborators, today)
+			if flags.asJSON {
+				return out.JSON(loads)
+			}
+
+			var total taskCounts
+			for _, t := range tasks {
+				total.add(t, today)
+			}
+			out.Printf("%s: %s\n", p.Name, total.line())
+			if len(loads) == 0 {
+				return nil
+			}
+
+			width := 0
+			for _, l := range loads {
+				width = max(width, len([]rune(l.Name)))
+			}
+			out.Printf("\n")
+			for _, l := range loads {
+				line := fmt.Sprintf("  %-*s  %4d", width, l.Name, l.Total)
+				if l.Overdue > 0 || l.Today > 0 {
+					line += fmt.Sprintf("  (%s)", summary(l.Today, l.Overdue))
+				}
+				out.Printf("%s\n", line)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+)
+
+func TestWorkload(t *testing.T) {
+	due := func(date string) *api.Due { return &api.Due{Date: date} }
+	tasks := []api.Task{
+		{Assignee: "2", Due: due("2024-03-12")},
+		{Assignee: "2", Due: due("2024-03-13")},
+		{Assignee: "3"},
+		{Assignee: "9"},
+		{},
+		{},
+		{},
+	}
+	collaborators := []api.Collaborator{
+		{ID: "1", Name: "Ann"},
+		{ID: "2", Name: "Bob"},
+		{ID: "3", Email: "cy@example.com"},
+	}
+
+	loads := workload(tasks, collaborators, "2024-03-13")
+	var names []string
+	for _, l := range loads {
+		names = append(names, l.Name)
+	}
+	want := []string{"Bob", "9", "cy@example.com", "Ann", "Unassigned"}
+	if len(names) != len(want) {
+		t.Fatalf("got %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("got %v, want %v", names, want)
+		}
+	}
+	if loads[0].Overdue != 1 || loads[0].Today != 1 || loads[4].Total != 3 || loads[3].Total != 0 {
+		t.Errorf("unexpected counts %+v", loads)
+	}
+}
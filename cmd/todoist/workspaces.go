@@ -0,0 +1,77 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+func newWorkspacesCmd(flags *rootFlags) *cobra.Command {
+	return &cobra.Command{
+		Use:     "workspaces",
+		Aliases: []string{"workspace", "ws"},
+		Short:   "List team workspaces",
+		Long: `List the team workspaces you belong to, with their plan, your role and how
+many projects each has. Personal accounts have none.
+
+Use todoist projects --workspace to list one workspace's projects.
+
+Examples:
+  todoist workspaces
+  todoist workspaces --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newFormatter(flags)
+
+			client, err := getClientWithFlags(flags)
+			if err != nil {
+				return err
+			}
+
+			workspaces, err := client.GetWorkspaces()
+			if err != nil {
+				return err
+			}
+			var projects []api.Project
+			if len(workspaces) > 0 && !flags.asJSON {
+				if projects, err = client.GetProjects(); err != nil {
+					return err
+				}
+			}
+
+			return out.WriteWorkspaces(workspaces, projects)
+		},
+	}
+}
+
+// hasTeamProjects reports whether any of projects belongs to a workspace
+func hasTeamProjects(projects []api.Project) bool {
+	for _, p := range projects {
+		if p.WorkspaceID != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// projectsInWorkspace keeps the projects of the workspace named by name, or
+// the personal projects when name is "personal"
+func projectsInWorkspace(projects []api.Project, workspaces []api.Workspace, name string) ([]api.Project, error) {
+	var id string
+	if !strings.EqualFold(name, "personal") {
+		w, err := api.MatchWorkspace(workspaces, name)
+		if err != nil {
+			return nil, err
+		}
+		id = w.ID
+	}
+
+	var kept []api.Project
+	for _, p := range projects {
+		if p.WorkspaceID == id {
+			kept = append(kept, p)
+		}
+	}
+	return kept, nil
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	clerrors "github.com/buddyh/todoist-cli/internal/errors"
+)
+
+// Backup is one of the automatic backups Todoist keeps of an account
+type Backup struct {
+	Version string `json:"version"` // when it was made, e.g. "2024-03-01 04:37"
+	URL     string `json:"url"`
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modeled here
+}
+
+// UnmarshalJSON decodes a backup leniently; see decodeObject
+func (b *Backup) UnmarshalJSON(data []byte) error {
+	type plain Backup
+	extra, err := decodeObject(data, (*plain)(b))
+	b.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the backup with any fields the API sent that it does not model
+func (b Backup) MarshalJSON() ([]byte, error) {
+	type plain Backup
+	return encodeObject(plain(b), b.Extra)
+}
+
+// GetBackups returns the account's backups
+func (c *Client) GetBackups() ([]Backup, error) {
+	resp, err := c.request("GET", "backups", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []Backup
+	if err := unmarshalList(resp, &backups); err != nil {
+		return nil, fmt.Errorf("failed to parse backups: %w", err)
+	}
+
+	return backups, nil
+}
+
+// DownloadBackup copies a backup's zip file to w and returns its size.
+// The API token is only sent to Todoist's own hosts.
+func (c *Client) DownloadBackup(ctx context.Context, b Backup, w io.Writer) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", b.URL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.trustedHost(req.URL) {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, clerrors.WrapNetworkError("download failed", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		apiErr := &APIError{StatusCode: resp.StatusCode, Body: string(body)}
+		if resp.StatusCode == 401 || resp.StatusCode == 403 {
+			return 0, clerrors.WrapAuthError("authentication failed", apiErr)
+		}
+		return 0, apiErr
+	}
+
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, clerrors.WrapNetworkError("download failed", err)
+	}
+	return n, nil
+}
+
+// trustedHost reports whether u is on the API's host or a todoist.com host
+func (c *Client) trustedHost(u *url.URL) bool {
+	host := u.Hostname()
+	if base, err := url.Parse(c.baseURL); err == nil && base.Hostname() == host {
+		return true
+	}
+	return host == "todoist.com" || strings.HasSuffix(host, ".todoist.com")
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Batch queues Sync API commands that create related objects. Each Add
+// method returns a temporary ID that later additions can use in place of a
+// real project, section, task or parent ID; CommitBatch resolves them.
+type Batch struct {
+	commands []syncCommand
+}
+
+// Len returns the number of queued commands
+func (b *Batch) Len() int {
+	return len(b.commands)
+}
+
+func (b *Batch) add(cmdType string, args map[string]interface{}) string {
+	cmd := newSyncCommand(cmdType, args)
+	cmd.TempID = fmt.Sprintf("tmp-%d-%d", time.Now().UnixNano(), atomic.AddUint64(&commandSeq, 1))
+	b.commands = append(b.commands, cmd)
+	return cmd.TempID
+}
+
+// AddProject queues a new project, nested under parentID when set
+func (b *Batch) AddProject(name, color, parentID string) string {
+	args := map[string]interface{}{"name": name}
+	if color != "" {
+		args["color"] = color
+	}
+	if parentID != "" {
+		args["parent_id"] = parentID
+	}
+	return b.add("project_add", args)
+}
+
+// AddSection queues a new section in projectID
+func (b *Batch) AddSection(name, projectID string) string {
+	return b.add("section_add", map[string]interface{}{"name": name, "project_id": projectID})
+}
+
+// AddTask queues a copy of t's content, description, priority, due date,
+// labels and assignee, placed in the given project, section and parent
+func (b *Batch) AddTask(t Task, projectID, sectionID, parentID string) string {
+	args := map[string]interface{}{
+		"content":    t.Content,
+		"project_id": projectID,
+		"priority":   t.Priority,
+	}
+	if len(t.Labels) > 0 {
+		args["labels"] = t.Labels
+	}
+	if t.Description != "" {
+		args["description"] = t.Description
+	}
+	if sectionID != "" {
+		args["section_id"] = sectionID
+	}
+	if parentID != "" {
+		args["parent_id"] = parentID
+	}
+	if t.Assignee != "" {
+		args["responsible_uid"] = t.Assignee
+	}
+	if t.Due != nil {
+		// The Sync API carries the time in the date field
+		due := map[string]interface{}{"date": t.Due.Date, "string": t.Due.String, "is_recurring": t.Due.IsRecurring}
+		if t.Due.Datetime != "" {
+			due["date"] = t.Due.Datetime
+		}
+		if t.Due.Timezone != "" {
+			due["timezone"] = t.Due.Timezone
+		}
+		args["due"] = due
+	}
+	return b.add("item_add", args)
+}
+
+// AddComment queues a comment on taskID
+func (b *Batch) AddComment(taskID, content string) string {
+	return b.add("note_add", map[string]interface{}{"item_id": taskID, "content": content})
+}
+
+// CommitBatch sends the queued commands, at most maxSyncCommands per request,
+// and returns the real ID for each temp ID. Temp IDs from earlier requests
+// are replaced before sending, since the API only resolves them within one
+// request.
+func (c *Client) CommitBatch(b *Batch) (map[string]string, error) {
+	ids := make(map[string]string)
+	total := len(b.commands)
+
+	for start := 0; start < total; start += maxSyncCommands {
+		end := start + maxSyncCommands
+		if end > total {
+			end = total
+		}
+		batch := b.commands[start:end]
+
+		for _, cmd := range batch {
+			args := cmd.Args.(map[string]interface{})
+			for k, v := range args {
+				if s, ok := v.(string); ok && ids[s] != "" {
+					args[k] = ids[s]
+				}
+			}
+		}
+
+		mapping, err := c.syncBatch(batch)
+		if err != nil {
+			return ids, err
+		}
+		for tmp, id := range mapping {
+			ids[tmp] = id
+		}
+		c.report("Creating", end, total)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCommitBatchResolvesTempIDsAcrossRequests(t *testing.T) {
+	var requests int
+	var lastArgs map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var body struct {
+			Commands []syncCommand `json:"commands"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		status := map[string]string{}
+		mapping := map[string]string{}
+		for i, cmd := range body.Commands {
+			status[cmd.UUID] = "ok"
+			mapping[cmd.TempID] = fmt.Sprintf("%d-%d", requests, i)
+			lastArgs = cmd.Args.(map[string]interface{})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"sync_status": status, "temp_id_mapping": mapping})
+	}))
+	defer srv.Close()
+
+	client := NewClient("test-token")
+	client.SetBaseURL(srv.URL)
+
+	var b Batch
+	parent := b.AddTask(Task{Content: "Parent", Priority: 1}, "p1", "", "")
+	for i := 0; i < maxSyncCommands-1; i++ {
+		b.AddTask(Task{Content: "Filler", Priority: 1}, "p1", "", "")
+	}
+	b.AddTask(Task{Content: "Child", Priority: 1}, "p1", "", parent)
+
+	var calls int
+	client.SetProgress(func(label string, done, total int) { calls++ })
+	ids, err := client.CommitBatch(&b)
+	if err != nil {
+		t.Fatalf("CommitBatch failed: %v", err)
+	}
+	if requests != 2 || calls != 2 {
+		t.Errorf("expected 2 requests and progress calls, got %d and %d", requests, calls)
+	}
+	if ids[parent] != "1-0" {
+		t.Errorf("expected the parent's real ID, got %q", ids[parent])
+	}
+	if lastArgs["parent_id"] != "1-0" {
+		t.Errorf("expected the child to reference the real parent ID, got %v", lastArgs["parent_id"])
+	}
+}
+
+func TestCompleteTaskAtReportsCommandFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Commands []syncCommand `json:"commands"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		status := map[string]interface{}{}
+		for _, cmd := range body.Commands {
+			status[cmd.UUID] = map[string]string{"error": "Item not found"}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"sync_status": status})
+	}))
+	defer srv.Close()
+
+	client := NewClient("test-token")
+	client.SetBaseURL(srv.URL)
+
+	err := client.CompleteTaskAt("missing", time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC))
+	if err == nil || !strings.Contains(err.Error(), "Item not found") {
+		t.Errorf("expected the command's failure, got %v", err)
+	}
+}
@@ -9,8 +9,10 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	clerrors "github.com/buddyh/todoist-cli/internal/errors"
@@ -19,6 +21,9 @@ import (
 const (
 	BaseURL    = "https://api.todoist.com/api/v1"
 	maxRetries = 3
+
+	// maxSyncCommands is the Sync API's limit on commands per request.
+	maxSyncCommands = 100
 )
 
 // paginatedResponse wraps list endpoints that return cursor-paginated results.
@@ -37,36 +42,142 @@ func unmarshalList(data []byte, v interface{}) error {
 	return json.Unmarshal(data, v)
 }
 
+// listPages fetches every page of a cursor-paginated list endpoint, calling
+// fn with each page's results as it arrives.
+func (c *Client) listPages(endpoint string, params map[string]string, fn func(results json.RawMessage) error) error {
+	return c.listPagesCtx(context.Background(), endpoint, params, fn)
+}
+
+// listPagesCtx is listPages with context support
+func (c *Client) listPagesCtx(ctx context.Context, endpoint string, params map[string]string, fn func(results json.RawMessage) error) error {
+	query := make(map[string]string, len(params)+1)
+	for k, v := range params {
+		query[k] = v
+	}
+
+	label := "Fetching " + endpoint
+	for pages := 1; ; pages++ {
+		resp, err := c.requestCtx(ctx, "GET", endpoint, query)
+		if err != nil {
+			return err
+		}
+
+		var page paginatedResponse
+		if err := json.Unmarshal(resp, &page); err != nil || page.Results == nil {
+			return fn(resp)
+		}
+		if err := fn(page.Results); err != nil {
+			return err
+		}
+		if page.NextCursor == nil || *page.NextCursor == "" {
+			c.report(label, pages, pages)
+			return nil
+		}
+		c.report(label, pages, 0)
+		query["cursor"] = *page.NextCursor
+	}
+}
+
 // Client is a Todoist API client
 type Client struct {
 	token      string
+	baseURL    string
 	httpClient *http.Client
 	debug      bool
+	debugOut   io.Writer
+	stats      *Stats
+	rate       *rateLimiter
+	httpLog    *httpLog
+	progress   ProgressFunc
+}
+
+// ProgressFunc is told how far a multi-request operation has got: done of
+// total steps, where a total of 0 means unknown
+type ProgressFunc func(label string, done, total int)
+
+// SetProgress reports the progress of paginated fetches and batched Sync
+// writes to fn
+func (c *Client) SetProgress(fn ProgressFunc) {
+	c.progress = fn
+}
+
+// report passes progress to the ProgressFunc, if any
+func (c *Client) report(label string, done, total int) {
+	if c.progress != nil {
+		c.progress(label, done, total)
+	}
 }
 
 // NewClient creates a new Todoist API client
 func NewClient(token string) *Client {
 	return &Client{
-		token: token,
+		token:   token,
+		baseURL: BaseURL,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: sharedTransport,
 		},
+		stats:    newStats(),
+		rate:     &rateLimiter{},
+		debugOut: os.Stderr,
 	}
 }
 
+// SetTransport sends requests through rt instead of the shared transport,
+// e.g. to proxy them through the daemon.
+func (c *Client) SetTransport(rt http.RoundTripper) {
+	c.httpClient.Transport = rt
+}
+
+// Stats returns request counts and timings collected by this client.
+func (c *Client) Stats() *Stats {
+	return c.stats
+}
+
+// SetBaseURL points the client at another API root, such as a gateway or a
+// mock server, e.g. "http://localhost:8080/api/v1".
+func (c *Client) SetBaseURL(u string) {
+	c.baseURL = strings.TrimRight(u, "/")
+}
+
+// RateLimit returns the request budget reported by the most recent
+// response. It returns false when the API has not reported one.
+func (c *Client) RateLimit() (RateLimit, bool) {
+	return c.rate.current()
+}
+
 // SetDebug enables HTTP request/response tracing to stderr.
 func (c *Client) SetDebug(enabled bool) {
 	c.debug = enabled
 }
 
+// SetDebugOutput sends the tracing of SetDebug to w instead of stderr
+func (c *Client) SetDebugOutput(w io.Writer) {
+	c.debugOut = w
+}
+
 // request makes an authenticated request to the Todoist API
 func (c *Client) request(method, endpoint string, data interface{}) ([]byte, error) {
 	return c.requestCtx(context.Background(), method, endpoint, data)
 }
 
+// Do sends an authenticated request to any API endpoint, such as
+// "projects" or "tasks/123/close", and returns the raw response body. A
+// non-nil body is sent as JSON.
+func (c *Client) Do(method, endpoint string, query url.Values, body json.RawMessage) ([]byte, error) {
+	endpoint = strings.TrimPrefix(endpoint, "/")
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	if body == nil {
+		return c.request(method, endpoint, nil)
+	}
+	return c.request(method, endpoint, body)
+}
+
 // requestCtx makes an authenticated request with context support and retry logic.
 func (c *Client) requestCtx(ctx context.Context, method, endpoint string, data interface{}) ([]byte, error) {
-	reqURL := fmt.Sprintf("%s/%s", BaseURL, endpoint)
+	reqURL := fmt.Sprintf("%s/%s", c.baseURL, endpoint)
 
 	var bodyBytes []byte
 	if data != nil {
@@ -120,6 +231,17 @@ func (c *Client) requestCtx(ctx context.Context, method, endpoint string, data i
 			body = bytes.NewReader(bodyBytes)
 		}
 
+		if wait := c.rate.delay(time.Now()); wait > 0 {
+			if c.debug {
+				fmt.Fprintf(c.debugOut, "[DEBUG] pacing: rate limit budget low, waiting %s\n", wait.Round(time.Millisecond))
+			}
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+
 		req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create request: %w", err)
@@ -129,14 +251,22 @@ func (c *Client) requestCtx(ctx context.Context, method, endpoint string, data i
 		req.Header.Set("Content-Type", "application/json")
 
 		start := time.Now()
+		var trace *timing
+		if c.httpLog != nil {
+			trace = &timing{start: start}
+			req = req.WithContext(trace.trace(req.Context()))
+		}
 		if c.debug {
-			fmt.Fprintf(os.Stderr, "[DEBUG] %s %s\n", method, reqURL)
+			fmt.Fprintf(c.debugOut, "[DEBUG] %s %s\n", method, reqURL)
 		}
 
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
 			if c.debug {
-				fmt.Fprintf(os.Stderr, "[DEBUG] error: %v (%s)\n", err, time.Since(start))
+				fmt.Fprintf(c.debugOut, "[DEBUG] error: %v (%s)\n", err, time.Since(start))
+			}
+			if c.httpLog != nil {
+				c.httpLog.record(req, bodyBytes, nil, nil, err, trace)
 			}
 			return nil, clerrors.WrapNetworkError("request failed", err)
 		}
@@ -147,8 +277,14 @@ func (c *Client) requestCtx(ctx context.Context, method, endpoint string, data i
 			return nil, clerrors.WrapNetworkError("failed to read response", err)
 		}
 
+		c.stats.record(method, endpoint, time.Since(start))
+		if c.httpLog != nil {
+			c.httpLog.record(req, bodyBytes, resp, respBody, nil, trace)
+		}
+		c.rate.update(resp.Header, time.Now())
+		c.stats.recordDate(resp.Header.Get("Date"), time.Now())
 		if c.debug {
-			fmt.Fprintf(os.Stderr, "[DEBUG] %d %s (%s)\n", resp.StatusCode, http.StatusText(resp.StatusCode), time.Since(start))
+			fmt.Fprintf(c.debugOut, "[DEBUG] %d %s (%s)\n", resp.StatusCode, http.StatusText(resp.StatusCode), time.Since(start))
 		}
 
 		if resp.StatusCode == 429 && attempt < maxRetries {
@@ -159,14 +295,16 @@ func (c *Client) requestCtx(ctx context.Context, method, endpoint string, data i
 				}
 			}
 			lastErr = &retryAfterError{after: wait}
+			c.rate.exhausted(wait, time.Now())
+			c.stats.recordRetry()
 			if c.debug {
-				fmt.Fprintf(os.Stderr, "[DEBUG] rate limited, retrying in %s\n", wait)
+				fmt.Fprintf(c.debugOut, "[DEBUG] rate limited, retrying in %s\n", wait)
 			}
 			continue
 		}
 
 		if resp.StatusCode >= 400 {
-			apiErr := fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
+			apiErr := &APIError{StatusCode: resp.StatusCode, Body: string(respBody)}
 			switch resp.StatusCode {
 			case 401, 403:
 				return nil, clerrors.WrapAuthError("authentication failed", apiErr)
@@ -189,6 +327,135 @@ func (e *retryAfterError) Error() string {
 	return fmt.Sprintf("rate limited, retry after %s", e.after)
 }
 
+// =============================================================================
+// SYNC
+// =============================================================================
+
+// syncCommand is a single write command sent to the Sync API
+type syncCommand struct {
+	Type   string      `json:"type"`
+	UUID   string      `json:"uuid"`
+	TempID string      `json:"temp_id,omitempty"`
+	Args   interface{} `json:"args"`
+}
+
+// syncResponse holds the per-command results of a Sync API write
+type syncResponse struct {
+	SyncStatus    map[string]json.RawMessage `json:"sync_status"`
+	TempIDMapping map[string]string          `json:"temp_id_mapping"`
+}
+
+var commandSeq uint64
+
+// newSyncCommand builds a command with a UUID that stays unique within a batch.
+func newSyncCommand(cmdType string, args interface{}) syncCommand {
+	n := atomic.AddUint64(&commandSeq, 1)
+	return syncCommand{
+		Type: cmdType,
+		UUID: fmt.Sprintf("%d-%d", time.Now().UnixNano(), n),
+		Args: args,
+	}
+}
+
+// sync sends commands to the Sync API in batches and returns the first
+// command failure reported in sync_status.
+func (c *Client) sync(commands []syncCommand) error {
+	for start := 0; start < len(commands); start += maxSyncCommands {
+		end := start + maxSyncCommands
+		if end > len(commands) {
+			end = len(commands)
+		}
+		if _, err := c.syncBatch(commands[start:end]); err != nil {
+			return err
+		}
+		c.report("Saving changes", end, len(commands))
+	}
+	return nil
+}
+
+// syncBatch sends one request's worth of commands and returns the IDs
+// created for their temp IDs
+func (c *Client) syncBatch(batch []syncCommand) (map[string]string, error) {
+	resp, err := c.request("POST", "sync", map[string]interface{}{"commands": batch})
+	if err != nil {
+		return nil, err
+	}
+
+	var result syncResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse sync response: %w", err)
+	}
+
+	for _, cmd := range batch {
+		status, ok := result.SyncStatus[cmd.UUID]
+		if !ok || string(status) == `"ok"` {
+			continue
+		}
+		var cmdErr struct {
+			Error string `json:"error"`
+		}
+		json.Unmarshal(status, &cmdErr)
+		return nil, fmt.Errorf("%s failed: %s", cmd.Type, cmdErr.Error)
+	}
+	return result.TempIDMapping, nil
+}
+
+// SyncCommand is a Sync API write command built by the caller, for
+// commands the client has no method for
+type SyncCommand struct {
+	Type   string          `json:"type"`
+	UUID   string          `json:"uuid"`
+	TempID string          `json:"temp_id,omitempty"`
+	Args   json.RawMessage `json:"args"`
+}
+
+// SyncResult is the outcome of SyncCommands: the status of each command by
+// UUID, "ok" or an error object, and the real IDs of created objects by
+// temp ID
+type SyncResult struct {
+	SyncStatus    map[string]json.RawMessage `json:"sync_status"`
+	TempIDMapping map[string]string          `json:"temp_id_mapping"`
+}
+
+// NewSyncCommand builds a command with a fresh UUID and, for commands that
+// create an object (those ending in _add), a temp ID that later commands
+// in the same request can use in place of its real ID
+func NewSyncCommand(cmdType string, args json.RawMessage) SyncCommand {
+	cmd := newSyncCommand(cmdType, nil)
+	raw := SyncCommand{Type: cmdType, UUID: cmd.UUID, Args: args}
+	if strings.HasSuffix(cmdType, "_add") {
+		raw.TempID = "tmp-" + cmd.UUID
+	}
+	return raw
+}
+
+// SyncCommands sends commands to the Sync API in one request, so temp IDs
+// resolve across them, and returns the per-command results. Failed
+// commands are reported in the result, not as an error.
+func (c *Client) SyncCommands(commands []SyncCommand) (*SyncResult, error) {
+	if len(commands) > maxSyncCommands {
+		return nil, fmt.Errorf("at most %d commands can be sent at once", maxSyncCommands)
+	}
+	resp, err := c.request("POST", "sync", map[string]interface{}{"commands": commands})
+	if err != nil {
+		return nil, err
+	}
+
+	var result SyncResult
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse sync response: %w", err)
+	}
+	return &result, nil
+}
+
+// syncRead fetches full copies of the given resource types from the Sync API
+func (c *Client) syncRead(resourceTypes ...string) ([]byte, error) {
+	return c.request("POST", "sync", map[string]interface{}{
+		"sync_token":     "*",
+		"resource_types": resourceTypes,
+	})
+}
+
 // =============================================================================
 // TASKS
 // =============================================================================
@@ -202,6 +469,7 @@ type Task struct {
 	SectionID   string   `json:"section_id,omitempty"`
 	ParentID    string   `json:"parent_id,omitempty"`
 	ChildOrder  int      `json:"child_order"`
+	DayOrder    int      `json:"day_order"`
 	Priority    int      `json:"priority"`
 	Due         *Due     `json:"due,omitempty"`
 	Labels      []string `json:"labels"`
@@ -210,6 +478,33 @@ type Task struct {
 	Assignee    string   `json:"responsible_uid,omitempty"`
 	Assigner    string   `json:"assigned_by_uid,omitempty"`
 	IsCompleted bool     `json:"checked"`
+	NoteCount   int      `json:"note_count"`
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modeled here
+}
+
+// UnmarshalJSON decodes a task leniently; see decodeObject
+func (t *Task) UnmarshalJSON(data []byte) error {
+	type plain Task
+	extra, err := decodeObject(data, (*plain)(t))
+	t.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the task with any fields the API sent that it does not model
+func (t Task) MarshalJSON() ([]byte, error) {
+	type plain Task
+	return encodeObject(plain(t), t.Extra)
+}
+
+// TaskURL returns the Todoist web app URL for a task
+func TaskURL(taskID string) string {
+	return "https://app.todoist.com/app/task/" + taskID
+}
+
+// TaskAppURL returns the desktop app deep link for a task
+func TaskAppURL(taskID string) string {
+	return "todoist://task?id=" + taskID
 }
 
 // Due represents a task due date
@@ -219,10 +514,40 @@ type Due struct {
 	Datetime    string `json:"datetime,omitempty"`
 	IsRecurring bool   `json:"is_recurring"`
 	Timezone    string `json:"timezone,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modeled here
+}
+
+// UnmarshalJSON decodes a due date leniently; see decodeObject
+func (d *Due) UnmarshalJSON(data []byte) error {
+	type plain Due
+	extra, err := decodeObject(data, (*plain)(d))
+	d.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the due date with any fields the API sent that it does not model
+func (d Due) MarshalJSON() ([]byte, error) {
+	type plain Due
+	return encodeObject(plain(d), d.Extra)
 }
 
 // GetTasks returns all active tasks with optional filters
 func (c *Client) GetTasks(projectID, filter string) ([]Task, error) {
+	var tasks []Task
+	err := c.GetTasksPages(projectID, filter, func(page []Task) error {
+		tasks = append(tasks, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetTasksPages fetches active tasks page by page, calling fn as each page
+// arrives so results can be streamed
+func (c *Client) GetTasksPages(projectID, filter string, fn func([]Task) error) error {
 	params := map[string]string{}
 	if projectID != "" {
 		params["project_id"] = projectID
@@ -231,22 +556,13 @@ func (c *Client) GetTasks(projectID, filter string) ([]Task, error) {
 		params["filter"] = filter
 	}
 
-	var data interface{}
-	if len(params) > 0 {
-		data = params
-	}
-
-	resp, err := c.request("GET", "tasks", data)
-	if err != nil {
-		return nil, err
-	}
-
-	var tasks []Task
-	if err := unmarshalList(resp, &tasks); err != nil {
-		return nil, fmt.Errorf("failed to parse tasks: %w", err)
-	}
-
-	return tasks, nil
+	return c.listPages("tasks", params, func(results json.RawMessage) error {
+		var page []Task
+		if err := json.Unmarshal(results, &page); err != nil {
+			return fmt.Errorf("failed to parse tasks: %w", err)
+		}
+		return fn(page)
+	})
 }
 
 // GetTask returns a single task by ID
@@ -270,6 +586,7 @@ type AddTaskParams struct {
 	Description string   `json:"description,omitempty"`
 	DueString   string   `json:"due_string,omitempty"`
 	DueDate     string   `json:"due_date,omitempty"`
+	DueDatetime string   `json:"due_datetime,omitempty"`
 	Priority    int      `json:"priority,omitempty"`
 	ProjectID   string   `json:"project_id,omitempty"`
 	SectionID   string   `json:"section_id,omitempty"`
@@ -299,6 +616,7 @@ type UpdateTaskParams struct {
 	Description string   `json:"description,omitempty"`
 	DueString   string   `json:"due_string,omitempty"`
 	DueDate     string   `json:"due_date,omitempty"`
+	DueDatetime string   `json:"due_datetime,omitempty"`
 	Priority    int      `json:"priority,omitempty"`
 	Labels      []string `json:"labels,omitempty"`
 	AssigneeID  string   `json:"assignee_id,omitempty"`
@@ -319,12 +637,90 @@ func (c *Client) UpdateTask(taskID string, params UpdateTaskParams) (*Task, erro
 	return &task, nil
 }
 
+// SetTaskLabels replaces a task's labels. Unlike UpdateTask, an empty list
+// is sent as-is so all labels can be removed.
+func (c *Client) SetTaskLabels(taskID string, labels []string) (*Task, error) {
+	if labels == nil {
+		labels = []string{}
+	}
+
+	resp, err := c.request("POST", fmt.Sprintf("tasks/%s", taskID), map[string]interface{}{"labels": labels})
+	if err != nil {
+		return nil, err
+	}
+
+	var task Task
+	if err := json.Unmarshal(resp, &task); err != nil {
+		return nil, fmt.Errorf("failed to parse task: %w", err)
+	}
+
+	return &task, nil
+}
+
+// ClearTaskParams selects optional task fields to remove
+type ClearTaskParams struct {
+	Due         bool
+	Description bool
+	Assignee    bool
+}
+
+// ClearTaskFields removes optional values from a task. UpdateTask omits empty
+// values from its payload, so they are sent here explicitly.
+func (c *Client) ClearTaskFields(taskID string, params ClearTaskParams) (*Task, error) {
+	body := map[string]interface{}{}
+	if params.Due {
+		body["due_string"] = "no date"
+	}
+	if params.Description {
+		body["description"] = ""
+	}
+	if params.Assignee {
+		body["assignee_id"] = nil
+	}
+
+	resp, err := c.request("POST", fmt.Sprintf("tasks/%s", taskID), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var task Task
+	if err := json.Unmarshal(resp, &task); err != nil {
+		return nil, fmt.Errorf("failed to parse task: %w", err)
+	}
+
+	return &task, nil
+}
+
 // CompleteTask marks a task as complete
 func (c *Client) CompleteTask(taskID string) error {
 	_, err := c.request("POST", fmt.Sprintf("tasks/%s/close", taskID), nil)
 	return err
 }
 
+// CompleteTaskAt marks a task as complete with an explicit completion time
+// using the Sync API, for back-filling tasks finished earlier.
+func (c *Client) CompleteTaskAt(taskID string, completedAt time.Time) error {
+	return c.sync([]syncCommand{newSyncCommand("item_complete", map[string]interface{}{
+		"id":             taskID,
+		"date_completed": completedAt.UTC().Format(time.RFC3339),
+	})})
+}
+
+// CompleteTasks marks many tasks as complete using batched Sync commands.
+// A non-zero completedAt records when they were actually finished.
+func (c *Client) CompleteTasks(taskIDs []string, completedAt time.Time) error {
+	commands := make([]syncCommand, 0, len(taskIDs))
+	for _, id := range taskIDs {
+		args := map[string]interface{}{"id": id}
+		if !completedAt.IsZero() {
+			args["date_completed"] = completedAt.UTC().Format(time.RFC3339)
+		}
+		commands = append(commands, newSyncCommand("item_complete", args))
+	}
+
+	return c.sync(commands)
+}
+
 // ReopenTask reopens a completed task
 func (c *Client) ReopenTask(taskID string) error {
 	_, err := c.request("POST", fmt.Sprintf("tasks/%s/reopen", taskID), nil)
@@ -337,6 +733,62 @@ func (c *Client) DeleteTask(taskID string) error {
 	return err
 }
 
+// DeleteTasks permanently deletes many tasks using batched Sync commands
+func (c *Client) DeleteTasks(taskIDs []string) error {
+	commands := make([]syncCommand, 0, len(taskIDs))
+	for _, id := range taskIDs {
+		commands = append(commands, newSyncCommand("item_delete", map[string]interface{}{"id": id}))
+	}
+
+	return c.sync(commands)
+}
+
+// DueUpdate describes a new due date for a task. Set either String (parsed by
+// Todoist, e.g. "next monday") or Date (YYYY-MM-DD or a full datetime).
+// Timezone fixes a timed due date to an IANA zone instead of floating.
+type DueUpdate struct {
+	TaskID   string
+	String   string
+	Date     string
+	Timezone string
+}
+
+// RescheduleTasks changes due dates for many tasks using batched Sync commands
+func (c *Client) RescheduleTasks(updates []DueUpdate) error {
+	commands := make([]syncCommand, 0, len(updates))
+	for _, u := range updates {
+		due := map[string]string{}
+		if u.String != "" {
+			due["string"] = u.String
+		} else {
+			due["date"] = u.Date
+		}
+		if u.Timezone != "" {
+			due["timezone"] = u.Timezone
+		}
+		commands = append(commands, newSyncCommand("item_update", map[string]interface{}{
+			"id":  u.TaskID,
+			"due": due,
+		}))
+	}
+
+	return c.sync(commands)
+}
+
+// SetTaskPriorities sets the API priority (4=highest) of many tasks using
+// batched Sync commands
+func (c *Client) SetTaskPriorities(taskIDs []string, priority int) error {
+	commands := make([]syncCommand, 0, len(taskIDs))
+	for _, id := range taskIDs {
+		commands = append(commands, newSyncCommand("item_update", map[string]interface{}{
+			"id":       id,
+			"priority": priority,
+		}))
+	}
+
+	return c.sync(commands)
+}
+
 // ReorderTask sets the order of a task using the Sync API
 func (c *Client) ReorderTask(taskID string, order int) error {
 	uuid := fmt.Sprintf("%d", time.Now().UnixNano())
@@ -376,6 +828,24 @@ type Project struct {
 	IsFavorite     bool   `json:"is_favorite"`
 	IsInboxProject bool   `json:"inbox_project"`
 	ViewStyle      string `json:"view_style"`
+	WorkspaceID    string `json:"workspace_id,omitempty"` // empty for personal projects
+	FolderID       string `json:"folder_id,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modeled here
+}
+
+// UnmarshalJSON decodes a project leniently; see decodeObject
+func (p *Project) UnmarshalJSON(data []byte) error {
+	type plain Project
+	extra, err := decodeObject(data, (*plain)(p))
+	p.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the project with any fields the API sent that it does not model
+func (p Project) MarshalJSON() ([]byte, error) {
+	type plain Project
+	return encodeObject(plain(p), p.Extra)
 }
 
 // GetProjects returns all projects
@@ -415,6 +885,17 @@ func (c *Client) FindProject(name string) (*Project, error) {
 		return nil, err
 	}
 
+	return MatchProject(projects, name)
+}
+
+// MatchProject finds a project by name in an already-fetched list
+// (case-insensitive; an exact match wins over a partial one)
+func MatchProject(projects []Project, name string) (*Project, error) {
+	for _, p := range projects {
+		if strings.EqualFold(p.Name, name) {
+			return &p, nil
+		}
+	}
 	nameLower := strings.ToLower(name)
 	for _, p := range projects {
 		if strings.Contains(strings.ToLower(p.Name), nameLower) {
@@ -425,11 +906,22 @@ func (c *Client) FindProject(name string) (*Project, error) {
 	return nil, fmt.Errorf("project not found: %s", name)
 }
 
+// ProjectURL returns the Todoist web app URL for a project
+func ProjectURL(projectID string) string {
+	return "https://app.todoist.com/app/project/" + projectID
+}
+
+// ProjectAppURL returns the desktop app deep link for a project
+func ProjectAppURL(projectID string) string {
+	return "todoist://project?id=" + projectID
+}
+
 // AddProjectParams contains parameters for creating a project
 type AddProjectParams struct {
-	Name       string `json:"name"`
-	Color      string `json:"color,omitempty"`
-	IsFavorite bool   `json:"is_favorite,omitempty"`
+	Name        string `json:"name"`
+	Color       string `json:"color,omitempty"`
+	IsFavorite  bool   `json:"is_favorite,omitempty"`
+	WorkspaceID string `json:"workspace_id,omitempty"` // create a team project
 }
 
 // AddProject creates a new project
@@ -447,12 +939,70 @@ func (c *Client) AddProject(params AddProjectParams) (*Project, error) {
 	return &project, nil
 }
 
+// ProjectColors are the color names Todoist accepts for projects and labels
+var ProjectColors = []string{
+	"berry_red", "red", "orange", "yellow", "olive_green", "lime_green", "green",
+	"mint_green", "teal", "sky_blue", "light_blue", "blue", "grape", "violet",
+	"lavender", "magenta", "salmon", "charcoal", "grey", "taupe",
+}
+
+// UpdateProjectParams contains the project fields to change. Nil fields are
+// left as they are.
+type UpdateProjectParams struct {
+	Name       string `json:"name,omitempty"`
+	Color      string `json:"color,omitempty"`
+	IsFavorite *bool  `json:"is_favorite,omitempty"`
+}
+
+// UpdateProject updates an existing project
+func (c *Client) UpdateProject(projectID string, params UpdateProjectParams) (*Project, error) {
+	resp, err := c.request("POST", fmt.Sprintf("projects/%s", projectID), params)
+	if err != nil {
+		return nil, err
+	}
+
+	var project Project
+	if err := json.Unmarshal(resp, &project); err != nil {
+		return nil, fmt.Errorf("failed to parse project: %w", err)
+	}
+
+	return &project, nil
+}
+
 // DeleteProject deletes a project
 func (c *Client) DeleteProject(projectID string) error {
 	_, err := c.request("DELETE", fmt.Sprintf("projects/%s", projectID), nil)
 	return err
 }
 
+// ProjectEmail returns the address that adds tasks to a project by email,
+// creating one if the project has none yet. With comments it returns the
+// address that posts comments to the project instead.
+func (c *Client) ProjectEmail(projectID string, comments bool) (string, error) {
+	objType := "project"
+	if comments {
+		objType = "project_comments"
+	}
+	resp, err := c.request("PUT", "emails", map[string]string{
+		"obj_type": objType,
+		"obj_id":   projectID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Email string `json:"email"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return "", fmt.Errorf("failed to parse email address: %w", err)
+	}
+	if result.Email == "" {
+		return "", fmt.Errorf("failed to parse email address: none returned")
+	}
+	return result.Email, nil
+}
+
 // =============================================================================
 // SECTIONS
 // =============================================================================
@@ -463,6 +1013,22 @@ type Section struct {
 	ProjectID    string `json:"project_id"`
 	SectionOrder int    `json:"section_order"`
 	Name         string `json:"name"`
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modeled here
+}
+
+// UnmarshalJSON decodes a section leniently; see decodeObject
+func (s *Section) UnmarshalJSON(data []byte) error {
+	type plain Section
+	extra, err := decodeObject(data, (*plain)(s))
+	s.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the section with any fields the API sent that it does not model
+func (s Section) MarshalJSON() ([]byte, error) {
+	type plain Section
+	return encodeObject(plain(s), s.Extra)
 }
 
 // GetSections returns all sections, optionally filtered by project
@@ -505,6 +1071,207 @@ func (c *Client) AddSection(name, projectID string) (*Section, error) {
 	return &section, nil
 }
 
+// GetProjectsAndSections returns all projects and sections in a single
+// Sync API request, for resolving IDs to names
+func (c *Client) GetProjectsAndSections() ([]Project, []Section, error) {
+	resp, err := c.syncRead("projects", "sections")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result struct {
+		Projects []Project `json:"projects"`
+		Sections []Section `json:"sections"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse projects and sections: %w", err)
+	}
+
+	var projects []Project
+	for _, p := range result.Projects {
+		if !isDeleted(p.Extra) {
+			projects = append(projects, p)
+		}
+	}
+	var sections []Section
+	for _, s := range result.Sections {
+		if !isDeleted(s.Extra) {
+			sections = append(sections, s)
+		}
+	}
+
+	return projects, sections, nil
+}
+
+// =============================================================================
+// WORKSPACES
+// =============================================================================
+
+// Workspace represents a Todoist team workspace
+type Workspace struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Plan        string `json:"plan,omitempty"` // e.g. STARTER or BUSINESS
+	Role        string `json:"role,omitempty"` // the user's role: ADMIN, MEMBER or GUEST
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modeled here
+}
+
+// UnmarshalJSON decodes a workspace leniently; see decodeObject
+func (w *Workspace) UnmarshalJSON(data []byte) error {
+	type plain Workspace
+	extra, err := decodeObject(data, (*plain)(w))
+	w.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the workspace with any fields the API sent that it does not model
+func (w Workspace) MarshalJSON() ([]byte, error) {
+	type plain Workspace
+	return encodeObject(plain(w), w.Extra)
+}
+
+// GetWorkspaces returns the workspaces the user belongs to; there are none
+// for accounts without a team
+func (c *Client) GetWorkspaces() ([]Workspace, error) {
+	resp, err := c.syncRead("workspaces")
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Workspaces []Workspace `json:"workspaces"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse workspaces: %w", err)
+	}
+
+	var workspaces []Workspace
+	for _, w := range result.Workspaces {
+		if !isDeleted(w.Extra) {
+			workspaces = append(workspaces, w)
+		}
+	}
+	return workspaces, nil
+}
+
+// MatchWorkspace finds a workspace by ID or name in an already-fetched list
+// (case-insensitive partial match)
+func MatchWorkspace(workspaces []Workspace, name string) (*Workspace, error) {
+	for _, w := range workspaces {
+		if w.ID == name {
+			return &w, nil
+		}
+	}
+	nameLower := strings.ToLower(name)
+	for _, w := range workspaces {
+		if strings.Contains(strings.ToLower(w.Name), nameLower) {
+			return &w, nil
+		}
+	}
+
+	return nil, fmt.Errorf("workspace not found: %s", name)
+}
+
+// Folder groups projects within a workspace
+type Folder struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	WorkspaceID  string `json:"workspace_id"`
+	DefaultOrder int    `json:"default_order"`
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modeled here
+}
+
+// UnmarshalJSON decodes a folder leniently; see decodeObject
+func (f *Folder) UnmarshalJSON(data []byte) error {
+	type plain Folder
+	extra, err := decodeObject(data, (*plain)(f))
+	f.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the folder with any fields the API sent that it does not model
+func (f Folder) MarshalJSON() ([]byte, error) {
+	type plain Folder
+	return encodeObject(plain(f), f.Extra)
+}
+
+// GetFolders returns the project folders of all the user's workspaces, in
+// their order
+func (c *Client) GetFolders() ([]Folder, error) {
+	resp, err := c.syncRead("folders")
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Folders []Folder `json:"folders"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse folders: %w", err)
+	}
+
+	var folders []Folder
+	for _, f := range result.Folders {
+		if !isDeleted(f.Extra) {
+			folders = append(folders, f)
+		}
+	}
+	sort.SliceStable(folders, func(i, j int) bool { return folders[i].DefaultOrder < folders[j].DefaultOrder })
+	return folders, nil
+}
+
+// AddFolder creates a folder in a workspace
+func (c *Client) AddFolder(name, workspaceID string) (*Folder, error) {
+	cmd := newSyncCommand("folder_add", map[string]string{
+		"name":         name,
+		"workspace_id": workspaceID,
+	})
+	cmd.TempID = "folder-" + cmd.UUID
+	mapping, err := c.syncBatch([]syncCommand{cmd})
+	if err != nil {
+		return nil, err
+	}
+	id := mapping[cmd.TempID]
+	if id == "" {
+		return nil, fmt.Errorf("failed to parse folder: no ID returned")
+	}
+	return &Folder{ID: id, Name: name, WorkspaceID: workspaceID}, nil
+}
+
+// MoveProjectToFolder puts a workspace project in a folder of the same
+// workspace, or takes it out of its folder when folderID is empty
+func (c *Client) MoveProjectToFolder(projectID, folderID string) error {
+	var folder interface{}
+	if folderID != "" {
+		folder = folderID
+	}
+	return c.sync([]syncCommand{newSyncCommand("project_update", map[string]interface{}{
+		"id":        projectID,
+		"folder_id": folder,
+	})})
+}
+
+// MatchFolder finds a folder by ID or name in an already-fetched list
+// (case-insensitive partial match)
+func MatchFolder(folders []Folder, name string) (*Folder, error) {
+	for _, f := range folders {
+		if f.ID == name {
+			return &f, nil
+		}
+	}
+	nameLower := strings.ToLower(name)
+	for _, f := range folders {
+		if strings.Contains(strings.ToLower(f.Name), nameLower) {
+			return &f, nil
+		}
+	}
+
+	return nil, fmt.Errorf("folder not found: %s", name)
+}
+
 // =============================================================================
 // LABELS
 // =============================================================================
@@ -516,6 +1283,22 @@ type Label struct {
 	Color      string `json:"color"`
 	Order      int    `json:"order"`
 	IsFavorite bool   `json:"is_favorite"`
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modeled here
+}
+
+// UnmarshalJSON decodes a label leniently; see decodeObject
+func (l *Label) UnmarshalJSON(data []byte) error {
+	type plain Label
+	extra, err := decodeObject(data, (*plain)(l))
+	l.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the label with any fields the API sent that it does not model
+func (l Label) MarshalJSON() ([]byte, error) {
+	type plain Label
+	return encodeObject(plain(l), l.Extra)
 }
 
 // GetLabels returns all labels
@@ -553,17 +1336,131 @@ func (c *Client) AddLabel(name, color string) (*Label, error) {
 	return &label, nil
 }
 
+// UpdateLabel renames a personal label. Todoist relabels tasks automatically.
+func (c *Client) UpdateLabel(labelID, name string) (*Label, error) {
+	resp, err := c.request("POST", fmt.Sprintf("labels/%s", labelID), map[string]string{"name": name})
+	if err != nil {
+		return nil, err
+	}
+
+	var label Label
+	if err := json.Unmarshal(resp, &label); err != nil {
+		return nil, fmt.Errorf("failed to parse label: %w", err)
+	}
+
+	return &label, nil
+}
+
+// =============================================================================
+// FILTERS (Sync API)
+// =============================================================================
+
+// Filter represents a saved Todoist filter
+type Filter struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Query      string `json:"query"`
+	Color      string `json:"color"`
+	ItemOrder  int    `json:"item_order"`
+	IsFavorite bool   `json:"is_favorite"`
+	IsDeleted  bool   `json:"is_deleted"`
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modeled here
+}
+
+// UnmarshalJSON decodes a filter leniently; see decodeObject
+func (f *Filter) UnmarshalJSON(data []byte) error {
+	type plain Filter
+	extra, err := decodeObject(data, (*plain)(f))
+	f.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the filter with any fields the API sent that it does not model
+func (f Filter) MarshalJSON() ([]byte, error) {
+	type plain Filter
+	return encodeObject(plain(f), f.Extra)
+}
+
+// GetFilters returns saved filters
+func (c *Client) GetFilters() ([]Filter, error) {
+	resp, err := c.syncRead("filters")
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Filters []Filter `json:"filters"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse filters: %w", err)
+	}
+
+	filters := result.Filters[:0]
+	for _, f := range result.Filters {
+		if !f.IsDeleted {
+			filters = append(filters, f)
+		}
+	}
+
+	return filters, nil
+}
+
 // =============================================================================
 // COMMENTS
 // =============================================================================
 
 // Comment represents a Todoist comment
 type Comment struct {
-	ID        string `json:"id"`
-	TaskID    string `json:"task_id,omitempty"`
-	ProjectID string `json:"project_id,omitempty"`
-	Content   string `json:"content"`
-	PostedAt  string `json:"posted_at"`
+	ID         string              `json:"id"`
+	TaskID     string              `json:"task_id,omitempty"`
+	ProjectID  string              `json:"project_id,omitempty"`
+	Content    string              `json:"content"`
+	PostedAt   string              `json:"posted_at"`
+	PostedUID  string              `json:"posted_uid,omitempty"`
+	Attachment *Attachment         `json:"file_attachment,omitempty"`
+	Reactions  map[string][]string `json:"reactions,omitempty"` // emoji -> IDs of users who reacted
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modeled here
+}
+
+// UnmarshalJSON decodes a comment leniently; see decodeObject
+func (c *Comment) UnmarshalJSON(data []byte) error {
+	type plain Comment
+	extra, err := decodeObject(data, (*plain)(c))
+	c.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the comment with any fields the API sent that it does not model
+func (c Comment) MarshalJSON() ([]byte, error) {
+	type plain Comment
+	return encodeObject(plain(c), c.Extra)
+}
+
+// Attachment is a file attached to a comment
+type Attachment struct {
+	FileName     string `json:"file_name,omitempty"`
+	FileType     string `json:"file_type,omitempty"`
+	FileURL      string `json:"file_url,omitempty"`
+	FileSize     int64  `json:"file_size,omitempty"`
+	ResourceType string `json:"resource_type,omitempty"` // file, image, video, audio or url
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modeled here
+}
+
+// UnmarshalJSON decodes an attachment leniently; see decodeObject
+func (a *Attachment) UnmarshalJSON(data []byte) error {
+	type plain Attachment
+	extra, err := decodeObject(data, (*plain)(a))
+	a.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the attachment with any fields the API sent that it does not model
+func (a Attachment) MarshalJSON() ([]byte, error) {
+	type plain Attachment
+	return encodeObject(plain(a), a.Extra)
 }
 
 // GetComments returns comments for a task or project
@@ -571,7 +1468,8 @@ func (c *Client) GetComments(taskID, projectID string) ([]Comment, error) {
 	return c.GetCommentsCtx(context.Background(), taskID, projectID)
 }
 
-// GetCommentsCtx returns comments with context support
+// GetCommentsCtx returns comments with context support, following every
+// page of long threads
 func (c *Client) GetCommentsCtx(ctx context.Context, taskID, projectID string) ([]Comment, error) {
 	params := map[string]string{}
 	if taskID != "" {
@@ -580,17 +1478,48 @@ func (c *Client) GetCommentsCtx(ctx context.Context, taskID, projectID string) (
 		params["project_id"] = projectID
 	}
 
-	resp, err := c.requestCtx(ctx, "GET", "comments", params)
+	var comments []Comment
+	err := c.listPagesCtx(ctx, "comments", params, func(results json.RawMessage) error {
+		var page []Comment
+		if err := json.Unmarshal(results, &page); err != nil {
+			return fmt.Errorf("failed to parse comments: %w", err)
+		}
+		comments = append(comments, page...)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var comments []Comment
-	if err := unmarshalList(resp, &comments); err != nil {
-		return nil, fmt.Errorf("failed to parse comments: %w", err)
+	return comments, nil
+}
+
+// GetComment returns a single comment by ID
+func (c *Client) GetComment(commentID string) (*Comment, error) {
+	resp, err := c.request("GET", fmt.Sprintf("comments/%s", commentID), nil)
+	if err != nil {
+		return nil, err
 	}
 
-	return comments, nil
+	var comment Comment
+	if err := json.Unmarshal(resp, &comment); err != nil {
+		return nil, fmt.Errorf("failed to parse comment: %w", err)
+	}
+
+	return &comment, nil
+}
+
+// SetCommentReactions replaces a comment's reactions through the Sync API.
+// Reactions have no REST endpoint, so callers read the comment, change the
+// map and write the whole of it back.
+func (c *Client) SetCommentReactions(commentID string, reactions map[string][]string) error {
+	if reactions == nil {
+		reactions = map[string][]string{}
+	}
+	return c.sync([]syncCommand{newSyncCommand("note_update", map[string]interface{}{
+		"id":        commentID,
+		"reactions": reactions,
+	})})
 }
 
 // AddComment adds a comment to a task or project
@@ -615,6 +1544,21 @@ func (c *Client) AddComment(content, taskID, projectID string) (*Comment, error)
 	return &comment, nil
 }
 
+// UpdateComment replaces the content of a comment
+func (c *Client) UpdateComment(commentID, content string) (*Comment, error) {
+	resp, err := c.request("POST", fmt.Sprintf("comments/%s", commentID), map[string]string{"content": content})
+	if err != nil {
+		return nil, err
+	}
+
+	var comment Comment
+	if err := json.Unmarshal(resp, &comment); err != nil {
+		return nil, fmt.Errorf("failed to parse comment: %w", err)
+	}
+
+	return &comment, nil
+}
+
 // =============================================================================
 // COLLABORATORS
 // =============================================================================
@@ -624,6 +1568,22 @@ type Collaborator struct {
 	ID    string `json:"id"`
 	Name  string `json:"name"`
 	Email string `json:"email"`
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modeled here
+}
+
+// UnmarshalJSON decodes a collaborator leniently; see decodeObject
+func (c *Collaborator) UnmarshalJSON(data []byte) error {
+	type plain Collaborator
+	extra, err := decodeObject(data, (*plain)(c))
+	c.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the collaborator with any fields the API sent that it does not model
+func (c Collaborator) MarshalJSON() ([]byte, error) {
+	type plain Collaborator
+	return encodeObject(plain(c), c.Extra)
 }
 
 // GetCollaborators returns collaborators for a project
@@ -641,6 +1601,48 @@ func (c *Client) GetCollaborators(projectID string) ([]Collaborator, error) {
 	return collaborators, nil
 }
 
+// GetAllCollaborators returns everyone who shares a project with the user,
+// in one Sync API request
+func (c *Client) GetAllCollaborators() ([]Collaborator, error) {
+	resp, err := c.syncRead("collaborators")
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Collaborators []struct {
+			ID       string `json:"id"`
+			FullName string `json:"full_name"`
+			Email    string `json:"email"`
+		} `json:"collaborators"`
+	}
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse collaborators: %w", err)
+	}
+
+	collaborators := make([]Collaborator, len(result.Collaborators))
+	for i, p := range result.Collaborators {
+		collaborators[i] = Collaborator{ID: p.ID, Name: p.FullName, Email: p.Email}
+	}
+	return collaborators, nil
+}
+
+// ShareProject invites a person to a project by email
+func (c *Client) ShareProject(projectID, email string) error {
+	return c.sync([]syncCommand{newSyncCommand("share_project", map[string]string{
+		"project_id": projectID,
+		"email":      email,
+	})})
+}
+
+// UnshareProject removes a collaborator from a project by email
+func (c *Client) UnshareProject(projectID, email string) error {
+	return c.sync([]syncCommand{newSyncCommand("delete_collaborator", map[string]string{
+		"project_id": projectID,
+		"email":      email,
+	})})
+}
+
 // =============================================================================
 // COMPLETED TASKS (Sync API)
 // =============================================================================
@@ -651,12 +1653,40 @@ type CompletedTask struct {
 	TaskID      string `json:"task_id"`
 	Content     string `json:"content"`
 	ProjectID   string `json:"project_id"`
+	SectionID   string `json:"section_id,omitempty"`
+	ParentID    string `json:"parent_id,omitempty"`
 	CompletedAt string `json:"completed_at"`
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modeled here
+}
+
+// UnmarshalJSON decodes a completed task leniently; see decodeObject
+func (t *CompletedTask) UnmarshalJSON(data []byte) error {
+	type plain CompletedTask
+	extra, err := decodeObject(data, (*plain)(t))
+	t.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the completed task with any fields the API sent that it does not model
+func (t CompletedTask) MarshalJSON() ([]byte, error) {
+	type plain CompletedTask
+	return encodeObject(plain(t), t.Extra)
+}
+
+// TaskKey returns the ID of the completed task itself. Subtasks refer to
+// their parent by this ID.
+func (t *CompletedTask) TaskKey() string {
+	if t.TaskID != "" {
+		return t.TaskID
+	}
+	return t.ID
 }
 
 // CompletedTasksResponse is the response from the completed tasks endpoint
 type CompletedTasksResponse struct {
-	Items []CompletedTask `json:"items"`
+	Items      []CompletedTask `json:"items"`
+	NextCursor string          `json:"next_cursor,omitempty"`
 }
 
 // MoveTask moves a task to a different section or project using the Sync API
@@ -687,11 +1717,48 @@ func (c *Client) MoveTask(taskID, sectionID, projectID string) error {
 	return err
 }
 
-// GetCompletedTasks returns completed tasks
-func (c *Client) GetCompletedTasks(projectID, since, until string, limit int) (*CompletedTasksResponse, error) {
+// SetTaskParent nests tasks under a new parent using the Sync API item_move command
+func (c *Client) SetTaskParent(parentID string, taskIDs ...string) error {
+	commands := make([]syncCommand, 0, len(taskIDs))
+	for _, id := range taskIDs {
+		commands = append(commands, newSyncCommand("item_move", map[string]string{
+			"id":        id,
+			"parent_id": parentID,
+		}))
+	}
+
+	return c.sync(commands)
+}
+
+// GetCompletedSubtasks returns the completed direct children of a task
+func (c *Client) GetCompletedSubtasks(ctx context.Context, parentID string) ([]CompletedTask, error) {
+	params := map[string]string{
+		"parent_id": parentID,
+		"limit":     "200",
+	}
+
+	resp, err := c.requestCtx(ctx, "GET", "tasks/completed", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result CompletedTasksResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse completed tasks: %w", err)
+	}
+
+	return result.Items, nil
+}
+
+// GetCompletedTasks returns one page of completed tasks, starting at cursor
+// when it is non-empty
+func (c *Client) GetCompletedTasks(projectID, since, until string, limit int, cursor string) (*CompletedTasksResponse, error) {
 	params := map[string]string{
 		"limit": strconv.Itoa(limit),
 	}
+	if cursor != "" {
+		params["cursor"] = cursor
+	}
 	if projectID != "" {
 		params["project_id"] = projectID
 	}
@@ -714,3 +1781,150 @@ func (c *Client) GetCompletedTasks(projectID, since, until string, limit int) (*
 
 	return &result, nil
 }
+
+// =============================================================================
+// ACTIVITY
+// =============================================================================
+
+// Activity is one event of the activity log, such as a task being added
+// or completed or a comment being posted
+type Activity struct {
+	ID              string       `json:"id"`
+	ObjectType      string       `json:"object_type"` // item, note, project or section
+	ObjectID        string       `json:"object_id"`
+	EventType       string       `json:"event_type"` // added, updated, completed, uncompleted, deleted, ...
+	EventDate       string       `json:"event_date"`
+	ParentProjectID string       `json:"parent_project_id,omitempty"`
+	ParentItemID    string       `json:"parent_item_id,omitempty"`
+	InitiatorID     string       `json:"initiator_id,omitempty"`
+	ExtraData       ActivityData `json:"extra_data"`
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modeled here
+}
+
+// ActivityData holds the details of an activity event
+type ActivityData struct {
+	Content     string `json:"content,omitempty"`       // the task or comment text
+	DueDate     string `json:"due_date,omitempty"`      // the new due date of an updated task
+	LastDueDate string `json:"last_due_date,omitempty"` // its due date before the update
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modeled here
+}
+
+// UnmarshalJSON decodes an activity event leniently; see decodeObject
+func (a *Activity) UnmarshalJSON(data []byte) error {
+	type plain Activity
+	extra, err := decodeObject(data, (*plain)(a))
+	a.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the event with any fields the API sent that it does not model
+func (a Activity) MarshalJSON() ([]byte, error) {
+	type plain Activity
+	return encodeObject(plain(a), a.Extra)
+}
+
+// UnmarshalJSON decodes activity details leniently; see decodeObject
+func (d *ActivityData) UnmarshalJSON(data []byte) error {
+	type plain ActivityData
+	extra, err := decodeObject(data, (*plain)(d))
+	d.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the details with any fields the API sent that it does not model
+func (d ActivityData) MarshalJSON() ([]byte, error) {
+	type plain ActivityData
+	return encodeObject(plain(d), d.Extra)
+}
+
+// GetActivities returns the activity log from since onwards, oldest first
+func (c *Client) GetActivities(since time.Time) ([]Activity, error) {
+	return c.activities(map[string]string{
+		"date_from": since.UTC().Format(time.RFC3339),
+	})
+}
+
+// GetTaskCompletions returns the times a task was completed, oldest first;
+// for a recurring task, one event per completed occurrence
+func (c *Client) GetTaskCompletions(taskID string) ([]Activity, error) {
+	return c.activities(map[string]string{
+		"object_type": "item",
+		"object_id":   taskID,
+		"event_type":  "completed",
+	})
+}
+
+// activities reads every page of the activity log matching params
+func (c *Client) activities(params map[string]string) ([]Activity, error) {
+	params["limit"] = "100"
+
+	var events []Activity
+	err := c.listPages("activities", params, func(results json.RawMessage) error {
+		var page []Activity
+		if err := json.Unmarshal(results, &page); err != nil {
+			return fmt.Errorf("failed to parse activity log: %w", err)
+		}
+		events = append(events, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].EventDate < events[j].EventDate })
+	return events, nil
+}
+
+// =============================================================================
+// USER
+// =============================================================================
+
+// User represents the authenticated Todoist user
+type User struct {
+	ID         string  `json:"id"`
+	Email      string  `json:"email"`
+	FullName   string  `json:"full_name"`
+	IsPremium  bool    `json:"is_premium"`
+	TZInfo     *TZInfo `json:"tz_info,omitempty"`
+	DailyGoal  int     `json:"daily_goal"`
+	WeeklyGoal int     `json:"weekly_goal"`
+
+	Extra map[string]json.RawMessage `json:"-"` // fields not modeled here
+}
+
+// UnmarshalJSON decodes a user leniently; see decodeObject
+func (u *User) UnmarshalJSON(data []byte) error {
+	type plain User
+	extra, err := decodeObject(data, (*plain)(u))
+	u.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the user with any fields the API sent that it does not model
+func (u User) MarshalJSON() ([]byte, error) {
+	type plain User
+	return encodeObject(plain(u), u.Extra)
+}
+
+// TZInfo is the user's timezone setting
+type TZInfo struct {
+	Timezone  string `json:"timezone"`
+	GMTString string `json:"gmt_string"`
+}
+
+// GetUser returns the account that owns the API token
+func (c *Client) GetUser() (*User, error) {
+	resp, err := c.request("GET", "user", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var user User
+	if err := json.Unmarshal(resp, &user); err != nil {
+		return nil, fmt.Errorf("failed to parse user: %w", err)
+	}
+
+	return &user, nil
+}
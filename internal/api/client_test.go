@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetBaseURL(t *testing.T) {
+	var gotPath, gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotAuth = r.URL.Path, r.Header.Get("Authorization")
+		w.Write([]byte(`{"id":"1","email":"me@example.com"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("test-token")
+	client.SetBaseURL(srv.URL + "/api/v1/")
+
+	user, err := client.GetUser()
+	if err != nil {
+		t.Fatalf("GetUser failed: %v", err)
+	}
+	if user.Email != "me@example.com" {
+		t.Errorf("got email %q", user.Email)
+	}
+	if gotPath != "/api/v1/user" {
+		t.Errorf("got path %q, want /api/v1/user", gotPath)
+	}
+	if gotAuth != "Bearer test-token" {
+		t.Errorf("got Authorization %q", gotAuth)
+	}
+}
+
+func TestMatchProject(t *testing.T) {
+	projects := []Project{{ID: "1", Name: "Inbox Zero Ideas"}, {ID: "2", Name: "Inbox"}, {ID: "3", Name: "Work"}}
+	for name, want := range map[string]string{"inbox": "2", "zero": "1", "WORK": "3"} {
+		p, err := MatchProject(projects, name)
+		if err != nil || p.ID != want {
+			t.Errorf("MatchProject(%q) = %v, %v; want project %s", name, p, err, want)
+		}
+	}
+	if _, err := MatchProject(projects, "Home"); err == nil {
+		t.Error("expected an unknown project to be an error")
+	}
+}
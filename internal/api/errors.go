@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// Error codes reported in the JSON envelope. They are part of the documented
+// output contract: existing codes keep their meaning.
+const (
+	CodeAuth        = "auth_failed"
+	CodeNotFound    = "not_found"
+	CodeBadRequest  = "bad_request"
+	CodeRateLimited = "rate_limited"
+	CodeServer      = "server_error"
+	CodeNetwork     = "network_error"
+	CodeTimeout     = "timeout"
+	CodeUnknown     = "error"
+)
+
+// APIError is a non-2xx response from the Todoist API
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error (%d): %s", e.StatusCode, e.Body)
+}
+
+// ErrorCode classifies err into one of the stable Code* values
+func ErrorCode(err error) string {
+	var apiErr *APIError
+	var retryErr *retryAfterError
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &apiErr):
+		switch {
+		case apiErr.StatusCode == 401 || apiErr.StatusCode == 403:
+			return CodeAuth
+		case apiErr.StatusCode == 404:
+			return CodeNotFound
+		case apiErr.StatusCode == 429:
+			return CodeRateLimited
+		case apiErr.StatusCode >= 500:
+			return CodeServer
+		default:
+			return CodeBadRequest
+		}
+	case errors.As(err, &retryErr):
+		return CodeRateLimited
+	case errors.Is(err, context.DeadlineExceeded):
+		return CodeTimeout
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			return CodeTimeout
+		}
+		return CodeNetwork
+	}
+	return CodeUnknown
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{&APIError{StatusCode: 401}, CodeAuth},
+		{fmt.Errorf("authentication failed: %w", &APIError{StatusCode: 403}), CodeAuth},
+		{&APIError{StatusCode: 404}, CodeNotFound},
+		{&APIError{StatusCode: 400}, CodeBadRequest},
+		{&APIError{StatusCode: 502}, CodeServer},
+		{fmt.Errorf("max retries exceeded: %w", &retryAfterError{after: time.Second}), CodeRateLimited},
+		{fmt.Errorf("task not found"), CodeUnknown},
+	}
+	for _, tt := range tests {
+		if got := ErrorCode(tt.err); got != tt.want {
+			t.Errorf("ErrorCode(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
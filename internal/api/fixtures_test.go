@@ -0,0 +1,99 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if r.URL.Path == "/api/v1/sync" {
+			w.Write([]byte(`{"sync_status":{}}`))
+			return
+		}
+		if n == 1 {
+			w.Write([]byte(`{"results":[{"id":"1","content":"First"}],"next_cursor":null}`))
+			return
+		}
+		w.Write([]byte(`{"results":[{"id":"1","content":"Changed"}],"next_cursor":null}`))
+	}))
+
+	recorder := NewClient("test-token")
+	recorder.SetBaseURL(srv.URL + "/api/v1")
+	if err := recorder.Record(dir); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := recorder.GetTasks("", ""); err != nil {
+			t.Fatalf("recording GetTasks failed: %v", err)
+		}
+	}
+	if err := recorder.SetTaskPriorities([]string{"1"}, 4); err != nil {
+		t.Fatalf("recording sync failed: %v", err)
+	}
+	srv.Close()
+
+	player := NewClient("")
+	player.SetBaseURL(srv.URL + "/api/v1")
+	if err := player.Replay(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	// Repeated requests replay in order, then stick to the last recording
+	for _, want := range []string{"First", "Changed", "Changed"} {
+		tasks, err := player.GetTasks("", "")
+		if err != nil {
+			t.Fatalf("replaying GetTasks failed: %v", err)
+		}
+		if len(tasks) != 1 || tasks[0].Content != want {
+			t.Errorf("got %+v, want content %q", tasks, want)
+		}
+	}
+
+	// Sync command UUIDs differ between runs but still match
+	if err := player.SetTaskPriorities([]string{"1"}, 4); err != nil {
+		t.Errorf("replaying sync failed: %v", err)
+	}
+
+	if _, err := player.GetTask("2"); err == nil {
+		t.Error("expected an error for a request that was never recorded")
+	}
+}
+
+func TestRecordScrubsSecretFields(t *testing.T) {
+	dir := t.TempDir()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"42","email":"me@example.com","token":"0123456789abcdef"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("test-token")
+	client.SetBaseURL(srv.URL + "/api/v1")
+	if err := client.Record(dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetUser(); err != nil {
+		t.Fatalf("recording GetUser failed: %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("expected one fixture, got %v", files)
+	}
+	data, _ := os.ReadFile(files[0])
+	if strings.Contains(string(data), "0123456789abcdef") {
+		t.Errorf("token saved in fixture:\n%s", data)
+	}
+	if !strings.Contains(string(data), `"token": "[REDACTED]"`) {
+		t.Errorf("expected the token field redacted, got:\n%s", data)
+	}
+}
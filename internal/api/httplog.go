@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redactedHeaders are never written to the HTTP log
+var redactedHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+// secretFields matches JSON fields whose values are credentials, such as
+// the API token in the /user response
+var secretFields = regexp.MustCompile(`("(?:token|access_token|refresh_token|api_token|client_secret|password)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// httpLog writes full request/response exchanges to a file for debugging.
+// It is safe for concurrent use; each exchange is written in one piece.
+type httpLog struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// SetHTTPLog records every request and response, including bodies and a
+// timing breakdown, to w. Credentials are redacted. Pass nil to stop.
+func (c *Client) SetHTTPLog(w io.Writer) {
+	if w == nil {
+		c.httpLog = nil
+		return
+	}
+	c.httpLog = &httpLog{w: w}
+}
+
+// timing collects connection phase durations for one request
+type timing struct {
+	start                  time.Time
+	dnsStart, dnsDone      time.Time
+	connectStart, connDone time.Time
+	tlsStart, tlsDone      time.Time
+	wrote, firstByte       time.Time
+	reused                 bool
+}
+
+// trace attaches a client trace that fills in t
+func (t *timing) trace(ctx context.Context) context.Context {
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { t.dnsStart = time.Now() },
+		DNSDone:              func(httptrace.DNSDoneInfo) { t.dnsDone = time.Now() },
+		ConnectStart:         func(string, string) { t.connectStart = time.Now() },
+		ConnectDone:          func(string, string, error) { t.connDone = time.Now() },
+		TLSHandshakeStart:    func() { t.tlsStart = time.Now() },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { t.tlsDone = time.Now() },
+		GotConn:              func(info httptrace.GotConnInfo) { t.reused = info.Reused },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { t.wrote = time.Now() },
+		GotFirstResponseByte: func() { t.firstByte = time.Now() },
+	})
+}
+
+// String summarizes the phases that happened, e.g.
+// "dns=3ms connect=21ms tls=48ms ttfb=130ms total=134ms"
+func (t *timing) String() string {
+	var parts []string
+	phase := func(name string, from, to time.Time) {
+		if !from.IsZero() && !to.IsZero() {
+			parts = append(parts, fmt.Sprintf("%s=%s", name, to.Sub(from).Round(time.Millisecond)))
+		}
+	}
+	phase("dns", t.dnsStart, t.dnsDone)
+	phase("connect", t.connectStart, t.connDone)
+	phase("tls", t.tlsStart, t.tlsDone)
+	phase("ttfb", t.wrote, t.firstByte)
+	parts = append(parts, fmt.Sprintf("total=%s", time.Since(t.start).Round(time.Millisecond)))
+	if t.reused {
+		parts = append(parts, "(connection reused)")
+	}
+	return strings.Join(parts, " ")
+}
+
+// record writes one exchange. resp is nil when the request failed, in which
+// case err is logged instead.
+func (l *httpLog) record(req *http.Request, reqBody []byte, resp *http.Response, respBody []byte, err error, t *timing) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== %s %s %s\n", t.start.UTC().Format(time.RFC3339Nano), req.Method, req.URL)
+	writeHeaders(&b, "> ", req.Header)
+	writeBody(&b, "> ", reqBody)
+
+	if resp != nil {
+		fmt.Fprintf(&b, "< %s\n", resp.Status)
+		writeHeaders(&b, "< ", resp.Header)
+		writeBody(&b, "< ", respBody)
+	} else {
+		fmt.Fprintf(&b, "! %v\n", err)
+	}
+	fmt.Fprintf(&b, "timing: %s\n\n", t)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	io.WriteString(l.w, b.String())
+}
+
+// writeHeaders writes headers in a stable order with credentials redacted
+func writeHeaders(b *strings.Builder, prefix string, h http.Header) {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, v := range h[name] {
+			if redactedHeaders[name] {
+				v = redact(v)
+			}
+			fmt.Fprintf(b, "%s%s: %s\n", prefix, name, v)
+		}
+	}
+}
+
+// redact hides a credential, keeping the auth scheme so the log still shows
+// which kind was sent
+func redact(v string) string {
+	if scheme, _, ok := strings.Cut(v, " "); ok {
+		return scheme + " [REDACTED]"
+	}
+	return "[REDACTED]"
+}
+
+// scrubBody hides the values of secret JSON fields in a body
+func scrubBody(body []byte) []byte {
+	return secretFields.ReplaceAll(body, []byte(`$1"[REDACTED]"`))
+}
+
+// writeBody writes a body after a blank separator line, prefixing each line.
+// Secret fields are scrubbed.
+func writeBody(b *strings.Builder, prefix string, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	b.WriteString(strings.TrimSpace(prefix) + "\n")
+	for _, line := range strings.Split(strings.TrimRight(string(scrubBody(body)), "\n"), "\n") {
+		fmt.Fprintf(b, "%s%s\n", prefix, line)
+	}
+}
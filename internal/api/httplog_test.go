@@ -0,0 +1,74 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTPLogRedactsCredentials(t *testing.T) {
+	var buf bytes.Buffer
+	l := &httpLog{w: &buf}
+
+	req := httptest.NewRequest("POST", "https://api.todoist.com/api/v1/sync", nil)
+	req.Header.Set("Authorization", "Bearer secret-token")
+	resp := &http.Response{Status: "200 OK", Header: http.Header{"Content-Type": {"application/json"}}}
+
+	l.record(req, []byte(`{"commands":[]}`), resp, []byte(`{"sync_status":{}}`), nil, &timing{start: time.Now()})
+
+	got := buf.String()
+	if strings.Contains(got, "secret-token") {
+		t.Errorf("token leaked into log:\n%s", got)
+	}
+	for _, want := range []string{
+		"POST https://api.todoist.com/api/v1/sync\n",
+		"> Authorization: Bearer [REDACTED]\n",
+		"> {\"commands\":[]}\n",
+		"< 200 OK\n",
+		"< {\"sync_status\":{}}\n",
+		"timing: total=",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestHTTPLogScrubsSecretFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := &httpLog{w: &buf}
+
+	req := httptest.NewRequest("GET", "https://api.todoist.com/api/v1/user", nil)
+	resp := &http.Response{Status: "200 OK", Header: http.Header{}}
+	body := `{"id":"42","email":"me@example.com","token": "0123456789abcdef","nested":{"access_token":"tok\"en"}}`
+
+	l.record(req, nil, resp, []byte(body), nil, &timing{start: time.Now()})
+
+	got := buf.String()
+	for _, secret := range []string{"0123456789abcdef", `tok\"en`} {
+		if strings.Contains(got, secret) {
+			t.Errorf("secret %q leaked into log:\n%s", secret, got)
+		}
+	}
+	for _, want := range []string{`"email":"me@example.com"`, `"token": "[REDACTED]"`, `"access_token":"[REDACTED]"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestHTTPLogRecordsFailures(t *testing.T) {
+	var buf bytes.Buffer
+	l := &httpLog{w: &buf}
+
+	req := httptest.NewRequest("GET", "https://api.todoist.com/api/v1/tasks", nil)
+	l.record(req, nil, nil, nil, errors.New("connection refused"), &timing{start: time.Now()})
+
+	if !strings.Contains(buf.String(), "! connection refused\n") {
+		t.Errorf("expected the error to be logged, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Resources decode through decodeObject and encode through encodeObject, so
+// the CLI keeps working as the API evolves: IDs sent as numbers are read as
+// strings, and fields the structs do not model are kept in Extra and written
+// back out, letting --json pass whole objects through.
+
+// decodeObject decodes the JSON object data into v, a pointer to a struct
+// without JSON methods, and returns the fields v has no place for
+func decodeObject(data []byte, v interface{}) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		// Not an object: let the plain decoder report it
+		return nil, json.Unmarshal(data, v)
+	}
+
+	quoted := false
+	for k, raw := range fields {
+		if isIDField(k) && isNumber(raw) {
+			fields[k] = json.RawMessage(`"` + string(raw) + `"`)
+			quoted = true
+		}
+	}
+	if quoted {
+		var err error
+		if data, err = json.Marshal(fields); err != nil {
+			return nil, err
+		}
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+
+	known := jsonFields(reflect.TypeOf(v).Elem())
+	var extra map[string]json.RawMessage
+	for k, raw := range fields {
+		if !known[k] {
+			if extra == nil {
+				extra = make(map[string]json.RawMessage)
+			}
+			extra[k] = raw
+		}
+	}
+	return extra, nil
+}
+
+// encodeObject encodes v, a struct without JSON methods, followed by the
+// extra fields it does not define itself
+func encodeObject(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+	known := jsonFields(reflect.TypeOf(v))
+	add := make(map[string]interface{}, len(extra))
+	for k, raw := range extra {
+		if !known[k] {
+			add[k] = raw
+		}
+	}
+	return appendFields(data, add)
+}
+
+// MarshalWith encodes base, a JSON object, with fields added after its own.
+// Types that embed a resource use it, since the resource's MarshalJSON
+// would otherwise hide their other fields.
+func MarshalWith(base interface{}, fields map[string]interface{}) ([]byte, error) {
+	data, err := json.Marshal(base)
+	if err != nil {
+		return nil, err
+	}
+	return appendFields(data, fields)
+}
+
+// appendFields adds fields, in key order, to the end of a JSON object
+func appendFields(object []byte, fields map[string]interface{}) ([]byte, error) {
+	if len(fields) == 0 {
+		return object, nil
+	}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	object = bytes.TrimSpace(object)
+	if len(object) < 2 || object[0] != '{' {
+		return nil, fmt.Errorf("cannot add fields to %s", object)
+	}
+	var b bytes.Buffer
+	b.Write(object[:len(object)-1])
+	empty := len(bytes.TrimSpace(object[1:len(object)-1])) == 0
+	for _, k := range keys {
+		value, err := json.Marshal(fields[k])
+		if err != nil {
+			return nil, err
+		}
+		if !empty {
+			b.WriteByte(',')
+		}
+		empty = false
+		name, _ := json.Marshal(k)
+		b.Write(name)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// isDeleted reports whether Sync marked a resource deleted, going by the
+// fields its type does not model
+func isDeleted(extra map[string]json.RawMessage) bool {
+	return string(extra["is_deleted"]) == "true"
+}
+
+// isIDField reports whether a field holds an ID, such as id, project_id or
+// responsible_uid
+func isIDField(name string) bool {
+	return name == "id" || strings.HasSuffix(name, "_id") || strings.HasSuffix(name, "_uid")
+}
+
+func isNumber(raw json.RawMessage) bool {
+	raw = bytes.TrimSpace(raw)
+	return len(raw) > 0 && (raw[0] == '-' || (raw[0] >= '0' && raw[0] <= '9'))
+}
+
+var fieldCache sync.Map // reflect.Type -> map[string]bool
+
+// jsonFields returns the JSON names of a struct type's fields
+func jsonFields(t reflect.Type) map[string]bool {
+	if known, ok := fieldCache.Load(t); ok {
+		return known.(map[string]bool)
+	}
+	known := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch {
+		case name == "-" || !f.IsExported():
+		case name == "":
+			known[f.Name] = true
+		default:
+			known[name] = true
+		}
+	}
+	fieldCache.Store(t, known)
+	return known
+}
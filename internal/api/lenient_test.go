@@ -0,0 +1,71 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTaskDecodesNumericIDs(t *testing.T) {
+	data := `{"id":61,"content":"Buy milk","project_id":2203306141,"parent_id":null,"responsible_uid":42,"priority":4}`
+
+	var task Task
+	if err := json.Unmarshal([]byte(data), &task); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if task.ID != "61" || task.ProjectID != "2203306141" || task.Assignee != "42" || task.ParentID != "" {
+		t.Errorf("unexpected IDs: %+v", task)
+	}
+	if task.Priority != 4 {
+		t.Errorf("expected other numbers to stay numbers, got priority %d", task.Priority)
+	}
+}
+
+func TestTaskKeepsUnknownFields(t *testing.T) {
+	data := `{"id":"1","content":"Write report","due":{"date":"2024-01-15","string":"today","lang":"en"},"child_count":3,"deadline":{"date":"2024-02-01"}}`
+
+	var task Task
+	if err := json.Unmarshal([]byte(data), &task); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if string(task.Extra["child_count"]) != "3" || task.Due.Extra["lang"] == nil {
+		t.Fatalf("expected unknown fields to be kept, got %v and %v", task.Extra, task.Due.Extra)
+	}
+	if _, ok := task.Extra["content"]; ok {
+		t.Error("expected modeled fields to stay out of Extra")
+	}
+
+	out, err := json.Marshal(task)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var back map[string]interface{}
+	json.Unmarshal(out, &back)
+	if back["child_count"] != 3.0 || back["deadline"] == nil || back["content"] != "Write report" {
+		t.Errorf("expected the full object to pass through, got %s", out)
+	}
+	if due := back["due"].(map[string]interface{}); due["lang"] != "en" {
+		t.Errorf("expected nested unknown fields to pass through, got %s", out)
+	}
+}
+
+func TestMarshalWith(t *testing.T) {
+	task := Task{ID: "1", Extra: map[string]json.RawMessage{"child_count": json.RawMessage("2")}}
+	out, err := MarshalWith(task, map[string]interface{}{"score": 1.5})
+	if err != nil {
+		t.Fatalf("MarshalWith failed: %v", err)
+	}
+	var back map[string]interface{}
+	if err := json.Unmarshal(out, &back); err != nil {
+		t.Fatalf("invalid JSON %s: %v", out, err)
+	}
+	if back["id"] != "1" || back["child_count"] != 2.0 || back["score"] != 1.5 {
+		t.Errorf("unexpected output %s", out)
+	}
+
+	if out, err := MarshalWith(struct{}{}, map[string]interface{}{"a": 1}); err != nil || string(out) != `{"a":1}` {
+		t.Errorf("empty object: got %s, %v", out, err)
+	}
+	if _, err := MarshalWith(nil, map[string]interface{}{"a": 1}); err == nil {
+		t.Error("expected an error adding fields to null")
+	}
+}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"sort"
+	"strconv"
+)
+
+// TaskOrder sorts tasks the way the Todoist apps list them. Project lists
+// group tasks by project and section, in sidebar order, and follow
+// child_order within each. Day lists, such as Today, go by due date and then
+// by day_order, the order tasks were dragged into for that day. Remaining
+// ties fall back to the task ID, so the result never depends on the order
+// the API returned.
+type TaskOrder struct {
+	ByDay    bool           // order as a day list rather than a project list
+	projects map[string]int // project ID -> position in the sidebar
+	sections map[string]int // section ID -> section_order
+}
+
+// SetPlaces records the positions of projects and sections. Without them
+// tasks are still grouped by project and section, but in ID order.
+func (o *TaskOrder) SetPlaces(projects []Project, sections []Section) {
+	o.projects = projectPositions(projects)
+	o.sections = make(map[string]int, len(sections))
+	for _, s := range sections {
+		o.sections[s.ID] = s.SectionOrder
+	}
+}
+
+// Sort orders tasks in place
+func (o *TaskOrder) Sort(tasks []Task) {
+	sort.SliceStable(tasks, func(i, j int) bool { return o.Less(&tasks[i], &tasks[j]) })
+}
+
+// Less reports whether a is listed before b
+func (o *TaskOrder) Less(a, b *Task) bool {
+	if o.ByDay {
+		if da, db := dueDay(a), dueDay(b); da != db {
+			switch {
+			case da == "":
+				return false // undated tasks go last
+			case db == "":
+				return true
+			}
+			return da < db
+		}
+		if ka, kb := dayOrderKey(a), dayOrderKey(b); ka != kb {
+			return ka < kb
+		}
+	}
+
+	if a.ProjectID != b.ProjectID {
+		return lessPlace(o.projects, a.ProjectID, b.ProjectID)
+	}
+	if a.SectionID != b.SectionID {
+		switch {
+		case a.SectionID == "":
+			return true // tasks outside sections come first
+		case b.SectionID == "":
+			return false
+		}
+		return lessPlace(o.sections, a.SectionID, b.SectionID)
+	}
+	if a.ChildOrder != b.ChildOrder {
+		return a.ChildOrder < b.ChildOrder
+	}
+	return lessID(a.ID, b.ID)
+}
+
+// SortSiblings orders subtasks of one parent by child_order
+func SortSiblings(tasks []*Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].ChildOrder != tasks[j].ChildOrder {
+			return tasks[i].ChildOrder < tasks[j].ChildOrder
+		}
+		return lessID(tasks[i].ID, tasks[j].ID)
+	})
+}
+
+// dueDay returns the date a task is due, without its time
+func dueDay(t *Task) string {
+	if t.Due == nil || len(t.Due.Date) < 10 {
+		return ""
+	}
+	return t.Due.Date[:10]
+}
+
+// dayOrderKey puts tasks arranged by hand (day_order 0 and up) before the
+// rest, which the API reports as -1
+func dayOrderKey(t *Task) int {
+	if t.DayOrder < 0 {
+		return int(^uint(0) >> 1)
+	}
+	return t.DayOrder
+}
+
+// lessPlace compares two project or section IDs by known position, placing
+// unknown ones after known ones
+func lessPlace(positions map[string]int, a, b string) bool {
+	pa, okA := positions[a]
+	pb, okB := positions[b]
+	switch {
+	case okA && okB && pa != pb:
+		return pa < pb
+	case okA != okB:
+		return okA
+	}
+	return lessID(a, b)
+}
+
+// lessID compares IDs numerically when both are numbers, since older IDs
+// are shorter
+func lessID(a, b string) bool {
+	na, errA := strconv.ParseInt(a, 10, 64)
+	nb, errB := strconv.ParseInt(b, 10, 64)
+	if errA == nil && errB == nil {
+		return na < nb
+	}
+	return a < b
+}
+
+// projectPositions numbers projects as the sidebar shows them: the Inbox
+// first, then each project followed by its sub-projects, by child_order
+func projectPositions(projects []Project) map[string]int {
+	children := make(map[string][]Project)
+	known := make(map[string]bool, len(projects))
+	for _, p := range projects {
+		known[p.ID] = true
+	}
+	for _, p := range projects {
+		parent := p.ParentID
+		if !known[parent] {
+			parent = ""
+		}
+		children[parent] = append(children[parent], p)
+	}
+
+	positions := make(map[string]int, len(projects))
+	var visit func(parent string)
+	visit = func(parent string) {
+		list := children[parent]
+		sort.SliceStable(list, func(i, j int) bool {
+			if list[i].IsInboxProject != list[j].IsInboxProject {
+				return list[i].IsInboxProject
+			}
+			if list[i].ChildOrder != list[j].ChildOrder {
+				return list[i].ChildOrder < list[j].ChildOrder
+			}
+			return lessID(list[i].ID, list[j].ID)
+		})
+		for _, p := range list {
+			positions[p.ID] = len(positions)
+			visit(p.ID)
+		}
+	}
+	visit("")
+	return positions
+}
@@ -0,0 +1,81 @@
+package api
+
+import "testing"
+
+func TestTaskOrderProjectList(t *testing.T) {
+	projects := []Project{
+		{ID: "p2", Name: "Work", ChildOrder: 1},
+		{ID: "p3", Name: "Client", ParentID: "p2", ChildOrder: 1},
+		{ID: "p4", Name: "Home", ChildOrder: 2},
+		{ID: "p1", Name: "Inbox", IsInboxProject: true, ChildOrder: 9},
+	}
+	sections := []Section{{ID: "s1", ProjectID: "p2", SectionOrder: 2}, {ID: "s2", ProjectID: "p2", SectionOrder: 1}}
+
+	tasks := []Task{
+		{ID: "h", ProjectID: "p4", ChildOrder: 1},
+		{ID: "c", ProjectID: "p3", ChildOrder: 1},
+		{ID: "s1b", ProjectID: "p2", SectionID: "s1", ChildOrder: 2},
+		{ID: "s1a", ProjectID: "p2", SectionID: "s1", ChildOrder: 1},
+		{ID: "s2a", ProjectID: "p2", SectionID: "s2", ChildOrder: 5},
+		{ID: "w", ProjectID: "p2", ChildOrder: 3},
+		{ID: "i", ProjectID: "p1", ChildOrder: 7},
+		{ID: "x", ProjectID: "unknown", ChildOrder: 0},
+	}
+
+	var o TaskOrder
+	o.SetPlaces(projects, sections)
+	o.Sort(tasks)
+
+	want := []string{"i", "w", "s2a", "s1a", "s1b", "c", "h", "x"}
+	for i, id := range want {
+		if tasks[i].ID != id {
+			t.Fatalf("got %v, want %v", taskIDs(tasks), want)
+		}
+	}
+}
+
+func TestTaskOrderDayList(t *testing.T) {
+	tasks := []Task{
+		{ID: "1", ProjectID: "a", DayOrder: -1, Due: &Due{Date: "2024-01-15"}},
+		{ID: "2", ProjectID: "b", DayOrder: 2, Due: &Due{Date: "2024-01-15T09:00:00"}},
+		{ID: "3", ProjectID: "a", DayOrder: 1, Due: &Due{Date: "2024-01-15"}},
+		{ID: "4", ProjectID: "a", DayOrder: 5, Due: &Due{Date: "2024-01-10"}},
+		{ID: "5", ProjectID: "a", DayOrder: 0},
+		{ID: "6", ProjectID: "b", DayOrder: -1, Due: &Due{Date: "2024-01-15"}},
+	}
+
+	o := TaskOrder{ByDay: true}
+	o.Sort(tasks)
+
+	want := []string{"4", "3", "2", "1", "6", "5"}
+	for i, id := range want {
+		if tasks[i].ID != id {
+			t.Fatalf("got %v, want %v", taskIDs(tasks), want)
+		}
+	}
+}
+
+func TestTaskOrderIsIndependentOfInput(t *testing.T) {
+	a := []Task{{ID: "10", ProjectID: "p"}, {ID: "9", ProjectID: "p"}, {ID: "11", ProjectID: "p"}}
+	b := []Task{a[2], a[0], a[1]}
+
+	var o TaskOrder
+	o.Sort(a)
+	o.Sort(b)
+	for i := range a {
+		if a[i].ID != b[i].ID {
+			t.Fatalf("got %v and %v", taskIDs(a), taskIDs(b))
+		}
+	}
+	if a[0].ID != "9" {
+		t.Errorf("expected numeric IDs to compare as numbers, got %v", taskIDs(a))
+	}
+}
+
+func taskIDs(tasks []Task) []string {
+	ids := make([]string, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+	return ids
+}
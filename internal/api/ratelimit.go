@@ -0,0 +1,172 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Todoist's documented per-user budgets, all measured over RateWindow
+const (
+	RateWindow         = 15 * time.Minute
+	RequestBudget      = 1000
+	FullSyncBudget     = 100
+	PartialSyncBudget  = 1000
+	paceReserveDivisor = 10 // start pacing when a tenth of the budget is left
+)
+
+// RateLimit is the request budget most recently reported by the API
+type RateLimit struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset"`
+}
+
+// rateLimiter tracks the reported budget and spaces requests out once it
+// runs low, so bulk commands slow down instead of running into a 429.
+type rateLimiter struct {
+	mu    sync.Mutex
+	limit RateLimit
+	known bool
+	next  time.Time // earliest start for the next paced request
+}
+
+// update records the budget from a response's rate limit headers. Both the
+// X-RateLimit-* and the draft standard RateLimit-* names are understood.
+func (r *rateLimiter) update(h http.Header, now time.Time) {
+	remaining, ok := headerInt(h, "X-RateLimit-Remaining", "RateLimit-Remaining")
+	if !ok {
+		return
+	}
+	limit, _ := headerInt(h, "X-RateLimit-Limit", "RateLimit-Limit")
+	reset := now.Add(RateWindow)
+	if v, ok := headerInt(h, "X-RateLimit-Reset", "RateLimit-Reset"); ok {
+		reset = resetTime(v, now)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.limit = RateLimit{Limit: limit, Remaining: remaining, Reset: reset}
+	r.known = true
+}
+
+// exhausted records a 429: nothing is left until the retry delay passes
+func (r *rateLimiter) exhausted(wait time.Duration, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.limit.Remaining = 0
+	r.limit.Reset = now.Add(wait)
+	r.known = true
+}
+
+// delay reserves a request and returns how long to wait before sending it.
+// Requests go out immediately while plenty of budget is left; after that the
+// rest of the budget is spread evenly over the time until the reset.
+func (r *rateLimiter) delay(now time.Time) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.known || !now.Before(r.limit.Reset) {
+		return 0
+	}
+
+	reserve := r.limit.Limit / paceReserveDivisor
+	if r.limit.Limit == 0 {
+		reserve = RequestBudget / paceReserveDivisor
+	}
+	if r.limit.Remaining > reserve {
+		r.limit.Remaining--
+		return 0
+	}
+
+	if r.limit.Remaining <= 0 {
+		// Nothing left: wait for the reset, after which the next response
+		// reports the new budget
+		return r.limit.Reset.Sub(now)
+	}
+
+	spacing := r.limit.Reset.Sub(now) / time.Duration(r.limit.Remaining+1)
+	r.limit.Remaining--
+
+	start := now
+	if r.next.After(start) {
+		start = r.next
+	}
+	r.next = start.Add(spacing)
+	return start.Sub(now)
+}
+
+// current returns the last reported budget
+func (r *rateLimiter) current() (RateLimit, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.limit, r.known
+}
+
+// PacedTransport applies one rate limit budget to every request sent through
+// it, so concurrent commands proxied by the daemon pace together instead of
+// each trusting the budget it last saw.
+type PacedTransport struct {
+	next http.RoundTripper
+	rate rateLimiter
+}
+
+// NewPacedTransport returns a PacedTransport sending requests through next
+func NewPacedTransport(next http.RoundTripper) *PacedTransport {
+	return &PacedTransport{next: next}
+}
+
+// RoundTrip waits for the budget, sends req and records the budget the
+// response reports
+func (p *PacedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if wait := p.rate.delay(time.Now()); wait > 0 {
+		select {
+		case <-req.Context().Done():
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+
+	resp, err := p.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		wait := 5 * time.Second
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			wait = time.Duration(secs) * time.Second
+		}
+		p.rate.exhausted(wait, time.Now())
+	} else {
+		p.rate.update(resp.Header, time.Now())
+	}
+	return resp, nil
+}
+
+// RateLimit returns the budget most recently reported through p
+func (p *PacedTransport) RateLimit() (RateLimit, bool) {
+	return p.rate.current()
+}
+
+// headerInt returns the first of the named headers that holds an integer
+func headerInt(h http.Header, names ...string) (int, bool) {
+	for _, name := range names {
+		if v, err := strconv.Atoi(h.Get(name)); err == nil {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// resetTime interprets a reset header, which is either seconds until the
+// reset or a Unix timestamp
+func resetTime(v int, now time.Time) time.Time {
+	if v > 1_000_000_000 {
+		return time.Unix(int64(v), 0)
+	}
+	return now.Add(time.Duration(v) * time.Second)
+}
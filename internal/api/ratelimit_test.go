@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterUpdate(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	var r rateLimiter
+	if _, ok := r.current(); ok {
+		t.Fatal("expected no budget before any response")
+	}
+
+	r.update(http.Header{}, now)
+	if _, ok := r.current(); ok {
+		t.Fatal("expected responses without headers to be ignored")
+	}
+
+	h := http.Header{}
+	h.Set("X-RateLimit-Limit", "1000")
+	h.Set("X-RateLimit-Remaining", "42")
+	h.Set("X-RateLimit-Reset", "60")
+	r.update(h, now)
+
+	got, ok := r.current()
+	if !ok || got.Limit != 1000 || got.Remaining != 42 || !got.Reset.Equal(now.Add(time.Minute)) {
+		t.Errorf("got %+v, %v", got, ok)
+	}
+
+	h.Set("X-RateLimit-Reset", "1705320000")
+	r.update(h, now)
+	if got, _ := r.current(); !got.Reset.Equal(time.Unix(1705320000, 0)) {
+		t.Errorf("expected epoch reset, got %v", got.Reset)
+	}
+}
+
+func TestRateLimiterDelay(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	r := rateLimiter{known: true, limit: RateLimit{Limit: 1000, Remaining: 500, Reset: now.Add(time.Minute)}}
+	if d := r.delay(now); d != 0 {
+		t.Errorf("expected no delay with plenty of budget, got %s", d)
+	}
+
+	// 3 left over 60s: requests are spaced 15s apart
+	r = rateLimiter{known: true, limit: RateLimit{Limit: 1000, Remaining: 3, Reset: now.Add(time.Minute)}}
+	if d := r.delay(now); d != 0 {
+		t.Errorf("first paced request: got %s, want 0", d)
+	}
+	if d := r.delay(now); d != 15*time.Second {
+		t.Errorf("second paced request: got %s, want 15s", d)
+	}
+
+	r = rateLimiter{known: true, limit: RateLimit{Limit: 1000, Remaining: 0, Reset: now.Add(time.Minute)}}
+	if d := r.delay(now); d != time.Minute {
+		t.Errorf("exhausted budget: got %s, want 1m", d)
+	}
+
+	if d := r.delay(now.Add(2 * time.Minute)); d != 0 {
+		t.Errorf("expected no delay after the reset, got %s", d)
+	}
+}
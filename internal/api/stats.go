@@ -0,0 +1,121 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointStats summarizes requests made to one endpoint
+type EndpointStats struct {
+	Endpoint string        `json:"endpoint"`
+	Calls    int           `json:"calls"`
+	Elapsed  time.Duration `json:"elapsed"`
+}
+
+// Stats records request counts and timings for a Client. It is safe for
+// concurrent use.
+type Stats struct {
+	mu        sync.Mutex
+	requests  int
+	retries   int
+	elapsed   time.Duration
+	endpoints map[string]*EndpointStats
+	skew      time.Duration
+	skewKnown bool
+}
+
+func newStats() *Stats {
+	return &Stats{endpoints: make(map[string]*EndpointStats)}
+}
+
+// record adds one completed HTTP round trip to the totals
+func (s *Stats) record(method, endpoint string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := method + " " + endpointResource(endpoint)
+	e, ok := s.endpoints[key]
+	if !ok {
+		e = &EndpointStats{Endpoint: key}
+		s.endpoints[key] = e
+	}
+	e.Calls++
+	e.Elapsed += d
+	s.requests++
+	s.elapsed += d
+}
+
+// recordRetry counts a request that had to be retried after rate limiting
+func (s *Stats) recordRetry() {
+	s.mu.Lock()
+	s.retries++
+	s.mu.Unlock()
+}
+
+// recordDate compares a response's Date header with the local clock
+func (s *Stats) recordDate(header string, local time.Time) {
+	server, err := http.ParseTime(header)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	s.skew = local.Sub(server)
+	s.skewKnown = true
+	s.mu.Unlock()
+}
+
+// ClockSkew returns how far the local clock is ahead of the server's, as
+// seen in the last response. Date headers have one-second resolution.
+func (s *Stats) ClockSkew() (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.skew, s.skewKnown
+}
+
+// Requests returns the number of HTTP round trips made
+func (s *Stats) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// Retries returns the number of rate-limited retries
+func (s *Stats) Retries() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.retries
+}
+
+// Elapsed returns the total time spent waiting on HTTP responses
+func (s *Stats) Elapsed() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.elapsed
+}
+
+// Endpoints returns per-endpoint totals, slowest first
+func (s *Stats) Endpoints() []EndpointStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]EndpointStats, 0, len(s.endpoints))
+	for _, e := range s.endpoints {
+		result = append(result, *e)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Elapsed > result[j].Elapsed
+	})
+	return result
+}
+
+// endpointResource reduces a request path to its resource name so that
+// "tasks/123" and "tasks/456" are counted together.
+func endpointResource(endpoint string) string {
+	if i := strings.IndexAny(endpoint, "/?"); i >= 0 {
+		return endpoint[:i]
+	}
+	return endpoint
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStats_GroupsByResource(t *testing.T) {
+	s := newStats()
+	s.record("GET", "tasks", time.Second)
+	s.record("GET", "tasks/123", 2*time.Second)
+	s.record("GET", "comments?task_id=1", 500*time.Millisecond)
+	s.recordRetry()
+
+	if s.Requests() != 3 {
+		t.Errorf("expected 3 requests, got %d", s.Requests())
+	}
+	if s.Retries() != 1 {
+		t.Errorf("expected 1 retry, got %d", s.Retries())
+	}
+
+	endpoints := s.Endpoints()
+	if len(endpoints) != 2 {
+		t.Fatalf("expected 2 endpoints, got %d: %+v", len(endpoints), endpoints)
+	}
+	if endpoints[0].Endpoint != "GET tasks" || endpoints[0].Calls != 2 || endpoints[0].Elapsed != 3*time.Second {
+		t.Errorf("unexpected slowest endpoint: %+v", endpoints[0])
+	}
+	if endpoints[1].Endpoint != "GET comments" {
+		t.Errorf("expected comments endpoint second, got %+v", endpoints[1])
+	}
+}
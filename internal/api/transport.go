@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// dnsTTL is how long a resolved API host is reused before looking it up
+// again
+const dnsTTL = 5 * time.Minute
+
+// sharedTransport is used by every client in the process, so commands that
+// make several clients, and the daemon, reuse warm connections
+var sharedTransport = newTransport()
+
+// Transport returns the tuned transport clients use by default: keep-alives,
+// HTTP/2 and cached DNS lookups.
+func Transport() http.RoundTripper {
+	return sharedTransport
+}
+
+func newTransport() *http.Transport {
+	dns := &dnsCache{
+		ttl:     dnsTTL,
+		lookup:  net.DefaultResolver.LookupHost,
+		entries: make(map[string]dnsEntry),
+	}
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dns.dial,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          16,
+		MaxIdleConnsPerHost:   8,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+// dnsCache remembers host lookups for ttl, saving a resolver round trip on
+// each new connection
+type dnsCache struct {
+	ttl    time.Duration
+	lookup func(ctx context.Context, host string) ([]string, error)
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]dnsEntry
+}
+
+type dnsEntry struct {
+	addrs   []string
+	expires time.Time
+}
+
+// resolve returns the addresses for host, from the cache when fresh
+func (d *dnsCache) resolve(ctx context.Context, host string) ([]string, error) {
+	now := time.Now()
+	if d.now != nil {
+		now = d.now()
+	}
+
+	d.mu.Lock()
+	e, ok := d.entries[host]
+	d.mu.Unlock()
+	if ok && now.Before(e.expires) {
+		return e.addrs, nil
+	}
+
+	addrs, err := d.lookup(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	d.mu.Lock()
+	d.entries[host] = dnsEntry{addrs: addrs, expires: now.Add(d.ttl)}
+	d.mu.Unlock()
+	return addrs, nil
+}
+
+// dial connects to addr, trying each cached address of its host in turn
+func (d *dnsCache) dial(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: 10 * time.Second, KeepAlive: 30 * time.Second}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || net.ParseIP(host) != nil {
+		return dialer.DialContext(ctx, network, addr)
+	}
+
+	addrs, err := d.resolve(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	var lastErr error
+	for _, ip := range addrs {
+		conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+	}
+	if lastErr == nil {
+		lastErr = &net.DNSError{Err: "no addresses", Name: host}
+	}
+	// Drop the entry so the next attempt looks the host up again
+	d.mu.Lock()
+	delete(d.entries, host)
+	d.mu.Unlock()
+	return nil, lastErr
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDNSCache(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	lookups := 0
+	d := &dnsCache{
+		ttl: time.Minute,
+		lookup: func(ctx context.Context, host string) ([]string, error) {
+			lookups++
+			if host == "bad.example" {
+				return nil, errors.New("no such host")
+			}
+			return []string{"192.0.2.1"}, nil
+		},
+		now:     func() time.Time { return now },
+		entries: make(map[string]dnsEntry),
+	}
+
+	for i := 0; i < 3; i++ {
+		addrs, err := d.resolve(context.Background(), "api.todoist.com")
+		if err != nil || len(addrs) != 1 || addrs[0] != "192.0.2.1" {
+			t.Fatalf("got %v, %v", addrs, err)
+		}
+	}
+	if lookups != 1 {
+		t.Errorf("expected one lookup while fresh, got %d", lookups)
+	}
+
+	now = now.Add(2 * time.Minute)
+	d.resolve(context.Background(), "api.todoist.com")
+	if lookups != 2 {
+		t.Errorf("expected a new lookup after the TTL, got %d", lookups)
+	}
+
+	if _, err := d.resolve(context.Background(), "bad.example"); err == nil {
+		t.Error("expected lookup failures to be returned")
+	}
+	d.resolve(context.Background(), "bad.example")
+	if lookups != 4 {
+		t.Errorf("expected failures not to be cached, got %d lookups", lookups)
+	}
+}
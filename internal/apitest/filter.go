@@ -0,0 +1,142 @@
+package apitest
+
+import (
+	"strings"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+)
+
+// parseFilter compiles the subset of Todoist filter syntax the CLI sends:
+// terms joined with & and |, where a term is today, tomorrow, overdue,
+// "no date", recurring, p1-p4, #project, @label, "search: text", "due
+// before: date" or "due after: date". Other terms are rejected with 400 like
+// the real API.
+func (s *Server) parseFilter(filter string) (func(*api.Task) bool, error) {
+	if strings.TrimSpace(filter) == "" {
+		return func(*api.Task) bool { return true }, nil
+	}
+
+	var any []func(*api.Task) bool
+	for _, alt := range splitUnescaped(filter, '|') {
+		var all []func(*api.Task) bool
+		for _, term := range splitUnescaped(alt, '&') {
+			m, err := s.parseTerm(strings.TrimSpace(term))
+			if err != nil {
+				return nil, err
+			}
+			all = append(all, m)
+		}
+		any = append(any, func(t *api.Task) bool {
+			for _, m := range all {
+				if !m(t) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+
+	return func(t *api.Task) bool {
+		for _, m := range any {
+			if m(t) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+func (s *Server) parseTerm(term string) (func(*api.Task) bool, error) {
+	today := s.Now().Format("2006-01-02")
+	due := func(t *api.Task) string {
+		if t.Due == nil || len(t.Due.Date) < 10 {
+			return ""
+		}
+		return t.Due.Date[:10]
+	}
+	lower := strings.ToLower(term)
+
+	switch {
+	case lower == "today":
+		return func(t *api.Task) bool { return due(t) == today }, nil
+	case lower == "tomorrow":
+		tomorrow := s.Now().AddDate(0, 0, 1).Format("2006-01-02")
+		return func(t *api.Task) bool { return due(t) == tomorrow }, nil
+	case lower == "overdue" || lower == "od":
+		return func(t *api.Task) bool { return due(t) != "" && due(t) < today }, nil
+	case lower == "no date":
+		return func(t *api.Task) bool { return due(t) == "" }, nil
+	case lower == "recurring":
+		return func(t *api.Task) bool { return t.Due != nil && t.Due.IsRecurring }, nil
+	case len(lower) == 2 && lower[0] == 'p' && lower[1] >= '1' && lower[1] <= '4':
+		priority := 5 - int(lower[1]-'0')
+		return func(t *api.Task) bool { return t.Priority == priority }, nil
+	case strings.HasPrefix(term, "#"):
+		name := unescape(term[1:])
+		return func(t *api.Task) bool {
+			p := s.findProject(t.ProjectID)
+			return p != nil && strings.EqualFold(p.Name, name)
+		}, nil
+	case strings.HasPrefix(term, "@"):
+		name := unescape(term[1:])
+		return func(t *api.Task) bool {
+			for _, l := range t.Labels {
+				if strings.EqualFold(l, name) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case strings.HasPrefix(lower, "search:"):
+		text := strings.ToLower(unescape(strings.TrimSpace(term[len("search:"):])))
+		return func(t *api.Task) bool {
+			return strings.Contains(strings.ToLower(t.Content), text) || strings.Contains(strings.ToLower(t.Description), text)
+		}, nil
+	case strings.HasPrefix(lower, "due before:"):
+		date := s.resolveDue(strings.TrimSpace(term[len("due before:"):]))
+		return func(t *api.Task) bool { return due(t) != "" && due(t) < date }, nil
+	case strings.HasPrefix(lower, "due after:"):
+		date := s.resolveDue(strings.TrimSpace(term[len("due after:"):]))
+		return func(t *api.Task) bool { return due(t) != "" && due(t) > date }, nil
+	}
+
+	return nil, badRequest("unsupported filter term %q", term)
+}
+
+// splitUnescaped splits s on sep, ignoring backslash-escaped separators
+func splitUnescaped(s string, sep rune) []string {
+	var parts []string
+	var b strings.Builder
+	escaped := false
+	for _, r := range s {
+		switch {
+		case escaped:
+			b.WriteRune('\\')
+			b.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == sep:
+			parts = append(parts, b.String())
+			b.Reset()
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return append(parts, b.String())
+}
+
+// unescape removes filter syntax backslash escapes
+func unescape(s string) string {
+	var b strings.Builder
+	escaped := false
+	for _, r := range s {
+		if r == '\\' && !escaped {
+			escaped = true
+			continue
+		}
+		escaped = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,937 @@
+// Package apitest provides an in-memory fake of the Todoist API for tests.
+// It implements the REST and Sync endpoints the CLI uses, closely enough
+// that commands can run end to end against it.
+package apitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+)
+
+// Token is the API token the server accepts
+const Token = "test-token"
+
+// Server is a fake Todoist API. Seed it with the Add* methods, point a
+// client at BaseURL, and inspect the resulting state with Task, Tasks and
+// Requests.
+type Server struct {
+	srv *httptest.Server
+
+	// PageSize splits task, comment and activity lists into pages of this
+	// many items; 0 returns everything in one page
+	PageSize int
+	// Now is the clock used for today/overdue filters and completion times
+	Now func() time.Time
+
+	mu            sync.Mutex
+	nextID        int
+	tasks         []*api.Task
+	completed     []api.CompletedTask
+	projects      []*api.Project
+	sections      []*api.Section
+	labels        []*api.Label
+	comments      []*api.Comment
+	workspaces    []*api.Workspace
+	folders       []*api.Folder
+	activities    []*api.Activity
+	backups       []api.Backup
+	files         map[string][]byte
+	collaborators map[string][]api.Collaborator
+	user          api.User
+	requests      []string
+}
+
+// NewServer starts a fake API with an Inbox project. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		nextID:        100,
+		Now:           time.Now,
+		collaborators: make(map[string][]api.Collaborator),
+		files:         make(map[string][]byte),
+		user:          api.User{ID: "1", Email: "test@example.com", FullName: "Test User"},
+	}
+	s.AddProject(api.Project{Name: "Inbox", IsInboxProject: true})
+	s.srv = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// BaseURL is the API root to give a client
+func (s *Server) BaseURL() string {
+	return s.srv.URL + "/api/v1"
+}
+
+// id hands out the next object ID. Callers hold s.mu.
+func (s *Server) id() string {
+	s.nextID++
+	return strconv.Itoa(s.nextID)
+}
+
+// AddProject seeds a project and returns it with its ID
+func (s *Server) AddProject(p api.Project) api.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p.ID == "" {
+		p.ID = s.id()
+	}
+	s.projects = append(s.projects, &p)
+	return p
+}
+
+// AddWorkspace seeds a workspace and returns it with its ID. Put projects
+// in it by setting their WorkspaceID.
+func (s *Server) AddWorkspace(w api.Workspace) api.Workspace {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if w.ID == "" {
+		w.ID = s.id()
+	}
+	s.workspaces = append(s.workspaces, &w)
+	return w
+}
+
+// AddFolder seeds a project folder and returns it with its ID
+func (s *Server) AddFolder(f api.Folder) api.Folder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if f.ID == "" {
+		f.ID = s.id()
+	}
+	s.folders = append(s.folders, &f)
+	return f
+}
+
+// AddSection seeds a section and returns it with its ID
+func (s *Server) AddSection(sec api.Section) api.Section {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sec.ID == "" {
+		sec.ID = s.id()
+	}
+	s.sections = append(s.sections, &sec)
+	return sec
+}
+
+// AddLabel seeds a label and returns it with its ID
+func (s *Server) AddLabel(l api.Label) api.Label {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if l.ID == "" {
+		l.ID = s.id()
+	}
+	s.labels = append(s.labels, &l)
+	return l
+}
+
+// AddTask seeds an active task and returns it with its ID. Tasks without a
+// project go to the Inbox.
+func (s *Server) AddTask(t api.Task) api.Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t.ID == "" {
+		t.ID = s.id()
+	}
+	if t.ProjectID == "" {
+		t.ProjectID = s.projects[0].ID
+	}
+	if t.Priority == 0 {
+		t.Priority = 1
+	}
+	if t.Labels == nil {
+		t.Labels = []string{}
+	}
+	s.tasks = append(s.tasks, &t)
+	return t
+}
+
+// AddComment seeds a comment and returns it with its ID
+func (s *Server) AddComment(c api.Comment) api.Comment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c.ID == "" {
+		c.ID = s.id()
+	}
+	s.comments = append(s.comments, &c)
+	return c
+}
+
+// AddActivity seeds an activity log event and returns it with its ID
+func (s *Server) AddActivity(a api.Activity) api.Activity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a.ID == "" {
+		a.ID = s.id()
+	}
+	s.activities = append(s.activities, &a)
+	return a
+}
+
+// AddBackup seeds a backup whose zip file holds data and returns it with
+// its download URL
+func (s *Server) AddBackup(version string, data []byte) api.Backup {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name := "backup-" + s.id() + ".zip"
+	s.files[name] = data
+	b := api.Backup{Version: version, URL: s.srv.URL + "/files/" + name}
+	s.backups = append(s.backups, b)
+	return b
+}
+
+// Project returns a project by ID
+func (s *Server) Project(id string) (api.Project, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p := s.findProject(id); p != nil {
+		return *p, true
+	}
+	return api.Project{}, false
+}
+
+// Task returns an active task by ID
+func (s *Server) Task(id string) (api.Task, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t := s.findTask(id); t != nil {
+		return *t, true
+	}
+	return api.Task{}, false
+}
+
+// Tasks returns all active tasks
+func (s *Server) Tasks() []api.Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tasks := make([]api.Task, len(s.tasks))
+	for i, t := range s.tasks {
+		tasks[i] = *t
+	}
+	return tasks
+}
+
+// Completed returns the tasks completed through the API
+func (s *Server) Completed() []api.CompletedTask {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]api.CompletedTask(nil), s.completed...)
+}
+
+// Collaborators returns the people a project is shared with
+func (s *Server) Collaborators(projectID string) []api.Collaborator {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]api.Collaborator(nil), s.collaborators[projectID]...)
+}
+
+// SetCollaborators replaces the people a project is shared with
+func (s *Server) SetCollaborators(projectID string, people ...api.Collaborator) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.collaborators[projectID] = people
+}
+
+// allCollaborators lists everyone sharing a project once, as the Sync API
+// reports them
+func (s *Server) allCollaborators() []map[string]string {
+	seen := make(map[string]bool)
+	people := []map[string]string{}
+	for _, list := range s.collaborators {
+		for _, c := range list {
+			if !seen[c.ID] {
+				seen[c.ID] = true
+				people = append(people, map[string]string{"id": c.ID, "full_name": c.Name, "email": c.Email})
+			}
+		}
+	}
+	return people
+}
+
+// Requests returns every request received as "METHOD /path?query", without
+// the /api/v1 prefix
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) findComment(id string) *api.Comment {
+	for _, c := range s.comments {
+		if c.ID == id {
+			return c
+		}
+	}
+	return nil
+}
+
+func (s *Server) findTask(id string) *api.Task {
+	for _, t := range s.tasks {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+func (s *Server) findProject(id string) *api.Project {
+	for _, p := range s.projects {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+// apiError is returned by handlers to send an error status
+type apiError struct {
+	status int
+	msg    string
+}
+
+func (e *apiError) Error() string { return e.msg }
+
+func notFound(what, id string) error {
+	return &apiError{http.StatusNotFound, fmt.Sprintf("%s %s not found", what, id)}
+}
+
+func badRequest(format string, args ...interface{}) error {
+	return &apiError{http.StatusBadRequest, fmt.Sprintf(format, args...)}
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	logged := r.Method + " " + path
+	if r.URL.RawQuery != "" {
+		logged += "?" + r.URL.RawQuery
+	}
+	s.requests = append(s.requests, logged)
+
+	if r.Header.Get("Authorization") != "Bearer "+Token {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if name, ok := strings.CutPrefix(path, "/files/"); ok {
+		data, ok := s.files[name]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/zip")
+		w.Write(data)
+		return
+	}
+
+	var body map[string]json.RawMessage
+	if r.Body != nil && r.ContentLength != 0 {
+		json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	result, err := s.route(r.Method, strings.Split(strings.Trim(path, "/"), "/"), r, body)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if e, ok := err.(*apiError); ok {
+			status = e.status
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	if result == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *Server) route(method string, parts []string, r *http.Request, body map[string]json.RawMessage) (interface{}, error) {
+	q := r.URL.Query()
+	switch {
+	case method == "POST" && parts[0] == "sync":
+		return s.sync(body)
+	case method == "GET" && parts[0] == "user":
+		return s.user, nil
+
+	case method == "GET" && len(parts) == 2 && parts[0] == "tasks" && parts[1] == "completed":
+		return s.listCompleted(q.Get("project_id"), q.Get("parent_id")), nil
+	case method == "GET" && len(parts) == 1 && parts[0] == "tasks":
+		return s.listTasks(q.Get("project_id"), q.Get("filter"), q.Get("cursor"))
+	case method == "POST" && len(parts) == 1 && parts[0] == "tasks":
+		return s.createTask(body)
+	case len(parts) >= 2 && parts[0] == "tasks":
+		t := s.findTask(parts[1])
+		if t == nil {
+			return nil, notFound("task", parts[1])
+		}
+		switch {
+		case method == "GET" && len(parts) == 2:
+			return t, nil
+		case method == "POST" && len(parts) == 2:
+			return t, s.updateTask(t, body)
+		case method == "DELETE" && len(parts) == 2:
+			s.removeTask(t.ID)
+			return nil, nil
+		case method == "POST" && parts[2] == "close":
+			s.completeTask(t, s.Now())
+			return nil, nil
+		case method == "POST" && parts[2] == "reopen":
+			return nil, nil
+		}
+
+	case method == "GET" && len(parts) == 1 && parts[0] == "projects":
+		return page(s.projects), nil
+	case method == "POST" && len(parts) == 1 && parts[0] == "projects":
+		p := &api.Project{ID: s.id()}
+		decode(body, "name", &p.Name)
+		decode(body, "color", &p.Color)
+		decode(body, "is_favorite", &p.IsFavorite)
+		decode(body, "workspace_id", &p.WorkspaceID)
+		s.projects = append(s.projects, p)
+		return p, nil
+	case len(parts) >= 2 && parts[0] == "projects":
+		p := s.findProject(parts[1])
+		if p == nil {
+			return nil, notFound("project", parts[1])
+		}
+		switch {
+		case method == "GET" && len(parts) == 2:
+			return p, nil
+		case method == "GET" && parts[2] == "collaborators":
+			return page(s.collaborators[p.ID]), nil
+		case method == "POST" && len(parts) == 2:
+			decode(body, "name", &p.Name)
+			decode(body, "color", &p.Color)
+			decode(body, "is_favorite", &p.IsFavorite)
+			return p, nil
+		case method == "DELETE" && len(parts) == 2:
+			s.removeProject(p.ID)
+			return nil, nil
+		}
+
+	case method == "GET" && parts[0] == "sections":
+		var sections []*api.Section
+		for _, sec := range s.sections {
+			if q.Get("project_id") == "" || sec.ProjectID == q.Get("project_id") {
+				sections = append(sections, sec)
+			}
+		}
+		return page(sections), nil
+	case method == "POST" && len(parts) == 1 && parts[0] == "sections":
+		sec := &api.Section{ID: s.id()}
+		decode(body, "name", &sec.Name)
+		decode(body, "project_id", &sec.ProjectID)
+		s.sections = append(s.sections, sec)
+		return sec, nil
+
+	case method == "GET" && parts[0] == "labels":
+		return page(s.labels), nil
+	case method == "POST" && len(parts) == 1 && parts[0] == "labels":
+		l := &api.Label{ID: s.id()}
+		decode(body, "name", &l.Name)
+		decode(body, "color", &l.Color)
+		s.labels = append(s.labels, l)
+		return l, nil
+	case method == "POST" && len(parts) == 2 && parts[0] == "labels":
+		for _, l := range s.labels {
+			if l.ID == parts[1] {
+				decode(body, "name", &l.Name)
+				return l, nil
+			}
+		}
+		return nil, notFound("label", parts[1])
+
+	case method == "GET" && len(parts) == 2 && parts[0] == "comments":
+		if c := s.findComment(parts[1]); c != nil {
+			return c, nil
+		}
+		return nil, notFound("comment", parts[1])
+	case method == "GET" && parts[0] == "comments":
+		var comments []*api.Comment
+		for _, c := range s.comments {
+			if (q.Get("task_id") != "" && c.TaskID == q.Get("task_id")) ||
+				(q.Get("project_id") != "" && c.ProjectID == q.Get("project_id")) {
+				comments = append(comments, c)
+			}
+		}
+		if comments == nil {
+			comments = []*api.Comment{}
+		}
+		return pageFrom(comments, q.Get("cursor"), s.PageSize), nil
+	case method == "GET" && len(parts) == 1 && parts[0] == "backups":
+		if s.backups == nil {
+			return []api.Backup{}, nil
+		}
+		return s.backups, nil
+	case method == "PUT" && len(parts) == 1 && parts[0] == "emails":
+		var objType, objID string
+		decode(body, "obj_type", &objType)
+		decode(body, "obj_id", &objID)
+		if s.findProject(objID) == nil {
+			return nil, notFound("project", objID)
+		}
+		prefix := "add.task"
+		if objType == "project_comments" {
+			prefix = "add.note"
+		}
+		return map[string]string{"email": fmt.Sprintf("%s.%s.abc123@todoist.net", prefix, objID)}, nil
+	case method == "GET" && len(parts) == 1 && parts[0] == "activities":
+		from, _ := time.Parse(time.RFC3339, q.Get("date_from"))
+		events := []*api.Activity{}
+		for _, a := range s.activities {
+			at, err := time.Parse(time.RFC3339, a.EventDate)
+			if err != nil || at.Before(from) {
+				continue
+			}
+			if want := q.Get("object_type"); want != "" && a.ObjectType != want {
+				continue
+			}
+			if want := q.Get("object_id"); want != "" && a.ObjectID != want {
+				continue
+			}
+			if want := q.Get("event_type"); want != "" && a.EventType != want {
+				continue
+			}
+			events = append(events, a)
+		}
+		return pageFrom(events, q.Get("cursor"), s.PageSize), nil
+	case method == "POST" && len(parts) == 1 && parts[0] == "comments":
+		c := &api.Comment{ID: s.id(), PostedAt: s.Now().UTC().Format(time.RFC3339), PostedUID: s.user.ID}
+		decode(body, "content", &c.Content)
+		decode(body, "task_id", &c.TaskID)
+		decode(body, "project_id", &c.ProjectID)
+		s.comments = append(s.comments, c)
+		return c, nil
+	}
+
+	return nil, &apiError{http.StatusNotFound, fmt.Sprintf("no route for %s /%s", method, strings.Join(parts, "/"))}
+}
+
+// page wraps a list in the paginated response envelope
+func page(items interface{}) map[string]interface{} {
+	return map[string]interface{}{"results": items, "next_cursor": nil}
+}
+
+// pageFrom returns the page of items starting at cursor, size items long
+// (all of them when size is 0), like the API's cursor pagination
+func pageFrom[T any](items []T, cursor string, size int) map[string]interface{} {
+	start, _ := strconv.Atoi(cursor)
+	start = min(start, len(items))
+	if size <= 0 || start+size >= len(items) {
+		return map[string]interface{}{"results": items[start:], "next_cursor": nil}
+	}
+	return map[string]interface{}{
+		"results":     items[start : start+size],
+		"next_cursor": strconv.Itoa(start + size),
+	}
+}
+
+// decode unmarshals one body field into v if present
+func decode(body map[string]json.RawMessage, key string, v interface{}) bool {
+	raw, ok := body[key]
+	return ok && json.Unmarshal(raw, v) == nil
+}
+
+func (s *Server) listTasks(projectID, filter, cursor string) (interface{}, error) {
+	match, err := s.parseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []*api.Task
+	for _, t := range s.tasks {
+		if (projectID == "" || t.ProjectID == projectID) && match(t) {
+			tasks = append(tasks, t)
+		}
+	}
+	if tasks == nil {
+		tasks = []*api.Task{}
+	}
+
+	start, _ := strconv.Atoi(cursor)
+	if s.PageSize <= 0 || start+s.PageSize >= len(tasks) {
+		return map[string]interface{}{"results": tasks[min(start, len(tasks)):], "next_cursor": nil}, nil
+	}
+	return map[string]interface{}{
+		"results":     tasks[start : start+s.PageSize],
+		"next_cursor": strconv.Itoa(start + s.PageSize),
+	}, nil
+}
+
+func (s *Server) createTask(body map[string]json.RawMessage) (interface{}, error) {
+	t := &api.Task{ID: s.id(), Priority: 1, Labels: []string{}, CreatedAt: s.Now().UTC().Format(time.RFC3339)}
+	if !decode(body, "content", &t.Content) || t.Content == "" {
+		return nil, badRequest("content is required")
+	}
+	decode(body, "project_id", &t.ProjectID)
+	if t.ProjectID == "" {
+		t.ProjectID = s.projects[0].ID
+	} else if s.findProject(t.ProjectID) == nil {
+		return nil, badRequest("unknown project_id %s", t.ProjectID)
+	}
+	decode(body, "section_id", &t.SectionID)
+	decode(body, "parent_id", &t.ParentID)
+	s.tasks = append(s.tasks, t)
+	return t, s.updateTask(t, body)
+}
+
+// updateTask applies the writable fields present in body
+func (s *Server) updateTask(t *api.Task, body map[string]json.RawMessage) error {
+	decode(body, "content", &t.Content)
+	decode(body, "description", &t.Description)
+	decode(body, "labels", &t.Labels)
+	for _, key := range []string{"responsible_uid", "assignee_id"} {
+		if string(body[key]) == "null" {
+			t.Assignee = ""
+		}
+		decode(body, key, &t.Assignee)
+	}
+
+	var priority int
+	if decode(body, "priority", &priority) {
+		if priority < 1 || priority > 4 {
+			return badRequest("priority must be 1-4")
+		}
+		t.Priority = priority
+	}
+
+	var dueString, dueDate, dueDatetime string
+	decode(body, "due_string", &dueString)
+	decode(body, "due_date", &dueDate)
+	decode(body, "due_datetime", &dueDatetime)
+	switch {
+	case dueDatetime != "":
+		t.Due = &api.Due{Date: dueDatetime[:10], Datetime: dueDatetime, String: dueDatetime}
+	case dueDate != "":
+		t.Due = &api.Due{Date: dueDate, String: dueDate}
+	case dueString == "no date":
+		t.Due = nil
+	case dueString != "":
+		// Like the real API, strings it cannot understand leave no due date
+		t.Due = nil
+		if date := s.resolveDue(dueString); validDate(date) {
+			t.Due = &api.Due{Date: date, String: dueString, IsRecurring: strings.HasPrefix(strings.ToLower(dueString), "every")}
+		}
+	}
+	return nil
+}
+
+// resolveDue understands the due strings tests commonly use, taking
+// recurrences ("every ...") to start today; anything else is kept verbatim
+// as the date
+func (s *Server) resolveDue(str string) string {
+	today := s.Now()
+	lower := strings.ToLower(str)
+	if strings.HasPrefix(lower, "every") {
+		return today.Format("2006-01-02")
+	}
+	switch lower {
+	case "today":
+		return today.Format("2006-01-02")
+	case "tomorrow":
+		return today.AddDate(0, 0, 1).Format("2006-01-02")
+	case "yesterday":
+		return today.AddDate(0, 0, -1).Format("2006-01-02")
+	}
+	return str
+}
+
+// syncDue reads a Sync API due object, where the date field also carries
+// the time, into the REST form
+func (s *Server) syncDue(raw json.RawMessage) *api.Due {
+	var due *api.Due
+	json.Unmarshal(raw, &due)
+	if due == nil {
+		return nil
+	}
+	if due.String != "" && due.Date == "" {
+		due.Date = s.resolveDue(due.String)
+	}
+	if strings.Contains(due.Date, "T") {
+		due.Datetime = due.Date
+		due.Date = due.Date[:10]
+	}
+	return due
+}
+
+// validDate reports whether s starts with a YYYY-MM-DD date
+func validDate(s string) bool {
+	if len(s) < 10 {
+		return false
+	}
+	_, err := time.Parse("2006-01-02", s[:10])
+	return err == nil
+}
+
+// removeProject deletes a project with its sub-projects, sections and tasks
+// like the real API
+func (s *Server) removeProject(id string) {
+	var projects []*api.Project
+	for _, p := range s.projects {
+		switch {
+		case p.ID == id:
+		case p.ParentID == id:
+			defer s.removeProject(p.ID)
+			projects = append(projects, p)
+		default:
+			projects = append(projects, p)
+		}
+	}
+	s.projects = projects
+
+	var sections []*api.Section
+	for _, sec := range s.sections {
+		if sec.ProjectID != id {
+			sections = append(sections, sec)
+		}
+	}
+	s.sections = sections
+
+	var tasks []*api.Task
+	for _, t := range s.tasks {
+		if t.ProjectID != id {
+			tasks = append(tasks, t)
+		}
+	}
+	s.tasks = tasks
+}
+
+func (s *Server) removeTask(id string) {
+	for i, t := range s.tasks {
+		if t.ID == id {
+			s.tasks = append(s.tasks[:i], s.tasks[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *Server) completeTask(t *api.Task, at time.Time) {
+	s.removeTask(t.ID)
+	s.completed = append(s.completed, api.CompletedTask{
+		TaskID:      t.ID,
+		Content:     t.Content,
+		ProjectID:   t.ProjectID,
+		SectionID:   t.SectionID,
+		ParentID:    t.ParentID,
+		CompletedAt: at.UTC().Format(time.RFC3339),
+	})
+}
+
+func (s *Server) listCompleted(projectID, parentID string) map[string]interface{} {
+	items := []api.CompletedTask{}
+	for _, c := range s.completed {
+		if (projectID == "" || c.ProjectID == projectID) && (parentID == "" || c.ParentID == parentID) {
+			items = append(items, c)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].CompletedAt > items[j].CompletedAt })
+	return map[string]interface{}{"items": items}
+}
+
+// sync handles Sync API reads (resource_types) and writes (commands)
+func (s *Server) sync(body map[string]json.RawMessage) (interface{}, error) {
+	result := map[string]interface{}{"sync_token": "fake"}
+
+	var resources []string
+	if decode(body, "resource_types", &resources) {
+		for _, r := range resources {
+			switch r {
+			case "projects":
+				result["projects"] = s.projects
+			case "sections":
+				result["sections"] = s.sections
+			case "labels":
+				result["labels"] = s.labels
+			case "filters":
+				result["filters"] = []interface{}{}
+			case "collaborators":
+				result["collaborators"] = s.allCollaborators()
+			case "workspaces":
+				result["workspaces"] = s.workspaces
+			case "folders":
+				result["folders"] = s.folders
+			}
+		}
+	}
+
+	var commands []struct {
+		Type   string                     `json:"type"`
+		UUID   string                     `json:"uuid"`
+		TempID string                     `json:"temp_id"`
+		Args   map[string]json.RawMessage `json:"args"`
+	}
+	if decode(body, "commands", &commands) {
+		status := map[string]interface{}{}
+		mapping := map[string]string{}
+		for _, c := range commands {
+			// Temp IDs from earlier commands in the request stand for real IDs
+			for k, v := range c.Args {
+				var ref string
+				if json.Unmarshal(v, &ref) == nil && mapping[ref] != "" {
+					c.Args[k], _ = json.Marshal(mapping[ref])
+				}
+			}
+			id, err := s.command(c.Type, c.Args)
+			if err != nil {
+				status[c.UUID] = map[string]string{"error": err.Error()}
+				continue
+			}
+			status[c.UUID] = "ok"
+			if c.TempID != "" && id != "" {
+				mapping[c.TempID] = id
+			}
+		}
+		result["sync_status"] = status
+		result["temp_id_mapping"] = mapping
+	}
+
+	return result, nil
+}
+
+// command applies one Sync API write command, returning the ID of any
+// object it creates
+func (s *Server) command(kind string, args map[string]json.RawMessage) (string, error) {
+	var id, projectID, email string
+	decode(args, "id", &id)
+	decode(args, "project_id", &projectID)
+	decode(args, "email", &email)
+
+	switch kind {
+	case "share_project":
+		s.collaborators[projectID] = append(s.collaborators[projectID], api.Collaborator{ID: s.id(), Email: email, Name: email})
+		return "", nil
+	case "delete_collaborator":
+		list := s.collaborators[projectID]
+		for i, c := range list {
+			if c.Email == email {
+				s.collaborators[projectID] = append(list[:i], list[i+1:]...)
+				return "", nil
+			}
+		}
+		return "", fmt.Errorf("collaborator not found")
+	case "project_add":
+		p := &api.Project{ID: s.id()}
+		decode(args, "name", &p.Name)
+		decode(args, "color", &p.Color)
+		decode(args, "parent_id", &p.ParentID)
+		s.projects = append(s.projects, p)
+		return p.ID, nil
+	case "project_update":
+		p := s.findProject(id)
+		if p == nil {
+			return "", fmt.Errorf("project %s not found", id)
+		}
+		decode(args, "name", &p.Name)
+		if raw, ok := args["folder_id"]; ok {
+			p.FolderID = ""
+			json.Unmarshal(raw, &p.FolderID)
+		}
+		return "", nil
+	case "folder_add":
+		f := &api.Folder{ID: s.id(), DefaultOrder: len(s.folders)}
+		decode(args, "name", &f.Name)
+		decode(args, "workspace_id", &f.WorkspaceID)
+		s.folders = append(s.folders, f)
+		return f.ID, nil
+	case "section_add":
+		if s.findProject(projectID) == nil {
+			return "", fmt.Errorf("project %s not found", projectID)
+		}
+		sec := &api.Section{ID: s.id(), ProjectID: projectID}
+		decode(args, "name", &sec.Name)
+		s.sections = append(s.sections, sec)
+		return sec.ID, nil
+	case "item_add":
+		t, err := s.createTask(args)
+		if err != nil {
+			return "", err
+		}
+		task := t.(*api.Task)
+		if raw, ok := args["due"]; ok {
+			task.Due = s.syncDue(raw)
+		}
+		return task.ID, nil
+	case "note_add":
+		c := &api.Comment{ID: s.id(), PostedAt: s.Now().UTC().Format(time.RFC3339), PostedUID: s.user.ID}
+		decode(args, "item_id", &c.TaskID)
+		decode(args, "content", &c.Content)
+		if s.findTask(c.TaskID) == nil {
+			return "", fmt.Errorf("task %s not found", c.TaskID)
+		}
+		s.comments = append(s.comments, c)
+		return c.ID, nil
+	case "note_update":
+		c := s.findComment(id)
+		if c == nil {
+			return "", fmt.Errorf("comment %s not found", id)
+		}
+		decode(args, "content", &c.Content)
+		if decode(args, "reactions", &c.Reactions) && len(c.Reactions) == 0 {
+			c.Reactions = nil
+		}
+		return "", nil
+	}
+
+	t := s.findTask(id)
+	if t == nil {
+		return "", fmt.Errorf("task %s not found", id)
+	}
+	switch kind {
+	case "item_update":
+		if raw, ok := args["due"]; ok {
+			t.Due = s.syncDue(raw)
+		}
+		return "", s.updateTask(t, args)
+	case "item_move":
+		var sectionID, parentID string
+		if decode(args, "project_id", &projectID) {
+			t.ProjectID, t.SectionID, t.ParentID = projectID, "", ""
+		}
+		if decode(args, "section_id", &sectionID) {
+			t.SectionID, t.ParentID = sectionID, ""
+			for _, sec := range s.sections {
+				if sec.ID == sectionID {
+					t.ProjectID = sec.ProjectID
+				}
+			}
+		}
+		if decode(args, "parent_id", &parentID) {
+			parent := s.findTask(parentID)
+			if parent == nil {
+				return "", notFound("task", parentID)
+			}
+			// A subtask lives in its parent's project and section
+			t.ParentID, t.ProjectID, t.SectionID = parentID, parent.ProjectID, parent.SectionID
+		}
+		return "", nil
+	case "item_complete", "item_close":
+		at := s.Now()
+		var completed string
+		if decode(args, "date_completed", &completed) {
+			if parsed, err := time.Parse(time.RFC3339, completed); err == nil {
+				at = parsed
+			}
+		}
+		s.completeTask(t, at)
+		return "", nil
+	case "item_delete":
+		s.removeTask(t.ID)
+		return "", nil
+	}
+	return "", fmt.Errorf("unsupported command %s", kind)
+}
@@ -0,0 +1,98 @@
+// Package cache stores small API lookups on disk so that latency-sensitive
+// paths such as shell completion do not hit the network on every keystroke.
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/config"
+)
+
+// entry is the on-disk envelope for a cached value
+type entry struct {
+	SavedAt time.Time       `json:"saved_at"`
+	Value   json.RawMessage `json:"value"`
+}
+
+// Dir returns the cache directory path
+func Dir() string {
+	return filepath.Join(config.ConfigDir(), "cache")
+}
+
+// path is the file for key. Keys can hold user input such as a project
+// name, so anything but letters, digits and -_.~ is escaped to keep the
+// file inside Dir().
+func path(key string) string {
+	return filepath.Join(Dir(), url.QueryEscape(key)+".json")
+}
+
+// Load reads a cached value into v. It returns false when the entry is
+// missing, unreadable, or older than maxAge.
+func Load(key string, maxAge time.Duration, v interface{}) bool {
+	data, err := os.ReadFile(path(key))
+	if err != nil {
+		return false
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return false
+	}
+	if time.Since(e.SavedAt) > maxAge {
+		return false
+	}
+
+	return json.Unmarshal(e.Value, v) == nil
+}
+
+// Save writes v to the cache under key
+func Save(key string, v interface{}) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+	data, err := json.Marshal(entry{SavedAt: time.Now(), Value: value})
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+
+	if err := os.MkdirAll(Dir(), 0700); err != nil {
+		return fmt.Errorf("failed to create cache dir: %w", err)
+	}
+	if err := os.WriteFile(path(key), data, 0600); err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	return nil
+}
+
+// Verify reads every cache entry and returns how many are usable and the
+// paths of any that cannot be parsed. A missing cache directory is healthy.
+func Verify() (entries int, corrupt []string, err error) {
+	paths, err := filepath.Glob(filepath.Join(Dir(), "*.json"))
+	if err != nil {
+		return 0, nil, err
+	}
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		var e entry
+		if err != nil || json.Unmarshal(data, &e) != nil {
+			corrupt = append(corrupt, p)
+			continue
+		}
+		entries++
+	}
+	return entries, corrupt, nil
+}
+
+// Clear removes all cached entries
+func Clear() error {
+	if err := os.RemoveAll(Dir()); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSaveLoad(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if err := Save("projects", []string{"Inbox", "Work"}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	var names []string
+	if !Load("projects", time.Minute, &names) {
+		t.Fatal("expected cache hit")
+	}
+	if len(names) != 2 || names[1] != "Work" {
+		t.Errorf("unexpected cached value: %v", names)
+	}
+
+	if Load("projects", -time.Second, &names) {
+		t.Error("expected stale entry to miss")
+	}
+	if Load("missing", time.Minute, &names) {
+		t.Error("expected missing entry to miss")
+	}
+
+	if err := Clear(); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	if Load("projects", time.Minute, &names) {
+		t.Error("expected miss after Clear")
+	}
+}
+
+func TestKeysStayInCacheDir(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	for _, key := range []string{"sections-work/home", "sections-../../config", `sections-a\b:c`} {
+		if filepath.Dir(path(key)) != Dir() {
+			t.Errorf("%q: path %s escapes %s", key, path(key), Dir())
+		}
+		if err := Save(key, []string{"Backlog"}); err != nil {
+			t.Fatalf("%q: Save failed: %v", key, err)
+		}
+		var names []string
+		if !Load(key, time.Minute, &names) || len(names) != 1 {
+			t.Errorf("%q: expected cache hit, got %v", key, names)
+		}
+	}
+	if path("projects") != filepath.Join(Dir(), "projects.json") {
+		t.Errorf("expected plain keys unchanged, got %s", path("projects"))
+	}
+}
+
+func TestVerify(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if n, corrupt, err := Verify(); err != nil || n != 0 || len(corrupt) != 0 {
+		t.Fatalf("expected an empty healthy cache, got %d %v %v", n, corrupt, err)
+	}
+
+	if err := Save("projects", []string{"Inbox"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path("broken"), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	n, corrupt, err := Verify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 || len(corrupt) != 1 || corrupt[0] != path("broken") {
+		t.Errorf("got %d entries, corrupt %v", n, corrupt)
+	}
+}
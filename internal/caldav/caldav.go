@@ -0,0 +1,198 @@
+// Package caldav writes entries to a CalDAV calendar collection: to-dos or
+// events as iCalendar resources, created or replaced with PUT.
+package caldav
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Entry is a calendar entry made from a task
+type Entry struct {
+	UID         string
+	Summary     string
+	Description string
+	URL         string
+	Categories  []string
+	Due         time.Time // the due date, or date and time
+	AllDay      bool      // Due has no time of day
+	Priority    int       // iCalendar priority: 1 is highest, 9 lowest, 0 none
+	Event       bool      // a VEVENT on the calendar rather than a VTODO
+}
+
+// eventLength is how long an event with a due time lasts
+const eventLength = 30 * time.Minute
+
+// ICS renders the entry as an iCalendar object stamped with now
+func (e Entry) ICS(now time.Time) []byte {
+	var b bytes.Buffer
+	line := func(name, value string) {
+		fold(&b, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//todoist-cli//EN")
+	kind := "VTODO"
+	if e.Event {
+		kind = "VEVENT"
+	}
+	line("BEGIN", kind)
+	line("UID", escape(e.UID))
+	line("DTSTAMP", now.UTC().Format("20060102T150405Z"))
+	line("SUMMARY", escape(e.Summary))
+	if e.Description != "" {
+		line("DESCRIPTION", escape(e.Description))
+	}
+	if e.URL != "" {
+		line("URL", e.URL)
+	}
+	if len(e.Categories) > 0 {
+		cats := make([]string, len(e.Categories))
+		for i, c := range e.Categories {
+			cats[i] = escape(c)
+		}
+		line("CATEGORIES", strings.Join(cats, ","))
+	}
+	if e.Priority > 0 {
+		line("PRIORITY", fmt.Sprint(e.Priority))
+	}
+
+	switch {
+	case !e.Event && e.AllDay:
+		line("DUE;VALUE=DATE", e.Due.Format("20060102"))
+	case !e.Event:
+		line("DUE", e.Due.UTC().Format("20060102T150405Z"))
+	case e.AllDay:
+		line("DTSTART;VALUE=DATE", e.Due.Format("20060102"))
+		line("DTEND;VALUE=DATE", e.Due.AddDate(0, 0, 1).Format("20060102"))
+	default:
+		line("DTSTART", e.Due.UTC().Format("20060102T150405Z"))
+		line("DTEND", e.Due.Add(eventLength).UTC().Format("20060102T150405Z"))
+	}
+
+	line("END", kind)
+	line("END", "VCALENDAR")
+	return b.Bytes()
+}
+
+// escape escapes an iCalendar text value
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// fold writes a content line, folded at 75 octets without splitting UTF-8
+// sequences, ending in CRLF
+func fold(b *bytes.Buffer, s string) {
+	limit := 75
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && s[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		limit = 74 // the leading space counts
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+}
+
+// Client writes to one calendar collection
+type Client struct {
+	base       *url.URL
+	user       string
+	password   string
+	httpClient *http.Client
+}
+
+// New creates a client for the collection at rawURL. Credentials in the URL
+// are used when user is empty.
+func New(rawURL, user, password string) (*Client, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return nil, fmt.Errorf("invalid CalDAV URL %q", rawURL)
+	}
+	if user == "" && u.User != nil {
+		user = u.User.Username()
+		password, _ = u.User.Password()
+	}
+	u.User = nil
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	return &Client{base: u, user: user, password: password, httpClient: &http.Client{Timeout: 30 * time.Second}}, nil
+}
+
+// URL returns the collection URL without credentials
+func (c *Client) URL() string {
+	return c.base.String()
+}
+
+// Put creates or replaces the resource name (e.g. "abc.ics") with an
+// iCalendar object
+func (c *Client) Put(ctx context.Context, name string, data []byte) error {
+	return c.do(ctx, "PUT", name, data)
+}
+
+// Delete removes the resource name; one already gone is not an error
+func (c *Client) Delete(ctx context.Context, name string) error {
+	err := c.do(ctx, "DELETE", name, nil)
+	if e, ok := err.(*StatusError); ok && (e.StatusCode == http.StatusNotFound || e.StatusCode == http.StatusGone) {
+		return nil
+	}
+	return err
+}
+
+// StatusError is a failed response from the server
+type StatusError struct {
+	Method     string
+	Name       string
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	msg := fmt.Sprintf("CalDAV %s %s: %d %s", e.Method, e.Name, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Body != "" {
+		msg += ": " + e.Body
+	}
+	return msg
+}
+
+func (c *Client) do(ctx context.Context, method, name string, data []byte) error {
+	u := c.base.ResolveReference(&url.URL{Path: name})
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if data != nil {
+		req.Header.Set("Content-Type", "text/calendar; charset=utf-8")
+	}
+	if c.user != "" {
+		req.SetBasicAuth(c.user, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("CalDAV %s %s failed: %w", method, name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &StatusError{Method: method, Name: name, StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(msg))}
+	}
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
@@ -0,0 +1,116 @@
+package caldav
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestICS(t *testing.T) {
+	now := time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC)
+	day := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+
+	todo := string(Entry{UID: "todoist-1@todoist-cli", Summary: "Pay rent, now; really", Due: day, AllDay: true, Priority: 1}.ICS(now))
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"BEGIN:VTODO\r\n",
+		"DTSTAMP:20240318T090000Z\r\n",
+		`SUMMARY:Pay rent\, now\; really` + "\r\n",
+		"DUE;VALUE=DATE:20240320\r\n",
+		"PRIORITY:1\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(todo, want) {
+			t.Errorf("to-do: expected %q in:\n%s", want, todo)
+		}
+	}
+
+	at := time.Date(2024, 3, 20, 17, 0, 0, 0, time.FixedZone("CET", 3600))
+	event := string(Entry{UID: "x", Summary: "Call", Description: "line 1\nline 2", Due: at, Event: true}.ICS(now))
+	for _, want := range []string{
+		"BEGIN:VEVENT\r\n",
+		"DTSTART:20240320T160000Z\r\n",
+		"DTEND:20240320T163000Z\r\n",
+		`DESCRIPTION:line 1\nline 2` + "\r\n",
+	} {
+		if !strings.Contains(event, want) {
+			t.Errorf("event: expected %q in:\n%s", want, event)
+		}
+	}
+	if strings.Contains(event, "PRIORITY") {
+		t.Errorf("event: expected no priority, got:\n%s", event)
+	}
+
+	allDay := string(Entry{UID: "x", Summary: "Trip", Due: day, AllDay: true, Event: true}.ICS(now))
+	if !strings.Contains(allDay, "DTSTART;VALUE=DATE:20240320\r\nDTEND;VALUE=DATE:20240321\r\n") {
+		t.Errorf("all-day event: got:\n%s", allDay)
+	}
+}
+
+func TestFold(t *testing.T) {
+	long := "SUMMARY:" + strings.Repeat("é", 60)
+	var b strings.Builder
+	for _, line := range strings.Split(string(Entry{UID: "x", Summary: strings.Repeat("é", 60)}.ICS(time.Now())), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+		if strings.HasPrefix(line, "SUMMARY:") || strings.HasPrefix(line, " ") && b.Len() > 0 {
+			b.WriteString(strings.TrimPrefix(line, " "))
+		}
+	}
+	if b.String() != long {
+		t.Errorf("unfolded summary: got %q, want %q", b.String(), long)
+	}
+}
+
+func TestClient(t *testing.T) {
+	type request struct{ method, path, user, body string }
+	var got []request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, _, _ := r.BasicAuth()
+		body, _ := io.ReadAll(r.Body)
+		got = append(got, request{r.Method, r.URL.Path, user, string(body)})
+		switch {
+		case r.Method == "PUT":
+			w.WriteHeader(http.StatusCreated)
+		case strings.Contains(r.URL.Path, "gone"):
+			w.WriteHeader(http.StatusNotFound)
+		case strings.Contains(r.URL.Path, "locked"):
+			http.Error(w, "locked", http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := New(strings.Replace(srv.URL, "http://", "http://me:secret@", 1)+"/cal/tasks", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(c.URL(), "secret") || !strings.HasSuffix(c.URL(), "/cal/tasks/") {
+		t.Errorf("URL() = %q", c.URL())
+	}
+
+	ctx := context.Background()
+	if err := c.Put(ctx, "todoist-1.ics", []byte("BEGIN:VCALENDAR")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Delete(ctx, "gone.ics"); err != nil {
+		t.Errorf("expected deleting a missing entry to succeed, got %v", err)
+	}
+	if err := c.Delete(ctx, "locked.ics"); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("expected a 403 error, got %v", err)
+	}
+
+	if len(got) != 3 || got[0].path != "/cal/tasks/todoist-1.ics" || got[0].user != "me" || got[0].body != "BEGIN:VCALENDAR" {
+		t.Errorf("unexpected requests: %+v", got)
+	}
+
+	if _, err := New("not a url", "", ""); err == nil {
+		t.Error("expected an error for an invalid URL")
+	}
+}
@@ -14,7 +14,32 @@ const (
 
 // Config holds the CLI configuration
 type Config struct {
-	APIToken string `json:"api_token"`
+	APIToken       string             `json:"api_token"`
+	Email          string             `json:"email,omitempty"`
+	Profiles       map[string]Profile `json:"profiles,omitempty"`
+	ActiveProfile  string             `json:"active_profile,omitempty"`
+	Hyperlinks     string             `json:"hyperlinks,omitempty"`        // auto, always, never
+	SlowThreshold  string             `json:"slow_threshold,omitempty"`    // e.g. "3s"; "off" disables
+	ShowProjects   string             `json:"show_projects,omitempty"`     // "off" hides #Project/Section in task lists
+	APIBaseURL     string             `json:"api_base_url,omitempty"`      // API root for gateways and mock servers
+	Aliases        map[string]string  `json:"aliases,omitempty"`           // name -> arguments, see 'todoist alias'
+	Views          map[string]string  `json:"views,omitempty"`             // name -> command line, see 'todoist view save'
+	Hooks          map[string]string  `json:"hooks,omitempty"`             // event -> shell command, e.g. "post-complete"
+	SnoozeLabel    string             `json:"snooze_label,omitempty"`      // label for 'todoist snooze', default "snoozed"
+	NextWeights    map[string]float64 `json:"next_weights,omitempty"`      // scoring for 'todoist next'; "@label" and "#Project" keys too
+	StartLabel     string             `json:"start_label,omitempty"`       // label for 'todoist next --start', default "in-progress"
+	HabitLabel     string             `json:"habit_label,omitempty"`       // label for 'todoist habits', default "habit"
+	BlockedLabel   string             `json:"blocked_label,omitempty"`     // label for tasks waiting on others, default "blocked"
+	DisplayTZ      string             `json:"display_timezone,omitempty"`  // IANA zone for showing times, default the system's
+	DateFormat     string             `json:"date_format,omitempty"`       // strftime-like, or iso, us, eu
+	TimeFormat     string             `json:"time_format,omitempty"`       // strftime-like, or 24h, 12h
+	ConfirmAbove   int                `json:"confirm_threshold,omitempty"` // bulk changes to more tasks ask first; negative never asks
+	Language       string             `json:"language,omitempty"`          // message language, e.g. "de"; default from LANG
+	DefaultSort    string             `json:"default_sort,omitempty"`      // --sort for task lists when none is given, e.g. "priority,due"
+	DefaultProject string             `json:"default_project,omitempty"`   // project for 'todoist add' without -p; default the Inbox
+	TodayFilter    string             `json:"today_filter,omitempty"`      // filter of the default task list, default "today | overdue"
+	Icons          string             `json:"icons,omitempty"`             // off, ascii, emoji or nerd (Nerd Font glyphs)
+	Theme          map[string]string  `json:"theme,omitempty"`             // element -> style, e.g. "overdue": "bold red"; "base" picks a built-in theme
 }
 
 // ConfigDir returns the config directory path
@@ -28,32 +53,88 @@ func ConfigPath() string {
 	return filepath.Join(ConfigDir(), configFileName)
 }
 
-// Load loads the configuration from disk
+// Load loads the configuration from disk. APIToken is resolved for the
+// selected profile; TODOIST_API_TOKEN overrides any profile.
 func Load() (*Config, error) {
 	// First check environment variable
 	if token := os.Getenv("TODOIST_API_TOKEN"); token != "" {
-		return &Config{APIToken: token}, nil
+		cfg := Settings()
+		cfg.APIToken = token
+		return cfg, nil
 	}
 
 	// Then try config file
-	path := ConfigPath()
-	data, err := os.ReadFile(path)
+	cfg, err := readFile()
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, fmt.Errorf("not configured. Run 'todoist auth' or set TODOIST_API_TOKEN")
 		}
-		return nil, fmt.Errorf("failed to read config: %w", err)
+		return nil, err
 	}
 
-	var cfg Config
-	if err := json.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("failed to parse config: %w", err)
+	name := cfg.ProfileName()
+	profile, ok := cfg.Profile(name)
+	if !ok {
+		return nil, fmt.Errorf("profile not found: %s. Run 'todoist auth --profile %s'", name, name)
 	}
+	cfg.APIToken = profile.APIToken
 
 	if cfg.APIToken == "" {
 		return nil, fmt.Errorf("no API token configured. Run 'todoist auth'")
 	}
 
+	return cfg, nil
+}
+
+// Settings returns the config file contents for reading preferences. A missing
+// or unreadable file yields an empty config so callers fall back to defaults.
+func Settings() *Config {
+	cfg, err := readFile()
+	if err != nil {
+		return &Config{}
+	}
+	return cfg
+}
+
+// LoadForUpdate returns the config file contents for editing and saving
+// back. Unlike Settings it fails when the file exists but can't be read or
+// parsed, so Save never replaces it with an empty config.
+func LoadForUpdate() (*Config, error) {
+	cfg, err := readFile()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Config{}, nil
+		}
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// APIBaseURL returns the API root to use instead of Todoist's:
+// TODOIST_API_BASE_URL, then api_base_url in the config file. It is empty
+// when neither is set.
+func APIBaseURL() string {
+	if u := os.Getenv("TODOIST_API_BASE_URL"); u != "" {
+		return u
+	}
+	return Settings().APIBaseURL
+}
+
+// readFile reads and parses the config file
+func readFile() (*Config, error) {
+	data, err := os.ReadFile(ConfigPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
 	return &cfg, nil
 }
 
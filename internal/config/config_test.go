@@ -0,0 +1,73 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAPIBaseURL(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("TODOIST_API_BASE_URL", "")
+
+	if got := APIBaseURL(); got != "" {
+		t.Errorf("expected no override without config, got %q", got)
+	}
+
+	dir := filepath.Join(home, configDirName)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	data := []byte(`{"api_base_url": "https://gateway.example.com/todoist/api/v1"}`)
+	if err := os.WriteFile(filepath.Join(dir, configFileName), data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got := APIBaseURL(); got != "https://gateway.example.com/todoist/api/v1" {
+		t.Errorf("expected config file value, got %q", got)
+	}
+
+	t.Setenv("TODOIST_API_BASE_URL", "http://localhost:8080/api/v1")
+	if got := APIBaseURL(); got != "http://localhost:8080/api/v1" {
+		t.Errorf("expected environment to win, got %q", got)
+	}
+}
+
+func TestLoadForUpdate(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cfg, err := LoadForUpdate()
+	if err != nil {
+		t.Fatalf("expected an empty config without a file, got %v", err)
+	}
+	if cfg.APIToken != "" || len(cfg.Aliases) != 0 {
+		t.Errorf("expected an empty config, got %+v", cfg)
+	}
+
+	dir := filepath.Join(home, configDirName)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, configFileName)
+	if err := os.WriteFile(path, []byte(`{"api_token": "secret",`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadForUpdate(); err == nil {
+		t.Fatal("expected an error for a config that doesn't parse")
+	}
+	if cfg := Settings(); cfg.APIToken != "" {
+		t.Errorf("expected Settings to fall back to defaults, got %+v", cfg)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"api_token": "secret"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = LoadForUpdate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.APIToken != "secret" {
+		t.Errorf("expected the saved token, got %q", cfg.APIToken)
+	}
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"os"
+	"sort"
+)
+
+// DefaultProfile names the account stored in the top-level api_token field
+const DefaultProfile = "default"
+
+// Profile is a named Todoist account
+type Profile struct {
+	APIToken string `json:"api_token"`
+	Email    string `json:"email,omitempty"`
+}
+
+// selectedProfile is set by the global --profile flag
+var selectedProfile string
+
+// UseProfile pins the profile for this process, overriding TODOIST_PROFILE
+// and the saved active profile.
+func UseProfile(name string) {
+	selectedProfile = name
+}
+
+// ProfileName returns the profile commands run against: --profile, then
+// TODOIST_PROFILE, then the saved active profile, then "default".
+func (c *Config) ProfileName() string {
+	if selectedProfile != "" {
+		return selectedProfile
+	}
+	if env := os.Getenv("TODOIST_PROFILE"); env != "" {
+		return env
+	}
+	if c.ActiveProfile != "" {
+		return c.ActiveProfile
+	}
+	return DefaultProfile
+}
+
+// Profile looks up a profile by name. The default profile is backed by the
+// top-level token fields.
+func (c *Config) Profile(name string) (Profile, bool) {
+	if name == DefaultProfile {
+		return Profile{APIToken: c.APIToken, Email: c.Email}, true
+	}
+	p, ok := c.Profiles[name]
+	return p, ok
+}
+
+// SetProfile stores credentials under a profile name
+func (c *Config) SetProfile(name string, p Profile) {
+	if name == DefaultProfile {
+		c.APIToken = p.APIToken
+		c.Email = p.Email
+		return
+	}
+	if c.Profiles == nil {
+		c.Profiles = make(map[string]Profile)
+	}
+	c.Profiles[name] = p
+}
+
+// RemoveProfile deletes a profile's credentials
+func (c *Config) RemoveProfile(name string) {
+	if name == DefaultProfile {
+		c.APIToken = ""
+		c.Email = ""
+		return
+	}
+	delete(c.Profiles, name)
+	if c.ActiveProfile == name {
+		c.ActiveProfile = ""
+	}
+}
+
+// ProfileNames lists configured profiles, default first
+func (c *Config) ProfileNames() []string {
+	var names []string
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if c.APIToken != "" {
+		names = append([]string{DefaultProfile}, names...)
+	}
+	return names
+}
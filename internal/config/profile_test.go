@@ -0,0 +1,40 @@
+package config
+
+import "testing"
+
+func TestProfileResolution(t *testing.T) {
+	t.Setenv("TODOIST_PROFILE", "")
+	defer UseProfile("")
+
+	cfg := &Config{APIToken: "default-token"}
+	cfg.SetProfile("work", Profile{APIToken: "work-token", Email: "me@work.example"})
+
+	if got := cfg.ProfileName(); got != DefaultProfile {
+		t.Errorf("expected default profile, got %q", got)
+	}
+
+	cfg.ActiveProfile = "work"
+	if got := cfg.ProfileName(); got != "work" {
+		t.Errorf("expected active profile work, got %q", got)
+	}
+
+	UseProfile(DefaultProfile)
+	if got := cfg.ProfileName(); got != DefaultProfile {
+		t.Errorf("expected --profile to override active profile, got %q", got)
+	}
+
+	p, ok := cfg.Profile(DefaultProfile)
+	if !ok || p.APIToken != "default-token" {
+		t.Errorf("default profile should use top-level token, got %+v", p)
+	}
+
+	names := cfg.ProfileNames()
+	if len(names) != 2 || names[0] != DefaultProfile || names[1] != "work" {
+		t.Errorf("unexpected profile names: %v", names)
+	}
+
+	cfg.RemoveProfile("work")
+	if cfg.ActiveProfile != "" {
+		t.Errorf("removing the active profile should clear it, got %q", cfg.ActiveProfile)
+	}
+}
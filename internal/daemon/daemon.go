@@ -0,0 +1,226 @@
+// Package daemon is an optional background service for the CLI. It listens
+// on a Unix socket and forwards API requests through one shared transport,
+// so short commands skip the DNS, TCP and TLS setup of a fresh connection.
+// It also owns what separate invocations cannot share on their own: one
+// rate limit budget, and Sync state kept current with sync tokens. Commands
+// talk to the API directly when it is not running.
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/config"
+)
+
+// Headers carrying the original request's destination through the socket
+const (
+	schemeHeader = "X-Todoist-Upstream-Scheme"
+	hostHeader   = "X-Todoist-Upstream-Host"
+)
+
+// SocketPath returns where the daemon listens
+func SocketPath() string {
+	return filepath.Join(config.ConfigDir(), "daemon.sock")
+}
+
+// Running reports whether a daemon is accepting connections on socket
+func Running(socket string) bool {
+	conn, err := net.DialTimeout("unix", socket, 100*time.Millisecond)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// Status describes a running daemon
+type Status struct {
+	PID       int            `json:"pid"`
+	Started   time.Time      `json:"started"`
+	Requests  int64          `json:"requests"`
+	SyncReads int64          `json:"sync_reads"` // full Sync reads answered incrementally
+	RateLimit *api.RateLimit `json:"rate_limit,omitempty"`
+}
+
+// server forwards API requests and owns what invocations share: the pacing
+// budget and the Sync state
+type server struct {
+	upstream *api.PacedTransport
+	proxy    *httputil.ReverseProxy
+	syncs    syncCache
+	started  time.Time
+	stop     func()
+
+	requests  int64
+	syncReads int64
+}
+
+func newServer(upstream http.RoundTripper) *server {
+	s := &server{upstream: api.NewPacedTransport(upstream), started: time.Now()}
+	s.proxy = &httputil.ReverseProxy{
+		Director:  toUpstream,
+		Transport: s.upstream,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+		},
+	}
+	return s
+}
+
+// Serve forwards requests arriving on socket through upstream until ctx is
+// done or a client calls stop. A socket left behind by a daemon that died
+// is replaced.
+func Serve(ctx context.Context, socket string, upstream http.RoundTripper) error {
+	if Running(socket) {
+		return fmt.Errorf("daemon already running on %s", socket)
+	}
+	if err := os.MkdirAll(filepath.Dir(socket), 0700); err != nil {
+		return fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	os.Remove(socket)
+
+	// Requests carry the account's token, so only its owner may connect
+	ln, err := listenPrivate(socket)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", socket, err)
+	}
+	if err := os.Chmod(socket, 0600); err != nil {
+		ln.Close()
+		return fmt.Errorf("failed to restrict socket: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	s := newServer(upstream)
+	s.stop = cancel
+	srv := &http.Server{Handler: s}
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+	if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// ServeHTTP answers RPC calls and forwards everything else to the scheme
+// and host named in the request's upstream headers
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == rpcPath && r.Header.Get(hostHeader) == "" {
+		s.serveRPC(w, r)
+		return
+	}
+	scheme := r.Header.Get(schemeHeader)
+	if r.Header.Get(hostHeader) == "" || (scheme != "http" && scheme != "https") {
+		http.Error(w, "missing upstream host", http.StatusBadRequest)
+		return
+	}
+	atomic.AddInt64(&s.requests, 1)
+
+	if r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/sync") {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if types, ok := parseSyncRead(body); ok {
+			out := r.Clone(r.Context())
+			out.RequestURI = ""
+			toUpstream(out)
+			if s.syncs.read(w, out, types, s.upstream) {
+				atomic.AddInt64(&s.syncReads, 1)
+			}
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	s.proxy.ServeHTTP(w, r)
+}
+
+// status reports the daemon's counters and the shared budget
+func (s *server) status() Status {
+	st := Status{
+		PID:       os.Getpid(),
+		Started:   s.started,
+		Requests:  atomic.LoadInt64(&s.requests),
+		SyncReads: atomic.LoadInt64(&s.syncReads),
+	}
+	if limit, ok := s.upstream.RateLimit(); ok {
+		st.RateLimit = &limit
+	}
+	return st
+}
+
+// toUpstream points a request that arrived on the socket at the API
+func toUpstream(r *http.Request) {
+	r.URL.Scheme = r.Header.Get(schemeHeader)
+	r.URL.Host = r.Header.Get(hostHeader)
+	r.Host = r.URL.Host
+	r.Header.Del(schemeHeader)
+	r.Header.Del(hostHeader)
+	r.Header["X-Forwarded-For"] = nil
+}
+
+// Transport returns a RoundTripper that sends requests through the daemon on
+// socket, and straight through direct when the daemon cannot be reached
+func Transport(socket string, direct http.RoundTripper) http.RoundTripper {
+	return &transport{
+		direct: direct,
+		socket: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socket)
+			},
+			MaxIdleConns:    2,
+			IdleConnTimeout: 30 * time.Second,
+		},
+	}
+}
+
+type transport struct {
+	direct http.RoundTripper
+	socket http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	out.URL.Scheme = "http"
+	out.URL.Host = "daemon"
+	out.Host = "daemon"
+	out.Header.Set(schemeHeader, req.URL.Scheme)
+	out.Header.Set(hostHeader, req.URL.Host)
+
+	resp, err := t.socket.RoundTrip(out)
+	var op *net.OpError
+	if err == nil || !errors.As(err, &op) || op.Op != "dial" {
+		return resp, err
+	}
+
+	// The daemon went away; the body may have been closed, so rewind it
+	if req.Body != nil {
+		if req.GetBody == nil {
+			return nil, err
+		}
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = body
+	}
+	return t.direct.RoundTrip(req)
+}
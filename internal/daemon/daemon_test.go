@@ -0,0 +1,139 @@
+package daemon
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServeForwardsRequests(t *testing.T) {
+	var gotPath, gotAuth, gotBody string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		io.WriteString(w, `{"ok":true}`)
+	}))
+	defer upstream.Close()
+
+	socket := filepath.Join(t.TempDir(), "d.sock")
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- Serve(ctx, socket, http.DefaultTransport) }()
+	defer func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Serve failed: %v", err)
+		}
+	}()
+	for i := 0; !Running(socket); i++ {
+		if i > 100 {
+			t.Fatal("daemon did not start")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := Serve(context.Background(), socket, http.DefaultTransport); err == nil {
+		t.Error("expected a second daemon to refuse the socket")
+	}
+
+	client := &http.Client{Transport: Transport(socket, failTransport{t})}
+	req, _ := http.NewRequest("POST", upstream.URL+"/api/v1/sync", strings.NewReader("commands"))
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if string(body) != `{"ok":true}` {
+		t.Errorf("unexpected response %q", body)
+	}
+	if gotPath != "/api/v1/sync" || gotAuth != "Bearer secret" || gotBody != "commands" {
+		t.Errorf("upstream got path %q, auth %q, body %q", gotPath, gotAuth, gotBody)
+	}
+
+	var st Status
+	if err := Call(socket, "status", &st); err != nil || st.Requests != 1 {
+		t.Errorf("expected status to count one request, got %+v, %v", st, err)
+	}
+	if err := Call(socket, "reboot", nil); err == nil {
+		t.Error("expected unknown methods to fail")
+	}
+	if err := Call(socket, "stop", nil); err != nil {
+		t.Errorf("stop failed: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Serve failed: %v", err)
+	}
+	done <- nil
+}
+
+func TestTransportFallsBackWithoutDaemon(t *testing.T) {
+	var gotBody string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+	}))
+	defer upstream.Close()
+
+	socket := filepath.Join(t.TempDir(), "missing.sock")
+	if Running(socket) {
+		t.Fatal("expected no daemon")
+	}
+
+	client := &http.Client{Transport: Transport(socket, http.DefaultTransport)}
+	resp, err := client.Post(upstream.URL+"/tasks", "application/json", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if gotBody != "payload" {
+		t.Errorf("expected the body to reach the API directly, got %q", gotBody)
+	}
+}
+
+func TestHandlerRejectsMissingUpstream(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newServer(http.DefaultTransport).ServeHTTP(rec, httptest.NewRequest("GET", "/tasks", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rec.Code)
+	}
+}
+
+// failTransport fails the test if a request bypasses the daemon
+type failTransport struct{ t *testing.T }
+
+func (f failTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	f.t.Error("request went direct instead of through the daemon")
+	return nil, http.ErrServerClosed
+}
+
+func TestListenPrivate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix socket permissions are not enforced on Windows")
+	}
+	socket := filepath.Join(t.TempDir(), "d.sock")
+	ln, err := listenPrivate(socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	info, err := os.Stat(socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm&0077 != 0 {
+		t.Errorf("expected a socket only its owner can use, got %v", perm)
+	}
+}
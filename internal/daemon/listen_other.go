@@ -0,0 +1,11 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package daemon
+
+import "net"
+
+// listenPrivate listens on a Unix socket; this platform has no umask, so
+// Serve restricts the socket right after it is created
+func listenPrivate(socket string) (net.Listener, error) {
+	return net.Listen("unix", socket)
+}
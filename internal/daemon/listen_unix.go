@@ -0,0 +1,17 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package daemon
+
+import (
+	"net"
+	"syscall"
+)
+
+// listenPrivate listens on a Unix socket that only the current user can
+// connect to from the moment it exists. The umask is process-wide, so it
+// is restored as soon as the socket has been created.
+func listenPrivate(socket string) (net.Listener, error) {
+	old := syscall.Umask(0077)
+	defer syscall.Umask(old)
+	return net.Listen("unix", socket)
+}
@@ -0,0 +1,115 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// rpcPath is where the daemon answers JSON-RPC 2.0 calls about itself
+const rpcPath = "/rpc"
+
+// JSON-RPC 2.0 error codes
+const (
+	rpcParseError     = -32700
+	rpcMethodNotFound = -32601
+)
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("daemon: %s (%d)", e.Message, e.Code)
+}
+
+// serveRPC answers a JSON-RPC call:
+//
+//	status  the daemon's Status
+//	flush   forget cached Sync state, so the next reads are full syncs
+//	stop    shut the daemon down
+func (s *server) serveRPC(w http.ResponseWriter, r *http.Request) {
+	var req rpcRequest
+	var stopping bool
+	resp := rpcResponse{JSONRPC: "2.0"}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		resp.Error = &rpcError{Code: rpcParseError, Message: err.Error()}
+	} else {
+		resp.ID = req.ID
+		switch req.Method {
+		case "status":
+			resp.Result = s.status()
+		case "flush":
+			s.syncs.flush()
+			resp.Result = true
+		case "stop":
+			resp.Result = true
+			stopping = s.stop != nil
+		default:
+			resp.Error = &rpcError{Code: rpcMethodNotFound, Message: "unknown method " + req.Method}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+	if stopping {
+		// Answer before the server closes this connection
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		s.stop()
+	}
+}
+
+// Call invokes method on the daemon listening on socket and decodes its
+// result into result, if not nil
+func Call(socket, method string, result interface{}) error {
+	client := &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socket)
+			},
+		},
+	}
+	body, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": method})
+	resp, err := client.Post("http://daemon"+rpcPath, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to reach daemon: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var out struct {
+		Result json.RawMessage `json:"result"`
+		Error  *rpcError       `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return fmt.Errorf("failed to decode daemon response: %w", err)
+	}
+	if out.Error != nil {
+		return out.Error
+	}
+	if result != nil {
+		return json.Unmarshal(out.Result, result)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package daemon
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Service describes a todoist command run in the background by the user's
+// service manager: systemd on Linux, launchd on macOS
+type Service struct {
+	Name string   // short name, e.g. "daemon" for todoist-daemon
+	Exe  string   // absolute path of the todoist executable
+	Args []string // arguments to it, e.g. ["daemon"]
+}
+
+// UnitName is the systemd unit file name, e.g. "todoist-daemon.service"
+func (s Service) UnitName() string {
+	return "todoist-" + s.Name + ".service"
+}
+
+// Label is the launchd job label, e.g. "com.github.buddyh.todoist-cli.daemon"
+func (s Service) Label() string {
+	return "com.github.buddyh.todoist-cli." + s.Name
+}
+
+// command is the command line the service runs, for descriptions
+func (s Service) command() string {
+	return strings.Join(append([]string{"todoist"}, s.Args...), " ")
+}
+
+// SystemdUnit renders a systemd user unit that starts at login and
+// restarts the command when it fails
+func (s Service) SystemdUnit() string {
+	words := make([]string, 0, len(s.Args)+1)
+	for _, w := range append([]string{s.Exe}, s.Args...) {
+		words = append(words, systemdQuote(w))
+	}
+	return fmt.Sprintf(`[Unit]
+Description=Todoist CLI: %s
+Wants=network-online.target
+After=network-online.target
+
+[Service]
+ExecStart=%s
+Restart=on-failure
+RestartSec=10
+
+[Install]
+WantedBy=default.target
+`, strings.ReplaceAll(s.command(), "%", "%%"), strings.Join(words, " "))
+}
+
+// systemdQuote quotes a word of ExecStart when needed; % and $ would
+// otherwise be expanded as specifiers and variables
+func systemdQuote(w string) string {
+	w = strings.NewReplacer("%", "%%", "$", "$$").Replace(w)
+	if w != "" && !strings.ContainsAny(w, " \t\"'\\;") {
+		return w
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(w) + `"`
+}
+
+// LaunchdPlist renders a launchd agent that starts at login, restarts the
+// command when it fails, and appends its output to logPath
+func (s Service) LaunchdPlist(logPath string) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+`)
+	fmt.Fprintf(&b, "\t<key>Label</key>\n\t<string>%s</string>\n", html.EscapeString(s.Label()))
+	b.WriteString("\t<key>ProgramArguments</key>\n\t<array>\n")
+	for _, w := range append([]string{s.Exe}, s.Args...) {
+		fmt.Fprintf(&b, "\t\t<string>%s</string>\n", html.EscapeString(w))
+	}
+	b.WriteString("\t</array>\n")
+	b.WriteString("\t<key>RunAtLoad</key>\n\t<true/>\n")
+	b.WriteString("\t<key>KeepAlive</key>\n\t<dict>\n\t\t<key>SuccessfulExit</key>\n\t\t<false/>\n\t</dict>\n")
+	fmt.Fprintf(&b, "\t<key>StandardOutPath</key>\n\t<string>%s</string>\n", html.EscapeString(logPath))
+	fmt.Fprintf(&b, "\t<key>StandardErrorPath</key>\n\t<string>%s</string>\n", html.EscapeString(logPath))
+	b.WriteString("</dict>\n</plist>\n")
+	return b.String()
+}
+
+// SystemdDir is where systemd looks for the user's units
+func SystemdDir() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "systemd", "user")
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "systemd", "user")
+}
+
+// LaunchAgentsDir is where launchd looks for the user's agents
+func LaunchAgentsDir() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, "Library", "LaunchAgents")
+}
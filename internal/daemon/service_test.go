@@ -0,0 +1,39 @@
+package daemon
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSystemdUnit(t *testing.T) {
+	s := Service{Name: "events", Exe: "/opt/todoist cli/todoist", Args: []string{"events", "--follow", "-p", `My "Work"`, "100%"}}
+	unit := s.SystemdUnit()
+	for _, want := range []string{
+		"Description=Todoist CLI: todoist events --follow -p My \"Work\" 100%%\n",
+		`ExecStart="/opt/todoist cli/todoist" events --follow -p "My \"Work\"" 100%%` + "\n",
+		"Restart=on-failure\n",
+		"WantedBy=default.target\n",
+	} {
+		if !strings.Contains(unit, want) {
+			t.Errorf("expected %q in:\n%s", want, unit)
+		}
+	}
+	if s.UnitName() != "todoist-events.service" {
+		t.Errorf("unexpected unit name %q", s.UnitName())
+	}
+}
+
+func TestLaunchdPlist(t *testing.T) {
+	s := Service{Name: "daemon", Exe: "/usr/local/bin/todoist", Args: []string{"--profile", "a&b", "daemon"}}
+	plist := s.LaunchdPlist("/Users/me/Library/Logs/todoist-daemon.log")
+	for _, want := range []string{
+		"<string>com.github.buddyh.todoist-cli.daemon</string>",
+		"\t\t<string>/usr/local/bin/todoist</string>\n\t\t<string>--profile</string>\n\t\t<string>a&amp;b</string>\n\t\t<string>daemon</string>\n",
+		"<key>RunAtLoad</key>\n\t<true/>",
+		"<string>/Users/me/Library/Logs/todoist-daemon.log</string>",
+	} {
+		if !strings.Contains(plist, want) {
+			t.Errorf("expected %q in:\n%s", want, plist)
+		}
+	}
+}
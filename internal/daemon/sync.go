@@ -0,0 +1,224 @@
+package daemon
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// syncCache answers full reads of the Sync API from state kept current with
+// incremental syncs. The API allows a tenth as many full syncs as partial
+// ones, and the daemon turns every read after the first into a partial one.
+type syncCache struct {
+	mu     sync.Mutex
+	states map[string]*syncState
+}
+
+// syncState is one account's view of one set of resource types
+type syncState struct {
+	mu        sync.Mutex
+	token     string
+	lists     map[string]*syncList
+	resources map[string]json.RawMessage
+}
+
+// syncList holds a resource array, such as items, keyed by ID in API order
+type syncList struct {
+	order []string
+	byID  map[string]json.RawMessage
+}
+
+// syncRead is the body of a read-only Sync request
+type syncRead struct {
+	SyncToken     string   `json:"sync_token"`
+	ResourceTypes []string `json:"resource_types"`
+}
+
+// parseSyncRead returns the resource types of a full Sync read. Requests
+// with commands, or anything else the cache cannot answer, return false.
+func parseSyncRead(body []byte) ([]string, bool) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil || len(fields) != 2 {
+		return nil, false
+	}
+	var read syncRead
+	if err := json.Unmarshal(body, &read); err != nil || read.SyncToken != "*" || len(read.ResourceTypes) == 0 {
+		return nil, false
+	}
+	return read.ResourceTypes, true
+}
+
+// state returns the state for an account and resource types, creating it
+func (c *syncCache) state(auth string, types []string) *syncState {
+	sorted := append([]string(nil), types...)
+	sort.Strings(sorted)
+	sum := sha256.Sum256([]byte(auth + "\n" + strings.Join(sorted, ",")))
+	key := hex.EncodeToString(sum[:])
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.states == nil {
+		c.states = make(map[string]*syncState)
+	}
+	s := c.states[key]
+	if s == nil {
+		s = &syncState{token: "*"}
+		c.states[key] = s
+	}
+	return s
+}
+
+// flush forgets every account's state, so the next reads are full syncs
+func (c *syncCache) flush() {
+	c.mu.Lock()
+	c.states = nil
+	c.mu.Unlock()
+}
+
+// read answers a full Sync read of types with an incremental sync sent
+// through upstream, and reports whether it could use a sync token. r is
+// already addressed to the API.
+func (c *syncCache) read(w http.ResponseWriter, r *http.Request, types []string, upstream http.RoundTripper) bool {
+	s := c.state(r.Header.Get("Authorization"), types)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	incremental := s.token != "*"
+
+	body, _ := json.Marshal(syncRead{SyncToken: s.token, ResourceTypes: types})
+	req := r.Clone(r.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	// Left to the transport, which then decompresses the response itself
+	req.Header.Del("Accept-Encoding")
+
+	resp, err := upstream.RoundTrip(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return false
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return false
+	}
+
+	var fields map[string]json.RawMessage
+	if resp.StatusCode != http.StatusOK || json.Unmarshal(data, &fields) != nil {
+		// A rejected token starts over with a full sync next time
+		s.reset()
+		copyHeader(w.Header(), resp.Header)
+		w.WriteHeader(resp.StatusCode)
+		w.Write(data)
+		return false
+	}
+
+	s.apply(fields)
+	out, _ := json.Marshal(s.response())
+	copyHeader(w.Header(), resp.Header)
+	w.Header().Del("Content-Length")
+	w.Header().Del("Content-Encoding")
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(out)
+	return incremental
+}
+
+func (s *syncState) reset() {
+	s.token = "*"
+	s.lists = nil
+	s.resources = nil
+}
+
+// apply merges a Sync response into the state
+func (s *syncState) apply(fields map[string]json.RawMessage) {
+	var full bool
+	json.Unmarshal(fields["full_sync"], &full)
+	if full || s.lists == nil {
+		s.lists = make(map[string]*syncList)
+		s.resources = make(map[string]json.RawMessage)
+	}
+	if token, err := strconv.Unquote(string(fields["sync_token"])); err == nil {
+		s.token = token
+	}
+
+	for name, value := range fields {
+		switch name {
+		case "sync_token", "full_sync", "temp_id_mapping", "sync_status":
+			continue
+		}
+		var entries []json.RawMessage
+		if bytes.HasPrefix(bytes.TrimSpace(value), []byte("[")) && json.Unmarshal(value, &entries) == nil {
+			list := s.lists[name]
+			if list == nil {
+				list = &syncList{byID: make(map[string]json.RawMessage)}
+				s.lists[name] = list
+			}
+			for _, e := range entries {
+				list.apply(name, e)
+			}
+			continue
+		}
+		s.resources[name] = value
+	}
+}
+
+// apply adds, replaces or removes one entry. Incremental syncs report
+// deleted entries, and completed tasks, which full syncs leave out.
+func (l *syncList) apply(name string, entry json.RawMessage) {
+	var meta struct {
+		ID        json.RawMessage `json:"id"`
+		IsDeleted bool            `json:"is_deleted"`
+		Checked   bool            `json:"checked"`
+	}
+	json.Unmarshal(entry, &meta)
+	id := strings.Trim(string(meta.ID), `"`)
+	if id == "" {
+		id = string(entry)
+	}
+
+	if meta.IsDeleted || (name == "items" && meta.Checked) {
+		if _, ok := l.byID[id]; ok {
+			delete(l.byID, id)
+			for i, o := range l.order {
+				if o == id {
+					l.order = append(l.order[:i], l.order[i+1:]...)
+					break
+				}
+			}
+		}
+		return
+	}
+	if _, ok := l.byID[id]; !ok {
+		l.order = append(l.order, id)
+	}
+	l.byID[id] = entry
+}
+
+// response renders the state as a full Sync response
+func (s *syncState) response() map[string]interface{} {
+	out := map[string]interface{}{"sync_token": s.token, "full_sync": true}
+	for name, value := range s.resources {
+		out[name] = value
+	}
+	for name, list := range s.lists {
+		entries := make([]json.RawMessage, len(list.order))
+		for i, id := range list.order {
+			entries[i] = list.byID[id]
+		}
+		out[name] = entries
+	}
+	return out
+}
+
+func copyHeader(dst, src http.Header) {
+	for k, v := range src {
+		dst[k] = v
+	}
+}
@@ -0,0 +1,94 @@
+package daemon
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestSyncReadsBecomeIncremental(t *testing.T) {
+	var tokens []string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req syncRead
+		json.NewDecoder(r.Body).Decode(&req)
+		tokens = append(tokens, req.SyncToken)
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		switch req.SyncToken {
+		case "*":
+			io.WriteString(w, `{"sync_token":"t1","full_sync":true,"items":[{"id":"1","content":"A"},{"id":"2","content":"B"}],"user":{"id":"u"}}`)
+		case "t1":
+			io.WriteString(w, `{"sync_token":"t2","full_sync":false,"items":[{"id":"2","checked":true},{"id":"1","content":"A2"},{"id":3,"content":"C"}]}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"error":"invalid sync token"}`)
+		}
+	}))
+	defer upstream.Close()
+	u, _ := url.Parse(upstream.URL)
+
+	s := newServer(http.DefaultTransport)
+	read := func() (int, map[string]json.RawMessage) {
+		req := httptest.NewRequest("POST", "/api/v1/sync", strings.NewReader(`{"sync_token":"*","resource_types":["items","user"]}`))
+		req.Header.Set(schemeHeader, u.Scheme)
+		req.Header.Set(hostHeader, u.Host)
+		req.Header.Set("Authorization", "Bearer secret")
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		var body map[string]json.RawMessage
+		json.Unmarshal(rec.Body.Bytes(), &body)
+		return rec.Code, body
+	}
+
+	if code, body := read(); code != 200 || !strings.Contains(string(body["items"]), `"B"`) {
+		t.Fatalf("first read: %d %s", code, body["items"])
+	}
+
+	code, body := read()
+	if code != 200 {
+		t.Fatalf("second read failed with %d", code)
+	}
+	var items []struct {
+		Content string `json:"content"`
+	}
+	json.Unmarshal(body["items"], &items)
+	if len(items) != 2 || items[0].Content != "A2" || items[1].Content != "C" {
+		t.Errorf("expected the updated task and the new one, got %s", body["items"])
+	}
+	if string(body["user"]) != `{"id":"u"}` {
+		t.Errorf("expected unchanged resources to be kept, got %s", body["user"])
+	}
+	if st := s.status(); st.SyncReads != 1 || st.Requests != 2 || st.RateLimit == nil || st.RateLimit.Remaining != 99 {
+		t.Errorf("unexpected status %+v", st)
+	}
+
+	// A rejected token is passed through and the next read starts over
+	if code, _ := read(); code != http.StatusBadRequest {
+		t.Errorf("expected the API's error, got %d", code)
+	}
+	read()
+	if got := strings.Join(tokens, ","); got != "*,t1,t2,*" {
+		t.Errorf("unexpected sync tokens sent: %s", got)
+	}
+}
+
+func TestParseSyncRead(t *testing.T) {
+	tests := []struct {
+		body string
+		ok   bool
+	}{
+		{`{"sync_token":"*","resource_types":["items"]}`, true},
+		{`{"sync_token":"abc","resource_types":["items"]}`, false},
+		{`{"commands":[{"type":"item_add"}]}`, false},
+		{`{"sync_token":"*","resource_types":["items"],"commands":[]}`, false},
+		{`not json`, false},
+	}
+	for _, tt := range tests {
+		if _, ok := parseSyncRead([]byte(tt.body)); ok != tt.ok {
+			t.Errorf("parseSyncRead(%s) = %v, want %v", tt.body, ok, tt.ok)
+		}
+	}
+}
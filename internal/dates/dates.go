@@ -0,0 +1,234 @@
+// Package dates parses the loose date/time expressions accepted by CLI flags.
+package dates
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeOfDayRe = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+	// offsetRe matches "-7d", "+2w", "-1m" and "-1y"
+	offsetRe = regexp.MustCompile(`^([+-])(\d+)([dwmy])$`)
+	// agoRe matches "3 days ago", "1 week ago" and so on
+	agoRe = regexp.MustCompile(`^(\d+) (day|week|month|year)s? ago$`)
+	// periodRe matches "beginning of month", "end of week", "last month"...
+	periodRe = regexp.MustCompile(`^(beginning of|start of|end of|this|last|next) (week|month|year)$`)
+)
+
+var weekdays = map[string]time.Weekday{
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+	"sunday": time.Sunday, "sun": time.Sunday,
+}
+
+// Parse interprets s relative to now. Supported forms:
+//
+//	2024-01-15T18:00:00Z          RFC 3339 timestamp
+//	2024-01-15, 2024-01-15 18:00  calendar date with optional time
+//	now, today, yesterday, tomorrow
+//	-7d, +2w, -1m, 3 days ago     offset from today
+//	monday, last fri, next tue    weekday: the coming one, or strictly before or after today
+//	beginning of month, end of week, last month, this year
+//	yesterday 6pm, today 9:30am   relative day with a time
+//	6pm, 18:00                    time today
+//
+// Weeks start on Monday. A day without a time resolves to midnight in now's
+// location.
+func Parse(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, fmt.Errorf("empty date")
+	}
+
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	s = strings.ToLower(s)
+	if s == "now" {
+		return now, nil
+	}
+
+	if day, ok := parseDay(s, now); ok {
+		return day, nil
+	}
+	// A bare time of day applies to today
+	if h, m, ok := parseTimeOfDay(s); ok {
+		return at(now, h, m), nil
+	}
+
+	// Otherwise a day is followed by a time, and either may contain spaces
+	var badTime string
+	for i := strings.IndexByte(s, ' '); i >= 0; i = nextSpace(s, i) {
+		day, ok := parseDay(s[:i], now)
+		if !ok {
+			continue
+		}
+		timePart := strings.TrimSpace(s[i+1:])
+		if h, m, ok := parseTimeOfDay(timePart); ok {
+			return at(day, h, m), nil
+		}
+		if badTime == "" {
+			badTime = timePart
+		}
+	}
+	if badTime != "" {
+		return time.Time{}, fmt.Errorf("unrecognized time: %q", badTime)
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date: %q", s)
+}
+
+// nextSpace returns the index of the first space in s after i, or -1
+func nextSpace(s string, i int) int {
+	j := strings.IndexByte(s[i+1:], ' ')
+	if j < 0 {
+		return -1
+	}
+	return i + 1 + j
+}
+
+// TimeOfDay parses a time such as "17:00" or "5:30pm" into hour and minute.
+func TimeOfDay(s string) (hour, minute int, err error) {
+	hour, minute, ok := parseTimeOfDay(strings.ToLower(strings.TrimSpace(s)))
+	if !ok {
+		return 0, 0, fmt.Errorf("unrecognized time: %q", s)
+	}
+	return hour, minute, nil
+}
+
+// parseDay resolves a keyword, offset, weekday, period or YYYY-MM-DD date
+// to midnight of that day.
+func parseDay(s string, now time.Time) (time.Time, bool) {
+	today := at(now, 0, 0)
+	switch s {
+	case "today":
+		return today, true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), true
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return t, true
+	}
+
+	if m := offsetRe.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[2])
+		if m[1] == "-" {
+			n = -n
+		}
+		return shift(today, m[3][:1], n), true
+	}
+	if m := agoRe.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		return shift(today, m[2][:1], -n), true
+	}
+	if m := periodRe.FindStringSubmatch(s); m != nil {
+		return period(today, m[1], m[2]), true
+	}
+
+	which, name, found := strings.Cut(s, " ")
+	if !found {
+		which, name = "", s
+	}
+	if day, ok := weekdays[name]; ok {
+		diff := (int(day) - int(today.Weekday()) + 7) % 7
+		switch which {
+		case "":
+			return today.AddDate(0, 0, diff), true
+		case "next":
+			if diff == 0 {
+				diff = 7
+			}
+			return today.AddDate(0, 0, diff), true
+		case "last":
+			back := (int(today.Weekday()) - int(day) + 7) % 7
+			if back == 0 {
+				back = 7
+			}
+			return today.AddDate(0, 0, -back), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// shift moves day by n days, weeks, months or years (unit d, w, m or y)
+func shift(day time.Time, unit string, n int) time.Time {
+	switch unit {
+	case "w":
+		return day.AddDate(0, 0, 7*n)
+	case "m":
+		return day.AddDate(0, n, 0)
+	case "y":
+		return day.AddDate(n, 0, 0)
+	}
+	return day.AddDate(0, 0, n)
+}
+
+// period resolves "beginning of month", "end of week", "last year" and the
+// like: the first day of the period, or its last day for "end of"
+func period(today time.Time, which, unit string) time.Time {
+	var start time.Time
+	switch unit {
+	case "week":
+		start = today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	case "month":
+		start = time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
+	case "year":
+		start = time.Date(today.Year(), 1, 1, 0, 0, 0, 0, today.Location())
+	}
+
+	u := unit[:1]
+	switch which {
+	case "last":
+		return shift(start, u, -1)
+	case "next":
+		return shift(start, u, 1)
+	case "end of":
+		return shift(start, u, 1).AddDate(0, 0, -1)
+	}
+	return start
+}
+
+// parseTimeOfDay accepts 24-hour ("18:00") and 12-hour ("6pm", "6:30 pm") times.
+func parseTimeOfDay(s string) (hour, minute int, ok bool) {
+	m := timeOfDayRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0, 0, false
+	}
+	hour, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+	switch m[3] {
+	case "am":
+		if hour < 1 || hour > 12 {
+			return 0, 0, false
+		}
+		if hour == 12 {
+			hour = 0
+		}
+	case "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, false
+		}
+		if hour != 12 {
+			hour += 12
+		}
+	}
+	if hour > 23 || minute > 59 {
+		return 0, 0, false
+	}
+	return hour, minute, true
+}
+
+func at(day time.Time, hour, minute int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, day.Location())
+}
@@ -0,0 +1,96 @@
+package dates
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	now := time.Date(2024, 3, 10, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"now", now},
+		{"today", time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{"yesterday 6pm", time.Date(2024, 3, 9, 18, 0, 0, 0, time.UTC)},
+		{"Tomorrow 9:15am", time.Date(2024, 3, 11, 9, 15, 0, 0, time.UTC)},
+		{"12am", time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{"18:05", time.Date(2024, 3, 10, 18, 5, 0, 0, time.UTC)},
+		{"2024-01-15", time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"2024-01-15 7:45 pm", time.Date(2024, 1, 15, 19, 45, 0, 0, time.UTC)},
+		{"2024-01-15T18:00:00Z", time.Date(2024, 1, 15, 18, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.in, now)
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("Parse(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParse_Relative(t *testing.T) {
+	// A Sunday
+	now := time.Date(2024, 3, 10, 14, 30, 0, 0, time.UTC)
+	day := func(month time.Month, d int) time.Time { return time.Date(2024, month, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"-7d", day(3, 3)},
+		{"+2w", day(3, 24)},
+		{"-1m", day(2, 10)},
+		{"3 days ago", day(3, 7)},
+		{"1 week ago", day(3, 3)},
+		{"monday", day(3, 11)},
+		{"sunday", day(3, 10)},
+		{"last monday", day(3, 4)},
+		{"last sun", day(3, 3)},
+		{"next sunday", day(3, 17)},
+		{"beginning of month", day(3, 1)},
+		{"end of month", day(3, 31)},
+		{"start of week", day(3, 4)},
+		{"end of week", day(3, 10)},
+		{"last month", day(2, 1)},
+		{"this year", day(1, 1)},
+		{"last friday 6:30 pm", time.Date(2024, 3, 8, 18, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.in, now)
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("Parse(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	now := time.Date(2024, 3, 10, 14, 30, 0, 0, time.UTC)
+
+	for _, in := range []string{"", "someday", "yesterday 25:00", "13pm", "2024-13-01", "-7x", "last someday", "end of decade"} {
+		if _, err := Parse(in, now); err == nil {
+			t.Errorf("Parse(%q) expected error", in)
+		}
+	}
+}
+
+func TestTimeOfDay(t *testing.T) {
+	h, m, err := TimeOfDay(" 5:30PM ")
+	if err != nil || h != 17 || m != 30 {
+		t.Errorf("TimeOfDay(5:30PM) = %d:%d, %v", h, m, err)
+	}
+	if _, _, err := TimeOfDay("noonish"); err == nil {
+		t.Error("expected an error for an unrecognized time")
+	}
+}
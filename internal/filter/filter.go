@@ -0,0 +1,135 @@
+// Package filter builds Todoist filter query strings, so searches can run
+// server-side instead of fetching every task.
+package filter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Query is a conjunction of filter predicates, joined with "&"
+type Query struct {
+	parts []string
+}
+
+// Search matches tasks whose content contains text
+func (q *Query) Search(text string) *Query {
+	return q.add("search: " + Escape(text))
+}
+
+// Project matches tasks in the named project
+func (q *Query) Project(name string) *Query {
+	return q.add("#" + Escape(name))
+}
+
+// Label matches tasks carrying the label
+func (q *Query) Label(name string) *Query {
+	return q.add("@" + Escape(strings.TrimPrefix(name, "@")))
+}
+
+// Priority matches tasks with the given user-facing priority (1 = highest)
+func (q *Query) Priority(p int) *Query {
+	return q.add(fmt.Sprintf("p%d", p))
+}
+
+// DueBefore matches tasks due before date (YYYY-MM-DD)
+func (q *Query) DueBefore(date string) *Query {
+	return q.add("due before: " + date)
+}
+
+// DueAfter matches tasks due after date (YYYY-MM-DD)
+func (q *Query) DueAfter(date string) *Query {
+	return q.add("due after: " + date)
+}
+
+// NoDate matches tasks without a due date
+func (q *Query) NoDate() *Query {
+	return q.add("no date")
+}
+
+// CreatedAfter matches tasks created after date (YYYY-MM-DD)
+func (q *Query) CreatedAfter(date string) *Query {
+	return q.add("created after: " + date)
+}
+
+// Empty reports whether the query has no predicates
+func (q *Query) Empty() bool {
+	return len(q.parts) == 0
+}
+
+// String returns the filter expression
+func (q *Query) String() string {
+	return strings.Join(q.parts, " & ")
+}
+
+// Within returns the query restricted to tasks that also match base, another
+// filter expression. Each |-separated alternative of base gets the query's
+// predicates, so no parentheses are needed unless base already uses them.
+func (q *Query) Within(base string) string {
+	switch {
+	case strings.TrimSpace(base) == "":
+		return q.String()
+	case q.Empty():
+		return base
+	case strings.ContainsAny(unescaped(base), "()"):
+		return "(" + base + ") & " + q.String()
+	}
+	alts := splitOr(base)
+	for i, alt := range alts {
+		alts[i] = strings.TrimSpace(alt) + " & " + q.String()
+	}
+	return strings.Join(alts, " | ")
+}
+
+func (q *Query) add(part string) *Query {
+	q.parts = append(q.parts, part)
+	return q
+}
+
+// Escape backslash-escapes characters that are operators in filter syntax
+func Escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`&|!()\,`, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// unescaped drops backslash-escaped characters, leaving only operators
+func unescaped(s string) string {
+	var b strings.Builder
+	escaped := false
+	for _, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// splitOr splits a filter expression on unescaped "|"
+func splitOr(s string) []string {
+	var parts []string
+	start := 0
+	escaped := false
+	for i, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '|':
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
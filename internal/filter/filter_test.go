@@ -0,0 +1,55 @@
+package filter
+
+import "testing"
+
+func TestQuery(t *testing.T) {
+	var q Query
+	q.Search("report").Project("Work").Label("@urgent").Priority(1).DueBefore("2024-02-01").DueAfter("2024-01-01")
+
+	want := "search: report & #Work & @urgent & p1 & due before: 2024-02-01 & due after: 2024-01-01"
+	if got := q.String(); got != want {
+		t.Errorf("got  %q\nwant %q", got, want)
+	}
+}
+
+func TestQuery_Within(t *testing.T) {
+	var q Query
+	q.Label("waiting").NoDate()
+
+	tests := map[string]string{
+		"":                  "@waiting & no date",
+		"today | overdue":   "today & @waiting & no date | overdue & @waiting & no date",
+		`#R\|D`:             `#R\|D & @waiting & no date`,
+		"(p1 | p2) & #Work": "((p1 | p2) & #Work) & @waiting & no date",
+	}
+	for base, want := range tests {
+		if got := q.Within(base); got != want {
+			t.Errorf("Within(%q) = %q, want %q", base, got, want)
+		}
+	}
+	if got := (&Query{}).Within("overdue"); got != "overdue" {
+		t.Errorf("empty query should leave base alone, got %q", got)
+	}
+}
+
+func TestQuery_Empty(t *testing.T) {
+	var q Query
+	if !q.Empty() || q.String() != "" {
+		t.Errorf("new query should be empty, got %q", q.String())
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := map[string]string{
+		"plain":         "plain",
+		"R&D":           `R\&D`,
+		"a | b":         `a \| b`,
+		"(draft)":       `\(draft\)`,
+		"Home, Garden!": `Home\, Garden\!`,
+	}
+	for in, want := range tests {
+		if got := Escape(in); got != want {
+			t.Errorf("Escape(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Package hooks runs user scripts configured for CLI events, passing the
+// affected task as JSON on stdin.
+package hooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/buddyh/todoist-cli/internal/config"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+)
+
+// Events that can have a hook. A failing pre- hook cancels the action; a
+// failing post- hook only prints a warning.
+const (
+	PreAdd       = "pre-add"
+	PostAdd      = "post-add"
+	PostUpdate   = "post-update"
+	PostComplete = "post-complete"
+	PreDelete    = "pre-delete"
+	PostDelete   = "post-delete"
+)
+
+// Run executes the hook configured for event, if any, with v as JSON on
+// stdin. The command runs through the shell with TODOIST_HOOK set to the
+// event name; its output goes to w, the command's stderr, so it cannot
+// corrupt the CLI's own output. A non-zero exit is returned as an error.
+func Run(w io.Writer, event string, v interface{}) error {
+	command := config.Settings().Hooks[event]
+	if command == "" {
+		return nil
+	}
+
+	input, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s hook input: %w", event, err)
+	}
+
+	cmd := shellCommand(command)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = w
+	cmd.Stderr = w
+	cmd.Env = append(os.Environ(), "TODOIST_HOOK="+event)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s hook failed: %w", event, err)
+	}
+	return nil
+}
+
+// RunPost runs a post- hook, reporting a failure as a warning on w instead
+// of failing the command whose action already happened
+func RunPost(w io.Writer, event string, v interface{}) {
+	if err := Run(w, event, v); err != nil {
+		fmt.Fprintln(w, i18n.T("Warning: %v", err))
+	}
+}
+
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
@@ -0,0 +1,55 @@
+package hooks
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/buddyh/todoist-cli/internal/config"
+)
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands in this test use sh")
+	}
+	t.Setenv("HOME", t.TempDir())
+	log := filepath.Join(t.TempDir(), "hook.log")
+
+	cfg := &config.Config{Hooks: map[string]string{
+		PostComplete: `{ echo "$TODOIST_HOOK"; cat; } > ` + log,
+		PreAdd:       "exit 1",
+		PostDelete:   "echo deleted; echo oops >&2; exit 2",
+	}}
+	if err := config.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Run(&bytes.Buffer{}, PostComplete, map[string]string{"id": "42"}); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	data, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != "post-complete\n{\"id\":\"42\"}" {
+		t.Errorf("hook got %q", got)
+	}
+
+	if err := Run(&bytes.Buffer{}, PreAdd, nil); err == nil || !strings.Contains(err.Error(), "pre-add hook failed") {
+		t.Errorf("expected a failing hook to return an error, got %v", err)
+	}
+
+	if err := Run(&bytes.Buffer{}, PostAdd, nil); err != nil {
+		t.Errorf("expected events without a hook to be ignored, got %v", err)
+	}
+
+	// Hook output and post- hook failures go to the writer given
+	var w bytes.Buffer
+	RunPost(&w, PostDelete, nil)
+	if got := w.String(); !strings.HasPrefix(got, "deleted\noops\nWarning: post-delete hook failed") {
+		t.Errorf("expected the hook's output and a warning, got %q", got)
+	}
+}
@@ -0,0 +1,239 @@
+package i18n
+
+// catalogs maps a language to translations keyed by the English message.
+// Translations must keep the message's format verbs in the same order.
+var catalogs = map[string]map[string]string{
+	"de": {
+		"Error: %v":                 "Fehler: %v",
+		"Cancelled":                 "Abgebrochen",
+		"No tasks found.":           "Keine Aufgaben gefunden.",
+		"No projects found.":        "Keine Projekte gefunden.",
+		"No labels found.":          "Keine Labels gefunden.",
+		"No sections found.":        "Keine Abschnitte gefunden.",
+		"No comments found.":        "Keine Kommentare gefunden.",
+		"No collaborators found.":   "Keine Mitarbeiter gefunden.",
+		"No workspaces found.":      "Keine Arbeitsbereiche gefunden.",
+		"No folders found.":         "Keine Ordner gefunden.",
+		"No backups found.":         "Keine Sicherungen gefunden.",
+		"No habits found.":          "Keine Gewohnheiten gefunden.",
+		"No dependencies found.":    "Keine Abhängigkeiten gefunden.",
+		"Personal":                  "Persönlich",
+		"No completed tasks found.": "Keine erledigten Aufgaben gefunden.",
+		"No tasks to postpone.":     "Keine Aufgaben zum Verschieben.",
+		"No aliases defined.":       "Keine Aliase definiert.",
+		"No views saved.":           "Keine Ansichten gespeichert.",
+		"No profiles configured.":   "Keine Profile eingerichtet.",
+		"No credentials stored.":    "Keine Zugangsdaten gespeichert.",
+		"Nothing to do right now.":  "Gerade gibt es nichts zu tun.",
+		"Nothing due today":         "Heute ist nichts fällig",
+		"today":                     "heute",
+		"tomorrow":                  "morgen",
+		"in %d days":                "in %d Tagen",
+		"1 day overdue":             "1 Tag überfällig",
+		"%d days overdue":           "%d Tage überfällig",
+		"Delete task: %s%s\nThis cannot be undone. Continue? [y/N] ": "Aufgabe löschen: %s%s\nDas kann nicht rückgängig gemacht werden. Fortfahren? [y/N] ",
+		"Rewrite %d mention(s)%s? [y/N] ":                            "%d Erwähnung(en) umschreiben%s? [y/N] ",
+		"Remove %s from %s%s? [y/N] ":                                "%s aus %s entfernen%s? [y/N] ",
+		"Select [number, text to filter, empty to cancel]: ":         "Auswahl [Nummer, Text zum Filtern, leer zum Abbrechen]: ",
+		"  (no matches)":                "  (keine Treffer)",
+		"No entry %d":                   "Kein Eintrag %d",
+		"  ... %d more, type to narrow": "  ... %d weitere, tippen zum Eingrenzen",
+		"hidden by the built-in %s command; rename this alias": "durch den eingebauten Befehl %s verdeckt; Alias umbenennen",
+		"Completed: %s":                         "Erledigt: %s",
+		"Completed %d tasks":                    "%d Aufgaben erledigt",
+		"Completing %d tasks":                   "%d Aufgaben werden erledigt",
+		"Deleted: %s":                           "Gelöscht: %s",
+		"Deleted %d tasks":                      "%d Aufgaben gelöscht",
+		"Deleting %d tasks":                     "%d Aufgaben werden gelöscht",
+		"Postponed %d task(s) to %s":            "%d Aufgabe(n) verschoben auf %s",
+		"Postponing %d tasks":                   "%d Aufgaben werden verschoben",
+		"Skipped recurring task: %s":            "Wiederkehrende Aufgabe übersprungen: %s",
+		"Set %d tasks to p%d":                   "%d Aufgaben auf p%d gesetzt",
+		"Snoozed %d task(s)":                    "%d Aufgabe(n) zurückgestellt",
+		"Woke %d task(s)":                       "%d Aufgabe(n) reaktiviert",
+		"Moved %d tasks under: %s":              "%d Aufgaben verschoben unter: %s",
+		"Task reopened":                         "Aufgabe wieder geöffnet",
+		"Comment added":                         "Kommentar hinzugefügt",
+		"Created label: @%s":                    "Label erstellt: @%s",
+		"Created section: %s":                   "Abschnitt erstellt: %s",
+		"Deleted project: %s":                   "Projekt gelöscht: %s",
+		"Showing %d-%d of %d tasks":             "Aufgaben %d-%d von %d",
+		"▶ %s on profile %s":                    "▶ %s im Profil %s",
+		"Adding %s":                             "%s wird hinzugefügt",
+		"Updating %s":                           "%s wird aktualisiert",
+		"Completing %s":                         "%s wird erledigt",
+		"Moving %s":                             "%s wird verschoben",
+		"Authenticated as %s":                   "Angemeldet als %s",
+		"Switched to profile %s":                "Zu Profil %s gewechselt",
+		"Logged out successfully.":              "Erfolgreich abgemeldet.",
+		"No problems found in recurring tasks.": "Keine Probleme bei wiederkehrenden Aufgaben gefunden.",
+		"Update available: %s":                  "Update verfügbar: %s",
+		"Run 'todoist self-update' to install it, or see %s":                       "Mit 'todoist self-update' installieren, oder siehe %s",
+		"Up to date (latest is %s)":                                                "Aktuell (neueste Version ist %s)",
+		"Would update %s from %s to %s":                                            "Würde %s von %s auf %s aktualisieren",
+		"Requests: %d of %d left":                                                  "Anfragen: %d von %d übrig",
+		"Requests: %d left":                                                        "Anfragen: %d übrig",
+		"Resets:   in %s (%s)":                                                     "Zurückgesetzt: in %s (%s)",
+		"Todoist did not report a rate limit for this token.":                      "Todoist hat für dieses Token kein Ratenlimit gemeldet.",
+		"Budget:   %d requests, %d full syncs and %d partial syncs per %d minutes": "Budget:   %d Anfragen, %d volle und %d teilweise Syncs pro %d Minuten",
+		"Daemon not running":                                                       "Daemon läuft nicht",
+		"Daemon running on %s (pid %d)":                                            "Daemon läuft auf %s (PID %d)",
+		"Up:       %s":                                                             "Laufzeit: %s",
+		"Requests: %d (%d Sync reads answered incrementally)":                      "Anfragen: %d (%d Sync-Lesezugriffe inkrementell beantwortet)",
+		"Budget:   %d of %d requests left":                                         "Budget:   %d von %d Anfragen übrig",
+		"Next: %s":                                                                 "Als Nächstes: %s",
+		"Updated %s ago":                                                           "Vor %s aktualisiert",
+		"Since %s: %s":                                                             "Seit %s: %s",
+		"Someone":                                                                  "Jemand",
+		"%d added":                                                                 "%d hinzugefügt",
+		"%d completed":                                                             "%d erledigt",
+		"%d rescheduled":                                                           "%d neu geplant",
+		"1 comment":                                                                "1 Kommentar",
+		"%d comments":                                                              "%d Kommentare",
+		"No changes":                                                               "Keine Änderungen",
+		"%s removed the date of %q":                                                "%s hat das Datum von %q entfernt",
+		"%s rescheduled %q to %s":                                                  "%s hat %q auf %s verschoben",
+		"%s commented: %q":                                                         "%s hat kommentiert: %q",
+		"%s added %q":                                                              "%s hat %q hinzugefügt",
+		"%s completed %q":                                                          "%s hat %q erledigt",
+		"Rename @%s → @%s":                                                         "@%s → @%s umbenennen",
+		"Text rewrites (%d):":                                                      "Textänderungen (%d):",
+		"task %s":                                                                  "Aufgabe %s",
+		"comment %s on task %s":                                                    "Kommentar %s zu Aufgabe %s",
+		"Round %d done, %s in total. [c]omplete, [a]nother round, [s]top? ": "Runde %d beendet, %s insgesamt. [c] erledigen, [a] noch eine Runde, [s] stoppen? ",
+		"Topics:": "Themen:",
+		"Run 'todoist examples <topic>', or give keywords to search.": "'todoist examples <Thema>' ausführen oder Suchbegriffe angeben.",
+		"Slow command: took %s":                                             "Langsamer Befehl: dauerte %s",
+		"Slow command: took %s across %d API request(s)":                    "Langsamer Befehl: dauerte %s bei %d API-Anfrage(n)",
+		"(rate limited %d time(s))":                                         "(%d-mal durch Ratenlimit gebremst)",
+		"fetching %s took %s":                                               "Abrufen von %s dauerte %s",
+		"fetching %d pages of %s took %s":                                   "Abrufen von %d Seiten %s dauerte %s",
+		"%d %s call(s) took %s":                                             "%d %s-Aufruf(e) dauerten %s",
+		"consider --filter or --project to fetch fewer tasks":               "--filter oder --project verwenden, um weniger Aufgaben abzurufen",
+		"comments are fetched per task; drop --details for faster listings": "Kommentare werden pro Aufgabe abgerufen; ohne --details geht die Liste schneller",
+		"(no changes)":                                                      "(keine Änderungen)",
+		"%s %d tasks%s? [y/N] ":                                             "%s: %d Aufgaben%s? [y/N] ",
+		"Import":                                                            "Importieren",
+		"Set p%d on":                                                        "Auf p%d setzen",
+		"Complete":                                                          "Erledigen",
+		"Postpone":                                                          "Aufschieben",
+		"Delete":                                                            "Löschen",
+		"Move":                                                              "Verschieben",
+	},
+	"es": {
+		"Cancelled":                 "Cancelado",
+		"No tasks found.":           "No se encontraron tareas.",
+		"No projects found.":        "No se encontraron proyectos.",
+		"No labels found.":          "No se encontraron etiquetas.",
+		"No sections found.":        "No se encontraron secciones.",
+		"No comments found.":        "No se encontraron comentarios.",
+		"No collaborators found.":   "No se encontraron colaboradores.",
+		"No workspaces found.":      "No se encontraron espacios de trabajo.",
+		"No folders found.":         "No se encontraron carpetas.",
+		"No backups found.":         "No se encontraron copias de seguridad.",
+		"No habits found.":          "No se encontraron hábitos.",
+		"No dependencies found.":    "No se encontraron dependencias.",
+		"Personal":                  "Personal",
+		"No completed tasks found.": "No se encontraron tareas completadas.",
+		"No tasks to postpone.":     "No hay tareas que posponer.",
+		"No aliases defined.":       "No hay alias definidos.",
+		"No views saved.":           "No hay vistas guardadas.",
+		"No profiles configured.":   "No hay perfiles configurados.",
+		"No credentials stored.":    "No hay credenciales guardadas.",
+		"Nothing to do right now.":  "Nada que hacer por ahora.",
+		"Nothing due today":         "Nada vence hoy",
+		"today":                     "hoy",
+		"tomorrow":                  "mañana",
+		"in %d days":                "en %d días",
+		"1 day overdue":             "1 día de retraso",
+		"%d days overdue":           "%d días de retraso",
+		"Delete task: %s%s\nThis cannot be undone. Continue? [y/N] ": "Eliminar tarea: %s%s\nNo se puede deshacer. ¿Continuar? [y/N] ",
+		"Rewrite %d mention(s)%s? [y/N] ":                            "¿Reescribir %d mención(es)%s? [y/N] ",
+		"Remove %s from %s%s? [y/N] ":                                "¿Quitar a %s de %s%s? [y/N] ",
+		"Select [number, text to filter, empty to cancel]: ":         "Elegir [número, texto para filtrar, vacío para cancelar]: ",
+		"  (no matches)":                "  (sin coincidencias)",
+		"No entry %d":                   "No existe la entrada %d",
+		"  ... %d more, type to narrow": "  ... %d más, escriba para acotar",
+		"hidden by the built-in %s command; rename this alias": "oculto por el comando integrado %s; cambie el nombre del alias",
+		"Completed: %s":                         "Completada: %s",
+		"Completed %d tasks":                    "%d tareas completadas",
+		"Completing %d tasks":                   "Completando %d tareas",
+		"Deleted: %s":                           "Eliminada: %s",
+		"Deleted %d tasks":                      "%d tareas eliminadas",
+		"Deleting %d tasks":                     "Eliminando %d tareas",
+		"Postponed %d task(s) to %s":            "%d tarea(s) pospuesta(s) a %s",
+		"Postponing %d tasks":                   "Posponiendo %d tareas",
+		"Skipped recurring task: %s":            "Tarea recurrente omitida: %s",
+		"Set %d tasks to p%d":                   "%d tareas con prioridad p%d",
+		"Snoozed %d task(s)":                    "%d tarea(s) aplazada(s)",
+		"Woke %d task(s)":                       "%d tarea(s) reactivada(s)",
+		"Moved %d tasks under: %s":              "%d tareas movidas bajo: %s",
+		"Task reopened":                         "Tarea reabierta",
+		"Comment added":                         "Comentario añadido",
+		"Created label: @%s":                    "Etiqueta creada: @%s",
+		"Created section: %s":                   "Sección creada: %s",
+		"Deleted project: %s":                   "Proyecto eliminado: %s",
+		"Showing %d-%d of %d tasks":             "Mostrando %d-%d de %d tareas",
+		"▶ %s on profile %s":                    "▶ %s en el perfil %s",
+		"Adding %s":                             "Añadiendo %s",
+		"Updating %s":                           "Actualizando %s",
+		"Completing %s":                         "Completando %s",
+		"Moving %s":                             "Moviendo %s",
+		"Authenticated as %s":                   "Sesión iniciada como %s",
+		"Switched to profile %s":                "Cambiado al perfil %s",
+		"Logged out successfully.":              "Sesión cerrada correctamente.",
+		"No problems found in recurring tasks.": "No se encontraron problemas en las tareas recurrentes.",
+		"Update available: %s":                  "Actualización disponible: %s",
+		"Run 'todoist self-update' to install it, or see %s":                       "Ejecute 'todoist self-update' para instalarla, o vea %s",
+		"Up to date (latest is %s)":                                                "Al día (la última es %s)",
+		"Would update %s from %s to %s":                                            "Se actualizaría %s de %s a %s",
+		"Requests: %d of %d left":                                                  "Solicitudes: quedan %d de %d",
+		"Requests: %d left":                                                        "Solicitudes: quedan %d",
+		"Resets:   in %s (%s)":                                                     "Se reinicia: en %s (%s)",
+		"Todoist did not report a rate limit for this token.":                      "Todoist no informó un límite de solicitudes para este token.",
+		"Budget:   %d requests, %d full syncs and %d partial syncs per %d minutes": "Presupuesto: %d solicitudes, %d sincronizaciones completas y %d parciales cada %d minutos",
+		"Daemon not running":                                                       "El daemon no se está ejecutando",
+		"Daemon running on %s (pid %d)":                                            "Daemon en ejecución en %s (pid %d)",
+		"Up:       %s":                                                             "Activo:   %s",
+		"Requests: %d (%d Sync reads answered incrementally)":                      "Solicitudes: %d (%d lecturas de Sync respondidas de forma incremental)",
+		"Budget:   %d of %d requests left":                                         "Presupuesto: quedan %d de %d solicitudes",
+		"Next: %s":                                                                 "Siguiente: %s",
+		"Updated %s ago":                                                           "Actualizado hace %s",
+		"Since %s: %s":                                                             "Desde %s: %s",
+		"Someone":                                                                  "Alguien",
+		"%d added":                                                                 "%d añadidas",
+		"%d completed":                                                             "%d completadas",
+		"%d rescheduled":                                                           "%d reprogramadas",
+		"1 comment":                                                                "1 comentario",
+		"%d comments":                                                              "%d comentarios",
+		"No changes":                                                               "Sin cambios",
+		"%s removed the date of %q":                                                "%s quitó la fecha de %q",
+		"%s rescheduled %q to %s":                                                  "%s reprogramó %q para %s",
+		"%s commented: %q":                                                         "%s comentó: %q",
+		"%s added %q":                                                              "%s añadió %q",
+		"%s completed %q":                                                          "%s completó %q",
+		"Rename @%s → @%s":                                                         "Renombrar @%s → @%s",
+		"Text rewrites (%d):":                                                      "Cambios de texto (%d):",
+		"task %s":                                                                  "tarea %s",
+		"comment %s on task %s":                                                    "comentario %s en la tarea %s",
+		"Round %d done, %s in total. [c]omplete, [a]nother round, [s]top? ": "Ronda %d terminada, %s en total. [c] completar, [a] otra ronda, [s] parar? ",
+		"Topics:": "Temas:",
+		"Run 'todoist examples <topic>', or give keywords to search.": "Ejecute 'todoist examples <tema>' o indique palabras clave para buscar.",
+		"Slow command: took %s":                                             "Comando lento: tardó %s",
+		"Slow command: took %s across %d API request(s)":                    "Comando lento: tardó %s en %d solicitud(es) a la API",
+		"(rate limited %d time(s))":                                         "(limitado %d vez/veces por la tasa de solicitudes)",
+		"fetching %s took %s":                                               "obtener %s tardó %s",
+		"fetching %d pages of %s took %s":                                   "obtener %d páginas de %s tardó %s",
+		"%d %s call(s) took %s":                                             "%d llamada(s) %s tardaron %s",
+		"consider --filter or --project to fetch fewer tasks":               "use --filter o --project para obtener menos tareas",
+		"comments are fetched per task; drop --details for faster listings": "los comentarios se obtienen por tarea; quite --details para listar más rápido",
+		"(no changes)":                                                      "(sin cambios)",
+		"%s %d tasks%s? [y/N] ":                                             "¿%s %d tareas%s? [y/N] ",
+		"Import":                                                            "Importar",
+		"Set p%d on":                                                        "Poner p%d en",
+		"Complete":                                                          "Completar",
+		"Postpone":                                                          "Posponer",
+		"Delete":                                                            "Eliminar",
+		"Move":                                                              "Mover",
+	},
+}
@@ -0,0 +1,82 @@
+// Package i18n translates the CLI's human-facing messages.
+//
+// Messages are looked up by their English text, so untranslated strings and
+// unknown languages fall back to English. JSON output is never translated.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// language is the active catalog, empty for English
+var language string
+
+// SetLanguage selects the catalog for a language tag such as "de",
+// "de_DE.UTF-8" or "pt-BR". Languages without a catalog select English.
+func SetLanguage(tag string) {
+	lang := base(tag)
+	if _, ok := catalogs[lang]; !ok {
+		lang = ""
+	}
+	language = lang
+}
+
+// Language returns the active language, "en" when none is selected
+func Language() string {
+	if language == "" {
+		return "en"
+	}
+	return language
+}
+
+// Detect returns the language to use: the configured one when set, else
+// the first of LC_ALL, LC_MESSAGES and LANG that is set
+func Detect(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(env); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// Available lists the languages with a catalog, English first
+func Available() []string {
+	langs := []string{"en"}
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs[1:])
+	return langs
+}
+
+// T translates msg into the active language and formats it with args like
+// fmt.Sprintf. Without args msg is returned as is, so it may contain %.
+func T(msg string, args ...interface{}) string {
+	if translated, ok := catalogs[language][msg]; ok {
+		msg = translated
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// base reduces a locale to its language, e.g. "de_DE.UTF-8" to "de". The
+// C and POSIX locales mean English.
+func base(tag string) string {
+	tag = strings.ToLower(tag)
+	if i := strings.IndexAny(tag, "_-.@"); i >= 0 {
+		tag = tag[:i]
+	}
+	if tag == "c" || tag == "posix" {
+		return ""
+	}
+	return tag
+}
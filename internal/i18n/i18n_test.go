@@ -0,0 +1,81 @@
+package i18n
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestSetLanguage(t *testing.T) {
+	defer SetLanguage("")
+
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"de", "de"},
+		{"de_DE.UTF-8", "de"},
+		{"es-MX", "es"},
+		{"C", "en"},
+		{"fr_FR.UTF-8", "en"},
+		{"", "en"},
+	}
+	for _, tt := range tests {
+		SetLanguage(tt.tag)
+		if got := Language(); got != tt.want {
+			t.Errorf("SetLanguage(%q): got %s, want %s", tt.tag, got, tt.want)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	defer SetLanguage("")
+
+	SetLanguage("de")
+	if got := T("%d days overdue", 3); got != "3 Tage überfällig" {
+		t.Errorf("got %q", got)
+	}
+	if got := T("Not in any catalog %s", "x"); got != "Not in any catalog x" {
+		t.Errorf("untranslated message: got %q", got)
+	}
+
+	if got := T("Deleted: %s", "Draft"); got != "Gelöscht: Draft" {
+		t.Errorf("got %q", got)
+	}
+
+	SetLanguage("")
+	if got := T("No tasks found."); got != "No tasks found." {
+		t.Errorf("english: got %q", got)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "es_ES.UTF-8")
+
+	if got := Detect("de"); got != "de" {
+		t.Errorf("configured language: got %q", got)
+	}
+	if got := Detect(""); got != "es_ES.UTF-8" {
+		t.Errorf("LANG: got %q", got)
+	}
+	t.Setenv("LC_ALL", "C")
+	if got := Detect(""); got != "C" {
+		t.Errorf("LC_ALL: got %q", got)
+	}
+}
+
+// Translations are passed to fmt.Sprintf with the English message's
+// arguments, so their verbs must match
+func TestCatalogVerbs(t *testing.T) {
+	verbs := regexp.MustCompile(`%[a-z%]`)
+	for lang, catalog := range catalogs {
+		for msg, translated := range catalog {
+			want := strings.Join(verbs.FindAllString(msg, -1), "")
+			if got := strings.Join(verbs.FindAllString(translated, -1), ""); got != want {
+				t.Errorf("%s: %q has verbs %q, want %q", lang, translated, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package output
+
+import "fmt"
+
+// Check statuses
+const (
+	CheckOK   = "ok"
+	CheckWarn = "warn"
+	CheckFail = "fail"
+)
+
+// Check is the result of one diagnostic check
+type Check struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+	Fix    string `json:"fix,omitempty"`
+}
+
+// WriteChecks outputs diagnostic results, one per line, with the suggested
+// fix under each problem
+func (f *Formatter) WriteChecks(checks []Check) error {
+	if f.asJSON {
+		return f.JSON(checks)
+	}
+
+	width := 0
+	for _, c := range checks {
+		width = max(width, displayWidth(c.Name))
+	}
+
+	for _, c := range checks {
+		mark := f.color.Wrap(ANSIGreen, "✓")
+		switch c.Status {
+		case CheckWarn:
+			mark = f.color.Wrap(ANSIYellow, "!")
+		case CheckFail:
+			mark = f.color.Wrap(ANSIRed, "✗")
+		}
+		fmt.Fprintf(f.w, "%s %s  %s\n", mark, pad(c.Name, width), c.Detail)
+		if c.Fix != "" {
+			fmt.Fprintf(f.w, "  %s %s\n", pad("", width), f.color.Wrap(ANSIGray, "fix: "+c.Fix))
+		}
+	}
+
+	return nil
+}
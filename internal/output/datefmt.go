@@ -0,0 +1,103 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dateLayout is the Go layout for dates chosen with SetDateFormat. Empty
+// keeps each view's own default, such as "Jan 2" for dates this year.
+var dateLayout string
+
+// timeLayout is the Go layout for times of day
+var timeLayout = defaultTimeLayout
+
+const defaultTimeLayout = "15:04"
+
+// formatPresets are the named formats accepted besides strftime patterns
+var formatPresets = map[string]string{
+	"iso": "%Y-%m-%d",
+	"us":  "%m/%d/%Y",
+	"eu":  "%d.%m.%Y",
+	"24h": "%H:%M",
+	"12h": "%I:%M %p",
+}
+
+// strftimeVerbs maps strftime conversions to Go layout elements
+var strftimeVerbs = map[byte]string{
+	'Y': "2006", 'y': "06", 'm': "01", 'd': "02", 'e': "_2",
+	'b': "Jan", 'B': "January", 'a': "Mon", 'A': "Monday",
+	'H': "15", 'I': "03", 'l': "3", 'M': "04", 'S': "05", 'p': "PM",
+	'Z': "MST", 'z': "-0700", '%': "%",
+}
+
+// Strftime converts a strftime-like pattern such as "%d/%m/%Y", or one of
+// the presets iso, us, eu, 24h and 12h, to a Go time layout.
+func Strftime(format string) (string, error) {
+	if preset, ok := formatPresets[strings.ToLower(format)]; ok {
+		format = preset
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			b.WriteByte(format[i])
+			continue
+		}
+		if i+1 == len(format) {
+			return "", fmt.Errorf("format %q ends with %%", format)
+		}
+		i++
+		layout, ok := strftimeVerbs[format[i]]
+		if !ok {
+			return "", fmt.Errorf("unsupported conversion %%%c in format %q", format[i], format)
+		}
+		b.WriteString(layout)
+	}
+	return b.String(), nil
+}
+
+// SetDateFormat sets how dates are displayed everywhere, as a strftime-like
+// pattern or preset. Empty restores the defaults.
+func SetDateFormat(format string) error {
+	if format == "" {
+		dateLayout = ""
+		return nil
+	}
+	layout, err := Strftime(format)
+	if err != nil {
+		return err
+	}
+	dateLayout = layout
+	return nil
+}
+
+// SetTimeFormat sets how times of day are displayed, as a strftime-like
+// pattern or preset. Empty restores 24-hour "15:04".
+func SetTimeFormat(format string) error {
+	if format == "" {
+		timeLayout = defaultTimeLayout
+		return nil
+	}
+	layout, err := Strftime(format)
+	if err != nil {
+		return err
+	}
+	timeLayout = layout
+	return nil
+}
+
+// FormatDate formats t's date with the configured date format, or with def
+// when none is set
+func FormatDate(t time.Time, def string) string {
+	if dateLayout != "" {
+		return t.Format(dateLayout)
+	}
+	return t.Format(def)
+}
+
+// FormatClock formats t's time of day with the configured time format
+func FormatClock(t time.Time) string {
+	return t.Format(timeLayout)
+}
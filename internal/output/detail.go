@@ -0,0 +1,288 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+)
+
+// TaskDetail is a task with the context shown by view. The task's own fields
+// are inlined in JSON so the output stays a task object.
+type TaskDetail struct {
+	*api.Task
+	Path     []string       `json:"path,omitempty"`
+	Subtasks []*SubtaskNode `json:"subtasks,omitempty"`
+	Comments []api.Comment  `json:"comments,omitempty"`
+	History  *TaskHistory   `json:"history,omitempty"`
+}
+
+// TaskHistory is when a recurring task was completed
+type TaskHistory struct {
+	Completed []string `json:"completed"`        // timestamps, newest first
+	Streak    *int     `json:"streak,omitempty"` // occurrences in a row done; nil if the recurrence is not understood
+}
+
+// MarshalJSON keeps the context fields, which the embedded task's own
+// MarshalJSON would otherwise drop
+func (d TaskDetail) MarshalJSON() ([]byte, error) {
+	fields := map[string]interface{}{}
+	if len(d.Path) > 0 {
+		fields["path"] = d.Path
+	}
+	if len(d.Subtasks) > 0 {
+		fields["subtasks"] = d.Subtasks
+	}
+	if len(d.Comments) > 0 {
+		fields["comments"] = d.Comments
+	}
+	if d.History != nil {
+		fields["history"] = d.History
+	}
+	return api.MarshalWith(d.Task, fields)
+}
+
+// SubtaskNode is a subtask in a task's subtree
+type SubtaskNode struct {
+	ID        string         `json:"id"`
+	Task      *api.Task      `json:"-"` // nil for completed subtasks
+	Content   string         `json:"content"`
+	Completed bool           `json:"completed"`
+	Children  []*SubtaskNode `json:"children,omitempty"`
+}
+
+// TaskWithComments is a task together with its comments
+type TaskWithComments struct {
+	api.Task
+	Comments []api.Comment `json:"comments"`
+}
+
+// MarshalJSON adds the comments to the embedded task's fields
+func (t TaskWithComments) MarshalJSON() ([]byte, error) {
+	return api.MarshalWith(t.Task, map[string]interface{}{"comments": t.Comments})
+}
+
+// UserInfo is the account behind the active profile
+type UserInfo struct {
+	*api.User
+	Profile string `json:"profile"`
+}
+
+// MarshalJSON adds the profile to the embedded user's fields
+func (u UserInfo) MarshalJSON() ([]byte, error) {
+	return api.MarshalWith(u.User, map[string]interface{}{"profile": u.Profile})
+}
+
+// WriteUser outputs the authenticated account
+func (f *Formatter) WriteUser(u *UserInfo) error {
+	if f.asJSON {
+		return f.JSON(u)
+	}
+
+	fmt.Fprintf(f.w, "Profile:  %s\n", u.Profile)
+	fmt.Fprintf(f.w, "Email:    %s\n", u.Email)
+	if u.FullName != "" {
+		fmt.Fprintf(f.w, "Name:     %s\n", u.FullName)
+	}
+	plan := "free"
+	if u.IsPremium {
+		plan = "pro"
+	}
+	fmt.Fprintf(f.w, "Plan:     %s\n", plan)
+	if u.TZInfo != nil && u.TZInfo.Timezone != "" {
+		fmt.Fprintf(f.w, "Timezone: %s %s\n", u.TZInfo.Timezone, f.color.Wrap(ANSIGray, "(GMT"+u.TZInfo.GMTString+")"))
+	}
+	fmt.Fprintf(f.w, "Goal:     %d tasks/day, %d tasks/week\n", u.DailyGoal, u.WeeklyGoal)
+
+	return nil
+}
+
+// WriteTaskDetail outputs a single task in detail
+func (f *Formatter) WriteTaskDetail(d *TaskDetail) error {
+	if f.asJSON {
+		return f.JSON(d)
+	}
+
+	t := d.Task
+	fmt.Fprintf(f.w, "ID:       %s\n", f.Link(api.TaskURL(t.ID), t.ID))
+	if len(d.Path) > 0 {
+		fmt.Fprintf(f.w, "Path:     %s\n", strings.Join(d.Path, " › "))
+	}
+	fmt.Fprintf(f.w, "Content:  %s\n", t.Content)
+	if t.Description != "" {
+		fmt.Fprintf(f.w, "Notes:    %s\n", f.block(f.Markdown(t.Description), "          ", ""))
+	}
+	if t.Due != nil {
+		fmt.Fprintf(f.w, "Due:      %s\n", f.dueDetail(t))
+	}
+	if t.Priority > 1 {
+		fmt.Fprintf(f.w, "Priority: p%d\n", 5-t.Priority)
+	}
+	if len(t.Labels) > 0 {
+		fmt.Fprintf(f.w, "Labels:   @%s\n", strings.Join(t.Labels, " @"))
+	}
+	if t.Assignee != "" {
+		assigned := f.person(t.Assignee)
+		if t.Assigner != "" && t.Assigner != t.Assignee {
+			assigned += " " + f.color.Wrap(ANSIGray, "(by "+f.person(t.Assigner)+")")
+		}
+		fmt.Fprintf(f.w, "Assigned: %s\n", assigned)
+	}
+
+	if len(d.Subtasks) > 0 {
+		fmt.Fprintf(f.w, "\nSubtasks:\n")
+		f.printSubtaskTree(d.Subtasks, 1)
+	}
+
+	if len(d.Comments) > 0 {
+		fmt.Fprintf(f.w, "\nComments (%d):\n", len(d.Comments))
+		for _, c := range d.Comments {
+			date := commentDate(c)
+			indent := strings.Repeat(" ", len(date)+5)
+			fmt.Fprintf(f.w, "  [%s] %s%s\n", date, f.commentAuthor(c), f.block(f.Markdown(c.Content), indent, ""))
+			if a := attachmentLine(c); a != "" {
+				fmt.Fprintf(f.w, "%s%s\n", indent, f.color.Wrap(ANSIGray, a))
+			}
+			if r := reactionsLine(c); r != "" {
+				fmt.Fprintf(f.w, "%s%s\n", indent, f.color.Wrap(ANSIGray, r))
+			}
+		}
+	}
+
+	if h := d.History; h != nil {
+		heading := fmt.Sprintf("%d completions", len(h.Completed))
+		if len(h.Completed) == 1 {
+			heading = "1 completion"
+		}
+		if h.Streak != nil {
+			heading += fmt.Sprintf(", streak %d", *h.Streak)
+		}
+		fmt.Fprintf(f.w, "\nHistory (%s):\n", heading)
+		for _, c := range h.Completed {
+			if at, err := time.Parse(time.RFC3339, c); err == nil {
+				at = at.In(location)
+				c = FormatDate(at, "Mon 2006-01-02") + " " + FormatClock(at)
+			}
+			fmt.Fprintf(f.w, "  %s\n", c)
+		}
+	}
+
+	return nil
+}
+
+// printSubtaskTree prints subtasks with a completion checkbox, indented by depth
+func (f *Formatter) printSubtaskTree(nodes []*SubtaskNode, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, n := range nodes {
+		if n.Completed || n.Task == nil {
+			fmt.Fprintf(f.w, "%s[x] %s  %s\n", indent, f.color.Wrap(theme.ID, n.ID), f.color.Wrap(ANSIStrike, n.Content))
+		} else {
+			fmt.Fprintf(f.w, "%s[ ] %s\n", indent, f.FormatTaskLine(n.Task))
+		}
+		f.printSubtaskTree(n.Children, depth+1)
+	}
+}
+
+// WriteTasksWithComments outputs tasks with their descriptions and comments
+func (f *Formatter) WriteTasksWithComments(tasks []TaskWithComments) error {
+	if f.asJSON {
+		return f.JSON(tasks)
+	}
+
+	if len(tasks) == 0 {
+		fmt.Fprintln(f.w, i18n.T("No tasks found."))
+		return nil
+	}
+
+	for i := range tasks {
+		t := &tasks[i]
+		fmt.Fprintln(f.w, f.FormatTaskLine(&t.Task))
+		if t.Description != "" {
+			fmt.Fprintf(f.w, "    %s\n", f.block(t.Description, "    ", ANSIGray))
+		}
+
+		if len(t.Comments) > 0 {
+			fmt.Fprintf(f.w, "    Comments (%d):\n", len(t.Comments))
+			for _, c := range t.Comments {
+				fmt.Fprintf(f.w, "      [%s] %s%s\n", commentDate(c), f.commentAuthor(c), c.Content)
+				if a := attachmentLine(c); a != "" {
+					fmt.Fprintf(f.w, "      %s\n", f.color.Wrap(ANSIGray, a))
+				}
+				if r := reactionsLine(c); r != "" {
+					fmt.Fprintf(f.w, "      %s\n", f.color.Wrap(ANSIGray, r))
+				}
+			}
+		}
+
+		if i < len(tasks)-1 {
+			fmt.Fprintln(f.w)
+		}
+	}
+
+	return nil
+}
+
+// block renders multi-line text, coloring each line separately and
+// indenting the lines after the first
+func (f *Formatter) block(text, indent, code string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			line = f.color.Wrap(code, line)
+		}
+		if i > 0 && line != "" {
+			line = indent + line
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// commentAuthor returns "Name: " for who posted a comment, or "" when the
+// names of people were not loaded
+func (f *Formatter) commentAuthor(c api.Comment) string {
+	if c.PostedUID == "" || f.people == nil {
+		return ""
+	}
+	return f.color.Wrap(ANSIBold, f.person(c.PostedUID)+":") + " "
+}
+
+// reactionsLine counts a comment's reactions, e.g. "👍 2  🎉 1", most
+// frequent first; it is empty when there are none
+func reactionsLine(c api.Comment) string {
+	type reaction struct {
+		emoji string
+		count int
+	}
+	var list []reaction
+	for emoji, users := range c.Reactions {
+		if len(users) > 0 {
+			list = append(list, reaction{emoji, len(users)})
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].count != list[j].count {
+			return list[i].count > list[j].count
+		}
+		return list[i].emoji < list[j].emoji
+	})
+	parts := make([]string, len(list))
+	for i, r := range list {
+		parts[i] = fmt.Sprintf("%s %d", r.emoji, r.count)
+	}
+	return strings.Join(parts, "  ")
+}
+
+// commentDate returns the date a comment was posted, in the display timezone
+func commentDate(c api.Comment) string {
+	if at, err := time.Parse(time.RFC3339, c.PostedAt); err == nil {
+		return FormatDate(at.In(location), "2006-01-02")
+	}
+	if len(c.PostedAt) >= 10 {
+		return c.PostedAt[:10]
+	}
+	return c.PostedAt
+}
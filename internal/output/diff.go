@@ -0,0 +1,105 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+)
+
+// FieldChange is a single before/after difference on a task
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// DiffTasks lists the user-visible fields that differ between two versions of
+// a task. names maps project, section and parent task IDs to display names;
+// IDs without an entry are shown as-is.
+func DiffTasks(before, after *api.Task, names map[string]string) []FieldChange {
+	var changes []FieldChange
+	add := func(field, b, a string) {
+		if b != a {
+			changes = append(changes, FieldChange{Field: field, Before: b, After: a})
+		}
+	}
+	name := func(id string) string {
+		if n, ok := names[id]; ok {
+			return n
+		}
+		return id
+	}
+
+	add("content", before.Content, after.Content)
+	add("description", before.Description, after.Description)
+	add("due", dueDisplay(before.Due), dueDisplay(after.Due))
+	add("priority", fmt.Sprintf("p%d", 5-before.Priority), fmt.Sprintf("p%d", 5-after.Priority))
+	add("labels", labelsDisplay(before.Labels), labelsDisplay(after.Labels))
+	add("project", name(before.ProjectID), name(after.ProjectID))
+	add("section", name(before.SectionID), name(after.SectionID))
+	add("parent", name(before.ParentID), name(after.ParentID))
+
+	return changes
+}
+
+// WriteTaskChanges prints a concise before → after summary of a modified
+// task. JSON output has the task as data, like other commands writing one
+// task, and the changes in meta.changes.
+func (f *Formatter) WriteTaskChanges(t *api.Task, changes []FieldChange) error {
+	if f.asJSON {
+		if changes == nil {
+			changes = []FieldChange{}
+		}
+		f.changes = &changes
+		return f.JSON(t)
+	}
+	if f.quiet {
+		fmt.Fprintln(f.w, t.ID)
+		return nil
+	}
+
+	fmt.Fprintf(f.w, "%s  %s\n", f.Link(api.TaskURL(t.ID), f.color.Wrap(theme.ID, t.ID)), t.Content)
+	if len(changes) == 0 {
+		fmt.Fprintln(f.w, "  "+i18n.T("(no changes)"))
+		return nil
+	}
+
+	width := 0
+	for _, c := range changes {
+		if len(c.Field) > width {
+			width = len(c.Field)
+		}
+	}
+	for _, c := range changes {
+		fmt.Fprintf(f.w, "  %-*s  %s → %s\n", width+1, c.Field+":",
+			f.color.Wrap(ANSIGray, emptyDash(c.Before)), emptyDash(c.After))
+	}
+
+	return nil
+}
+
+func dueDisplay(d *api.Due) string {
+	if d == nil {
+		return ""
+	}
+	if d.String != "" {
+		return d.String
+	}
+	return d.Date
+}
+
+func labelsDisplay(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	return "@" + strings.Join(labels, " @")
+}
+
+func emptyDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,138 @@
+package output
+
+import (
+	"strings"
+	"time"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+)
+
+// location is the timezone due dates and timestamps are shown in
+var location = time.Local
+
+// SetLocation sets the timezone times are displayed in, instead of the
+// system's local zone
+func SetLocation(loc *time.Location) {
+	location = loc
+}
+
+// Location returns the timezone times are displayed in
+func Location() *time.Location {
+	return location
+}
+
+// SetNow fixes the time due dates are rendered relative to. The zero time
+// means the current time.
+func (f *Formatter) SetNow(now time.Time) {
+	f.now = now
+}
+
+func (f *Formatter) clock() time.Time {
+	if f.now.IsZero() {
+		return time.Now()
+	}
+	return f.now
+}
+
+// DueTime parses a due date in the display timezone and reports whether it
+// has a time of day. Datetimes fixed to a timezone are converted; floating
+// ones without an offset keep their wall-clock time.
+func DueTime(d *api.Due) (time.Time, bool, error) {
+	s := d.Datetime
+	if s == "" {
+		s = d.Date
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.In(location), true, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02T15:04:05", s, location); err == nil {
+		return t, true, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, location)
+	return t, false, err
+}
+
+// RelativeDue renders a due date relative to now, e.g. "today 17:00",
+// "tomorrow", "in 3 days" or "2 days overdue", and reports whether the task
+// is overdue. Dates that cannot be parsed fall back to the due string.
+func RelativeDue(d *api.Due, now time.Time) (string, bool) {
+	due, timed, err := DueTime(d)
+	if err != nil {
+		if d.String != "" {
+			return d.String, false
+		}
+		return d.Date, false
+	}
+
+	// Count calendar days, so DST changes don't skew the difference
+	dayNumber := func(t time.Time) int64 {
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400
+	}
+	days := int(dayNumber(due) - dayNumber(now.In(location)))
+
+	var text string
+	switch {
+	case days < -1:
+		text = i18n.T("%d days overdue", -days)
+	case days == -1:
+		text = i18n.T("1 day overdue")
+	case days == 0:
+		text = i18n.T("today")
+	case days == 1:
+		text = i18n.T("tomorrow")
+	case days < 7:
+		text = i18n.T("in %d days", days)
+	case due.Year() == now.Year():
+		text = FormatDate(due, "Jan 2")
+	default:
+		text = FormatDate(due, "Jan 2 2006")
+	}
+	if timed && days >= 0 {
+		text += " " + FormatClock(due)
+	}
+	if d.IsRecurring {
+		text += " " + recurringMark()
+	}
+
+	overdue := days < 0 || (timed && due.Before(now))
+	return text, overdue
+}
+
+// dueCell returns a task's relative due text and its style, which differs
+// when the task is overdue
+func (f *Formatter) dueCell(t *api.Task) (string, string) {
+	if t.Due == nil {
+		return "", ""
+	}
+	text, overdue := RelativeDue(t.Due, f.clock())
+	if overdue {
+		return text, theme.Overdue
+	}
+	return text, theme.Due
+}
+
+// dueDetail is the due line of the detailed view: the relative date, then
+// the date and the due string as entered when they add information
+func (f *Formatter) dueDetail(t *api.Task) string {
+	text, code := f.dueCell(t)
+	var extra []string
+	if date := t.Due.Date; date != "" {
+		if d, err := time.Parse("2006-01-02", date[:min(len(date), 10)]); err == nil {
+			date = FormatDate(d, "2006-01-02")
+		}
+		extra = append(extra, date)
+	}
+	if s := t.Due.String; s != "" && s != text && !strings.EqualFold(s, t.Due.Date) {
+		extra = append(extra, s)
+	}
+	if tz := t.Due.Timezone; tz != "" && tz != location.String() {
+		extra = append(extra, "fixed to "+tz)
+	}
+	text = f.color.Wrap(code, text)
+	if len(extra) > 0 {
+		text += " " + f.color.Wrap(ANSIGray, "("+strings.Join(extra, ", ")+")")
+	}
+	return text
+}
@@ -0,0 +1,90 @@
+package output
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ParseFields splits a comma-separated field list such as
+// "id,content,due.date". Nested fields are separated by dots.
+func ParseFields(s string) []string {
+	var fields []string
+	for _, f := range strings.Split(s, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// SetFields limits JSON output to the given fields. Each object, or each
+// element of a list, keeps only those fields; missing ones are null.
+func (f *Formatter) SetFields(fields []string) {
+	f.fields = fields
+}
+
+// selectFields projects v onto the formatter's fields
+func (f *Formatter) selectFields(v interface{}) (interface{}, error) {
+	if len(f.fields) == 0 {
+		return v, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return nil, err
+	}
+
+	if list, ok := generic.([]interface{}); ok {
+		for i, item := range list {
+			list[i] = projectFields(item, f.fields)
+		}
+		return list, nil
+	}
+	return projectFields(generic, f.fields), nil
+}
+
+// projectFields returns an object holding only the given dotted paths of v.
+// Values that are not objects are returned unchanged.
+func projectFields(v interface{}, fields []string) interface{} {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+
+	out := make(map[string]interface{})
+	for _, field := range fields {
+		path := strings.Split(field, ".")
+		setPath(out, path, lookupPath(obj, path))
+	}
+	return out
+}
+
+// lookupPath returns the value at path in obj, or nil when it is missing
+func lookupPath(obj map[string]interface{}, path []string) interface{} {
+	var cur interface{} = obj
+	for _, key := range path {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		cur = m[key]
+	}
+	return cur
+}
+
+// setPath stores value at path in obj, creating nested objects as needed
+func setPath(obj map[string]interface{}, path []string, value interface{}) {
+	for _, key := range path[:len(path)-1] {
+		next, ok := obj[key].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			obj[key] = next
+		}
+		obj = next
+	}
+	obj[path[len(path)-1]] = value
+}
@@ -4,34 +4,80 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
+	"reflect"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
 )
 
+// SchemaVersion is the version of the JSON envelope contract. It is bumped
+// only when existing fields are removed or change meaning.
+const SchemaVersion = 1
+
 // Envelope wraps all JSON responses for consistent parsing
 type Envelope struct {
+	Version int         `json:"version"`
 	Success bool        `json:"success"`
 	Data    interface{} `json:"data,omitempty"`
 	Error   *string     `json:"error,omitempty"`
+	Code    string      `json:"code,omitempty"` // stable error code, see api.ErrorCode
+	Meta    *Meta       `json:"meta,omitempty"`
+}
+
+// Meta describes the request that produced an envelope
+type Meta struct {
+	Count      *int           `json:"count,omitempty"`       // number of items when data is a list
+	NextCursor string         `json:"next_cursor,omitempty"` // cursor for the next page, if any
+	Changes    *[]FieldChange `json:"changes,omitempty"`     // fields a modified task changed
+	ElapsedMS  int64          `json:"elapsed_ms"`
+	Requests   int            `json:"requests"` // API requests made
 }
 
 // Formatter handles output formatting
 type Formatter struct {
-	w      io.Writer
-	asJSON bool
-	color  *Color
+	w        io.Writer
+	asJSON   bool
+	color    *Color
+	links    bool
+	quiet    bool
+	width    int
+	table    []string
+	names    map[string]string
+	places   bool
+	now      time.Time
+	jsonl    bool
+	fields   []string
+	start    time.Time
+	count    func() int
+	cursor   string
+	changes  *[]FieldChange
+	markdown bool
+	order    *api.TaskOrder
+	people   map[string]string
 }
 
 // NewFormatter creates a new output formatter
 func NewFormatter(w io.Writer, asJSON bool) *Formatter {
-	return &Formatter{w: w, asJSON: asJSON, color: NewColor(ColorAuto)}
+	return NewFormatterWithColor(w, asJSON, ColorAuto)
 }
 
 // NewFormatterWithColor creates a formatter with explicit color control
 func NewFormatterWithColor(w io.Writer, asJSON bool, mode ColorMode) *Formatter {
-	return &Formatter{w: w, asJSON: asJSON, color: NewColor(mode)}
+	// NO_COLOR (https://no-color.org) turns off automatic color; an
+	// explicit --color always still wins
+	if mode == ColorAuto && os.Getenv("NO_COLOR") != "" {
+		mode = ColorNever
+	}
+	f := &Formatter{w: w, asJSON: asJSON, color: NewColor(mode), links: hyperlinksSupported(w), width: terminalWidth(w), order: &api.TaskOrder{}}
+	if f.width > 0 {
+		f.table = DefaultTaskColumns
+	}
+	f.markdown = f.width > 0 || mode == ColorAlways
+	return f
 }
 
 // Color returns the formatter's Color for external use.
@@ -39,9 +85,73 @@ func (f *Formatter) Color() *Color {
 	return f.color
 }
 
+// SetQuiet suppresses confirmations in human output. Commands that create or
+// change a single item print just its ID; JSON output is unaffected.
+func (f *Formatter) SetQuiet(quiet bool) {
+	f.quiet = quiet
+}
+
+// Printf writes free-form human output
+func (f *Formatter) Printf(format string, a ...interface{}) {
+	fmt.Fprintf(f.w, format, a...)
+}
+
+// SetJSONLines switches JSON output to JSON Lines: one object per line with
+// no envelope, and one line per element for lists
+func (f *Formatter) SetJSONLines(jsonl bool) {
+	f.jsonl = jsonl
+	if jsonl {
+		f.asJSON = true
+	}
+}
+
 // JSON outputs data wrapped in envelope
 func (f *Formatter) JSON(v interface{}) error {
-	env := Envelope{Success: true, Data: v}
+	v, err := f.selectFields(v)
+	if err != nil {
+		return err
+	}
+	return f.writeJSON(v)
+}
+
+// SetStart records when the command started, for elapsed_ms in the envelope
+func (f *Formatter) SetStart(start time.Time) {
+	f.start = start
+}
+
+// SetRequestCounter sets the function reporting how many API requests the
+// command has made, for requests in the envelope
+func (f *Formatter) SetRequestCounter(count func() int) {
+	f.count = count
+}
+
+// SetNextCursor records the cursor for the page after the data written next
+func (f *Formatter) SetNextCursor(cursor string) {
+	f.cursor = cursor
+}
+
+// meta returns the envelope metadata for data
+func (f *Formatter) meta(data interface{}) *Meta {
+	m := &Meta{NextCursor: f.cursor, Changes: f.changes}
+	if !f.start.IsZero() {
+		m.ElapsedMS = time.Since(f.start).Milliseconds()
+	}
+	if f.count != nil {
+		m.Requests = f.count()
+	}
+	if rv := reflect.ValueOf(data); rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		n := rv.Len()
+		m.Count = &n
+	}
+	return m
+}
+
+// writeJSON outputs v as-is, in an envelope or as JSON Lines
+func (f *Formatter) writeJSON(v interface{}) error {
+	if f.jsonl {
+		return f.jsonLines(v)
+	}
+	env := Envelope{Version: SchemaVersion, Success: true, Data: v, Meta: f.meta(v)}
 	b, err := json.Marshal(env)
 	if err != nil {
 		return err
@@ -52,21 +162,47 @@ func (f *Formatter) JSON(v interface{}) error {
 
 // WriteError outputs an error
 func (f *Formatter) WriteError(err error) {
-	if f.asJSON {
+	if f.jsonl {
+		b, _ := json.Marshal(map[string]string{"error": err.Error(), "code": api.ErrorCode(err)})
+		fmt.Fprintln(f.w, string(b))
+	} else if f.asJSON {
 		msg := err.Error()
-		env := Envelope{Success: false, Error: &msg}
+		env := Envelope{Version: SchemaVersion, Success: false, Error: &msg, Code: api.ErrorCode(err), Meta: f.meta(nil)}
 		b, _ := json.Marshal(env)
 		fmt.Fprintln(f.w, string(b))
 	} else {
-		fmt.Fprintf(f.w, "Error: %v\n", err)
+		fmt.Fprintln(f.w, i18n.T("Error: %v", err))
+	}
+}
+
+// jsonLines writes v as JSON Lines, one line per element when v is a slice
+func (f *Formatter) jsonLines(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return f.jsonLine(v)
+	}
+	for i := 0; i < rv.Len(); i++ {
+		if err := f.jsonLine(rv.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Formatter) jsonLine(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
 	}
+	_, err = fmt.Fprintln(f.w, string(b))
+	return err
 }
 
 // WriteSuccess outputs a success message
 func (f *Formatter) WriteSuccess(msg string) {
 	if f.asJSON {
-		f.JSON(map[string]string{"message": msg})
-	} else {
+		f.writeJSON(map[string]string{"message": msg})
+	} else if !f.quiet {
 		fmt.Fprintln(f.w, msg)
 	}
 }
@@ -85,45 +221,44 @@ func priorityString(p int) string {
 	}
 }
 
-// priorityColorCode returns ANSI color code for priority
-func priorityColorCode(p int) string {
-	switch p {
-	case 4:
-		return ANSIRed
-	case 3:
-		return ANSIYellow
-	case 2:
-		return ANSIBlue
-	default:
-		return ""
-	}
-}
-
 // FormatTask formats a single task for human output
 func (f *Formatter) FormatTask(t *api.Task) string {
 	var parts []string
 
 	// Priority indicator
-	pStr := priorityString(t.Priority)
-	if pStr != "" {
-		parts = append(parts, f.color.Wrap(priorityColorCode(t.Priority), "["+pStr+"]"))
+	if icon := priorityIcon(t.Priority); icon != "" {
+		parts = append(parts, f.color.Wrap(theme.priority(t.Priority), icon))
+	} else if pStr := priorityString(t.Priority); pStr != "" && icons == nil {
+		parts = append(parts, f.color.Wrap(theme.priority(t.Priority), "["+pStr+"]"))
 	}
 
 	// Task content
 	parts = append(parts, t.Content)
+	if mark := commentsMark(t); mark != "" {
+		parts = append(parts, f.color.Wrap(ANSIGray, mark))
+	}
 
 	// Due date
 	if t.Due != nil {
-		dueStr := t.Due.String
-		if dueStr == "" {
-			dueStr = t.Due.Date
-		}
-		parts = append(parts, f.color.Wrap(ANSIGray, "("+dueStr+")"))
+		text, code := f.dueCell(t)
+		parts = append(parts, f.color.Wrap(code, "("+text+")"))
 	}
 
 	// Labels
 	if len(t.Labels) > 0 {
-		parts = append(parts, f.color.Wrap(ANSICyan, "@"+strings.Join(t.Labels, " @")))
+		parts = append(parts, f.color.Wrap(theme.Label, "@"+strings.Join(t.Labels, " @")))
+	}
+
+	// Assignee
+	if t.Assignee != "" {
+		parts = append(parts, f.color.Wrap(theme.Assignee, "→ "+f.person(t.Assignee)))
+	}
+
+	// Project and section
+	if f.places {
+		if place := f.taskPlace(t); place != "" {
+			parts = append(parts, f.color.Wrap(theme.Project, "#"+place))
+		}
 	}
 
 	return strings.Join(parts, " ")
@@ -131,7 +266,7 @@ func (f *Formatter) FormatTask(t *api.Task) string {
 
 // FormatTaskLine formats a task as a single line with ID
 func (f *Formatter) FormatTaskLine(t *api.Task) string {
-	return f.color.Wrap(ANSIGray, t.ID) + "  " + f.FormatTask(t)
+	return f.Link(api.TaskURL(t.ID), f.color.Wrap(theme.ID, t.ID)) + "  " + f.FormatTask(t)
 }
 
 // WriteTasks outputs a list of tasks
@@ -141,7 +276,7 @@ func (f *Formatter) WriteTasks(tasks []api.Task) error {
 	}
 
 	if len(tasks) == 0 {
-		fmt.Fprintln(f.w, "No tasks found.")
+		fmt.Fprintln(f.w, i18n.T("No tasks found."))
 		return nil
 	}
 
@@ -166,33 +301,54 @@ func (f *Formatter) WriteTasks(tasks []api.Task) error {
 		}
 	}
 
-	sortTasks(roots)
+	if f.order != nil {
+		sort.SliceStable(roots, func(i, j int) bool { return f.order.Less(roots[i], roots[j]) })
+	}
 	for _, children := range childrenMap {
-		sortTasks(children)
+		api.SortSiblings(children)
 	}
 
+	var rows []taskRow
 	for _, root := range roots {
-		f.printTaskRecursive(root, 0, childrenMap)
+		rows = flattenTasks(rows, root, 0, childrenMap)
+	}
+
+	if f.table != nil {
+		f.writeTaskTable(rows)
+		return nil
+	}
+	for _, r := range rows {
+		fmt.Fprintf(f.w, "%s%s\n", strings.Repeat("  ", r.level), f.FormatTaskLine(r.task))
 	}
 
 	return nil
 }
 
-func sortTasks(tasks []*api.Task) {
-	sort.Slice(tasks, func(i, j int) bool {
-		return tasks[i].ChildOrder < tasks[j].ChildOrder
-	})
+// TaskOrder returns the order task lists are shown in
+func (f *Formatter) TaskOrder() *api.TaskOrder {
+	return f.order
+}
+
+// SetTaskOrder changes the order task lists are shown in. With nil, lists
+// keep the order they are given in, e.g. after sorting by another field;
+// subtasks still follow their parents.
+func (f *Formatter) SetTaskOrder(o *api.TaskOrder) {
+	f.order = o
 }
 
-func (f *Formatter) printTaskRecursive(t *api.Task, level int, childrenMap map[string][]*api.Task) {
-	indent := strings.Repeat("  ", level)
-	fmt.Fprintf(f.w, "%s%s\n", indent, f.FormatTaskLine(t))
+// taskRow is a task in a list with its nesting depth
+type taskRow struct {
+	task  *api.Task
+	level int
+}
 
-	if children, ok := childrenMap[t.ID]; ok {
-		for _, child := range children {
-			f.printTaskRecursive(child, level+1, childrenMap)
-		}
+// flattenTasks appends t and its descendants to rows in display order
+func flattenTasks(rows []taskRow, t *api.Task, level int, childrenMap map[string][]*api.Task) []taskRow {
+	rows = append(rows, taskRow{task: t, level: level})
+	for _, child := range childrenMap[t.ID] {
+		rows = flattenTasks(rows, child, level+1, childrenMap)
 	}
+	return rows
 }
 
 // WriteTask outputs a single task
@@ -200,10 +356,14 @@ func (f *Formatter) WriteTask(t *api.Task) error {
 	if f.asJSON {
 		return f.JSON(t)
 	}
+	if f.quiet {
+		fmt.Fprintln(f.w, t.ID)
+		return nil
+	}
 
 	fmt.Fprintln(f.w, f.FormatTaskLine(t))
 	if t.Description != "" {
-		fmt.Fprintf(f.w, "    %s\n", f.color.Wrap(ANSIGray, t.Description))
+		fmt.Fprintf(f.w, "    %s\n", f.block(t.Description, "    ", ANSIGray))
 	}
 
 	return nil
@@ -234,12 +394,12 @@ func (f *Formatter) WriteProjects(projects []api.Project) error {
 	}
 
 	if len(projects) == 0 {
-		fmt.Fprintln(f.w, "No projects found.")
+		fmt.Fprintln(f.w, i18n.T("No projects found."))
 		return nil
 	}
 
 	for _, p := range projects {
-		fmt.Fprintf(f.w, "%s  %s\n", f.color.Wrap(ANSIGray, p.ID), f.FormatProject(&p))
+		fmt.Fprintf(f.w, "%s  %s\n", f.color.Wrap(theme.ID, p.ID), f.FormatProject(&p))
 	}
 
 	return nil
@@ -250,8 +410,12 @@ func (f *Formatter) WriteProject(p *api.Project) error {
 	if f.asJSON {
 		return f.JSON(p)
 	}
+	if f.quiet {
+		fmt.Fprintln(f.w, p.ID)
+		return nil
+	}
 
-	fmt.Fprintf(f.w, "%s  %s\n", f.color.Wrap(ANSIGray, p.ID), f.FormatProject(p))
+	fmt.Fprintf(f.w, "%s  %s\n", f.color.Wrap(theme.ID, p.ID), f.FormatProject(p))
 	return nil
 }
 
@@ -262,12 +426,12 @@ func (f *Formatter) WriteLabels(labels []api.Label) error {
 	}
 
 	if len(labels) == 0 {
-		fmt.Fprintln(f.w, "No labels found.")
+		fmt.Fprintln(f.w, i18n.T("No labels found."))
 		return nil
 	}
 
 	for _, l := range labels {
-		fmt.Fprintf(f.w, "%s  %s\n", f.color.Wrap(ANSIGray, l.ID), f.color.Wrap(ANSICyan, "@"+l.Name))
+		fmt.Fprintf(f.w, "%s  %s\n", f.color.Wrap(theme.ID, l.ID), f.color.Wrap(theme.Label, "@"+l.Name))
 	}
 
 	return nil
@@ -280,12 +444,12 @@ func (f *Formatter) WriteSections(sections []api.Section) error {
 	}
 
 	if len(sections) == 0 {
-		fmt.Fprintln(f.w, "No sections found.")
+		fmt.Fprintln(f.w, i18n.T("No sections found."))
 		return nil
 	}
 
 	for _, s := range sections {
-		fmt.Fprintf(f.w, "%s  %s\n", f.color.Wrap(ANSIGray, s.ID), s.Name)
+		fmt.Fprintf(f.w, "%s  %s\n", f.color.Wrap(theme.ID, s.ID), s.Name)
 	}
 
 	return nil
@@ -298,12 +462,19 @@ func (f *Formatter) WriteComments(comments []api.Comment) error {
 	}
 
 	if len(comments) == 0 {
-		fmt.Fprintln(f.w, "No comments found.")
+		fmt.Fprintln(f.w, i18n.T("No comments found."))
 		return nil
 	}
 
 	for _, c := range comments {
-		fmt.Fprintf(f.w, "%s  %s\n", f.color.Wrap(ANSIGray, c.PostedAt), c.Content)
+		indent := strings.Repeat(" ", displayWidth(c.PostedAt)+2)
+		fmt.Fprintf(f.w, "%s  %s%s\n", f.color.Wrap(ANSIGray, c.PostedAt), f.commentAuthor(c), f.block(f.Markdown(c.Content), indent, ""))
+		if a := attachmentLine(c); a != "" {
+			fmt.Fprintf(f.w, "%s%s\n", indent, f.color.Wrap(ANSIGray, a))
+		}
+		if r := reactionsLine(c); r != "" {
+			fmt.Fprintf(f.w, "%s%s\n", indent, f.color.Wrap(ANSIGray, r))
+		}
 	}
 
 	return nil
@@ -316,31 +487,106 @@ func (f *Formatter) WriteCollaborators(collaborators []api.Collaborator) error {
 	}
 
 	if len(collaborators) == 0 {
-		fmt.Fprintln(f.w, "No collaborators found.")
+		fmt.Fprintln(f.w, i18n.T("No collaborators found."))
 		return nil
 	}
 
 	for _, c := range collaborators {
-		fmt.Fprintf(f.w, "%s  %s %s\n", f.color.Wrap(ANSIGray, c.ID), c.Name, f.color.Wrap(ANSIGray, "<"+c.Email+">"))
+		fmt.Fprintf(f.w, "%s  %s %s\n", f.color.Wrap(theme.ID, c.ID), c.Name, f.color.Wrap(ANSIGray, "<"+c.Email+">"))
 	}
 
 	return nil
 }
 
-// WriteCompletedTasks outputs completed tasks
+// WriteCompletedTasks outputs completed tasks grouped by day of completion
+// in local time. Subtasks are nested under their parent when it completed on
+// the same day.
 func (f *Formatter) WriteCompletedTasks(resp *api.CompletedTasksResponse) error {
+	if f.jsonl {
+		return f.JSON(resp.Items)
+	}
 	if f.asJSON {
+		f.cursor = resp.NextCursor
 		return f.JSON(resp)
 	}
 
 	if len(resp.Items) == 0 {
-		fmt.Fprintln(f.w, "No completed tasks found.")
+		fmt.Fprintln(f.w, i18n.T("No completed tasks found."))
 		return nil
 	}
 
-	for _, t := range resp.Items {
-		fmt.Fprintf(f.w, "%s  %s\n", f.color.Wrap(ANSIGray, t.CompletedAt[:10]), f.color.Wrap(ANSIStrike, t.Content))
+	type day struct {
+		label string
+		items []*api.CompletedTask
+	}
+	var days []*day
+	byLabel := make(map[string]*day)
+	for i := range resp.Items {
+		t := &resp.Items[i]
+		label := t.CompletedAt
+		if at, err := time.Parse(time.RFC3339, t.CompletedAt); err == nil {
+			label = FormatDate(at.In(location), "Mon Jan 2 2006")
+		} else if len(label) >= 10 {
+			label = label[:10]
+		}
+		d, ok := byLabel[label]
+		if !ok {
+			d = &day{label: label}
+			byLabel[label] = d
+			days = append(days, d)
+		}
+		d.items = append(d.items, t)
+	}
+
+	for i, d := range days {
+		if i > 0 {
+			fmt.Fprintln(f.w)
+		}
+		fmt.Fprintln(f.w, f.color.Wrap(ANSIBold, d.label))
+
+		inDay := make(map[string]bool)
+		children := make(map[string][]*api.CompletedTask)
+		for _, t := range d.items {
+			inDay[t.TaskKey()] = true
+		}
+		var roots []*api.CompletedTask
+		for _, t := range d.items {
+			if t.ParentID != "" && inDay[t.ParentID] {
+				children[t.ParentID] = append(children[t.ParentID], t)
+			} else {
+				roots = append(roots, t)
+			}
+		}
+		for _, t := range roots {
+			f.printCompleted(t, 0, children)
+		}
+	}
+
+	if resp.NextCursor != "" {
+		fmt.Fprintln(f.w)
+		fmt.Fprintln(f.w, f.color.Wrap(ANSIGray, "More completed tasks available (next cursor: "+resp.NextCursor+")"))
 	}
 
 	return nil
 }
+
+func (f *Formatter) printCompleted(t *api.CompletedTask, level int, children map[string][]*api.CompletedTask) {
+	clock := "     "
+	if at, err := time.Parse(time.RFC3339, t.CompletedAt); err == nil {
+		clock = FormatClock(at.In(location))
+	}
+
+	line := "  " + f.color.Wrap(ANSIGray, clock) + "  " + strings.Repeat("  ", level) + f.color.Wrap(ANSIStrike, t.Content)
+	if project := f.names[t.ProjectID]; project != "" {
+		place := project
+		if section := f.names[t.SectionID]; section != "" {
+			place += "/" + section
+		}
+		line += "  " + f.color.Wrap(theme.Project, "#"+place)
+	}
+	fmt.Fprintln(f.w, line)
+
+	for _, c := range children[t.TaskKey()] {
+		f.printCompleted(c, level+1, children)
+	}
+}
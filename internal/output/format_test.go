@@ -2,8 +2,11 @@ package output
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/buddyh/todoist-cli/internal/api"
 )
@@ -103,6 +106,7 @@ func TestWriteTasks_NoColor(t *testing.T) {
 
 func TestFormatTask_WithDue(t *testing.T) {
 	f := NewFormatterWithColor(nil, false, ColorNever)
+	f.SetNow(time.Date(2024, 1, 14, 9, 0, 0, 0, time.Local))
 	task := &api.Task{
 		Content: "Buy milk",
 		Due:     &api.Due{String: "tomorrow", Date: "2024-01-15"},
@@ -129,3 +133,459 @@ func TestFormatTask_WithLabels(t *testing.T) {
 		t.Errorf("Should contain labels, got: %q", got)
 	}
 }
+
+func TestFormatTaskLine_Hyperlinks(t *testing.T) {
+	f := NewFormatterWithColor(nil, false, ColorNever)
+	task := &api.Task{ID: "42", Content: "Linked"}
+
+	if got := f.FormatTaskLine(task); strings.Contains(got, "\033]8;;") {
+		t.Errorf("Hyperlinks should be off for non-terminal output, got: %q", got)
+	}
+
+	f.SetHyperlinks(HyperlinkAlways)
+	got := f.FormatTaskLine(task)
+	if !strings.Contains(got, "\033]8;;https://app.todoist.com/app/task/42\033\\42\033]8;;\033\\") {
+		t.Errorf("Expected OSC 8 link around ID, got: %q", got)
+	}
+}
+
+func TestDiffTasks(t *testing.T) {
+	before := &api.Task{ID: "1", Content: "Write report", Priority: 1, ProjectID: "p1", Labels: []string{"work"}}
+	after := &api.Task{ID: "1", Content: "Write report", Priority: 4, ProjectID: "p2",
+		Labels: []string{"work", "urgent"}, Due: &api.Due{String: "tomorrow"}}
+
+	changes := DiffTasks(before, after, map[string]string{"p1": "Inbox", "p2": "Work"})
+
+	want := map[string]FieldChange{
+		"due":      {Field: "due", Before: "", After: "tomorrow"},
+		"priority": {Field: "priority", Before: "p4", After: "p1"},
+		"labels":   {Field: "labels", Before: "@work", After: "@work @urgent"},
+		"project":  {Field: "project", Before: "Inbox", After: "Work"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %+v", len(want), changes)
+	}
+	for _, c := range changes {
+		if want[c.Field] != c {
+			t.Errorf("unexpected change for %s: %+v", c.Field, c)
+		}
+	}
+}
+
+func TestWriteTaskChanges_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	f := NewFormatter(&buf, true)
+	task := &api.Task{ID: "1", Content: "Write report", Priority: 4}
+	if err := f.WriteTaskChanges(task, []FieldChange{{Field: "priority", Before: "p4", After: "p1"}}); err != nil {
+		t.Fatalf("WriteTaskChanges failed: %v", err)
+	}
+
+	var env struct {
+		Data api.Task `json:"data"`
+		Meta struct {
+			Changes []FieldChange `json:"changes"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatal(err)
+	}
+	if env.Data.ID != "1" || env.Data.Content != "Write report" {
+		t.Errorf("expected the task as data, got %s", buf.String())
+	}
+	if len(env.Meta.Changes) != 1 || env.Meta.Changes[0].After != "p1" {
+		t.Errorf("expected the changes in meta, got %s", buf.String())
+	}
+}
+
+func TestWriteTask_Quiet(t *testing.T) {
+	var buf bytes.Buffer
+	f := NewFormatterWithColor(&buf, false, ColorAlways)
+	f.SetQuiet(true)
+
+	f.WriteSuccess("Task completed")
+	if err := f.WriteTask(&api.Task{ID: "42", Content: "Buy milk", Priority: 4}); err != nil {
+		t.Fatalf("WriteTask failed: %v", err)
+	}
+
+	if got := buf.String(); got != "42\n" {
+		t.Errorf("quiet output = %q, want %q", got, "42\n")
+	}
+}
+
+func TestWriteTaskDetail_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	f := NewFormatter(&buf, true)
+
+	detail := &TaskDetail{
+		Task:     &api.Task{ID: "1", Content: "Parent"},
+		Path:     []string{"Work"},
+		Subtasks: []*SubtaskNode{{ID: "2", Content: "Child", Completed: true}},
+	}
+	if err := f.WriteTaskDetail(detail); err != nil {
+		t.Fatalf("WriteTaskDetail failed: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{`"id":"1"`, `"content":"Parent"`, `"path":["Work"]`, `"completed":true`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %s in output: %s", want, output)
+		}
+	}
+	if strings.Contains(output, `"comments"`) {
+		t.Errorf("empty comments should be omitted: %s", output)
+	}
+}
+
+func TestWriteTasks_Table(t *testing.T) {
+	tasks := []api.Task{
+		{ID: "1", Content: "A task with a very long description that will not fit", Priority: 4, ChildOrder: 1,
+			Due: &api.Due{Date: "2024-01-15", String: "tomorrow"}, Labels: []string{"work"}, ProjectID: "p1"},
+		{ID: "22", Content: "Short", ChildOrder: 2, ProjectID: "p2"},
+	}
+
+	var buf bytes.Buffer
+	f := NewFormatterWithColor(&buf, false, ColorNever)
+	f.SetTable(DefaultTaskColumns)
+	f.SetNames(map[string]string{"p1": "Work", "p2": "Home"})
+	f.SetWidth(60)
+	f.SetNow(time.Date(2024, 1, 14, 9, 0, 0, 0, time.Local))
+
+	if err := f.WriteTasks(tasks); err != nil {
+		t.Fatalf("WriteTasks failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and 2 rows, got %d:\n%s", len(lines), buf.String())
+	}
+	if !strings.HasPrefix(lines[0], "ID  PRI  DUE") {
+		t.Errorf("unexpected header: %q", lines[0])
+	}
+	for _, line := range lines {
+		if w := displayWidth(line); w > 60 {
+			t.Errorf("line is %d columns wide, want <= 60: %q", w, line)
+		}
+	}
+	if !strings.Contains(lines[1], "…") || !strings.HasSuffix(lines[1], "Work") {
+		t.Errorf("expected truncated content and project name: %q", lines[1])
+	}
+	if strings.Index(lines[1], "A task") != strings.Index(lines[2], "Short") {
+		t.Errorf("content columns are not aligned:\n%s", buf.String())
+	}
+}
+
+func TestParseColumns(t *testing.T) {
+	columns, err := ParseColumns("id, pri,Content")
+	if err != nil {
+		t.Fatalf("ParseColumns failed: %v", err)
+	}
+	if strings.Join(columns, ",") != "id,priority,content" {
+		t.Errorf("got %v", columns)
+	}
+	if _, err := ParseColumns("id,bogus"); err == nil {
+		t.Error("expected error for unknown column")
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		in    string
+		width int
+		want  string
+	}{
+		{"hello", 10, "hello"},
+		{"hello world", 6, "hello…"},
+		{"日本語テキスト", 7, "日本語…"},
+		{"abc", 0, ""},
+	}
+	for _, tt := range tests {
+		if got := truncate(tt.in, tt.width); got != tt.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.in, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestRelativeDue(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		due     api.Due
+		want    string
+		overdue bool
+	}{
+		{api.Due{Date: "2024-01-15"}, "today", false},
+		{api.Due{Date: "2024-01-15T17:00:00"}, "today 17:00", false},
+		{api.Due{Date: "2024-01-15T09:30:00"}, "today 09:30", true},
+		{api.Due{Date: "2024-01-16"}, "tomorrow", false},
+		{api.Due{Date: "2024-01-18"}, "in 3 days", false},
+		{api.Due{Date: "2024-01-14"}, "1 day overdue", true},
+		{api.Due{Date: "2024-01-13"}, "2 days overdue", true},
+		{api.Due{Date: "2024-03-01"}, "Mar 1", false},
+		{api.Due{Date: "2025-03-01"}, "Mar 1 2025", false},
+		{api.Due{Date: "2024-01-16", IsRecurring: true}, "tomorrow ↻", false},
+		{api.Due{String: "someday"}, "someday", false},
+	}
+	for _, tt := range tests {
+		got, overdue := RelativeDue(&tt.due, now)
+		if got != tt.want || overdue != tt.overdue {
+			t.Errorf("RelativeDue(%+v) = %q, %v; want %q, %v", tt.due, got, overdue, tt.want, tt.overdue)
+		}
+	}
+}
+
+func TestRelativeDue_Location(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*3600)
+	SetLocation(tokyo)
+	defer SetLocation(time.Local)
+
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, tokyo)
+	fixed := api.Due{Date: "2024-01-15", Datetime: "2024-01-15T05:00:00Z"}
+	if got, _ := RelativeDue(&fixed, now); got != "today 14:00" {
+		t.Errorf("fixed-zone due: got %q, want today 14:00", got)
+	}
+	floating := api.Due{Date: "2024-01-15", Datetime: "2024-01-15T17:00:00"}
+	if got, _ := RelativeDue(&floating, now); got != "today 17:00" {
+		t.Errorf("floating due: got %q, want today 17:00", got)
+	}
+}
+
+func TestStrftime(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%Y-%m-%d", "2006-01-02"},
+		{"%d/%m/%y", "02/01/06"},
+		{"%a %e %B", "Mon _2 January"},
+		{"%I:%M %p", "03:04 PM"},
+		{"100%%", "100%"},
+		{"iso", "2006-01-02"},
+		{"12h", "03:04 PM"},
+	}
+	for _, tt := range tests {
+		got, err := Strftime(tt.format)
+		if err != nil || got != tt.want {
+			t.Errorf("Strftime(%q) = %q, %v; want %q", tt.format, got, err, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"%Q", "%Y-%"} {
+		if _, err := Strftime(bad); err == nil {
+			t.Errorf("Strftime(%q): expected an error", bad)
+		}
+	}
+}
+
+func TestRelativeDue_Formats(t *testing.T) {
+	if err := SetDateFormat("iso"); err != nil {
+		t.Fatal(err)
+	}
+	defer SetDateFormat("")
+	if err := SetTimeFormat("12h"); err != nil {
+		t.Fatal(err)
+	}
+	defer SetTimeFormat("")
+
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.Local)
+	later := api.Due{Date: "2024-03-11", Datetime: "2024-03-11T17:00:00"}
+	if got, _ := RelativeDue(&later, now); got != "2024-03-11 05:00 PM" {
+		t.Errorf("got %q, want 2024-03-11 05:00 PM", got)
+	}
+	today := api.Due{Date: "2024-01-15", Datetime: "2024-01-15T09:30:00"}
+	if got, _ := RelativeDue(&today, now); got != "today 09:30 AM" {
+		t.Errorf("got %q, want today 09:30 AM", got)
+	}
+}
+
+func TestFormatTask_Places(t *testing.T) {
+	f := NewFormatterWithColor(nil, false, ColorNever)
+	f.ShowPlaces(true)
+	f.SetNames(map[string]string{"p1": "Work", "s1": "Planning"})
+
+	got := f.FormatTask(&api.Task{Content: "Plan Q3", ProjectID: "p1", SectionID: "s1"})
+	if !strings.HasSuffix(got, "#Work/Planning") {
+		t.Errorf("expected project/section suffix, got: %q", got)
+	}
+
+	got = f.FormatTask(&api.Task{Content: "Unknown", ProjectID: "p9"})
+	if strings.Contains(got, "#") {
+		t.Errorf("unknown project should have no suffix, got: %q", got)
+	}
+}
+
+func TestWriteTasks_JSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	f := NewFormatter(&buf, false)
+	f.SetJSONLines(true)
+
+	tasks := []api.Task{{ID: "1", Content: "One"}, {ID: "2", Content: "Two"}}
+	if err := f.WriteTasks(tasks); err != nil {
+		t.Fatalf("WriteTasks failed: %v", err)
+	}
+	f.WriteSuccess("done")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d:\n%s", len(lines), buf.String())
+	}
+	if !strings.HasPrefix(lines[0], `{"id":"1"`) || !strings.HasPrefix(lines[1], `{"id":"2"`) {
+		t.Errorf("expected one bare task object per line:\n%s", buf.String())
+	}
+	if lines[2] != `{"message":"done"}` {
+		t.Errorf("unexpected message line: %s", lines[2])
+	}
+	if strings.Contains(buf.String(), `"success"`) {
+		t.Errorf("JSON Lines should not be wrapped in an envelope:\n%s", buf.String())
+	}
+}
+
+func TestJSON_Fields(t *testing.T) {
+	var buf bytes.Buffer
+	f := NewFormatter(&buf, true)
+	f.SetFields(ParseFields("id, due.date,priority"))
+
+	tasks := []api.Task{
+		{ID: "1", Content: "One", Priority: 4, Due: &api.Due{Date: "2024-01-15", String: "tomorrow"}},
+		{ID: "2", Content: "Two", Priority: 1},
+	}
+	if err := f.WriteTasks(tasks); err != nil {
+		t.Fatalf("WriteTasks failed: %v", err)
+	}
+
+	want := `"data":[{"due":{"date":"2024-01-15"},"id":"1","priority":4},{"due":{"date":null},"id":"2","priority":1}]`
+	if got := buf.String(); !strings.Contains(got, want) {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestJSON_EnvelopeMeta(t *testing.T) {
+	var buf bytes.Buffer
+	f := NewFormatter(&buf, true)
+	f.SetRequestCounter(func() int { return 3 })
+	f.SetNextCursor("abc")
+
+	if err := f.WriteTasks([]api.Task{{ID: "1"}, {ID: "2"}}); err != nil {
+		t.Fatalf("WriteTasks failed: %v", err)
+	}
+
+	var env struct {
+		Version int
+		Success bool
+		Meta    Meta
+	}
+	if err := json.Unmarshal(buf.Bytes(), &env); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if env.Version != SchemaVersion || !env.Success {
+		t.Errorf("unexpected envelope: %s", buf.String())
+	}
+	if env.Meta.Count == nil || *env.Meta.Count != 2 || env.Meta.Requests != 3 || env.Meta.NextCursor != "abc" {
+		t.Errorf("unexpected meta: %s", buf.String())
+	}
+}
+
+func TestWriteError_Code(t *testing.T) {
+	var buf bytes.Buffer
+	f := NewFormatter(&buf, true)
+	f.WriteError(fmt.Errorf("authentication failed: %w", &api.APIError{StatusCode: 401, Body: "Unauthorized"}))
+
+	if got := buf.String(); !strings.Contains(got, `"success":false`) || !strings.Contains(got, `"code":"auth_failed"`) {
+		t.Errorf("unexpected error envelope: %s", got)
+	}
+}
+
+func TestWriteCompletedTasks_GroupsByDay(t *testing.T) {
+	day1 := time.Date(2024, 1, 15, 9, 0, 0, 0, time.Local).UTC().Format(time.RFC3339)
+	day2 := time.Date(2024, 1, 14, 18, 30, 0, 0, time.Local).UTC().Format(time.RFC3339)
+	resp := &api.CompletedTasksResponse{Items: []api.CompletedTask{
+		{TaskID: "2", Content: "Child", ParentID: "1", ProjectID: "p1", CompletedAt: day1},
+		{TaskID: "1", Content: "Parent", ProjectID: "p1", CompletedAt: day1},
+		{TaskID: "3", Content: "Yesterday", ProjectID: "p1", CompletedAt: day2},
+	}}
+
+	var buf bytes.Buffer
+	f := NewFormatterWithColor(&buf, false, ColorNever)
+	f.SetNames(map[string]string{"p1": "Work"})
+	if err := f.WriteCompletedTasks(resp); err != nil {
+		t.Fatalf("WriteCompletedTasks failed: %v", err)
+	}
+
+	want := "Mon Jan 15 2024\n" +
+		"  09:00  Parent  #Work\n" +
+		"  09:00    Child  #Work\n" +
+		"\n" +
+		"Sun Jan 14 2024\n" +
+		"  18:30  Yesterday  #Work\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteTaskDetail_MultilineNotes(t *testing.T) {
+	var buf bytes.Buffer
+	f := NewFormatterWithColor(&buf, false, ColorNever)
+
+	detail := &TaskDetail{Task: &api.Task{ID: "1", Content: "Plan", Description: "- one\n\n- two"}}
+	if err := f.WriteTaskDetail(detail); err != nil {
+		t.Fatalf("WriteTaskDetail failed: %v", err)
+	}
+
+	want := "Notes:    - one\n\n          - two\n"
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("expected aligned continuation lines, got:\n%s", buf.String())
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	f := NewFormatterWithColor(&bytes.Buffer{}, false, ColorNever)
+	f.SetMarkdown(true)
+
+	tests := []struct {
+		in, want string
+	}{
+		{"plain text", "plain text"},
+		{"**bold** and *italic* and _under_", "bold and italic and under"},
+		{"run `go **test**`", "run go **test**"},
+		{"see [docs](https://example.com)", "see docs (https://example.com)"},
+		{"# Heading", "Heading"},
+		{"- one\n* two", "• one\n• two"},
+		{"> quoted", "│ quoted"},
+		{"```\n*raw*\n```", "  *raw*"},
+		{"snake_case_name and 2*3*4", "snake_case_name and 2*3*4"},
+		{"~~gone~~", "gone"},
+	}
+	for _, tt := range tests {
+		if got := f.Markdown(tt.in); got != tt.want {
+			t.Errorf("Markdown(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	f.SetMarkdown(false)
+	if got := f.Markdown("**raw**"); got != "**raw**" {
+		t.Errorf("disabled Markdown changed text: %q", got)
+	}
+}
+
+func TestWriteUser(t *testing.T) {
+	var buf bytes.Buffer
+	f := NewFormatterWithColor(&buf, false, ColorNever)
+
+	u := &UserInfo{
+		User: &api.User{
+			Email:     "me@example.com",
+			FullName:  "Me",
+			IsPremium: true,
+			TZInfo:    &api.TZInfo{Timezone: "Europe/Rome", GMTString: "+01:00"},
+			DailyGoal: 5,
+		},
+		Profile: "work",
+	}
+	if err := f.WriteUser(u); err != nil {
+		t.Fatalf("WriteUser failed: %v", err)
+	}
+
+	for _, want := range []string{"Profile:  work\n", "Email:    me@example.com\n", "Plan:     pro\n", "Timezone: Europe/Rome (GMT+01:00)\n"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("missing %q in:\n%s", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// HyperlinkMode controls OSC 8 terminal hyperlink output
+type HyperlinkMode int
+
+const (
+	HyperlinkAuto HyperlinkMode = iota
+	HyperlinkAlways
+	HyperlinkNever
+)
+
+// ParseHyperlinkMode converts "auto", "always" or "never" to a HyperlinkMode.
+// An empty string means auto.
+func ParseHyperlinkMode(s string) (HyperlinkMode, error) {
+	switch strings.ToLower(s) {
+	case "", "auto":
+		return HyperlinkAuto, nil
+	case "always":
+		return HyperlinkAlways, nil
+	case "never":
+		return HyperlinkNever, nil
+	default:
+		return HyperlinkAuto, fmt.Errorf("invalid hyperlink mode %q (use auto, always, or never)", s)
+	}
+}
+
+// SetHyperlinks enables or disables OSC 8 hyperlinks for this formatter
+func (f *Formatter) SetHyperlinks(mode HyperlinkMode) {
+	switch mode {
+	case HyperlinkAlways:
+		f.links = true
+	case HyperlinkNever:
+		f.links = false
+	default:
+		f.links = hyperlinksSupported(f.w)
+	}
+}
+
+// Link wraps text in an OSC 8 hyperlink to url when hyperlinks are enabled
+func (f *Formatter) Link(url, text string) string {
+	if !f.links || url == "" {
+		return text
+	}
+	return "\033]8;;" + url + "\033\\" + text + "\033]8;;\033\\"
+}
+
+// hyperlinksSupported reports whether w is a terminal known to render OSC 8 links.
+func hyperlinksSupported(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "ghostty", "Hyper":
+		return true
+	}
+	if os.Getenv("WT_SESSION") != "" || os.Getenv("KITTY_WINDOW_ID") != "" {
+		return true
+	}
+	// GNOME Terminal and other VTE terminals gained OSC 8 in 0.50
+	if v, err := strconv.Atoi(os.Getenv("VTE_VERSION")); err == nil && v >= 5000 {
+		return true
+	}
+	return false
+}
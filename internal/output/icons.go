@@ -0,0 +1,127 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+)
+
+// IconSet holds the symbols that mark tasks when icons are turned on
+type IconSet struct {
+	Priority   [3]string // p1 to p3; p4 has none
+	Recurring  string
+	Comments   string
+	Attachment string
+}
+
+// iconSets are the choices for the icons config option. "off" keeps the
+// plain output: [p1] markers and ↻ for recurring dates.
+var iconSets = map[string]*IconSet{
+	"off": nil,
+	"ascii": {
+		Priority:   [3]string{"!!!", "!!", "!"},
+		Recurring:  "(r)",
+		Comments:   "[c]",
+		Attachment: "[file]",
+	},
+	"emoji": {
+		Priority:   [3]string{"🔴", "🟠", "🔵"},
+		Recurring:  "🔁",
+		Comments:   "💬",
+		Attachment: "📎",
+	},
+	"nerd": {
+		Priority:   [3]string{"\uf024", "\uf024", "\uf024"}, // nf-fa-flag, colored by the theme
+		Recurring:  "\uf021",                                // nf-fa-refresh
+		Comments:   "\uf075",                                // nf-fa-comment
+		Attachment: "\uf0c6",                                // nf-fa-paperclip
+	},
+}
+
+// icons is the icon set in use; nil when icons are off
+var icons *IconSet
+
+// IconNames lists the values the icons option accepts
+func IconNames() []string {
+	names := make([]string, 0, len(iconSets))
+	for name := range iconSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetIcons chooses the icon set by name; "" turns icons off. Without a
+// UTF-8 locale the emoji and Nerd Font sets fall back to ASCII.
+func SetIcons(name string) error {
+	if name == "" {
+		name = "off"
+	}
+	set, ok := iconSets[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("unknown icons %q (use %s)", name, strings.Join(IconNames(), ", "))
+	}
+	if set != nil && !UnicodeLocale() {
+		set = iconSets["ascii"]
+	}
+	icons = set
+	return nil
+}
+
+// UnicodeLocale reports whether the locale, from LC_ALL, LC_CTYPE or LANG
+// in that order, uses UTF-8
+func UnicodeLocale() bool {
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := os.Getenv(name); v != "" {
+			v = strings.ToLower(v)
+			return strings.Contains(v, "utf-8") || strings.Contains(v, "utf8")
+		}
+	}
+	return false
+}
+
+// recurringMark is appended to the due date of recurring tasks
+func recurringMark() string {
+	if icons != nil {
+		return icons.Recurring
+	}
+	return "↻"
+}
+
+// priorityIcon returns the icon for an API priority (4 is p1), or "" when
+// icons are off or the task has the default priority
+func priorityIcon(p int) string {
+	if icons == nil || p < 2 || p > 4 {
+		return ""
+	}
+	return icons.Priority[4-p]
+}
+
+// commentsMark returns the comment count indicator, e.g. "💬 2", or ""
+func commentsMark(t *api.Task) string {
+	if icons == nil || t.NoteCount == 0 {
+		return ""
+	}
+	return icons.Comments + " " + strconv.Itoa(t.NoteCount)
+}
+
+// attachmentLine names the file attached to a comment, e.g.
+// "📎 receipt.pdf", or returns "" when there is none
+func attachmentLine(c api.Comment) string {
+	a := c.Attachment
+	if a == nil {
+		return ""
+	}
+	name := a.FileName
+	if name == "" {
+		name = a.FileURL
+	}
+	if icons != nil {
+		return icons.Attachment + " " + name
+	}
+	return "Attachment: " + name
+}
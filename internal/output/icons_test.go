@@ -0,0 +1,59 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+)
+
+func TestIcons(t *testing.T) {
+	t.Setenv("LC_ALL", "en_US.UTF-8")
+	defer SetIcons("")
+	if err := SetIcons("emoji"); err != nil {
+		t.Fatal(err)
+	}
+
+	task := api.Task{ID: "1", Content: "Pay rent", Priority: 4, NoteCount: 2,
+		Due: &api.Due{Date: "2024-03-01", IsRecurring: true, String: "every month"}}
+	f := NewFormatterWithColor(nil, false, ColorNever)
+	got := f.FormatTask(&task)
+	if !strings.HasPrefix(got, "🔴 Pay rent 💬 2 (") || !strings.Contains(got, "🔁") || strings.Contains(got, "[p1]") {
+		t.Errorf("unexpected task line %q", got)
+	}
+
+	var buf bytes.Buffer
+	f = NewFormatterWithColor(&buf, false, ColorNever)
+	f.WriteComments([]api.Comment{
+		{Content: "Receipt", PostedAt: "2024-03-01", Attachment: &api.Attachment{FileName: "r.pdf"}},
+		{Content: "Paid", PostedAt: "2024-03-02"},
+	})
+	if !strings.Contains(buf.String(), "Receipt\n            📎 r.pdf\n") || strings.Count(buf.String(), "📎") != 1 {
+		t.Errorf("expected only the attachment marked, got:\n%s", buf.String())
+	}
+}
+
+func TestIcons_ASCIIFallback(t *testing.T) {
+	t.Setenv("LC_ALL", "C")
+	defer SetIcons("")
+	if err := SetIcons("nerd"); err != nil {
+		t.Fatal(err)
+	}
+	task := api.Task{Content: "Call", Priority: 3}
+	if got := NewFormatterWithColor(nil, false, ColorNever).FormatTask(&task); got != "!! Call" {
+		t.Errorf("expected ASCII icons, got %q", got)
+	}
+
+	if err := SetIcons("sparkles"); err == nil {
+		t.Error("expected unknown icons to fail")
+	}
+}
+
+func TestIcons_Off(t *testing.T) {
+	SetIcons("off")
+	task := api.Task{Content: "Call", Priority: 3, NoteCount: 1}
+	if got := NewFormatterWithColor(nil, false, ColorNever).FormatTask(&task); got != "[p2] Call" {
+		t.Errorf("expected the plain line, got %q", got)
+	}
+}
@@ -0,0 +1,170 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Log levels of diagnostic lines
+const (
+	LevelDebug = "debug"
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
+)
+
+// ansiEscape matches the color codes of a terminal line
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// LogOptions chooses how a LogWriter formats diagnostics
+type LogOptions struct {
+	JSON       bool // one JSON object per line, with time, level and msg
+	Timestamps bool // prefix text lines with the time
+	Quiet      bool // drop info lines, keeping warnings, errors and debug traces
+}
+
+// ParseLogFormat checks a --log-format value, returning whether it asks for JSON
+func ParseLogFormat(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "", "text":
+		return false, nil
+	case "json":
+		return true, nil
+	}
+	return false, fmt.Errorf("invalid log format %q (use text or json)", s)
+}
+
+// LogWriter formats the diagnostic lines written to it, such as
+// "Warning: ..." notes and errors, before passing them on. Incomplete
+// lines are held until their newline or Flush. It is safe for concurrent
+// use.
+type LogWriter struct {
+	w    io.Writer
+	opts LogOptions
+	now  func() time.Time
+
+	mu  sync.Mutex
+	buf []byte
+}
+
+// NewLogWriter returns a LogWriter passing lines on to w
+func NewLogWriter(w io.Writer, opts LogOptions) *LogWriter {
+	return &LogWriter{w: w, opts: opts, now: time.Now}
+}
+
+// Options returns how the writer formats lines
+func (l *LogWriter) Options() LogOptions {
+	return l.opts
+}
+
+// Write formats each complete line of p, taking its level from its prefix
+func (l *LogWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.buf = append(l.buf, p...)
+	for {
+		i := bytes.IndexByte(l.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		line := string(l.buf[:i])
+		l.buf = l.buf[i+1:]
+		if err := l.emit(line, ""); err != nil {
+			return len(p), err
+		}
+	}
+}
+
+// Flush writes out a last line left without its newline
+func (l *LogWriter) Flush() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.buf) == 0 {
+		return nil
+	}
+	line := string(l.buf)
+	l.buf = nil
+	return l.emit(line, "")
+}
+
+// Level returns a writer whose lines all have the given level, for
+// messages such as translated errors that carry no recognizable prefix
+func (l *LogWriter) Level(level string) io.Writer {
+	return levelWriter{l, level}
+}
+
+type levelWriter struct {
+	l     *LogWriter
+	level string
+}
+
+func (w levelWriter) Write(p []byte) (int, error) {
+	w.l.mu.Lock()
+	defer w.l.mu.Unlock()
+	for _, line := range strings.Split(strings.TrimSuffix(string(p), "\n"), "\n") {
+		if err := w.l.emit(line, w.level); err != nil {
+			return len(p), err
+		}
+	}
+	return len(p), nil
+}
+
+// lineLevel classifies a diagnostic line by its prefix and returns the
+// message without it
+func lineLevel(line string) (string, string) {
+	for prefix, level := range map[string]string{
+		"Error: ":   LevelError,
+		"Warning: ": LevelWarn,
+		"[DEBUG] ":  LevelDebug,
+	} {
+		if msg, ok := strings.CutPrefix(line, prefix); ok {
+			return level, msg
+		}
+	}
+	return LevelInfo, line
+}
+
+// emit writes one line at level, or at the level of its prefix when level
+// is empty
+func (l *LogWriter) emit(line, level string) error {
+	prefixed, msg := lineLevel(line)
+	if level == "" {
+		level = prefixed
+	}
+	if l.opts.Quiet && level == LevelInfo {
+		return nil
+	}
+
+	if !l.opts.JSON {
+		if l.opts.Timestamps && line != "" {
+			line = l.now().Format(time.RFC3339) + " " + line
+		}
+		_, err := fmt.Fprintln(l.w, line)
+		return err
+	}
+
+	if strings.TrimSpace(line) == "" {
+		return nil
+	}
+	if strings.HasPrefix(line, "{") && json.Valid([]byte(line)) {
+		// Already structured, like the error envelope of --json
+		_, err := fmt.Fprintln(l.w, line)
+		return err
+	}
+	b, err := json.Marshal(struct {
+		Time  string `json:"time"`
+		Level string `json:"level"`
+		Msg   string `json:"msg"`
+	}{l.now().Format(time.RFC3339), level, ansiEscape.ReplaceAllString(msg, "")})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(l.w, string(b))
+	return err
+}
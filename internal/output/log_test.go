@@ -0,0 +1,56 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestLogWriter(t *testing.T) {
+	at := time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC)
+	newWriter := func(opts LogOptions) (*LogWriter, *bytes.Buffer) {
+		var buf bytes.Buffer
+		l := NewLogWriter(&buf, opts)
+		l.now = func() time.Time { return at }
+		return l, &buf
+	}
+
+	l, buf := newWriter(LogOptions{})
+	fmt.Fprint(l, "Warning: half")
+	if buf.Len() != 0 {
+		t.Errorf("expected an incomplete line held back, got %q", buf)
+	}
+	fmt.Fprint(l, " a line\nShowing 1-5 of 9 tasks\nrest")
+	l.Flush()
+	if want := "Warning: half a line\nShowing 1-5 of 9 tasks\nrest\n"; buf.String() != want {
+		t.Errorf("plain: got %q, want %q", buf, want)
+	}
+
+	l, buf = newWriter(LogOptions{Timestamps: true, Quiet: true})
+	fmt.Fprintln(l, "Snoozed: Laundry")
+	fmt.Fprintln(l, "Warning: could not remember this run")
+	fmt.Fprintln(l.Level(LevelError), "Fehler: kaputt")
+	if want := "2024-03-18T09:00:00Z Warning: could not remember this run\n2024-03-18T09:00:00Z Fehler: kaputt\n"; buf.String() != want {
+		t.Errorf("quiet with timestamps: got %q, want %q", buf, want)
+	}
+
+	l, buf = newWriter(LogOptions{JSON: true})
+	fmt.Fprintln(l, "Snoozed: \x1b[1mLaundry\x1b[0m")
+	fmt.Fprintln(l, "")
+	fmt.Fprintln(l, "[DEBUG] GET https://api.todoist.com/api/v1/tasks")
+	fmt.Fprintln(l.Level(LevelError), "Error: task not found")
+	fmt.Fprintln(l.Level(LevelError), `{"success":false,"error":"task not found"}`)
+	want := `{"time":"2024-03-18T09:00:00Z","level":"info","msg":"Snoozed: Laundry"}
+{"time":"2024-03-18T09:00:00Z","level":"debug","msg":"GET https://api.todoist.com/api/v1/tasks"}
+{"time":"2024-03-18T09:00:00Z","level":"error","msg":"task not found"}
+{"success":false,"error":"task not found"}
+`
+	if buf.String() != want {
+		t.Errorf("json: got\n%s\nwant\n%s", buf, want)
+	}
+
+	if _, err := ParseLogFormat("yaml"); err == nil {
+		t.Error("expected an unknown format to be refused")
+	}
+}
@@ -0,0 +1,113 @@
+package output
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	ansiItalic    = "\033[3m"
+	ansiUnderline = "\033[4m"
+)
+
+var (
+	mdCode        = regexp.MustCompile("`([^`]+)`")
+	mdLink        = regexp.MustCompile(`\[([^\]]+)\]\((\S+?)\)`)
+	mdBold        = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	mdStrike      = regexp.MustCompile(`~~(.+?)~~`)
+	mdItalic      = regexp.MustCompile(`(^|[^\w*])[*_]([^*_\s](?:[^*_]*[^*_\s])?)[*_]($|[^\w*])`)
+	mdHeading     = regexp.MustCompile(`^#{1,6}\s+(.*)$`)
+	mdBullet      = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	mdQuote       = regexp.MustCompile(`^>\s?(.*)$`)
+	mdPlaceholder = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// SetMarkdown turns rendering of markdown in descriptions and comments on or
+// off. It is on by default when writing to a terminal or with forced color.
+func (f *Formatter) SetMarkdown(enabled bool) {
+	f.markdown = enabled
+}
+
+// Markdown renders the common subset of markdown used in Todoist notes for
+// the terminal: emphasis, code, links, headings, lists and quotes. Text is
+// returned unchanged when markdown rendering is off.
+func (f *Formatter) Markdown(text string) string {
+	if !f.markdown {
+		return text
+	}
+
+	lines := strings.Split(text, "\n")
+	inFence := false
+	out := lines[:0]
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			out = append(out, "  "+f.color.Wrap(ANSICyan, line))
+			continue
+		}
+
+		if m := mdHeading.FindStringSubmatch(line); m != nil {
+			line = f.color.Wrap(ANSIBold, f.markdownInline(m[1]))
+		} else if m := mdBullet.FindStringSubmatch(line); m != nil {
+			line = m[1] + "• " + f.markdownInline(m[2])
+		} else if m := mdQuote.FindStringSubmatch(line); m != nil {
+			line = f.color.Wrap(ANSIGray, "│ ") + f.markdownInline(m[1])
+		} else {
+			line = f.markdownInline(line)
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
+
+// markdownInline renders code spans, links and emphasis within a line. Code
+// spans and links are swapped for placeholders first so emphasis markers in
+// them are left alone.
+func (f *Formatter) markdownInline(s string) string {
+	var saved []string
+	protect := func(rendered string) string {
+		saved = append(saved, rendered)
+		return fmt.Sprintf("\x00%d\x00", len(saved)-1)
+	}
+
+	s = mdCode.ReplaceAllStringFunc(s, func(m string) string {
+		return protect(f.color.Wrap(ANSICyan, m[1:len(m)-1]))
+	})
+	s = mdLink.ReplaceAllStringFunc(s, func(m string) string {
+		sub := mdLink.FindStringSubmatch(m)
+		text, url := f.markdownEmphasis(sub[1]), sub[2]
+		if f.links {
+			return protect(f.Link(url, f.color.Wrap(ansiUnderline, text)))
+		}
+		return protect(text + " " + f.color.Wrap(ANSIGray, "("+url+")"))
+	})
+	s = f.markdownEmphasis(s)
+
+	// Link text may itself hold code placeholders, so restore until none remain
+	for mdPlaceholder.MatchString(s) {
+		s = mdPlaceholder.ReplaceAllStringFunc(s, func(m string) string {
+			i, _ := strconv.Atoi(m[1 : len(m)-1])
+			return saved[i]
+		})
+	}
+	return s
+}
+
+// markdownEmphasis renders bold, strikethrough and italic spans
+func (f *Formatter) markdownEmphasis(s string) string {
+	s = mdBold.ReplaceAllStringFunc(s, func(m string) string {
+		return f.color.Wrap(ANSIBold, m[2:len(m)-2])
+	})
+	s = mdStrike.ReplaceAllStringFunc(s, func(m string) string {
+		return f.color.Wrap(ANSIStrike, m[2:len(m)-2])
+	})
+	return mdItalic.ReplaceAllStringFunc(s, func(m string) string {
+		sub := mdItalic.FindStringSubmatch(m)
+		return sub[1] + f.color.Wrap(ansiItalic, sub[2]) + sub[3]
+	})
+}
@@ -0,0 +1,250 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+)
+
+// TaskColumns are the columns a task table can show
+var TaskColumns = []string{"id", "priority", "due", "content", "labels", "assignee", "project", "section"}
+
+// DefaultTaskColumns are shown when no --columns are given
+var DefaultTaskColumns = []string{"id", "priority", "due", "content", "labels", "project"}
+
+// maxColumnWidth caps every column except content, which takes the rest
+const maxColumnWidth = 24
+
+// ParseColumns parses a comma-separated column list such as "id,due,content"
+func ParseColumns(s string) ([]string, error) {
+	var columns []string
+	for _, c := range strings.Split(s, ",") {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if c == "" {
+			continue
+		}
+		if c == "p" || c == "pri" {
+			c = "priority"
+		}
+		known := false
+		for _, k := range TaskColumns {
+			known = known || k == c
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown column %q (use %s)", c, strings.Join(TaskColumns, ", "))
+		}
+		columns = append(columns, c)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns given")
+	}
+	return columns, nil
+}
+
+// SetTable switches task lists to an aligned table with the given columns.
+// Nil columns print one plain line per task.
+func (f *Formatter) SetTable(columns []string) {
+	f.table = columns
+}
+
+// TableColumns returns the columns task lists are shown with, or nil
+func (f *Formatter) TableColumns() []string {
+	return f.table
+}
+
+// SetNames sets the project and section names shown in task lists, keyed by ID
+func (f *Formatter) SetNames(names map[string]string) {
+	f.names = names
+}
+
+// SetPeople sets the names shown for assignees, keyed by user ID
+func (f *Formatter) SetPeople(people map[string]string) {
+	f.people = people
+}
+
+// NeedsPeople reports whether showing tasks needs assignee names
+func (f *Formatter) NeedsPeople(tasks []api.Task) bool {
+	if f.asJSON {
+		return false
+	}
+	for _, t := range tasks {
+		if t.Assignee != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedsAuthors reports whether showing comments needs the names of who
+// posted them
+func (f *Formatter) NeedsAuthors(comments []api.Comment) bool {
+	if f.asJSON {
+		return false
+	}
+	for _, c := range comments {
+		if c.PostedUID != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// person returns the name of a user, or the ID when the name is unknown
+func (f *Formatter) person(id string) string {
+	if name := f.people[id]; name != "" {
+		return name
+	}
+	return id
+}
+
+// ShowPlaces appends a dimmed "#Project/Section" to plain task lines when
+// names have been set with SetNames
+func (f *Formatter) ShowPlaces(show bool) {
+	f.places = show
+}
+
+// NeedsNames reports whether the output shows project or section names, so
+// callers only look them up when they are used
+func (f *Formatter) NeedsNames() bool {
+	if f.asJSON {
+		return false
+	}
+	if f.table == nil {
+		return f.places
+	}
+	for _, c := range f.table {
+		if c == "project" || c == "section" {
+			return true
+		}
+	}
+	return false
+}
+
+// showsColumn reports whether task tables include column
+func (f *Formatter) showsColumn(column string) bool {
+	for _, c := range f.table {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// taskPlace returns "Project/Section" for a task, or "" when names are unknown
+func (f *Formatter) taskPlace(t *api.Task) string {
+	project := f.names[t.ProjectID]
+	if project == "" {
+		return ""
+	}
+	if section := f.names[t.SectionID]; section != "" {
+		return project + "/" + section
+	}
+	return project
+}
+
+// taskCell returns the plain text and color of a task's value in a column
+func (f *Formatter) taskCell(column string, r taskRow) (string, string) {
+	t := r.task
+	switch column {
+	case "id":
+		return t.ID, theme.ID
+	case "priority":
+		if icon := priorityIcon(t.Priority); icon != "" {
+			return icon, theme.priority(t.Priority)
+		}
+		return priorityString(t.Priority), theme.priority(t.Priority)
+	case "due":
+		return f.dueCell(t)
+	case "content":
+		text := strings.Repeat("  ", r.level) + t.Content
+		if mark := commentsMark(t); mark != "" {
+			text += " " + mark
+		}
+		if t.Assignee != "" && !f.showsColumn("assignee") {
+			text += " → " + f.person(t.Assignee)
+		}
+		return text, ""
+	case "labels":
+		if len(t.Labels) == 0 {
+			return "", ""
+		}
+		return "@" + strings.Join(t.Labels, " @"), theme.Label
+	case "assignee":
+		if t.Assignee == "" {
+			return "", ""
+		}
+		return f.person(t.Assignee), theme.Assignee
+	case "project":
+		return f.names[t.ProjectID], theme.Project
+	case "section":
+		return f.names[t.SectionID], theme.Project
+	}
+	return "", ""
+}
+
+// writeTaskTable prints tasks as a table with a header row. On a terminal
+// the content column shrinks, down to a minimum, so lines fit the width.
+func (f *Formatter) writeTaskTable(rows []taskRow) {
+	columns := f.table
+	widths := make([]int, len(columns))
+	for i, c := range columns {
+		widths[i] = displayWidth(c)
+		if c == "priority" {
+			widths[i] = len("pri")
+		}
+		for _, r := range rows {
+			text, _ := f.taskCell(c, r)
+			widths[i] = max(widths[i], displayWidth(text))
+		}
+		if c != "content" && c != "id" {
+			widths[i] = min(widths[i], maxColumnWidth)
+		}
+	}
+
+	if f.width > 0 {
+		others := 2 * (len(columns) - 1)
+		for i, c := range columns {
+			if c != "content" {
+				others += widths[i]
+			}
+		}
+		for i, c := range columns {
+			if c == "content" {
+				widths[i] = min(widths[i], max(f.width-others, minContentWidth))
+			}
+		}
+	}
+
+	var header []string
+	for i, c := range columns {
+		if c == "priority" {
+			c = "pri"
+		}
+		header = append(header, pad(strings.ToUpper(c), widths[i]))
+	}
+	fmt.Fprintln(f.w, f.color.Wrap(ANSIBold, strings.TrimRight(strings.Join(header, "  "), " ")))
+
+	for _, r := range rows {
+		var b strings.Builder
+		for i, c := range columns {
+			text, code := f.taskCell(c, r)
+			text = truncate(text, widths[i])
+			cell := text
+			if text != "" {
+				cell = f.color.Wrap(code, text)
+			}
+			if c == "id" {
+				cell = f.Link(api.TaskURL(r.task.ID), cell)
+			}
+			if i > 0 {
+				b.WriteString("  ")
+			}
+			b.WriteString(cell)
+			if i < len(columns)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-displayWidth(text)))
+			}
+		}
+		fmt.Fprintln(f.w, strings.TrimRight(b.String(), " "))
+	}
+}
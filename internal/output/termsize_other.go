@@ -0,0 +1,10 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package output
+
+import "os"
+
+// ttyColumns is not implemented on this platform; COLUMNS still applies
+func ttyColumns(f *os.File) int {
+	return 0
+}
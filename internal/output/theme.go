@@ -0,0 +1,197 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Theme holds the ANSI style of each part of a task as it is shown. An
+// empty style leaves that part unstyled.
+type Theme struct {
+	Priority1 string // p1, the highest
+	Priority2 string
+	Priority3 string
+	Priority4 string // p4, the default priority
+	Due       string
+	Overdue   string
+	Label     string
+	ID        string
+	Project   string // project and section names
+	Assignee  string
+}
+
+// themes are the built-in themes, selected with --theme or "base" in the
+// theme config
+var themes = map[string]Theme{
+	"default": {
+		Priority1: ANSIRed,
+		Priority2: ANSIYellow,
+		Priority3: ANSIBlue,
+		Due:       ANSIGray,
+		Overdue:   ANSIRed,
+		Label:     ANSICyan,
+		ID:        ANSIGray,
+		Project:   ANSIGray,
+		Assignee:  ANSIGreen,
+	},
+	"solarized": mustTheme(map[string]string{
+		"priority1": "#dc322f",
+		"priority2": "#cb4b16",
+		"priority3": "#268bd2",
+		"due":       "#657b83",
+		"overdue":   "bold #dc322f",
+		"label":     "#2aa198",
+		"id":        "#586e75",
+		"project":   "#586e75",
+		"assignee":  "#859900",
+	}),
+	"monochrome": mustTheme(map[string]string{
+		"priority1": "bold underline",
+		"priority2": "bold",
+		"due":       "dim",
+		"overdue":   "bold",
+		"label":     "italic",
+		"id":        "dim",
+		"project":   "dim",
+	}),
+}
+
+// theme is the theme task output is shown in
+var theme = themes["default"]
+
+// SetTheme changes the theme task output is shown in
+func SetTheme(t Theme) {
+	theme = t
+}
+
+// ThemeNames lists the built-in themes
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseTheme returns the built-in theme named base ("" for the default),
+// with styles overriding its elements. Elements are priority1 to priority4,
+// due, overdue, label, id, project and assignee; the key "base" is ignored
+// so a whole theme config section can be passed. See ParseStyle for styles.
+func ParseTheme(base string, styles map[string]string) (Theme, error) {
+	if base == "" {
+		base = "default"
+	}
+	t, ok := themes[strings.ToLower(base)]
+	if !ok {
+		return Theme{}, fmt.Errorf("unknown theme %q (use %s)", base, strings.Join(ThemeNames(), ", "))
+	}
+	for element, style := range styles {
+		if element == "base" {
+			continue
+		}
+		field := t.element(strings.ToLower(element))
+		if field == nil {
+			return Theme{}, fmt.Errorf("unknown theme element %q", element)
+		}
+		code, err := ParseStyle(style)
+		if err != nil {
+			return Theme{}, fmt.Errorf("theme element %s: %w", element, err)
+		}
+		*field = code
+	}
+	return t, nil
+}
+
+// element returns the field holding a theme element's style
+func (t *Theme) element(name string) *string {
+	switch name {
+	case "priority1":
+		return &t.Priority1
+	case "priority2":
+		return &t.Priority2
+	case "priority3":
+		return &t.Priority3
+	case "priority4":
+		return &t.Priority4
+	case "due":
+		return &t.Due
+	case "overdue":
+		return &t.Overdue
+	case "label":
+		return &t.Label
+	case "id":
+		return &t.ID
+	case "project":
+		return &t.Project
+	case "assignee":
+		return &t.Assignee
+	}
+	return nil
+}
+
+// priority returns the style of an API priority (4 is p1)
+func (t *Theme) priority(p int) string {
+	switch p {
+	case 4:
+		return t.Priority1
+	case 3:
+		return t.Priority2
+	case 2:
+		return t.Priority3
+	default:
+		return t.Priority4
+	}
+}
+
+var styleCodes = map[string]string{
+	"bold": "1", "dim": "2", "italic": "3", "underline": "4", "strike": "9",
+	"black": "30", "red": "31", "green": "32", "yellow": "33",
+	"blue": "34", "magenta": "35", "cyan": "36", "white": "37", "gray": "90",
+	"bright-red": "91", "bright-green": "92", "bright-yellow": "93",
+	"bright-blue": "94", "bright-magenta": "95", "bright-cyan": "96", "bright-white": "97",
+}
+
+// ParseStyle converts a style such as "bold red", "#268bd2" or "208" (a
+// 256-color number) to an ANSI escape sequence. Words may be separated by
+// spaces or commas; "" or "none" gives no style.
+func ParseStyle(style string) (string, error) {
+	var codes []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(style), func(r rune) bool { return r == ' ' || r == ',' }) {
+		switch {
+		case word == "none":
+		case styleCodes[word] != "":
+			codes = append(codes, styleCodes[word])
+		case strings.HasPrefix(word, "#") && len(word) == 7:
+			rgb, err := strconv.ParseUint(word[1:], 16, 32)
+			if err != nil {
+				return "", fmt.Errorf("invalid color %q", word)
+			}
+			codes = append(codes, fmt.Sprintf("38;2;%d;%d;%d", rgb>>16, rgb>>8&0xff, rgb&0xff))
+		default:
+			n, err := strconv.Atoi(word)
+			if err != nil || n < 0 || n > 255 {
+				return "", fmt.Errorf("unknown style %q", word)
+			}
+			codes = append(codes, "38;5;"+word)
+		}
+	}
+	if len(codes) == 0 {
+		return "", nil
+	}
+	return "\033[" + strings.Join(codes, ";") + "m", nil
+}
+
+func mustTheme(styles map[string]string) Theme {
+	var t Theme
+	for element, style := range styles {
+		code, err := ParseStyle(style)
+		if err != nil {
+			panic(err)
+		}
+		*t.element(element) = code
+	}
+	return t
+}
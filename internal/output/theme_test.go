@@ -0,0 +1,65 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+)
+
+func TestParseStyle(t *testing.T) {
+	tests := map[string]string{
+		"":              "",
+		"none":          "",
+		"red":           "\033[31m",
+		"Bold, red":     "\033[1;31m",
+		"#268bd2":       "\033[38;2;38;139;210m",
+		"208 underline": "\033[38;5;208;4m",
+	}
+	for style, want := range tests {
+		got, err := ParseStyle(style)
+		if err != nil || got != want {
+			t.Errorf("ParseStyle(%q) = %q, %v; want %q", style, got, err, want)
+		}
+	}
+	for _, bad := range []string{"reddish", "#12345g", "256"} {
+		if _, err := ParseStyle(bad); err == nil {
+			t.Errorf("ParseStyle(%q): expected an error", bad)
+		}
+	}
+}
+
+func TestParseTheme(t *testing.T) {
+	th, err := ParseTheme("monochrome", map[string]string{"base": "ignored", "Overdue": "red"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if th.Overdue != ANSIRed || th.Due != "\033[2m" || th.Label != "\033[3m" {
+		t.Errorf("unexpected theme %+v", th)
+	}
+
+	if _, err := ParseTheme("neon", nil); err == nil {
+		t.Error("expected an unknown theme to fail")
+	}
+	if _, err := ParseTheme("", map[string]string{"heading": "bold"}); err == nil {
+		t.Error("expected an unknown element to fail")
+	}
+}
+
+func TestSetTheme(t *testing.T) {
+	defer SetTheme(themes["default"])
+	th, _ := ParseTheme("", map[string]string{"priority1": "magenta", "label": "none"})
+	SetTheme(th)
+
+	var buf bytes.Buffer
+	f := NewFormatterWithColor(&buf, false, ColorAlways)
+	f.WriteTasks([]api.Task{{ID: "1", Content: "Ship", Priority: 4, Labels: []string{"work"}}})
+	out := buf.String()
+	if !strings.Contains(out, "\033[35m[p1]") {
+		t.Errorf("expected the themed priority, got %q", out)
+	}
+	if strings.Contains(out, ANSICyan) || !strings.Contains(out, " @work") {
+		t.Errorf("expected an unstyled label, got %q", out)
+	}
+}
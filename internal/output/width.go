@@ -0,0 +1,97 @@
+package output
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// minContentWidth keeps task text readable on very narrow terminals
+const minContentWidth = 20
+
+// terminalWidth returns the width of w in columns when it is a terminal, or
+// 0 when output is piped. COLUMNS overrides the detected width.
+func terminalWidth(w io.Writer) int {
+	file, ok := w.(*os.File)
+	if !ok {
+		return 0
+	}
+	info, err := file.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return 0
+	}
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	return ttyColumns(file)
+}
+
+// SetWidth sets the terminal width used to align and truncate task lists.
+// Zero disables column layout.
+func (f *Formatter) SetWidth(width int) {
+	f.width = width
+}
+
+// runeWidth returns the number of terminal columns r occupies
+func runeWidth(r rune) int {
+	switch {
+	case unicode.Is(unicode.Mn, r) || r == '\u200d' || (r >= '\ufe00' && r <= '\ufe0f'):
+		return 0
+	case r >= 0x1100 && r <= 0x115f, // Hangul Jamo
+		r >= 0x2e80 && r <= 0xa4cf, // CJK, Yi
+		r >= 0xac00 && r <= 0xd7a3, // Hangul syllables
+		r >= 0xf900 && r <= 0xfaff, // CJK compatibility
+		r >= 0xfe30 && r <= 0xfe4f,
+		r >= 0xff00 && r <= 0xff60, // fullwidth forms
+		r >= 0xffe0 && r <= 0xffe6,
+		r >= 0x1f300 && r <= 0x1faff, // emoji
+		r >= 0x20000 && r <= 0x3fffd:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// displayWidth returns the number of terminal columns s occupies
+func displayWidth(s string) int {
+	n := 0
+	for _, r := range s {
+		n += runeWidth(r)
+	}
+	return n
+}
+
+// Truncate shortens s to at most width terminal columns, ending in "…" when
+// cut
+func Truncate(s string, width int) string {
+	return truncate(s, width)
+}
+
+// truncate shortens s to at most width columns, ending in "…" when cut
+func truncate(s string, width int) string {
+	if displayWidth(s) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range s {
+		rw := runeWidth(r)
+		if n+rw > width-1 {
+			break
+		}
+		b.WriteRune(r)
+		n += rw
+	}
+	return b.String() + "…"
+}
+
+// pad truncates or right-pads s with spaces to exactly width columns
+func pad(s string, width int) string {
+	s = truncate(s, width)
+	return s + strings.Repeat(" ", width-displayWidth(s))
+}
@@ -0,0 +1,162 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/buddyh/todoist-cli/internal/api"
+	"github.com/buddyh/todoist-cli/internal/i18n"
+)
+
+// WriteWorkspaces outputs workspaces with how many of projects are in each
+func (f *Formatter) WriteWorkspaces(workspaces []api.Workspace, projects []api.Project) error {
+	if f.asJSON {
+		return f.JSON(workspaces)
+	}
+
+	if len(workspaces) == 0 {
+		fmt.Fprintln(f.w, i18n.T("No workspaces found."))
+		return nil
+	}
+
+	counts := make(map[string]int)
+	for _, p := range projects {
+		counts[p.WorkspaceID]++
+	}
+	for _, w := range workspaces {
+		line := w.Name
+		var markers []string
+		for _, m := range []string{w.Plan, w.Role} {
+			if m != "" {
+				markers = append(markers, strings.ToLower(m))
+			}
+		}
+		if len(markers) > 0 {
+			line += " " + f.color.Wrap(ANSIGray, "["+strings.Join(markers, ", ")+"]")
+		}
+		noun := "projects"
+		if counts[w.ID] == 1 {
+			noun = "project"
+		}
+		line += f.color.Wrap(ANSIGray, fmt.Sprintf("  %d %s", counts[w.ID], noun))
+		fmt.Fprintf(f.w, "%s  %s\n", f.color.Wrap(theme.ID, w.ID), line)
+	}
+
+	return nil
+}
+
+// WriteFolders outputs project folders with their workspace and how many
+// of projects are in each
+func (f *Formatter) WriteFolders(folders []api.Folder, workspaces []api.Workspace, projects []api.Project) error {
+	if f.asJSON {
+		return f.JSON(folders)
+	}
+
+	if len(folders) == 0 {
+		fmt.Fprintln(f.w, i18n.T("No folders found."))
+		return nil
+	}
+
+	counts := make(map[string]int)
+	for _, p := range projects {
+		counts[p.FolderID]++
+	}
+	for _, folder := range folders {
+		line := folder.Name
+		if w := findWorkspace(workspaces, folder.WorkspaceID); w != nil {
+			line += " " + f.color.Wrap(ANSIGray, "["+w.Name+"]")
+		}
+		noun := "projects"
+		if counts[folder.ID] == 1 {
+			noun = "project"
+		}
+		line += f.color.Wrap(ANSIGray, fmt.Sprintf("  %d %s", counts[folder.ID], noun))
+		fmt.Fprintf(f.w, "%s  %s\n", f.color.Wrap(theme.ID, folder.ID), line)
+	}
+
+	return nil
+}
+
+// WriteProjectsByWorkspace outputs projects under a heading for each
+// workspace, personal projects first, with a workspace's projects that are
+// in folders listed under the folder's name. Without team projects it is
+// the same as WriteProjects.
+func (f *Formatter) WriteProjectsByWorkspace(projects []api.Project, workspaces []api.Workspace, folders []api.Folder) error {
+	groups := make(map[string][]api.Project)
+	order := []string{""}
+	for _, w := range workspaces {
+		order = append(order, w.ID)
+	}
+	for _, p := range projects {
+		if _, ok := groups[p.WorkspaceID]; !ok && p.WorkspaceID != "" && findWorkspace(workspaces, p.WorkspaceID) == nil {
+			order = append(order, p.WorkspaceID)
+		}
+		groups[p.WorkspaceID] = append(groups[p.WorkspaceID], p)
+	}
+	if f.asJSON || len(groups) == 0 || len(groups) == 1 && groups[""] != nil {
+		return f.WriteProjects(projects)
+	}
+
+	first := true
+	for _, id := range order {
+		if len(groups[id]) == 0 {
+			continue
+		}
+		if !first {
+			fmt.Fprintln(f.w)
+		}
+		first = false
+
+		heading := i18n.T("Personal")
+		if id != "" {
+			heading = id
+			if w := findWorkspace(workspaces, id); w != nil {
+				heading = w.Name
+			}
+		}
+		fmt.Fprintln(f.w, f.color.Wrap(ANSIBold, heading))
+		f.writeFolderedProjects(groups[id], folders)
+	}
+
+	return nil
+}
+
+// writeFolderedProjects lists one workspace's projects, those outside a
+// folder first and then each folder's
+func (f *Formatter) writeFolderedProjects(projects []api.Project, folders []api.Folder) {
+	inFolder := make(map[string][]api.Project)
+	for _, p := range projects {
+		if p.FolderID != "" && findFolder(folders, p.FolderID) != nil {
+			inFolder[p.FolderID] = append(inFolder[p.FolderID], p)
+			continue
+		}
+		fmt.Fprintf(f.w, "  %s  %s\n", f.color.Wrap(theme.ID, p.ID), f.FormatProject(&p))
+	}
+	for _, folder := range folders {
+		if len(inFolder[folder.ID]) == 0 {
+			continue
+		}
+		fmt.Fprintf(f.w, "  %s\n", f.color.Wrap(ANSIBold, folder.Name+"/"))
+		for _, p := range inFolder[folder.ID] {
+			fmt.Fprintf(f.w, "    %s  %s\n", f.color.Wrap(theme.ID, p.ID), f.FormatProject(&p))
+		}
+	}
+}
+
+func findWorkspace(workspaces []api.Workspace, id string) *api.Workspace {
+	for i := range workspaces {
+		if workspaces[i].ID == id {
+			return &workspaces[i]
+		}
+	}
+	return nil
+}
+
+func findFolder(folders []api.Folder, id string) *api.Folder {
+	for i := range folders {
+		if folders[i].ID == id {
+			return &folders[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package picker
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/buddyh/todoist-cli/internal/i18n"
+)
+
+// Keys read in raw mode
+const (
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlG     = 7
+	keyBackspace = 8
+	keyCtrlN     = 14
+	keyCtrlP     = 16
+	keyCtrlU     = 21
+	keyCtrlW     = 23
+	keyEscape    = 27
+	keyDelete    = 127
+)
+
+// rawMode switches tty to unbuffered input without echo using stty, and
+// returns a function restoring the previous settings along with the
+// terminal's width, or 0 when unknown
+func rawMode(tty *os.File) (func(), int, error) {
+	stty := func(args ...string) (string, error) {
+		cmd := exec.Command("stty", args...)
+		cmd.Stdin = tty
+		out, err := cmd.Output()
+		return strings.TrimSpace(string(out)), err
+	}
+
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, 0, err
+	}
+	if _, err := stty("raw", "-echo"); err != nil {
+		return nil, 0, err
+	}
+
+	width := 0
+	if size, err := stty("size"); err == nil {
+		if _, cols, ok := strings.Cut(size, " "); ok {
+			width, _ = strconv.Atoi(cols)
+		}
+	}
+	return func() { stty(saved) }, width, nil
+}
+
+// pickKeys is the key loop of Run, reading keys from in. Labels are cut to
+// width so that every entry takes one line; 0 leaves them whole.
+func pickKeys(in *bufio.Reader, out io.Writer, items []Item, width int) (*Item, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("nothing to pick from")
+	}
+
+	var query []rune
+	shown, selected := items, 0
+	update := func() {
+		shown, selected = Filter(items, string(query)), 0
+	}
+	for {
+		draw(out, string(query), shown, selected, width)
+
+		r, _, err := in.ReadRune()
+		if err != nil {
+			clearPicker(out)
+			return nil, ErrCancelled
+		}
+		switch r {
+		case '\r', '\n':
+			if len(shown) == 0 {
+				continue
+			}
+			clearPicker(out)
+			return &shown[selected], nil
+		case keyCtrlC, keyCtrlG:
+			clearPicker(out)
+			return nil, ErrCancelled
+		case keyCtrlD:
+			if len(query) == 0 {
+				clearPicker(out)
+				return nil, ErrCancelled
+			}
+		case keyEscape:
+			// Arrow keys arrive as ESC [ A at once; a lone Esc cancels
+			if in.Buffered() == 0 {
+				clearPicker(out)
+				return nil, ErrCancelled
+			}
+			if next, _ := in.ReadByte(); next != '[' && next != 'O' {
+				continue
+			}
+			switch dir, _ := in.ReadByte(); dir {
+			case 'A':
+				selected = max(selected-1, 0)
+			case 'B':
+				selected = min(selected+1, min(len(shown), maxShown)-1)
+			}
+		case keyCtrlP:
+			selected = max(selected-1, 0)
+		case keyCtrlN:
+			selected = min(selected+1, min(len(shown), maxShown)-1)
+		case keyDelete, keyBackspace:
+			if len(query) > 0 {
+				query = query[:len(query)-1]
+				update()
+			}
+		case keyCtrlU:
+			query = nil
+			update()
+		case keyCtrlW:
+			end := len(query)
+			for end > 0 && unicode.IsSpace(query[end-1]) {
+				end--
+			}
+			for end > 0 && !unicode.IsSpace(query[end-1]) {
+				end--
+			}
+			query = query[:end]
+			update()
+		default:
+			if unicode.IsPrint(r) {
+				query = append(query, r)
+				update()
+			}
+		}
+		selected = max(selected, 0)
+	}
+}
+
+// draw writes the matches below the prompt, then leaves the cursor after
+// the query so the next draw starts from the prompt line
+func draw(out io.Writer, query string, shown []Item, selected, width int) {
+	var b strings.Builder
+	b.WriteString("\r\033[J")
+
+	lines := 0
+	for i, it := range shown {
+		if i == maxShown {
+			b.WriteString("\r\n" + i18n.T("  ... %d more, type to narrow", len(shown)-maxShown))
+			lines++
+			break
+		}
+		marker := "  "
+		if i == selected {
+			marker = "> "
+		}
+		b.WriteString("\r\n" + marker + fit(it.Label, width-len(marker)))
+		lines++
+	}
+	if len(shown) == 0 {
+		b.WriteString("\r\n" + i18n.T("  (no matches)"))
+		lines++
+	}
+
+	fmt.Fprintf(&b, "\033[%dA\r> %s", lines, query)
+	io.WriteString(out, b.String())
+}
+
+// clearPicker erases the prompt and the list under it
+func clearPicker(out io.Writer) {
+	io.WriteString(out, "\r\033[J")
+}
+
+// fit cuts s to width runes, or leaves it whole when width is not positive
+func fit(s string, width int) string {
+	r := []rune(s)
+	if width <= 0 || len(r) <= width {
+		return s
+	}
+	if width == 1 {
+		return "…"
+	}
+	return string(r[:width-1]) + "…"
+}
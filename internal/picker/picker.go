@@ -0,0 +1,163 @@
+// Package picker implements a small fuzzy finder for choosing a task
+// interactively without copying IDs.
+package picker
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/buddyh/todoist-cli/internal/i18n"
+)
+
+// maxShown limits how many matches are listed at once
+const maxShown = 20
+
+// ErrCancelled is returned when the user leaves the picker without choosing
+var ErrCancelled = errors.New("selection cancelled")
+
+// Item is a selectable entry
+type Item struct {
+	ID    string
+	Label string
+}
+
+// IsTerminal reports whether f is attached to an interactive terminal
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Filter returns the items whose label contains query as a case-insensitive
+// subsequence, best matches first. An empty query returns all items.
+func Filter(items []Item, query string) []Item {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return items
+	}
+
+	type scored struct {
+		item  Item
+		score int
+	}
+	var matches []scored
+	for _, it := range items {
+		if s := Score(it.Label, query); s >= 0 {
+			matches = append(matches, scored{it, s})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		// Prefer shorter labels on ties: the query covers more of them
+		return len(matches[i].item.Label) < len(matches[j].item.Label)
+	})
+
+	result := make([]Item, len(matches))
+	for i, m := range matches {
+		result[i] = m.item
+	}
+	return result
+}
+
+// Score rates how well query fuzzy-matches text, or returns -1 when the
+// query's characters do not all appear in order. Consecutive characters and
+// matches at word starts score higher.
+func Score(text, query string) int {
+	t := []rune(strings.ToLower(text))
+	q := []rune(strings.ToLower(query))
+
+	score, ti, prev := 0, 0, -2
+	for _, qc := range q {
+		if unicode.IsSpace(qc) {
+			continue
+		}
+		found := false
+		for ; ti < len(t); ti++ {
+			if t[ti] != qc {
+				continue
+			}
+			score++
+			if ti == prev+1 {
+				score += 3
+			}
+			if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+				score += 2
+			}
+			prev = ti
+			ti++
+			found = true
+			break
+		}
+		if !found {
+			return -1
+		}
+	}
+	return score
+}
+
+// Run picks an item on the terminal tty the way fzf does: each key narrows
+// the list, the arrow keys or Ctrl-N/Ctrl-P move the selection, Enter
+// chooses and Esc or Ctrl-C cancels. Where the terminal cannot be put in
+// raw mode, such as on Windows, it falls back to Pick.
+func Run(tty *os.File, out io.Writer, items []Item) (*Item, error) {
+	restore, width, err := rawMode(tty)
+	if err != nil {
+		return Pick(tty, out, items)
+	}
+	defer restore()
+	return pickKeys(bufio.NewReader(tty), out, items, width)
+}
+
+// Pick runs a line-based selection loop: typing text narrows the list,
+// typing a number chooses that entry, and an empty line or "q" cancels.
+func Pick(in io.Reader, out io.Writer, items []Item) (*Item, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("nothing to pick from")
+	}
+
+	reader := bufio.NewReader(in)
+	shown := items
+	for {
+		for i, it := range shown {
+			if i == maxShown {
+				fmt.Fprintln(out, i18n.T("  ... %d more, type to narrow", len(shown)-maxShown))
+				break
+			}
+			fmt.Fprintf(out, "%3d  %s\n", i+1, it.Label)
+		}
+		if len(shown) == 0 {
+			fmt.Fprintln(out, i18n.T("  (no matches)"))
+		}
+		fmt.Fprint(out, i18n.T("Select [number, text to filter, empty to cancel]: "))
+
+		line, err := reader.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if line == "" || line == "q" {
+			if err != nil && err != io.EOF {
+				return nil, err
+			}
+			return nil, ErrCancelled
+		}
+
+		if n, convErr := strconv.Atoi(line); convErr == nil {
+			if n >= 1 && n <= len(shown) && n <= maxShown {
+				return &shown[n-1], nil
+			}
+			fmt.Fprintln(out, i18n.T("No entry %d", n))
+		} else {
+			shown = Filter(items, line)
+		}
+
+		if err != nil {
+			return nil, ErrCancelled
+		}
+	}
+}
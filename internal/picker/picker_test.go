@@ -0,0 +1,107 @@
+package picker
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFilter_OrdersByScore(t *testing.T) {
+	items := []Item{
+		{ID: "1", Label: "Review budget spreadsheet"},
+		{ID: "2", Label: "Buy milk"},
+		{ID: "3", Label: "Book flights"},
+	}
+
+	got := Filter(items, "bu")
+	if len(got) != 2 {
+		t.Fatalf("expected 2 matches, got %+v", got)
+	}
+	if got[0].ID != "2" {
+		t.Errorf("word-start match should rank first, got %+v", got)
+	}
+
+	if got := Filter(items, "xyz"); len(got) != 0 {
+		t.Errorf("expected no matches, got %+v", got)
+	}
+	if got := Filter(items, ""); len(got) != 3 {
+		t.Errorf("empty query should return all items, got %d", len(got))
+	}
+}
+
+func TestPick_FilterThenSelect(t *testing.T) {
+	items := []Item{
+		{ID: "1", Label: "Buy milk"},
+		{ID: "2", Label: "Call mom"},
+	}
+
+	var out bytes.Buffer
+	got, err := Pick(strings.NewReader("mom\n1\n"), &out, items)
+	if err != nil {
+		t.Fatalf("Pick failed: %v", err)
+	}
+	if got.ID != "2" {
+		t.Errorf("expected item 2, got %+v", got)
+	}
+}
+
+func TestPick_Cancel(t *testing.T) {
+	items := []Item{{ID: "1", Label: "Buy milk"}}
+
+	var out bytes.Buffer
+	if _, err := Pick(strings.NewReader("\n"), &out, items); err != ErrCancelled {
+		t.Errorf("expected ErrCancelled, got %v", err)
+	}
+}
+
+func TestPickKeys(t *testing.T) {
+	items := []Item{
+		{ID: "1", Label: "Buy milk"},
+		{ID: "2", Label: "Call mom"},
+		{ID: "3", Label: "Book flights"},
+	}
+
+	for _, tc := range []struct {
+		name, keys string
+		want       string
+	}{
+		{"enter takes the first", "\r", "1"},
+		{"typing narrows", "mom\r", "2"},
+		{"ctrl-n moves down", "\x0e\x0e\r", "3"},
+		{"arrows move", "\x1b[B\x1b[B\x1b[A\r", "2"},
+		{"selection stops at the ends", "\x10\x10\r", "1"},
+		{"backspace widens again", "mom\x7f\x7f\x7fbo\r", "3"},
+		{"ctrl-u clears the query", "xyz\x15\x0e\r", "2"},
+		{"enter without matches waits", "xyz\r\x7f\x7f\x7fflights\r", "3"},
+	} {
+		var out bytes.Buffer
+		got, err := pickKeys(bufio.NewReader(strings.NewReader(tc.keys)), &out, items, 0)
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if got.ID != tc.want {
+			t.Errorf("%s: got item %s, want %s", tc.name, got.ID, tc.want)
+		}
+	}
+
+	for _, keys := range []string{"\x03", "mo\x1b", "\x04", ""} {
+		var out bytes.Buffer
+		if _, err := pickKeys(bufio.NewReader(strings.NewReader(keys)), &out, items, 0); err != ErrCancelled {
+			t.Errorf("%q: expected ErrCancelled, got %v", keys, err)
+		}
+	}
+}
+
+func TestFit(t *testing.T) {
+	if got := fit("Review budget", 8); got != "Review …" {
+		t.Errorf("got %q", got)
+	}
+	if got := fit("Buy milk", 8); got != "Buy milk" {
+		t.Errorf("got %q", got)
+	}
+	if got := fit("Buy milk", 0); got != "Buy milk" {
+		t.Errorf("got %q", got)
+	}
+}
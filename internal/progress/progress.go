@@ -0,0 +1,114 @@
+// Package progress reports how far long-running operations have got: a bar
+// redrawn in place on a terminal, or a periodic log line otherwise.
+package progress
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// delay keeps quick operations from flashing a bar
+	delay = 500 * time.Millisecond
+	// logInterval spaces log lines when output is not a terminal
+	logInterval = 2 * time.Second
+	// barWidth is the number of cells in a bar
+	barWidth = 24
+)
+
+// Reporter draws progress for operations identified by a label, such as
+// "Fetching tasks". It is safe for concurrent use.
+type Reporter struct {
+	w   io.Writer
+	tty bool
+	now func() time.Time
+
+	mu  sync.Mutex
+	ops map[string]*op
+}
+
+type op struct {
+	started time.Time
+	logged  time.Time
+	shown   bool
+}
+
+// New returns a Reporter writing to w, redrawing a bar in place when tty
+// is set and printing log lines otherwise
+func New(w io.Writer, tty bool) *Reporter {
+	return &Reporter{w: w, tty: tty, now: time.Now, ops: make(map[string]*op)}
+}
+
+// Report records that done of total steps of label are finished. A total of
+// 0 means unknown, e.g. pages still to fetch. The operation ends when done
+// reaches a known total, or with Finish.
+func (r *Reporter) Report(label string, done, total int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	o := r.ops[label]
+	if o == nil {
+		o = &op{started: now}
+		r.ops[label] = o
+	}
+	finished := total > 0 && done >= total
+
+	switch {
+	case now.Sub(o.started) < delay && !o.shown:
+		// Too early to be worth showing
+	case r.tty:
+		fmt.Fprintf(r.w, "\r\033[K%s", render(label, done, total))
+		o.shown = true
+	case finished && o.shown, now.Sub(o.logged) >= logInterval:
+		fmt.Fprintln(r.w, line(label, done, total))
+		o.logged, o.shown = now, true
+	}
+
+	if finished {
+		r.finish(label, o)
+	}
+}
+
+// Finish ends label's operation, clearing its bar
+func (r *Reporter) Finish(label string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if o := r.ops[label]; o != nil {
+		r.finish(label, o)
+	}
+}
+
+func (r *Reporter) finish(label string, o *op) {
+	if r.tty && o.shown {
+		fmt.Fprint(r.w, "\r\033[K")
+	}
+	delete(r.ops, label)
+}
+
+// render draws a bar such as "Copying [#######.....] 12/40", or a count
+// when the total is unknown
+func render(label string, done, total int) string {
+	if total <= 0 {
+		return line(label, done, total)
+	}
+	filled := min(done*barWidth/total, barWidth)
+	return fmt.Sprintf("%s [%s%s] %d/%d", label, strings.Repeat("#", filled), strings.Repeat(".", barWidth-filled), done, total)
+}
+
+// line describes progress as plain text, e.g. "Copying: 12/40"
+func line(label string, done, total int) string {
+	if total <= 0 {
+		return fmt.Sprintf("%s: %d", label, done)
+	}
+	return fmt.Sprintf("%s: %d/%d", label, done, total)
+}
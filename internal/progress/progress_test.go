@@ -0,0 +1,69 @@
+package progress
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// clock is a fake time source advanced by tests
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time { return c.t }
+
+func newTestReporter(tty bool) (*Reporter, *strings.Builder, *clock) {
+	var b strings.Builder
+	c := &clock{t: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)}
+	r := New(&b, tty)
+	r.now = c.now
+	return r, &b, c
+}
+
+func TestReporter_QuickOperationsStaySilent(t *testing.T) {
+	r, b, _ := newTestReporter(true)
+	r.Report("Copying", 1, 2)
+	r.Report("Copying", 2, 2)
+	if b.Len() != 0 {
+		t.Errorf("expected no output, got %q", b.String())
+	}
+}
+
+func TestReporter_Terminal(t *testing.T) {
+	r, b, c := newTestReporter(true)
+	r.Report("Copying", 0, 4)
+	c.t = c.t.Add(time.Second)
+	r.Report("Copying", 1, 4)
+	if want := "\r\033[KCopying [######..................] 1/4"; b.String() != want {
+		t.Errorf("got %q, want %q", b.String(), want)
+	}
+
+	r.Report("Copying", 4, 4)
+	if !strings.HasSuffix(b.String(), "\r\033[K") {
+		t.Errorf("expected the bar to be cleared when done, got %q", b.String())
+	}
+}
+
+func TestReporter_Log(t *testing.T) {
+	r, b, c := newTestReporter(false)
+	r.Report("Fetching tasks", 1, 0)
+	for page := 2; page <= 5; page++ {
+		c.t = c.t.Add(time.Second)
+		r.Report("Fetching tasks", page, 0)
+	}
+	// One line every two seconds after the delay
+	if want := "Fetching tasks: 2\nFetching tasks: 4\n"; b.String() != want {
+		t.Errorf("got %q, want %q", b.String(), want)
+	}
+
+	r.Finish("Fetching tasks")
+	r.Report("Fetching tasks", 1, 0)
+	if strings.Count(b.String(), "\n") != 2 {
+		t.Errorf("expected a finished operation to start over, got %q", b.String())
+	}
+}
+
+func TestReporter_Nil(t *testing.T) {
+	var r *Reporter
+	r.Report("Copying", 1, 2)
+	r.Finish("Copying")
+}
@@ -0,0 +1,26 @@
+//go:build darwin
+
+package reminders
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// osascript runs a JavaScript for Automation script with arguments. The
+// first run asks the user to let the terminal control Reminders.
+func osascript(script string, args ...string) ([]byte, error) {
+	cmd := exec.Command("osascript", append([]string{"-l", "JavaScript", "-e", script}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("Reminders: %s", msg)
+		}
+		return nil, fmt.Errorf("failed to run osascript: %w", err)
+	}
+	return out, nil
+}
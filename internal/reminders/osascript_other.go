@@ -0,0 +1,8 @@
+//go:build !darwin
+
+package reminders
+
+// osascript is not available on this platform
+func osascript(script string, args ...string) ([]byte, error) {
+	return nil, ErrUnsupported
+}
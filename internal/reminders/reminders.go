@@ -0,0 +1,119 @@
+// Package reminders reads and writes Apple Reminders lists on macOS,
+// through osascript and the Reminders app's scripting interface.
+package reminders
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnsupported is returned on platforms without Apple Reminders
+var ErrUnsupported = errors.New("Apple Reminders is only available on macOS")
+
+// Reminder is one item of a Reminders list
+type Reminder struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Body      string    `json:"body"`
+	Due       time.Time `json:"due"`      // zero when there is no due date
+	AllDay    bool      `json:"all_day"`  // Due has no time of day
+	Priority  int       `json:"priority"` // 0 none, 1 high to 9 low
+	Completed bool      `json:"completed"`
+}
+
+// runScript runs a JavaScript for Automation script with arguments and
+// returns its output; replaced in tests
+var runScript = osascript
+
+// listScript prints the reminders of the list named by the first argument
+// as JSON, leaving out completed ones unless the second is "all"
+const listScript = `function run(argv) {
+	const app = Application("Reminders");
+	const lists = app.lists.whose({name: argv[0]});
+	if (lists.length === 0) throw new Error("no Reminders list named " + argv[0]);
+	let rs = lists[0].reminders;
+	if (argv[1] !== "all") rs = rs.whose({completed: false});
+	const ids = rs.id(), names = rs.name(), bodies = rs.body(), due = rs.dueDate(),
+		allDay = rs.alldayDueDate(), prio = rs.priority(), done = rs.completed();
+	return JSON.stringify(ids.map((id, i) => ({
+		id: id, name: names[i], body: bodies[i] || "",
+		due: allDay[i] ? allDay[i].toISOString() : due[i] ? due[i].toISOString() : null,
+		all_day: !!allDay[i], priority: prio[i], completed: done[i],
+	})));
+}`
+
+// addScript adds a reminder to the list named by the first argument and
+// prints its ID. The arguments after it are the name, body, due date in
+// RFC 3339 or "", whether it is all-day, and the priority.
+const addScript = `function run(argv) {
+	const app = Application("Reminders");
+	const lists = app.lists.whose({name: argv[0]});
+	if (lists.length === 0) throw new Error("no Reminders list named " + argv[0]);
+	const props = {name: argv[1], priority: parseInt(argv[5], 10)};
+	if (argv[2] !== "") props.body = argv[2];
+	if (argv[3] !== "") {
+		if (argv[4] === "true") props.alldayDueDate = new Date(argv[3]);
+		else props.dueDate = new Date(argv[3]);
+	}
+	const r = app.Reminder(props);
+	lists[0].reminders.push(r);
+	return r.id();
+}`
+
+// List returns the reminders of a list; completed ones only when all is set
+func List(list string, all bool) ([]Reminder, error) {
+	which := "open"
+	if all {
+		which = "all"
+	}
+	out, err := runScript(listScript, list, which)
+	if err != nil {
+		return nil, err
+	}
+	return parseList(out)
+}
+
+// parseList decodes the output of listScript
+func parseList(out []byte) ([]Reminder, error) {
+	var raw []struct {
+		ID        string  `json:"id"`
+		Name      string  `json:"name"`
+		Body      string  `json:"body"`
+		Due       *string `json:"due"`
+		AllDay    bool    `json:"all_day"`
+		Priority  int     `json:"priority"`
+		Completed bool    `json:"completed"`
+	}
+	if err := json.Unmarshal(out, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse reminders: %w", err)
+	}
+	list := make([]Reminder, len(raw))
+	for i, r := range raw {
+		list[i] = Reminder{ID: r.ID, Name: r.Name, Body: r.Body, AllDay: r.AllDay, Priority: r.Priority, Completed: r.Completed}
+		if r.Due != nil {
+			due, err := time.Parse(time.RFC3339, *r.Due)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse due date of %q: %w", r.Name, err)
+			}
+			list[i].Due = due.Local()
+		}
+	}
+	return list, nil
+}
+
+// Add creates a reminder in a list and returns its ID
+func Add(list string, r Reminder) (string, error) {
+	due := ""
+	if !r.Due.IsZero() {
+		due = r.Due.Format(time.RFC3339)
+	}
+	out, err := runScript(addScript, list, r.Name, r.Body, due, strconv.FormatBool(r.AllDay), strconv.Itoa(r.Priority))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,54 @@
+package reminders
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseList(t *testing.T) {
+	out := []byte(`[
+		{"id":"x-apple-reminder://A","name":"Milk","body":"","due":null,"all_day":false,"priority":0,"completed":false},
+		{"id":"x-apple-reminder://B","name":"Call","body":"about rent","due":"2024-03-20T14:30:00.000Z","all_day":false,"priority":1,"completed":true}
+	]`)
+	list, err := parseList(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("expected 2 reminders, got %d", len(list))
+	}
+	if !list[0].Due.IsZero() {
+		t.Errorf("expected no due date, got %v", list[0].Due)
+	}
+	want := time.Date(2024, 3, 20, 14, 30, 0, 0, time.UTC)
+	if b := list[1]; !b.Due.Equal(want) || b.Body != "about rent" || b.Priority != 1 || !b.Completed {
+		t.Errorf("unexpected reminder %+v", b)
+	}
+
+	if _, err := parseList([]byte("not json")); err == nil {
+		t.Error("expected an error for bad output")
+	}
+}
+
+func TestAdd(t *testing.T) {
+	var got []string
+	runScript = func(script string, args ...string) ([]byte, error) {
+		got = args
+		return []byte("x-apple-reminder://C\n"), nil
+	}
+	defer func() { runScript = osascript }()
+
+	due := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	id, err := Add("Groceries", Reminder{Name: "Eggs", Due: due, AllDay: true, Priority: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "x-apple-reminder://C" {
+		t.Errorf("unexpected ID %q", id)
+	}
+	want := []string{"Groceries", "Eggs", "", "2024-03-20T00:00:00Z", "true", "5"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("arguments = %q, want %q", got, want)
+	}
+}
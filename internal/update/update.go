@@ -0,0 +1,331 @@
+// Package update finds newer releases of the CLI on GitHub and installs
+// them in place of the running binary, after checking them against the
+// release's published checksums.
+package update
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReleasesURL lists the CLI's releases through the GitHub API
+const ReleasesURL = "https://api.github.com/repos/buddyh/todoist-cli/releases"
+
+// checksumsName is the release asset listing the SHA-256 of every archive
+const checksumsName = "checksums.txt"
+
+// Release is a published version of the CLI
+type Release struct {
+	Tag        string  `json:"tag_name"`
+	Prerelease bool    `json:"prerelease"`
+	Draft      bool    `json:"draft"`
+	URL        string  `json:"html_url"`
+	Assets     []Asset `json:"assets"`
+}
+
+// Asset is a file attached to a release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Version is the release's version without the leading "v"
+func (r Release) Version() string {
+	return strings.TrimPrefix(r.Tag, "v")
+}
+
+// archiveName is the name of the release archive for a platform, as the
+// release build names them
+func (r Release) archiveName(goos, goarch string) string {
+	ext := ".tar.gz"
+	if goos == "windows" {
+		ext = ".zip"
+	}
+	return fmt.Sprintf("todoist_%s_%s_%s%s", r.Version(), goos, goarch, ext)
+}
+
+func (r Release) asset(name string) (Asset, bool) {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Asset{}, false
+}
+
+// Source reads releases from a GitHub releases endpoint
+type Source struct {
+	URL   string // e.g. ReleasesURL
+	Token string // optional GitHub token, for a higher rate limit
+	HTTP  *http.Client
+}
+
+// NewSource returns a Source for the CLI's releases, using GITHUB_TOKEN
+// when set
+func NewSource() *Source {
+	return &Source{URL: ReleasesURL, Token: os.Getenv("GITHUB_TOKEN"), HTTP: &http.Client{Timeout: 2 * time.Minute}}
+}
+
+func (s *Source) get(url string, accept string) ([]byte, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	if s.Token != "" && strings.HasPrefix(url, s.URL) {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+	resp, err := s.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach GitHub: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", path.Base(url), err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", path.Base(url), resp.Status)
+	}
+	return body, nil
+}
+
+// Latest returns the newest published release, counting prereleases only
+// when asked to
+func (s *Source) Latest(prerelease bool) (*Release, error) {
+	body, err := s.get(s.URL+"?per_page=30", "application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+	var releases []Release
+	if err := json.Unmarshal(body, &releases); err != nil {
+		return nil, fmt.Errorf("failed to parse releases: %w", err)
+	}
+
+	var latest *Release
+	for i, r := range releases {
+		if r.Draft || (r.Prerelease && !prerelease) {
+			continue
+		}
+		if latest == nil || Compare(r.Version(), latest.Version()) > 0 {
+			latest = &releases[i]
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no releases found")
+	}
+	return latest, nil
+}
+
+// Download fetches the release's binary for a platform and checks the
+// archive against the release's checksums
+func (s *Source) Download(r *Release, goos, goarch string) ([]byte, error) {
+	name := r.archiveName(goos, goarch)
+	archive, ok := r.asset(name)
+	if !ok {
+		return nil, fmt.Errorf("release %s has no build for %s/%s", r.Tag, goos, goarch)
+	}
+	sums, ok := r.asset(checksumsName)
+	if !ok {
+		return nil, fmt.Errorf("release %s publishes no checksums; not installing it", r.Tag)
+	}
+
+	list, err := s.get(sums.URL, "application/octet-stream")
+	if err != nil {
+		return nil, err
+	}
+	want, err := checksum(list, name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.get(archive.URL, "application/octet-stream")
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return nil, fmt.Errorf("checksum mismatch for %s: got %s, want %s", name, got, want)
+	}
+
+	binary := "todoist"
+	if goos == "windows" {
+		binary += ".exe"
+		return unzip(data, binary)
+	}
+	return untar(data, binary)
+}
+
+// checksum finds the SHA-256 of name in a checksums.txt listing
+func checksum(list []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(list))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("%s is not listed in %s", name, checksumsName)
+}
+
+func untar(data []byte, binary string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("archive has no %s", binary)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if h.Typeflag == tar.TypeReg && path.Base(h.Name) == binary {
+			return io.ReadAll(tr)
+		}
+	}
+}
+
+func unzip(data []byte, binary string) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	for _, f := range zr.File {
+		if path.Base(f.Name) != binary {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+	return nil, fmt.Errorf("archive has no %s", binary)
+}
+
+// Replace swaps the executable at exe for binary. The new file is written
+// beside it and renamed over it, so a failure leaves the old one working.
+func Replace(exe string, binary []byte) error {
+	info, err := os.Stat(exe)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(exe), ".todoist-update-*")
+	if err != nil {
+		return fmt.Errorf("cannot write to %s: %w", filepath.Dir(exe), err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()|0o111); err != nil {
+		return err
+	}
+
+	// Windows cannot replace a running executable, only rename it away
+	old := exe + ".old"
+	os.Remove(old)
+	if err := os.Rename(exe, old); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), exe); err != nil {
+		os.Rename(old, exe)
+		return err
+	}
+	os.Remove(old)
+	return nil
+}
+
+// Compare orders versions such as "1.4.0" and "1.5.0-rc.1": negative when
+// a is older than b, positive when newer. Versions that do not parse, such
+// as "dev", are older than any release.
+func Compare(a, b string) int {
+	av, aok := parseVersion(a)
+	bv, bok := parseVersion(b)
+	switch {
+	case !aok && !bok:
+		return 0
+	case !aok:
+		return -1
+	case !bok:
+		return 1
+	}
+	for i := 0; i < 3; i++ {
+		if av.nums[i] != bv.nums[i] {
+			return av.nums[i] - bv.nums[i]
+		}
+	}
+	// A prerelease comes before its release
+	switch {
+	case av.pre == bv.pre:
+		return 0
+	case av.pre == "":
+		return 1
+	case bv.pre == "":
+		return -1
+	}
+	ap, bp := strings.Split(av.pre, "."), strings.Split(bv.pre, ".")
+	for i := 0; i < len(ap) && i < len(bp); i++ {
+		an, aerr := strconv.Atoi(ap[i])
+		bn, berr := strconv.Atoi(bp[i])
+		switch {
+		case aerr == nil && berr == nil && an != bn:
+			return an - bn
+		case (aerr == nil) != (berr == nil):
+			// Numeric identifiers come first
+			if aerr == nil {
+				return -1
+			}
+			return 1
+		case ap[i] != bp[i]:
+			return strings.Compare(ap[i], bp[i])
+		}
+	}
+	return len(ap) - len(bp)
+}
+
+type version struct {
+	nums [3]int
+	pre  string
+}
+
+func parseVersion(s string) (version, bool) {
+	var v version
+	s = strings.TrimPrefix(s, "v")
+	s, _, _ = strings.Cut(s, "+")
+	s, v.pre, _ = strings.Cut(s, "-")
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return v, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v.nums[i] = n
+	}
+	return v, true
+}
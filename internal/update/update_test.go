@@ -0,0 +1,142 @@
+package update
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	for _, tc := range []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.0", "1.10.0", -1},
+		{"v2.0.0", "1.9.9", 1},
+		{"1.2.3", "1.2.3", 0},
+		{"1.3.0-rc.1", "1.3.0", -1},
+		{"1.3.0-rc.2", "1.3.0-rc.10", -1},
+		{"1.3.0-beta", "1.3.0-alpha", 1},
+		{"1.3.0-rc.1", "1.3.0-rc.1.1", -1},
+		{"dev", "0.0.1", -1},
+		{"dev", "dev", 0},
+	} {
+		got := Compare(tc.a, tc.b)
+		if (got < 0 && tc.want >= 0) || (got > 0 && tc.want <= 0) || (got == 0 && tc.want != 0) {
+			t.Errorf("Compare(%q, %q) = %d, want sign of %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+// releaseServer serves releases, the newest a prerelease and one a draft,
+// each with a linux/amd64 archive of binary and checksums.txt
+func releaseServer(t *testing.T, binary []byte, tamper bool) *Source {
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+	tw.WriteHeader(&tar.Header{Name: "README.md", Mode: 0o644, Size: 2, Typeflag: tar.TypeReg})
+	tw.Write([]byte("hi"))
+	tw.WriteHeader(&tar.Header{Name: "todoist", Mode: 0o755, Size: int64(len(binary)), Typeflag: tar.TypeReg})
+	tw.Write(binary)
+	tw.Close()
+	gz.Close()
+	sum := sha256.Sum256(archive.Bytes())
+	if tamper {
+		sum[0] ^= 0xff
+	}
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	release := func(tag string, pre bool) Release {
+		name := "todoist_" + strings.TrimPrefix(tag, "v") + "_linux_amd64.tar.gz"
+		return Release{Tag: tag, Prerelease: pre, URL: srv.URL + "/tag/" + tag, Assets: []Asset{
+			{Name: name, URL: srv.URL + "/download/" + name},
+			{Name: "checksums.txt", URL: srv.URL + "/download/" + tag + "/checksums.txt"},
+		}}
+	}
+	mux.HandleFunc("/releases", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]Release{
+			release("v1.3.0-rc.1", true),
+			release("v1.2.0", false),
+			release("v1.1.0", false),
+			{Tag: "v9.0.0", Draft: true},
+		})
+	})
+	mux.HandleFunc("/download/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/checksums.txt") {
+			version := strings.TrimPrefix(strings.Split(r.URL.Path, "/")[2], "v")
+			w.Write([]byte("0000  todoist_" + version + "_darwin_arm64.tar.gz\n" +
+				hex.EncodeToString(sum[:]) + "  todoist_" + version + "_linux_amd64.tar.gz\n"))
+			return
+		}
+		w.Write(archive.Bytes())
+	})
+	return &Source{URL: srv.URL + "/releases", HTTP: srv.Client()}
+}
+
+func TestLatestAndDownload(t *testing.T) {
+	src := releaseServer(t, []byte("new binary"), false)
+
+	stable, err := src.Latest(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stable.Version() != "1.2.0" {
+		t.Errorf("expected 1.2.0 as the latest stable release, got %s", stable.Version())
+	}
+	pre, err := src.Latest(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pre.Version() != "1.3.0-rc.1" {
+		t.Errorf("expected the prerelease, got %s", pre.Version())
+	}
+
+	binary, err := src.Download(stable, "linux", "amd64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(binary) != "new binary" {
+		t.Errorf("got binary %q", binary)
+	}
+	if _, err := src.Download(stable, "plan9", "386"); err == nil {
+		t.Error("expected a platform without a build to be refused")
+	}
+
+	src = releaseServer(t, []byte("new binary"), true)
+	if stable, err = src.Latest(false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := src.Download(stable, "linux", "amd64"); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("expected a checksum mismatch, got %v", err)
+	}
+}
+
+func TestReplace(t *testing.T) {
+	exe := filepath.Join(t.TempDir(), "todoist")
+	if err := os.WriteFile(exe, []byte("old"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := Replace(exe, []byte("new")); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(exe)
+	info, _ := os.Stat(exe)
+	if string(data) != "new" || info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("got %q with mode %v", data, info.Mode())
+	}
+	entries, _ := os.ReadDir(filepath.Dir(exe))
+	if len(entries) != 1 {
+		t.Errorf("expected no leftovers beside the binary, got %d files", len(entries))
+	}
+}